@@ -82,19 +82,54 @@ class SemanticSearchTools:
         )
 
         # Initialize embedding model
+        self.embedding_model_name = embedding_model
         self.embedding_model = SentenceTransformer(embedding_model)
 
         # Get or create collections
-        self.servers_collection = self.chroma_client.get_or_create_collection(
-            name="mcp_servers",
-            metadata={"description": "MCP server documentation summaries"}
+        self.servers_collection = self._open_collection(
+            "mcp_servers", "MCP server documentation summaries"
         )
 
-        self.tools_collection = self.chroma_client.get_or_create_collection(
-            name="mcp_tools",
-            metadata={"description": "MCP tool descriptions with server context"}
+        self.tools_collection = self._open_collection(
+            "mcp_tools", "MCP tool descriptions with server context"
         )
 
+    def _open_collection(self, name: str, description: str):
+        """Get or create a collection for the current embedding model.
+
+        Embeddings of different models can't be compared, so a collection
+        embedded with another model is recreated and its documents are
+        re-embedded with the current one.
+
+        Args:
+            name: Collection name
+            description: Collection description
+
+        Returns:
+            ChromaDB collection
+        """
+        metadata = {"description": description, "embedding_model": self.embedding_model_name}
+        collection = self.chroma_client.get_or_create_collection(name=name, metadata=metadata)
+
+        stored_model = (collection.metadata or {}).get("embedding_model")
+        if stored_model is None or stored_model == self.embedding_model_name:
+            return collection
+
+        existing = collection.get(include=["documents", "metadatas"])
+        self.chroma_client.delete_collection(name)
+        collection = self.chroma_client.create_collection(name=name, metadata=metadata)
+
+        if existing and existing["ids"]:
+            collection.upsert(
+                ids=existing["ids"],
+                documents=existing["documents"],
+                embeddings=[self._generate_embedding(doc) for doc in existing["documents"]],
+                metadatas=existing["metadatas"]
+            )
+        print(f"✓ Re-embedded {len(existing['ids']) if existing else 0} documents of '{name}' "
+              f"with {self.embedding_model_name} (was {stored_model})")
+        return collection
+
     def _generate_embedding(self, text: str) -> List[float]:
         """Generate embedding for text.
 
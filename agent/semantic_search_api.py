@@ -11,7 +11,7 @@ import sys
 from typing import Optional
 from contextlib import asynccontextmanager
 
-from fastapi import FastAPI, HTTPException
+from fastapi import FastAPI, HTTPException, Request
 from fastapi.responses import JSONResponse
 from pydantic import BaseModel, Field
 import uvicorn
@@ -23,12 +23,49 @@ from mcp_agent.graph.semantic_agent import SemanticSearchAgent, SearchRequest, S
 # Global instances
 semantic_tools: Optional[SemanticSearchTools] = None
 semantic_agent: Optional[SemanticSearchAgent] = None
+active_embedding_model: Optional[str] = None
+model_switch: Optional[asyncio.Task] = None
+failed_models: dict[str, str] = {}  # Models that failed to load, with the error
+
+# mcpproxy sends its pinned embedding_model in this header on every request
+EMBEDDING_MODEL_HEADER = "X-Embedding-Model"
+
+
+def load_semantic_service(embedding_model: str) -> tuple[SemanticSearchTools, SemanticSearchAgent]:
+    """Create the semantic search tools and agent for an embedding model."""
+    tools = SemanticSearchTools(
+        base_url=os.getenv("MCPPROXY_URL", "http://localhost:8080"),
+        data_dir=os.getenv("MCPPROXY_DATA_DIR", "~/.mcpproxy"),
+        embedding_model=embedding_model
+    )
+    agent = SemanticSearchAgent(
+        semantic_tools=tools,
+        use_postgres=False  # Can be enabled via env var
+    )
+    return tools, agent
+
+
+async def switch_embedding_model(embedding_model: str) -> None:
+    """Load another embedding model and re-embed the index with it."""
+    global semantic_tools, semantic_agent, active_embedding_model
+
+    print(f"🔄 Switching embedding model from {active_embedding_model} to {embedding_model}...")
+    try:
+        tools, agent = await asyncio.to_thread(load_semantic_service, embedding_model)
+        indexed_count = await asyncio.to_thread(tools.sync_from_mcpproxy)
+    except Exception as e:
+        print(f"❌ Failed to switch embedding model to {embedding_model}: {e}")
+        failed_models[embedding_model] = str(e)
+        return
+
+    semantic_tools, semantic_agent, active_embedding_model = tools, agent, embedding_model
+    print(f"✓ Switched embedding model to {embedding_model}, indexed {indexed_count} tools")
 
 
 @asynccontextmanager
 async def lifespan(app: FastAPI):
     """Lifespan context manager for initialization and cleanup."""
-    global semantic_tools, semantic_agent
+    global semantic_tools, semantic_agent, active_embedding_model
 
     # Initialize on startup
     print("🚀 Initializing semantic search service...")
@@ -36,24 +73,15 @@ async def lifespan(app: FastAPI):
     base_url = os.getenv("MCPPROXY_URL", "http://localhost:8080")
     data_dir = os.getenv("MCPPROXY_DATA_DIR", "~/.mcpproxy")
     embedding_model = os.getenv("EMBEDDING_MODEL", "all-MiniLM-L6-v2")
+    active_embedding_model = embedding_model
 
     try:
-        # Initialize semantic search tools
-        semantic_tools = SemanticSearchTools(
-            base_url=base_url,
-            data_dir=data_dir,
-            embedding_model=embedding_model
-        )
+        # Initialize semantic search tools and agent
+        semantic_tools, semantic_agent = load_semantic_service(embedding_model)
         print(f"✓ Semantic search tools initialized")
         print(f"  - MCPProxy: {base_url}")
         print(f"  - Data directory: {data_dir}")
         print(f"  - Embedding model: {embedding_model}")
-
-        # Initialize semantic agent
-        semantic_agent = SemanticSearchAgent(
-            semantic_tools=semantic_tools,
-            use_postgres=False  # Can be enabled via env var
-        )
         print(f"✓ Semantic search agent initialized")
 
         # Initial sync from mcpproxy
@@ -81,11 +109,41 @@ app = FastAPI(
 )
 
 
+@app.middleware("http")
+async def honour_embedding_model(request: Request, call_next):
+    """Serve requests with the embedding model mcpproxy pinned.
+
+    A request for another model than the active one starts loading that model in
+    the background and is answered with 503 until the switch is done, so mcpproxy
+    treats the service as unavailable instead of mixing models.
+    """
+    global model_switch
+
+    requested_model = request.headers.get(EMBEDDING_MODEL_HEADER)
+    if not requested_model or not semantic_tools or requested_model == active_embedding_model:
+        return await call_next(request)
+
+    if requested_model in failed_models:
+        return JSONResponse(
+            status_code=503,
+            content={"detail": f"Embedding model {requested_model} failed to load: {failed_models[requested_model]}"}
+        )
+
+    if model_switch is None or model_switch.done():
+        model_switch = asyncio.create_task(switch_embedding_model(requested_model))
+
+    return JSONResponse(
+        status_code=503,
+        content={"detail": f"Switching embedding model to {requested_model}, retry shortly"}
+    )
+
+
 class HealthResponse(BaseModel):
     """Health check response."""
     status: str
     tools_indexed: int
     servers_indexed: int
+    embedding_model: Optional[str] = None
 
 
 class SyncResponse(BaseModel):
@@ -117,7 +175,8 @@ async def health_check():
         return HealthResponse(
             status="healthy",
             tools_indexed=tools_count,
-            servers_indexed=servers_count
+            servers_indexed=servers_count,
+            embedding_model=active_embedding_model
         )
     except Exception as e:
         raise HTTPException(status_code=500, detail=f"Health check failed: {str(e)}")
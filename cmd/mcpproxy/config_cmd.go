@@ -0,0 +1,163 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"mcpproxy-go/internal/config"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	configCmd = &cobra.Command{
+		Use:   "config",
+		Short: "Configuration management commands",
+		Long:  "Commands for exporting and importing the mcpproxy configuration",
+	}
+
+	configExportCmd = &cobra.Command{
+		Use:   "export",
+		Short: "Export servers, groups and quarantine states as a portable bundle",
+		Long: `Export upstream servers, groups, server-group assignments and quarantine states
+as a single JSON bundle that can be imported on another machine.
+
+Env values, headers and OAuth client secrets are redacted unless --include-secrets is set.`,
+		Args: cobra.NoArgs,
+		RunE: runConfigExport,
+	}
+
+	configImportCmd = &cobra.Command{
+		Use:   "import <bundle.json>",
+		Short: "Import a bundle created with 'mcpproxy config export'",
+		Long: `Validate a config bundle and merge it into the configuration file.
+Servers that already exist are kept unless --overwrite is set. Groups are matched by name
+and created if missing. Redacted secrets are filled from existing servers of the same name
+where possible; the rest are reported and must be set by hand.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runConfigImport,
+	}
+
+	// Command flags for config commands
+	configFilePath       string
+	configOutputPath     string
+	configIncludeSecrets bool
+	configOverwrite      bool
+)
+
+// GetConfigCommand returns the config command for adding to the root command
+func GetConfigCommand() *cobra.Command {
+	return configCmd
+}
+
+func init() {
+	configCmd.AddCommand(configExportCmd)
+	configCmd.AddCommand(configImportCmd)
+
+	configExportCmd.Flags().StringVarP(&configFilePath, "config", "c", "", "Path to MCP configuration file (default: ~/.mcpproxy/mcp_config.json)")
+	configExportCmd.Flags().StringVarP(&configOutputPath, "output", "o", "", "Write the bundle to this file instead of stdout")
+	configExportCmd.Flags().BoolVar(&configIncludeSecrets, "include-secrets", false, "Include env, header and OAuth secret values")
+
+	configImportCmd.Flags().StringVarP(&configFilePath, "config", "c", "", "Path to MCP configuration file (default: ~/.mcpproxy/mcp_config.json)")
+	configImportCmd.Flags().BoolVar(&configOverwrite, "overwrite", false, "Replace servers that already exist")
+
+	configExportCmd.Example = `  # Export to a file, without secrets
+  mcpproxy config export -o mcpproxy-bundle.json
+
+  # Export including secrets (keep the file private)
+  mcpproxy config export --include-secrets -o mcpproxy-bundle.json`
+
+	configImportCmd.Example = `  # Add servers from a bundle, keeping existing ones
+  mcpproxy config import mcpproxy-bundle.json
+
+  # Replace existing servers with the bundle's versions
+  mcpproxy config import mcpproxy-bundle.json --overwrite`
+}
+
+func runConfigExport(_ *cobra.Command, _ []string) error {
+	cfg, _, err := loadConfigForBundle()
+	if err != nil {
+		return err
+	}
+
+	bundle, err := config.ExportBundle(cfg, configIncludeSecrets)
+	if err != nil {
+		return fmt.Errorf("failed to export config: %w", err)
+	}
+
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal bundle: %w", err)
+	}
+
+	if configOutputPath == "" {
+		fmt.Println(string(data))
+		return nil
+	}
+	if err := os.WriteFile(configOutputPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Exported %d server(s) and %d group(s) to %s\n", len(bundle.Servers), len(bundle.Groups), configOutputPath)
+	return nil
+}
+
+func runConfigImport(_ *cobra.Command, args []string) error {
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to read bundle: %w", err)
+	}
+	bundle, err := config.ParseBundle(data)
+	if err != nil {
+		return err
+	}
+
+	cfg, path, err := loadConfigForBundle()
+	if err != nil {
+		return err
+	}
+
+	result, err := cfg.ImportBundle(bundle, configOverwrite, false)
+	if err != nil {
+		return fmt.Errorf("failed to import config: %w", err)
+	}
+
+	if err := config.SaveConfig(cfg, path); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Printf("Imported bundle into %s\n", path)
+	printBundleNames("Added servers", result.Added)
+	printBundleNames("Updated servers", result.Updated)
+	printBundleNames("Skipped existing servers (use --overwrite to replace)", result.Skipped)
+	printBundleNames("Added groups", result.GroupsAdded)
+	printBundleNames("Secrets to set by hand", result.MissingSecrets)
+	fmt.Println("A running mcpproxy picks up the changes on its next config reload or restart.")
+	return nil
+}
+
+// loadConfigForBundle loads the configuration named by --config, or the default one
+func loadConfigForBundle() (*config.Config, string, error) {
+	path := configFilePath
+	if path == "" {
+		dataDir, err := config.ResolveDataDir("")
+		if err != nil {
+			return nil, "", err
+		}
+		path = config.GetConfigPath(dataDir)
+	}
+
+	cfg, err := config.LoadFromFile(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load config from %s: %w", path, err)
+	}
+	return cfg, path, nil
+}
+
+func printBundleNames(label string, names []string) {
+	if len(names) == 0 {
+		return
+	}
+	fmt.Printf("%s: %s\n", label, strings.Join(names, ", "))
+}
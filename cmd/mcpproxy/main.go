@@ -31,6 +31,7 @@ var (
 	toolResponseLimit int
 	logToFile         bool
 	logDir            string
+	printConfig       bool
 
 	// Security flags
 	readOnlyMode      bool
@@ -81,11 +82,16 @@ func main() {
 		Short: "Smart MCP Proxy - Intelligent tool discovery and proxying for Model Context Protocol servers",
 		Version: fmt.Sprintf("%s\n  Build Time: %s\n  Git Commit: %s\n  Git Branch: %s",
 			version, buildTime, gitCommit, gitBranch),
+		// Apply --data-dir before any command loads the config, so storage and index
+		// managers are created in the overridden directory
+		PersistentPreRun: func(_ *cobra.Command, _ []string) {
+			config.SetDataDirOverride(dataDir)
+		},
 	}
 
 	// Add global flags
 	rootCmd.PersistentFlags().StringVarP(&configFile, "config", "c", "", "Configuration file path")
-	rootCmd.PersistentFlags().StringVarP(&dataDir, "data-dir", "d", "", "Data directory path (default: ~/.mcpproxy)")
+	rootCmd.PersistentFlags().StringVarP(&dataDir, "data-dir", "d", "", "Data directory path, overrides "+config.DataDirEnvVar+" and the config file (default: ~/.mcpproxy)")
 	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "", "Log level (trace, debug, info, warn, error) - defaults: server=info, other commands=warn")
 	rootCmd.PersistentFlags().BoolVar(&logToFile, "log-to-file", false, "Enable logging to file in standard OS location (default: console only)")
 	rootCmd.PersistentFlags().StringVar(&logDir, "log-dir", "", "Custom log directory path (overrides standard OS location)")
@@ -108,6 +114,7 @@ func main() {
 	serverCmd.Flags().BoolVar(&allowServerAdd, "allow-server-add", true, "Allow adding new servers")
 	serverCmd.Flags().BoolVar(&allowServerRemove, "allow-server-remove", true, "Allow removing existing servers")
 	serverCmd.Flags().BoolVar(&enablePrompts, "enable-prompts", true, "Enable prompts for user input")
+	serverCmd.Flags().BoolVar(&printConfig, "print-config", false, "Print the effective configuration (after loading, migration, merging and flags) as JSON and exit")
 
 	// Add search-servers command
 	searchCmd := createSearchServersCommand()
@@ -121,12 +128,16 @@ func main() {
 	// Add auth command
 	authCmd := GetAuthCommand()
 
+	// Add config command
+	configCmd := GetConfigCommand()
+
 	// Add commands to root
 	rootCmd.AddCommand(serverCmd)
 	rootCmd.AddCommand(searchCmd)
 	rootCmd.AddCommand(toolsCmd)
 	rootCmd.AddCommand(callCmd)
 	rootCmd.AddCommand(authCmd)
+	rootCmd.AddCommand(configCmd)
 
 	// Default to server command for backward compatibility
 	rootCmd.RunE = runServer
@@ -264,6 +275,17 @@ func listAllRegistries(logger *zap.Logger) {
 	fmt.Printf("\nUse --registry <ID> to search a specific registry\n")
 }
 
+// printEffectiveConfig writes the resolved configuration to stdout, with credentials redacted
+func printEffectiveConfig(cfg *config.Config) error {
+	effective, err := config.EffectiveConfig(cfg)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(effective)
+}
+
 func runServer(cmd *cobra.Command, _ []string) error {
 	// Get flag values from command (handles both global and local flags)
 	cmdLogLevel, _ := cmd.Flags().GetString("log-level")
@@ -382,6 +404,10 @@ func runServer(cmd *cobra.Command, _ []string) error {
 		cfg.EnablePrompts = cmdEnablePrompts
 	}
 
+	if printConfig {
+		return printEffectiveConfig(cfg)
+	}
+
 	logger.Info("Configuration loaded",
 		zap.String("data_dir", cfg.DataDir),
 		zap.Int("servers_count", len(cfg.Servers)),
@@ -415,6 +441,7 @@ func runServer(cmd *cobra.Command, _ []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to create server: %w", err)
 	}
+	srv.SetBuildInfo(version, buildTime, gitCommit)
 
 	// Setup signal handling for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
@@ -522,9 +549,7 @@ func loadConfig(cmd *cobra.Command) (*config.Config, error) {
 	}
 
 	// Override with command line flags ONLY if they were explicitly set
-	if dataDir != "" {
-		cfg.DataDir = dataDir
-	}
+	// (--data-dir is applied by the config loader, see config.SetDataDirOverride)
 	if cmd.Flags().Changed("listen") {
 		listenFlag, _ := cmd.Flags().GetString("listen")
 		cfg.Listen = listenFlag
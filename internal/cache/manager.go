@@ -72,8 +72,28 @@ func GenerateKey(toolName string, args map[string]interface{}, timestamp time.Ti
 	return hex.EncodeToString(hash[:])
 }
 
+// GenerateResultKey generates a cache key for the result of a tool call. Unlike GenerateKey
+// it is stable over time, so identical calls map to the same entry.
+func GenerateResultKey(toolName string, args map[string]interface{}) string {
+	// json.Marshal sorts map keys, so equal arguments always produce the same key
+	argsJSON, _ := json.Marshal(args)
+	input := fmt.Sprintf("result:%s:%s", toolName, string(argsJSON))
+
+	hash := sha256.Sum256([]byte(input))
+	return hex.EncodeToString(hash[:])
+}
+
 // Store saves a tool response to cache
 func (m *Manager) Store(key, toolName string, args map[string]interface{}, content, recordPath string, totalRecords int) error {
+	return m.store(key, toolName, args, content, recordPath, totalRecords, DefaultTTL)
+}
+
+// StoreResult caches the full result of an idempotent tool call for ttl
+func (m *Manager) StoreResult(key, toolName string, args map[string]interface{}, content string, ttl time.Duration) error {
+	return m.store(key, toolName, args, content, "", 0, ttl)
+}
+
+func (m *Manager) store(key, toolName string, args map[string]interface{}, content, recordPath string, totalRecords int, ttl time.Duration) error {
 	record := &Record{
 		Key:          key,
 		ToolName:     toolName,
@@ -83,7 +103,7 @@ func (m *Manager) Store(key, toolName string, args map[string]interface{}, conte
 		RecordPath:   recordPath,
 		TotalRecords: totalRecords,
 		TotalSize:    len(content),
-		ExpiresAt:    time.Now().Add(DefaultTTL),
+		ExpiresAt:    time.Now().Add(ttl),
 		AccessCount:  0,
 		LastAccessed: time.Now(),
 		CreatedAt:    time.Now(),
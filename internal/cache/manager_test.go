@@ -645,3 +645,51 @@ func TestExtractRecordsArrayComplexPaths(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateResultKey(t *testing.T) {
+	args := map[string]interface{}{"query": "open issues", "limit": 10}
+	sameArgs := map[string]interface{}{"limit": 10, "query": "open issues"}
+
+	if GenerateResultKey("github:search", args) != GenerateResultKey("github:search", sameArgs) {
+		t.Error("Identical arguments should produce the same result key")
+	}
+	if GenerateResultKey("github:search", args) == GenerateResultKey("gitlab:search", args) {
+		t.Error("Different tools should produce different result keys")
+	}
+	if GenerateResultKey("github:search", args) == GenerateKey("github:search", args, time.Now()) {
+		t.Error("Result keys should not collide with truncation keys")
+	}
+}
+
+func TestStoreResultTTL(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	manager, err := NewManager(db, zap.NewNop())
+	if err != nil {
+		t.Fatalf("Failed to create cache manager: %v", err)
+	}
+	defer manager.Close()
+
+	args := map[string]interface{}{"id": 1}
+	key := GenerateResultKey("docs:get_page", args)
+	if err := manager.StoreResult(key, "docs:get_page", args, `{"content":"page"}`, time.Minute); err != nil {
+		t.Fatalf("Failed to store result: %v", err)
+	}
+
+	record, err := manager.Get(key)
+	if err != nil {
+		t.Fatalf("Expected cached result: %v", err)
+	}
+	if record.FullContent != `{"content":"page"}` {
+		t.Errorf("Unexpected cached content: %s", record.FullContent)
+	}
+
+	expiredKey := GenerateResultKey("docs:get_page", map[string]interface{}{"id": 2})
+	if err := manager.StoreResult(expiredKey, "docs:get_page", nil, "{}", -time.Second); err != nil {
+		t.Fatalf("Failed to store result: %v", err)
+	}
+	if _, err := manager.Get(expiredKey); err == nil {
+		t.Error("Expected expired result to be a cache miss")
+	}
+}
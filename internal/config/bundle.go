@@ -0,0 +1,351 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// BundleVersion is the format version written to exported config bundles
+const BundleVersion = 1
+
+// Bundle is a portable export of the server setup: servers with their startup modes
+// (including quarantine state), groups and server-group assignments. Group assignments are
+// stored by group name because group IDs differ between installations.
+type Bundle struct {
+	Version                int               `json:"version"`
+	ExportedAt             time.Time         `json:"exported_at"`
+	IncludesSecrets        bool              `json:"includes_secrets"`
+	Servers                []*ServerConfig   `json:"mcpServers"`
+	Groups                 []GroupConfig     `json:"groups,omitempty"`
+	ServerGroupAssignments map[string]string `json:"server_group_assignments,omitempty"` // server name -> group name
+}
+
+// BundleImportResult reports what ImportBundle changed
+type BundleImportResult struct {
+	Added       []string `json:"added"`
+	Updated     []string `json:"updated"`
+	Skipped     []string `json:"skipped"`
+	GroupsAdded []string `json:"groups_added"`
+	// Quarantined lists imported servers that were put in quarantine instead of the bundle's startup mode
+	Quarantined []string `json:"quarantined,omitempty"`
+	// MissingSecrets lists redacted env, header, default_args and OAuth values ("server: env.KEY") that
+	// could not be filled from an existing server and must be set by hand
+	MissingSecrets []string `json:"missing_secrets,omitempty"`
+}
+
+// ExportBundle builds a bundle from cfg. Unless includeSecrets is set, env, header and default
+// argument values and OAuth client secrets are replaced with a redacted marker; keys are kept so it is still
+// visible what has to be set. Connection history is machine-specific and is not exported.
+func ExportBundle(cfg *Config, includeSecrets bool) (*Bundle, error) {
+	bundle := &Bundle{
+		Version:                BundleVersion,
+		ExportedAt:             time.Now().UTC(),
+		IncludesSecrets:        includeSecrets,
+		Servers:                []*ServerConfig{},
+		Groups:                 append([]GroupConfig(nil), cfg.Groups...),
+		ServerGroupAssignments: make(map[string]string),
+	}
+
+	groupNames := make(map[int]string, len(cfg.Groups))
+	for _, group := range cfg.Groups {
+		if group.ID > 0 {
+			groupNames[group.ID] = group.Name
+		}
+	}
+
+	for _, server := range cfg.Servers {
+		exported, err := copyServerConfig(server)
+		if err != nil {
+			return nil, fmt.Errorf("failed to copy server %s: %w", server.Name, err)
+		}
+
+		if name, ok := groupNames[server.GroupID]; ok {
+			bundle.ServerGroupAssignments[server.Name] = name
+		} else if server.GroupName != "" {
+			bundle.ServerGroupAssignments[server.Name] = server.GroupName
+		}
+		exported.GroupID = 0
+		exported.GroupName = ""
+
+		exported.EverConnected = false
+		exported.LastSuccessfulConnection = time.Time{}
+		exported.ToolCount = 0
+
+		if !includeSecrets {
+			redactServerSecrets(exported)
+		}
+		bundle.Servers = append(bundle.Servers, exported)
+	}
+
+	return bundle, nil
+}
+
+// ParseBundle decodes and validates an exported bundle
+func ParseBundle(data []byte) (*Bundle, error) {
+	var bundle Bundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("invalid config bundle: %w", err)
+	}
+	if err := bundle.Validate(); err != nil {
+		return nil, err
+	}
+	return &bundle, nil
+}
+
+// Validate checks that the bundle has a supported version and well-formed servers
+func (b *Bundle) Validate() error {
+	if b.Version < 1 || b.Version > BundleVersion {
+		return fmt.Errorf("unsupported config bundle version %d (supported: 1-%d)", b.Version, BundleVersion)
+	}
+
+	names := make(map[string]bool, len(b.Servers))
+	for i, server := range b.Servers {
+		if server == nil || server.Name == "" {
+			return fmt.Errorf("server %d: name is required", i)
+		}
+		if names[server.Name] {
+			return fmt.Errorf("server %s: duplicate name", server.Name)
+		}
+		names[server.Name] = true
+
+		if server.URL == "" && server.Command == "" {
+			return fmt.Errorf("server %s: url or command is required", server.Name)
+		}
+		if server.StartupMode != "" {
+			if err := ValidateStartupMode(server.StartupMode); err != nil {
+				return fmt.Errorf("server %s: %w", server.Name, err)
+			}
+		}
+	}
+
+	groups := make(map[string]bool, len(b.Groups))
+	for i, group := range b.Groups {
+		if group.Name == "" {
+			return fmt.Errorf("group %d: name is required", i)
+		}
+		groups[group.Name] = true
+	}
+	for server, group := range b.ServerGroupAssignments {
+		if !names[server] {
+			return fmt.Errorf("group assignment for unknown server %s", server)
+		}
+		if !groups[group] {
+			return fmt.Errorf("server %s is assigned to unknown group %s", server, group)
+		}
+	}
+
+	return ValidateServerDependencies(b.Servers)
+}
+
+// ImportBundle merges a bundle into c. Servers that already exist are skipped unless overwrite
+// is set, in which case their config is replaced while their connection history is kept.
+// Groups are matched by name and missing ones are created. Nothing is changed if the merged
+// configuration is invalid.
+//
+// A server that replaces a quarantined one stays quarantined. With quarantine set, as for
+// bundles from untrusted sources, every imported server is quarantined for review.
+func (c *Config) ImportBundle(b *Bundle, overwrite, quarantine bool) (*BundleImportResult, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	result := &BundleImportResult{
+		Added:       []string{},
+		Updated:     []string{},
+		Skipped:     []string{},
+		GroupsAdded: []string{},
+	}
+
+	// Merge groups by name, giving new groups the next free ID
+	mergedGroups := append([]GroupConfig(nil), c.Groups...)
+	groupIDs := make(map[string]int, len(mergedGroups))
+	nextID := 1
+	for _, group := range mergedGroups {
+		groupIDs[group.Name] = group.ID
+		if group.ID >= nextID {
+			nextID = group.ID + 1
+		}
+	}
+	for _, group := range b.Groups {
+		if _, exists := groupIDs[group.Name]; exists {
+			continue
+		}
+		group.ID = nextID
+		nextID++
+		mergedGroups = append(mergedGroups, group)
+		groupIDs[group.Name] = group.ID
+		result.GroupsAdded = append(result.GroupsAdded, group.Name)
+	}
+
+	// Merge servers by name
+	mergedServers := append([]*ServerConfig(nil), c.Servers...)
+	existingIndex := make(map[string]int, len(mergedServers))
+	for i, server := range mergedServers {
+		existingIndex[server.Name] = i
+	}
+
+	now := time.Now()
+	for _, server := range b.Servers {
+		imported, err := copyServerConfig(server)
+		if err != nil {
+			return nil, fmt.Errorf("failed to copy server %s: %w", server.Name, err)
+		}
+		if imported.StartupMode == "" {
+			imported.StartupMode = "active"
+		}
+
+		var existing *ServerConfig
+		i, exists := existingIndex[imported.Name]
+		if exists {
+			if !overwrite {
+				result.Skipped = append(result.Skipped, imported.Name)
+				continue
+			}
+			existing = mergedServers[i]
+		}
+
+		if quarantine || (existing != nil && existing.IsQuarantined()) {
+			if !imported.IsQuarantined() {
+				result.Quarantined = append(result.Quarantined, imported.Name)
+			}
+			imported.StartupMode = "quarantined"
+		}
+
+		result.MissingSecrets = append(result.MissingSecrets, restoreRedactedSecrets(imported, existing)...)
+
+		imported.GroupID = 0
+		imported.GroupName = ""
+		if group, ok := b.ServerGroupAssignments[imported.Name]; ok {
+			if id := groupIDs[group]; id > 0 {
+				imported.GroupID = id
+			} else {
+				imported.GroupName = group // legacy groups without IDs are assigned by name
+			}
+		}
+
+		if exists {
+			imported.Created = existing.Created
+			imported.Updated = now
+			imported.EverConnected = existing.EverConnected
+			imported.LastSuccessfulConnection = existing.LastSuccessfulConnection
+			imported.ToolCount = existing.ToolCount
+			mergedServers[i] = imported
+			result.Updated = append(result.Updated, imported.Name)
+			continue
+		}
+
+		imported.Created = now
+		imported.EverConnected = false
+		imported.LastSuccessfulConnection = time.Time{}
+		imported.ToolCount = 0
+		mergedServers = append(mergedServers, imported)
+		existingIndex[imported.Name] = len(mergedServers) - 1
+		result.Added = append(result.Added, imported.Name)
+	}
+
+	if err := ValidateServerDependencies(mergedServers); err != nil {
+		return nil, err
+	}
+
+	c.Groups = mergedGroups
+	c.Servers = mergedServers
+	sort.Strings(result.MissingSecrets)
+	return result, nil
+}
+
+// copyServerConfig deep-copies a server config
+func copyServerConfig(server *ServerConfig) (*ServerConfig, error) {
+	data, err := json.Marshal(server)
+	if err != nil {
+		return nil, err
+	}
+	var copied ServerConfig
+	if err := json.Unmarshal(data, &copied); err != nil {
+		return nil, err
+	}
+	return &copied, nil
+}
+
+// redactServerSecrets replaces env, header and default argument values, the http_proxy
+// password and the OAuth client secret in place
+func redactServerSecrets(server *ServerConfig) {
+	if server.HTTPProxy != "" {
+		server.HTTPProxy = RedactProxyURL(server.HTTPProxy)
+	}
+	for key := range server.Env {
+		server.Env[key] = redactedValue
+	}
+	for key := range server.Headers {
+		server.Headers[key] = redactedValue
+	}
+	for key := range server.DefaultArgs {
+		server.DefaultArgs[key] = redactedValue
+	}
+	if server.OAuth != nil && server.OAuth.ClientSecret != "" {
+		server.OAuth.ClientSecret = redactedValue
+	}
+}
+
+// restoreRedactedSecrets fills redacted values of an imported server from the existing server
+// of the same name. Values that can't be filled are removed and returned as "server: field".
+func restoreRedactedSecrets(imported, existing *ServerConfig) []string {
+	var missing []string
+
+	restore := func(field string, values, existingValues map[string]string) {
+		for key, value := range values {
+			if value != redactedValue {
+				continue
+			}
+			if previous, ok := existingValues[key]; ok && previous != redactedValue {
+				values[key] = previous
+				continue
+			}
+			delete(values, key)
+			missing = append(missing, fmt.Sprintf("%s: %s.%s", imported.Name, field, key))
+		}
+	}
+
+	var existingEnv, existingHeaders map[string]string
+	if existing != nil {
+		existingEnv, existingHeaders = existing.Env, existing.Headers
+	}
+	restore("env", imported.Env, existingEnv)
+	restore("headers", imported.Headers, existingHeaders)
+
+	for key, value := range imported.DefaultArgs {
+		if value != redactedValue {
+			continue
+		}
+		if existing != nil {
+			if previous, ok := existing.DefaultArgs[key]; ok && previous != redactedValue {
+				imported.DefaultArgs[key] = previous
+				continue
+			}
+		}
+		delete(imported.DefaultArgs, key)
+		missing = append(missing, fmt.Sprintf("%s: default_args.%s", imported.Name, key))
+	}
+
+	if isRedactedProxyURL(imported.HTTPProxy) {
+		if existing != nil && existing.HTTPProxy != imported.HTTPProxy && RedactProxyURL(existing.HTTPProxy) == imported.HTTPProxy {
+			imported.HTTPProxy = existing.HTTPProxy
+		} else {
+			imported.HTTPProxy = ""
+			missing = append(missing, fmt.Sprintf("%s: http_proxy", imported.Name))
+		}
+	}
+
+	if imported.OAuth != nil && imported.OAuth.ClientSecret == redactedValue {
+		imported.OAuth.ClientSecret = ""
+		if existing != nil && existing.OAuth != nil {
+			imported.OAuth.ClientSecret = existing.OAuth.ClientSecret
+		}
+		if imported.OAuth.ClientSecret == "" {
+			missing = append(missing, fmt.Sprintf("%s: oauth.client_secret", imported.Name))
+		}
+	}
+
+	return missing
+}
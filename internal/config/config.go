@@ -4,13 +4,25 @@ import (
 	"encoding/json"
 	"fmt"
 	"mcpproxy-go/internal/secureenv"
+	"net"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
 )
 
 const (
 	defaultPort = ":8080"
+
+	loopbackHost = "127.0.0.1"
+
+	// DefaultCompressionMinBytes is the smallest MCP response compressed when compression_min_bytes is unset
+	DefaultCompressionMinBytes = 1024
+
+	// DefaultStatusBufferSize is the number of queued status updates when status_buffer_size is unset
+	DefaultStatusBufferSize = 10
 )
 
 // Duration is a wrapper around time.Duration that can be marshaled to/from JSON
@@ -44,6 +56,9 @@ func (d Duration) Duration() time.Duration {
 
 // Config represents the main configuration structure
 type Config struct {
+	// ConfigVersion is the schema version of this config file, see CurrentConfigVersion
+	ConfigVersion int `json:"config_version" mapstructure:"config-version"`
+
 	Listen            string          `json:"listen" mapstructure:"listen"`
 	DataDir           string          `json:"data_dir" mapstructure:"data-dir"`
 	EnableTray        bool            `json:"enable_tray" mapstructure:"tray"`
@@ -54,6 +69,29 @@ type Config struct {
 	ToolResponseLimit int             `json:"tool_response_limit" mapstructure:"tool-response-limit"`
 	CallToolTimeout   Duration        `json:"call_tool_timeout" mapstructure:"call-tool-timeout"`
 
+	// MaxDescriptionLength truncates tool descriptions in retrieve_tools results to this many
+	// characters (0 = full descriptions). get_tool always returns the full text.
+	MaxDescriptionLength int `json:"max_description_length,omitempty" mapstructure:"max-description-length"`
+
+	// MaxToolsPerServer and MaxTotalTools cap how many tools are added to the search index, so an
+	// upstream advertising thousands of tools can't balloon it (0 = no limit). Tools past a cap
+	// are skipped with a warning and their server is reported as truncated.
+	MaxToolsPerServer int `json:"max_tools_per_server,omitempty" mapstructure:"max-tools-per-server"`
+	MaxTotalTools     int `json:"max_total_tools,omitempty" mapstructure:"max-total-tools"`
+
+	// ValidateToolArgs checks call_tool arguments against the tool's stored input schema and
+	// rejects mismatches (missing required fields, wrong types) without calling the upstream
+	ValidateToolArgs bool `json:"validate_tool_args,omitempty" mapstructure:"validate-tool-args"`
+
+	// MaxCallDuration bounds proxied tool calls from clients that set no deadline of their own,
+	// so a hanging upstream can't pin a subprocess (0 = no extra bound). Servers may override it.
+	// It can only shorten calls: CallToolTimeout still caps every call.
+	MaxCallDuration Duration `json:"max_call_duration,omitempty" mapstructure:"max-call-duration"`
+
+	// ShutdownTimeout is the grace period for shutting down the HTTP server, startup script and
+	// upstream servers before remaining stages are aborted (0 = default: 10s)
+	ShutdownTimeout Duration `json:"shutdown_timeout,omitempty" mapstructure:"shutdown-timeout"`
+
 	// Environment configuration for secure variable filtering
 	Environment *secureenv.EnvConfig `json:"environment,omitempty" mapstructure:"environment"`
 
@@ -66,6 +104,44 @@ type Config struct {
 	AllowServerAdd    bool `json:"allow_server_add" mapstructure:"allow-server-add"`
 	AllowServerRemove bool `json:"allow_server_remove" mapstructure:"allow-server-remove"`
 
+	// DisabledBuiltinTools lists built-in tools (e.g. "upstream_servers") that are hidden from
+	// clients and refuse calls. Applied to the advertised tool list at startup.
+	DisabledBuiltinTools []string `json:"disabled_builtin_tools,omitempty" mapstructure:"disabled-builtin-tools"`
+
+	// FeaturedTools lists prefixed upstream tools (e.g. "github:create_issue") that are advertised
+	// in the tool list next to the built-in tools, so clients can call them without a search
+	FeaturedTools []string `json:"featured_tools,omitempty" mapstructure:"featured-tools"`
+
+	// BindLoopbackOnly forces the HTTP server to bind to 127.0.0.1 regardless of the host in Listen
+	BindLoopbackOnly bool `json:"bind_loopback_only" mapstructure:"bind-loopback-only"`
+
+	// ListenSocketMode is the octal file mode (e.g. "0600") of the socket file when Listen is unix://
+	ListenSocketMode string `json:"listen_socket_mode,omitempty" mapstructure:"listen-socket-mode"`
+
+	// TLS for the HTTP listener: HTTPS is served when both files are set. Certificates are reloaded on SIGHUP
+	TLSCertFile string `json:"tls_cert_file,omitempty" mapstructure:"tls-cert-file"`
+	TLSKeyFile  string `json:"tls_key_file,omitempty" mapstructure:"tls-key-file"`
+
+	// TLSRedirectListen, when TLS is enabled, is a plain HTTP address that redirects to HTTPS (e.g. ":8081")
+	TLSRedirectListen string `json:"tls_redirect_listen,omitempty" mapstructure:"tls-redirect-listen"`
+
+	// MaxHeaderBytes limits the size of request headers accepted by the HTTP server (default 1MB)
+	MaxHeaderBytes int `json:"max_header_bytes,omitempty" mapstructure:"max-header-bytes"`
+
+	// MaxRequestBodyBytes limits request bodies on the MCP endpoints; larger requests get 413. 0 means unlimited
+	MaxRequestBodyBytes int64 `json:"max_request_body_bytes,omitempty" mapstructure:"max-request-body-bytes"`
+
+	// MCP responses of at least CompressionMinBytes (default 1024) are gzip/deflate-compressed for
+	// clients sending Accept-Encoding, unless DisableCompression is set. Event streams are never compressed
+	DisableCompression  bool `json:"disable_compression,omitempty" mapstructure:"disable-compression"`
+	CompressionMinBytes int  `json:"compression_min_bytes,omitempty" mapstructure:"compression-min-bytes"`
+
+	// APIToken, when set, is required as "Authorization: Bearer <token>" on /api/, /chat/ and /server/chat routes
+	APIToken string `json:"api_token,omitempty" mapstructure:"api-token"`
+
+	// DiagnosticChatEnabled serves the OpenAI-backed diagnostic chat UI (/server/chat, /chat/*, /api/chat/*)
+	DiagnosticChatEnabled bool `json:"diagnostic_chat_enabled" mapstructure:"diagnostic-chat-enabled"`
+
 	// Prompts settings
 	EnablePrompts bool `json:"enable_prompts" mapstructure:"enable-prompts"`
 
@@ -87,15 +163,30 @@ type Config struct {
 	// GitHub repository URL for the project
 	GitHubURL string `json:"github_url,omitempty" mapstructure:"github-url"`
 
+	// UpdateWindow restricts when the tray applies automatic updates, e.g. "02:00-04:00" (local time).
+	// Outside the window updates are only reported as available. Empty means any time.
+	UpdateWindow string `json:"update_window,omitempty" mapstructure:"update-window"`
+
+	// StatusBufferSize is how many status updates are queued for the tray (default 10). Updates
+	// arriving while the queue is full are dropped and counted. Applied at startup
+	StatusBufferSize int `json:"status_buffer_size,omitempty" mapstructure:"status-buffer-size"`
+
 	// Startup script configuration, executed when mcpproxy starts
 	StartupScript *StartupScriptConfig `json:"startup_script,omitempty" mapstructure:"startup-script"`
 
 	// Maximum number of concurrent server connections during startup
 	MaxConcurrentConnections int `json:"max_concurrent_connections" mapstructure:"max-concurrent-connections"`
 
+	// Maximum number of concurrent ListTools calls while discovering tools (0 = max_concurrent_connections)
+	MaxConcurrentDiscovery int `json:"max_concurrent_discovery,omitempty" mapstructure:"max-concurrent-discovery"`
+
 	// Lazy loading configuration - only connect to servers when their tools are called
 	EnableLazyLoading bool `json:"enable_lazy_loading" mapstructure:"enable-lazy-loading"`
 
+	// PrefetchOnConnect lists and indexes the tools of all connected servers in the background
+	// when the first client connects, so early retrieve_tools searches see a full index
+	PrefetchOnConnect bool `json:"prefetch_on_connect,omitempty" mapstructure:"prefetch-on-connect"`
+
 	// Tool cache TTL in seconds (default: 300 = 5 minutes)
 	ToolCacheTTL int `json:"tool_cache_ttl" mapstructure:"tool-cache-ttl"`
 
@@ -110,8 +201,37 @@ type Config struct {
 	// When true, auto-disable state is written to both database AND config file.
 	PersistAutoDisableToConfig bool `json:"persist_auto_disable_to_config,omitempty" mapstructure:"persist-auto-disable-to-config"`
 
+	// AutoQuarantineAfterFailures quarantines a server after this many consecutive connection or
+	// tool call failures, taking precedence over auto-disable (0 = off)
+	AutoQuarantineAfterFailures int `json:"auto_quarantine_after_failures,omitempty" mapstructure:"auto-quarantine-after-failures"`
+
+	// OAuthRefreshWindow is how long before expiry OAuth tokens are proactively refreshed (default: 5m)
+	OAuthRefreshWindow Duration `json:"oauth_refresh_window,omitempty" mapstructure:"oauth-refresh-window"`
+
+	// Reconnect backoff for disconnected upstream servers. Each failed attempt multiplies the
+	// delay by ReconnectMultiplier, starting at ReconnectInitialInterval and capped at
+	// ReconnectMaxInterval (defaults: 1s, 5m, 2). A successful connection resets the delay.
+	ReconnectInitialInterval Duration `json:"reconnect_initial_interval,omitempty" mapstructure:"reconnect-initial-interval"`
+	ReconnectMaxInterval     Duration `json:"reconnect_max_interval,omitempty" mapstructure:"reconnect-max-interval"`
+	ReconnectMultiplier      float64  `json:"reconnect_multiplier,omitempty" mapstructure:"reconnect-multiplier"`
+
+	// AuditLogEnabled records every upstream tool call (client, tool, args, status) in an
+	// append-only audit log that can be queried via /api/audit
+	AuditLogEnabled bool `json:"audit_log_enabled,omitempty" mapstructure:"audit-log-enabled"`
+
+	// AuditLogRetention is how long audit log entries are kept (default: DefaultAuditLogRetention)
+	AuditLogRetention Duration `json:"audit_log_retention,omitempty" mapstructure:"audit-log-retention"`
+
 	// Semantic search configuration
 	SemanticSearch *SemanticSearchConfig `json:"semantic_search,omitempty" mapstructure:"semantic-search"`
+
+	// migrated is set when MigrateConfig upgraded the config on load (runtime-only)
+	migrated bool
+
+	// Drop-in config files (runtime-only): server and group name -> fragment file it was loaded from
+	serverSources map[string]string
+	groupSources  map[string]string
+	fragmentFiles []string
 }
 
 // SemanticSearchConfig represents semantic search configuration
@@ -120,6 +240,10 @@ type SemanticSearchConfig struct {
 	HybridMode    bool    `json:"hybrid_mode" mapstructure:"hybrid-mode"`       // Combine BM25 and semantic search
 	HybridWeight  float64 `json:"hybrid_weight" mapstructure:"hybrid-weight"`   // Weight for semantic search in hybrid mode (0.0-1.0)
 	MinSimilarity float32 `json:"min_similarity" mapstructure:"min-similarity"` // Minimum similarity threshold (0.0-1.0)
+
+	// EmbeddingModel pins the embedding model (e.g. "all-MiniLM-L6-v2"); empty uses the service default.
+	// Cached embeddings are keyed by model, so changing it re-embeds all tools.
+	EmbeddingModel string `json:"embedding_model,omitempty" mapstructure:"embedding-model"`
 }
 
 // LLMConfig represents LLM provider configuration for AI Diagnostic Agent
@@ -134,6 +258,9 @@ type LLMConfig struct {
 	// Ollama specific settings
 	OllamaURL string `json:"ollama_url,omitempty" mapstructure:"ollama_url"` // Default: "http://localhost:11434"
 
+	// BaseURL points the diagnostic chat at an OpenAI-compatible API (e.g. LM Studio, vLLM)
+	BaseURL string `json:"base_url,omitempty" mapstructure:"base_url"` // Default: "https://api.openai.com/v1"
+
 	// General settings
 	Temperature float64 `json:"temperature,omitempty" mapstructure:"temperature"` // 0.0 - 1.0, default: 0.7
 	MaxTokens   int     `json:"max_tokens,omitempty" mapstructure:"max_tokens"`   // Default: 2000
@@ -171,13 +298,16 @@ type CommunicationLogConfig struct {
 // StartupScriptConfig represents configuration for an optional startup script that
 // runs when mcpproxy launches. The script can be managed via tray and MCP tools.
 type StartupScriptConfig struct {
-    Enabled     bool              `json:"enabled" mapstructure:"enabled"`
-    Path        string            `json:"path,omitempty" mapstructure:"path"`               // Script file path or shell command
-    Shell       string            `json:"shell,omitempty" mapstructure:"shell"`             // Shell to execute with -c (default: /bin/bash)
-    Args        []string          `json:"args,omitempty" mapstructure:"args"`               // Optional extra args to append after -c
-    WorkingDir  string            `json:"working_dir,omitempty" mapstructure:"working_dir"`
-    Env         map[string]string `json:"env,omitempty" mapstructure:"env"`
-    Timeout     Duration          `json:"timeout,omitempty" mapstructure:"timeout"`         // Optional max runtime before forced stop (0 = no timeout)
+    Enabled        bool              `json:"enabled" mapstructure:"enabled"`
+    Path           string            `json:"path,omitempty" mapstructure:"path"`                       // Script file path or shell command
+    CommandDarwin  string            `json:"command_darwin,omitempty" mapstructure:"command_darwin"`   // Used instead of Path on macOS
+    CommandLinux   string            `json:"command_linux,omitempty" mapstructure:"command_linux"`     // Used instead of Path on Linux
+    CommandWindows string            `json:"command_windows,omitempty" mapstructure:"command_windows"` // Used instead of Path on Windows
+    Shell          string            `json:"shell,omitempty" mapstructure:"shell"`                     // Shell to execute with -c (default: /bin/bash)
+    Args           []string          `json:"args,omitempty" mapstructure:"args"`                       // Optional extra args to append after -c
+    WorkingDir     string            `json:"working_dir,omitempty" mapstructure:"working_dir"`
+    Env            map[string]string `json:"env,omitempty" mapstructure:"env"`
+    Timeout        Duration          `json:"timeout,omitempty" mapstructure:"timeout"`                 // Optional max runtime before forced stop (0 = no timeout)
 }
 
 // ServerConfig represents upstream MCP server configuration
@@ -190,7 +320,10 @@ type ServerConfig struct {
 	Args          []string          `json:"args,omitempty" mapstructure:"args"`
 	WorkingDir    string            `json:"working_dir,omitempty" mapstructure:"working_dir"` // Working directory for stdio servers
 	Env           map[string]string `json:"env,omitempty" mapstructure:"env"`
+	EnvFile       string            `json:"env_file,omitempty" mapstructure:"env_file"` // Dotenv file merged into Env (inline Env wins)
+	Shell         string            `json:"shell,omitempty" mapstructure:"shell"`       // Shell that runs Command+Args, e.g. "sh -c" or "cmd /c" (empty = user's login shell)
 	Headers       map[string]string `json:"headers,omitempty" mapstructure:"headers"`        // For HTTP servers
+	HTTPProxy     string            `json:"http_proxy,omitempty" mapstructure:"http_proxy"`  // Proxy URL for this HTTP server only (empty = no per-server proxy)
 	OAuth         *OAuthConfig      `json:"oauth,omitempty" mapstructure:"oauth"`            // OAuth configuration
 	RepositoryURL string            `json:"repository_url,omitempty" mapstructure:"repository_url"` // GitHub/Repository URL for the MCP server
 
@@ -203,6 +336,7 @@ type ServerConfig struct {
 	Isolation                 *IsolationConfig  `json:"isolation,omitempty" mapstructure:"isolation"` // Per-server isolation settings
 	GroupID                   int               `json:"group_id,omitempty" mapstructure:"group_id"`       // Assigned group ID (new format)
 	GroupName                 string            `json:"group_name,omitempty" mapstructure:"group_name"`   // Assigned group name (legacy)
+	Tags                      []string          `json:"tags,omitempty" mapstructure:"tags"`               // Free-form labels; unlike groups a server can have many
 
 	// Connection history for prioritization
 	EverConnected             bool      `json:"ever_connected,omitempty" mapstructure:"ever_connected"`                         // Has this server ever successfully connected
@@ -219,14 +353,187 @@ type ServerConfig struct {
 	// Useful for slow-starting servers like uvx-based AWS servers
 	ConnectionTimeout         Duration  `json:"connection_timeout,omitempty" mapstructure:"connection_timeout"` // Connection timeout for this server
 
+	// Crash supervision for stdio servers - relaunch immediately with exponential backoff
+	RestartOnExit             bool      `json:"restart_on_exit,omitempty" mapstructure:"restart_on_exit"` // Relaunch the subprocess as soon as it exits unexpectedly
+	MaxRestarts               int       `json:"max_restarts,omitempty" mapstructure:"max_restarts"`       // Immediate restart attempts before falling back to periodic retry (0 = default: 5)
+
+	// Response size limits - per-server/per-tool overrides of the global tool_response_limit (0 = use global)
+	MaxResponseBytes          int            `json:"max_response_bytes,omitempty" mapstructure:"max_response_bytes"`           // Response limit for all tools of this server
+	ToolMaxResponseBytes      map[string]int `json:"tool_max_response_bytes,omitempty" mapstructure:"tool_max_response_bytes"` // Response limit per tool (unprefixed tool name)
+
+	// Protocol version pin - for legacy servers that break on the latest MCP version
+	ProtocolVersion           string    `json:"protocol_version,omitempty" mapstructure:"protocol_version"` // MCP protocol version sent in initialize (empty = latest)
+
+	// Result caching for idempotent tools - identical calls within CacheTTL are served from the cache
+	CacheableTools            []string  `json:"cacheable_tools,omitempty" mapstructure:"cacheable_tools"` // Unprefixed names of read-only tools whose results may be cached
+	CacheTTL                  Duration  `json:"cache_ttl,omitempty" mapstructure:"cache_ttl"`             // How long cached results are served (0 = default: 5m)
+
+	// Audit log redaction - arguments of these tools are never written to the audit log
+	SensitiveTools            []string  `json:"sensitive_tools,omitempty" mapstructure:"sensitive_tools"` // Unprefixed names of tools whose arguments are redacted
+
+	// Read-only mode - only tools known to be read-only can be called; all tools stay indexed
+	ReadOnly                  bool      `json:"read_only,omitempty" mapstructure:"read_only"`     // Refuse calls to tools not annotated or listed as read-only
+	ReadTools                 []string  `json:"read_tools,omitempty" mapstructure:"read_tools"`   // Unprefixed names of tools allowed in read-only mode without a readOnlyHint annotation
+	WriteTools                []string  `json:"write_tools,omitempty" mapstructure:"write_tools"` // Unprefixed names of tools blocked in read-only mode, whatever their annotations
+
+	// Experimental - calls are allowed, but agents are warned in tool search and call results
+	Experimental              bool      `json:"experimental,omitempty" mapstructure:"experimental"` // Warn agents to treat the server's tools with care
+
+	// Search priority - steers retrieve_tools toward preferred servers without hiding the others
+	SearchPriority            int       `json:"search_priority,omitempty" mapstructure:"search_priority"` // Each point scales the server's tool scores by 10% (0 = no boost, negative = demote)
+
+	// Call retry - a call that fails with a connection error is replayed once after reconnecting
+	DisableCallRetry          bool      `json:"disable_call_retry,omitempty" mapstructure:"disable_call_retry"` // Never replay calls (for servers with non-idempotent tools)
+
+	// Durable calls - calls are queued in the database, answered with a call ID and replayed after a proxy restart
+	Durable                   bool      `json:"durable,omitempty" mapstructure:"durable"` // Poll results with get_call_result
+
+	// Startup ordering - these servers are connected first and must reach connected before this one starts
+	DependsOn                 []string  `json:"depends_on,omitempty" mapstructure:"depends_on"` // Names of servers this server depends on
+
+	// Concurrency limit - calls beyond the limit queue until a slot frees or the call deadline passes
+	MaxConcurrentCalls        int       `json:"max_concurrent_calls,omitempty" mapstructure:"max_concurrent_calls"` // Max in-flight tool calls to this server (0 = unlimited)
+
+	// Call duration limit - per-server override of the global max_call_duration for clients without a deadline
+	MaxCallDuration           Duration  `json:"max_call_duration,omitempty" mapstructure:"max_call_duration"` // Max duration of a tool call to this server (0 = use global)
+
+	// Idle disconnect - the connection is closed after this long without calls and reopened on the next call
+	IdleDisconnectAfter       Duration  `json:"idle_disconnect_after,omitempty" mapstructure:"idle_disconnect_after"` // Idle time before the server is put to sleep (0 = never)
+
+	// Default arguments - merged into every tool call to this server; arguments passed by the caller win
+	DefaultArgs               map[string]interface{} `json:"default_args,omitempty" mapstructure:"default_args"` // Top-level arguments added to each call
+
 	// Auto-disable state - persisted across restarts
 	AutoDisableReason         string    `json:"auto_disable_reason,omitempty" mapstructure:"auto_disable_reason"` // Reason for auto-disable
 
+	// Quarantine reason - set when the server was quarantined automatically, cleared on unquarantine
+	QuarantineReason          string    `json:"quarantine_reason,omitempty" mapstructure:"quarantine_reason"` // Why the server was quarantined
+
 	// NOTE: "Stopped" field has been REMOVED - it was runtime-only state that should NOT be persisted
 	// Use StateManager.IsUserStopped() / SetUserStopped() for runtime-only stopped state
 	// When app restarts, all servers return to their original startup_mode (no persisted "stopped" state)
 }
 
+// GetMaxRestarts returns the number of immediate restart attempts for a crashed
+// stdio subprocess, falling back to DefaultMaxRestarts when not configured.
+func (s *ServerConfig) GetMaxRestarts() int {
+	if s.MaxRestarts > 0 {
+		return s.MaxRestarts
+	}
+	return DefaultMaxRestarts
+}
+
+// GetEnvFilePath returns the path of EnvFile with "~/" expanded. A relative path is resolved
+// against WorkingDir when one is set.
+func (s *ServerConfig) GetEnvFilePath() string {
+	path := s.EnvFile
+	if path == "" {
+		return ""
+	}
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+	if !filepath.IsAbs(path) && s.WorkingDir != "" {
+		path = filepath.Join(s.WorkingDir, path)
+	}
+	return filepath.Clean(path)
+}
+
+// IsToolCacheable reports whether results of the given (unprefixed) tool may be cached
+func (s *ServerConfig) IsToolCacheable(toolName string) bool {
+	for _, name := range s.CacheableTools {
+		if name == toolName {
+			return true
+		}
+	}
+	return false
+}
+
+// IsToolSensitive reports whether arguments of the given (unprefixed) tool must be redacted
+func (s *ServerConfig) IsToolSensitive(toolName string) bool {
+	for _, name := range s.SensitiveTools {
+		if name == toolName {
+			return true
+		}
+	}
+	return false
+}
+
+// GetCacheTTL returns how long cached tool results of this server are served,
+// falling back to DefaultToolResultCacheTTL when not configured.
+func (s *ServerConfig) GetCacheTTL() time.Duration {
+	if s.CacheTTL.Duration() > 0 {
+		return s.CacheTTL.Duration()
+	}
+	return DefaultToolResultCacheTTL
+}
+
+// GetResponseLimit returns the effective response size limit for a tool of this server.
+// A per-tool limit takes precedence over the per-server MaxResponseBytes, which in turn
+// overrides the global limit passed in.
+func (s *ServerConfig) GetResponseLimit(toolName string, globalLimit int) int {
+	if limit, ok := s.ToolMaxResponseBytes[toolName]; ok && limit > 0 {
+		return limit
+	}
+	if s.MaxResponseBytes > 0 {
+		return s.MaxResponseBytes
+	}
+	return globalLimit
+}
+
+// MergeDefaultArgs returns args with the server's default_args added. Only arguments declared
+// in the properties of the tool's input schema (paramsJSON) are added, so tools that don't take
+// them (or reject unknown arguments) are unaffected. Arguments passed by the caller take
+// precedence; args itself is never modified.
+func (s *ServerConfig) MergeDefaultArgs(args map[string]interface{}, paramsJSON string) map[string]interface{} {
+	if s == nil || len(s.DefaultArgs) == 0 || paramsJSON == "" {
+		return args
+	}
+	var schema struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal([]byte(paramsJSON), &schema); err != nil || len(schema.Properties) == 0 {
+		return args
+	}
+
+	merged := make(map[string]interface{}, len(s.DefaultArgs)+len(args))
+	for key, value := range s.DefaultArgs {
+		if _, declared := schema.Properties[key]; declared {
+			merged[key] = value
+		}
+	}
+	for key, value := range args {
+		merged[key] = value
+	}
+	return merged
+}
+
+// HasTags reports whether the server carries the given tags (case-insensitive).
+// With matchAll it must carry every tag, otherwise any one of them is enough.
+func (s *ServerConfig) HasTags(tags []string, matchAll bool) bool {
+	if len(tags) == 0 {
+		return false
+	}
+	for _, want := range tags {
+		found := false
+		for _, have := range s.Tags {
+			if strings.EqualFold(have, want) {
+				found = true
+				break
+			}
+		}
+		if found && !matchAll {
+			return true
+		}
+		if !found && matchAll {
+			return false
+		}
+	}
+	return matchAll
+}
+
 // ShouldConnectOnStartup determines if the server should connect when mcpproxy starts
 // based on the StartupMode field
 func (s *ServerConfig) ShouldConnectOnStartup() bool {
@@ -238,11 +545,105 @@ func (s *ServerConfig) IsQuarantined() bool {
 	return s.StartupMode == "quarantined"
 }
 
+// SearchBoost returns the multiplier applied to the server's retrieve_tools scores: 10% per
+// SearchPriority point, never below 0.1 so demoted tools still rank among themselves
+func (s *ServerConfig) SearchBoost() float64 {
+	boost := 1 + float64(s.SearchPriority)/10
+	if boost < 0.1 {
+		return 0.1
+	}
+	return boost
+}
+
 // IsDisabled determines if the server is disabled
 func (s *ServerConfig) IsDisabled() bool {
 	return s.StartupMode == "disabled" || s.StartupMode == "auto_disabled"
 }
 
+// GetProtocolVersion returns the MCP protocol version to request during initialize:
+// the pinned ProtocolVersion if set, otherwise the latest version supported by mcp-go.
+func (s *ServerConfig) GetProtocolVersion() string {
+	if s.ProtocolVersion != "" {
+		return s.ProtocolVersion
+	}
+	return mcp.LATEST_PROTOCOL_VERSION
+}
+
+// GetMaxConcurrentDiscovery returns how many servers may be probed for tools at once:
+// max_concurrent_discovery if set, otherwise max_concurrent_connections (default: 10)
+func (c *Config) GetMaxConcurrentDiscovery() int {
+	if c == nil {
+		return 10
+	}
+	if c.MaxConcurrentDiscovery > 0 {
+		return c.MaxConcurrentDiscovery
+	}
+	if c.MaxConcurrentConnections > 0 {
+		return c.MaxConcurrentConnections
+	}
+	return 10
+}
+
+// GetShutdownTimeout returns the grace period for a graceful shutdown:
+// shutdown_timeout if set, otherwise DefaultShutdownTimeout
+func (c *Config) GetShutdownTimeout() time.Duration {
+	if c != nil && c.ShutdownTimeout > 0 {
+		return c.ShutdownTimeout.Duration()
+	}
+	return DefaultShutdownTimeout
+}
+
+// GetAuditLogRetention returns how long audit log entries are kept: audit_log_retention if
+// set, otherwise DefaultAuditLogRetention
+func (c *Config) GetAuditLogRetention() time.Duration {
+	if c != nil && c.AuditLogRetention > 0 {
+		return c.AuditLogRetention.Duration()
+	}
+	return DefaultAuditLogRetention
+}
+
+// IsBuiltinToolDisabled reports whether the built-in tool name is listed in disabled_builtin_tools
+func (c *Config) IsBuiltinToolDisabled(name string) bool {
+	if c == nil {
+		return false
+	}
+	for _, disabled := range c.DisabledBuiltinTools {
+		if strings.TrimSpace(disabled) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// GetCompressionMinBytes returns the smallest MCP response that is compressed
+func (c *Config) GetCompressionMinBytes() int {
+	if c.CompressionMinBytes > 0 {
+		return c.CompressionMinBytes
+	}
+	return DefaultCompressionMinBytes
+}
+
+// GetStatusBufferSize returns how many status updates are queued before updates are dropped
+func (c *Config) GetStatusBufferSize() int {
+	if c.StatusBufferSize > 0 {
+		return c.StatusBufferSize
+	}
+	return DefaultStatusBufferSize
+}
+
+// GetMaxCallDuration returns the bound for tool calls to server from clients without a deadline:
+// the server's max_call_duration if set, otherwise the global one. Zero means no bound beyond
+// call_tool_timeout, which applies in any case.
+func (c *Config) GetMaxCallDuration(server *ServerConfig) time.Duration {
+	if server != nil && server.MaxCallDuration > 0 {
+		return server.MaxCallDuration.Duration()
+	}
+	if c != nil && c.MaxCallDuration > 0 {
+		return c.MaxCallDuration.Duration()
+	}
+	return 0
+}
+
 // GetConnectionTimeout returns the effective connection timeout for this server.
 // If a per-server timeout is configured (ConnectionTimeout > 0), it uses that.
 // Otherwise, it returns the global DefaultConnectionTimeout.
@@ -295,29 +696,64 @@ type OAuthConfig struct {
 
 // DockerIsolationConfig represents global Docker isolation settings
 type DockerIsolationConfig struct {
-	Enabled       bool              `json:"enabled" mapstructure:"enabled"`                       // Global enable/disable for Docker isolation
-	DefaultImages map[string]string `json:"default_images" mapstructure:"default_images"`         // Map of runtime type to Docker image
-	Registry      string            `json:"registry,omitempty" mapstructure:"registry"`           // Custom registry (defaults to docker.io)
-	NetworkMode   string            `json:"network_mode,omitempty" mapstructure:"network_mode"`   // Docker network mode (default: bridge)
-	MemoryLimit   string            `json:"memory_limit,omitempty" mapstructure:"memory_limit"`   // Memory limit for containers
-	CPULimit      string            `json:"cpu_limit,omitempty" mapstructure:"cpu_limit"`         // CPU limit for containers
-	Timeout       Duration          `json:"timeout,omitempty" mapstructure:"timeout"`             // Container startup timeout
-	ExtraArgs     []string          `json:"extra_args,omitempty" mapstructure:"extra_args"`       // Additional docker run arguments
-	LogDriver     string            `json:"log_driver,omitempty" mapstructure:"log_driver"`       // Docker log driver (default: json-file)
-	LogMaxSize    string            `json:"log_max_size,omitempty" mapstructure:"log_max_size"`   // Maximum size of log files (default: 100m)
-	LogMaxFiles   string            `json:"log_max_files,omitempty" mapstructure:"log_max_files"` // Maximum number of log files (default: 3)
+	Enabled        bool              `json:"enabled" mapstructure:"enabled"`                           // Global enable/disable for Docker isolation
+	DefaultImages  map[string]string `json:"default_images" mapstructure:"default_images"`             // Map of runtime type to Docker image
+	Registry       string            `json:"registry,omitempty" mapstructure:"registry"`               // Custom registry (defaults to docker.io)
+	NetworkMode    string            `json:"network_mode,omitempty" mapstructure:"network_mode"`       // Docker network mode (default: bridge)
+	MemoryLimit    string            `json:"memory_limit,omitempty" mapstructure:"memory_limit"`       // Memory limit for containers
+	CPULimit       string            `json:"cpu_limit,omitempty" mapstructure:"cpu_limit"`             // CPU limit for containers
+	Timeout        Duration          `json:"timeout,omitempty" mapstructure:"timeout"`                 // Container startup timeout
+	ExtraArgs      []string          `json:"extra_args,omitempty" mapstructure:"extra_args"`           // Additional docker run arguments
+	LogDriver      string            `json:"log_driver,omitempty" mapstructure:"log_driver"`           // Docker log driver (default: json-file)
+	LogMaxSize     string            `json:"log_max_size,omitempty" mapstructure:"log_max_size"`       // Maximum size of log files (default: 100m)
+	LogMaxFiles    string            `json:"log_max_files,omitempty" mapstructure:"log_max_files"`     // Maximum number of log files (default: 3)
+	CleanupOrphans bool              `json:"cleanup_orphans,omitempty" mapstructure:"cleanup_orphans"` // Remove orphaned containers found while running (default: only report them)
 }
 
 // IsolationConfig represents per-server isolation settings
 type IsolationConfig struct {
-	Enabled     bool     `json:"enabled" mapstructure:"enabled"`                       // Enable Docker isolation for this server
-	Image       string   `json:"image,omitempty" mapstructure:"image"`                 // Custom Docker image (overrides default)
-	NetworkMode string   `json:"network_mode,omitempty" mapstructure:"network_mode"`   // Custom network mode for this server
-	ExtraArgs   []string `json:"extra_args,omitempty" mapstructure:"extra_args"`       // Additional docker run arguments for this server
-	WorkingDir  string   `json:"working_dir,omitempty" mapstructure:"working_dir"`     // Custom working directory in container
-	LogDriver   string   `json:"log_driver,omitempty" mapstructure:"log_driver"`       // Docker log driver override for this server
-	LogMaxSize  string   `json:"log_max_size,omitempty" mapstructure:"log_max_size"`   // Maximum size of log files override
-	LogMaxFiles string   `json:"log_max_files,omitempty" mapstructure:"log_max_files"` // Maximum number of log files override
+	Enabled         bool     `json:"enabled" mapstructure:"enabled"`                               // Enable Docker isolation for this server
+	Image           string   `json:"image,omitempty" mapstructure:"image"`                         // Custom Docker image (overrides default)
+	NetworkMode     string   `json:"network_mode,omitempty" mapstructure:"network_mode"`           // Custom network mode for this server
+	ExtraArgs       []string `json:"extra_args,omitempty" mapstructure:"extra_args"`               // Additional docker run arguments for this server
+	WorkingDir      string   `json:"working_dir,omitempty" mapstructure:"working_dir"`             // Custom working directory in container
+	LogDriver       string   `json:"log_driver,omitempty" mapstructure:"log_driver"`               // Docker log driver override for this server
+	LogMaxSize      string   `json:"log_max_size,omitempty" mapstructure:"log_max_size"`           // Maximum size of log files override
+	LogMaxFiles     string   `json:"log_max_files,omitempty" mapstructure:"log_max_files"`         // Maximum number of log files override
+	MemoryLimitMB   int      `json:"memory_limit_mb,omitempty" mapstructure:"memory_limit_mb"`     // Container memory limit in MB (docker --memory), overrides memory_limit
+	CPUShares       float64  `json:"cpu_shares,omitempty" mapstructure:"cpu_shares"`               // Number of CPUs the container may use (docker --cpus), overrides cpu_limit
+	ImagePullPolicy string   `json:"image_pull_policy,omitempty" mapstructure:"image_pull_policy"` // When to docker pull the image before launch: always, ifnotpresent (default) or never
+}
+
+// Docker image pull policies for isolated servers
+const (
+	ImagePullAlways       = "always"
+	ImagePullIfNotPresent = "ifnotpresent"
+	ImagePullNever        = "never"
+)
+
+// GetImagePullPolicy returns the configured image pull policy, defaulting to ifnotpresent
+func (i *IsolationConfig) GetImagePullPolicy() string {
+	if i == nil || i.ImagePullPolicy == "" {
+		return ImagePullIfNotPresent
+	}
+	return i.ImagePullPolicy
+}
+
+// Validate checks that resource limits, when set, are positive and the pull policy is known
+func (i *IsolationConfig) Validate() error {
+	if i.MemoryLimitMB < 0 {
+		return fmt.Errorf("invalid isolation memory_limit_mb: %d (must be positive)", i.MemoryLimitMB)
+	}
+	if i.CPUShares < 0 {
+		return fmt.Errorf("invalid isolation cpu_shares: %g (must be positive)", i.CPUShares)
+	}
+	switch i.GetImagePullPolicy() {
+	case ImagePullAlways, ImagePullIfNotPresent, ImagePullNever:
+	default:
+		return fmt.Errorf("invalid isolation image_pull_policy: %s (must be one of: always, ifnotpresent, never)", i.ImagePullPolicy)
+	}
+	return nil
 }
 
 // GroupConfig represents a server group configuration
@@ -439,6 +875,38 @@ type ToolMetadata struct {
 	Hash        string    `json:"hash"`
 	Created     time.Time `json:"created"`
 	Updated     time.Time `json:"updated"`
+
+	// MCP tool annotations used by read-only servers (nil when the server doesn't set them)
+	ReadOnlyHint    *bool `json:"read_only_hint,omitempty"`
+	DestructiveHint *bool `json:"destructive_hint,omitempty"`
+}
+
+// ResourceMetadata represents resource information stored in the index
+type ResourceMetadata struct {
+	URI         string    `json:"uri"` // Unprefixed URI as reported by the upstream server
+	ServerName  string    `json:"server_name"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	MIMEType    string    `json:"mime_type,omitempty"`
+	Created     time.Time `json:"created"`
+	Updated     time.Time `json:"updated"`
+}
+
+// PromptMetadata represents prompt information stored in the index
+type PromptMetadata struct {
+	Name        string           `json:"name"` // Unprefixed prompt name as reported by the upstream server
+	ServerName  string           `json:"server_name"`
+	Description string           `json:"description"`
+	Arguments   []PromptArgument `json:"arguments,omitempty"`
+	Created     time.Time        `json:"created"`
+	Updated     time.Time        `json:"updated"`
+}
+
+// PromptArgument describes an argument of a prompt template
+type PromptArgument struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
 }
 
 // ToolRegistration represents a tool registration
@@ -539,6 +1007,7 @@ func DefaultDockerIsolationConfig() *DockerIsolationConfig {
 // DefaultConfig returns a default configuration
 func DefaultConfig() *Config {
 	return &Config{
+		ConfigVersion:     CurrentConfigVersion,
 		Listen:            defaultPort,
 		DataDir:           "", // Will be set to ~/.mcpproxy by loader
 		EnableTray:        true,
@@ -575,6 +1044,9 @@ func DefaultConfig() *Config {
 		// Prompts enabled by default
 		EnablePrompts: true,
 
+		// Diagnostic chat enabled by default
+		DiagnosticChatEnabled: true,
+
 		// Repository detection enabled by default
 		CheckServerRepo: true,
 
@@ -671,6 +1143,47 @@ func (c *Config) Validate() error {
 	if c.Listen == "" {
 		c.Listen = defaultPort
 	}
+	if path, ok := UnixSocketPath(c.Listen); ok && path == "" {
+		return fmt.Errorf("listen: unix socket address %q has no path", c.Listen)
+	}
+	if _, err := c.GetListenSocketMode(); err != nil {
+		return err
+	}
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		return fmt.Errorf("tls_cert_file and tls_key_file must be set together")
+	}
+	if c.TLSRedirectListen != "" && !c.TLSEnabled() {
+		return fmt.Errorf("tls_redirect_listen requires tls_cert_file and tls_key_file")
+	}
+	if c.MaxHeaderBytes < 0 {
+		return fmt.Errorf("max_header_bytes must not be negative")
+	}
+	if c.MaxRequestBodyBytes < 0 {
+		return fmt.Errorf("max_request_body_bytes must not be negative")
+	}
+	if c.CompressionMinBytes < 0 {
+		return fmt.Errorf("compression_min_bytes must not be negative")
+	}
+	if c.StatusBufferSize < 0 {
+		return fmt.Errorf("status_buffer_size must not be negative")
+	}
+	if c.MaxCallDuration < 0 {
+		return fmt.Errorf("max_call_duration must not be negative")
+	}
+	if c.ShutdownTimeout < 0 {
+		return fmt.Errorf("shutdown_timeout must not be negative")
+	}
+	if c.AuditLogRetention < 0 {
+		return fmt.Errorf("audit_log_retention must not be negative")
+	}
+	if c.AutoQuarantineAfterFailures < 0 {
+		return fmt.Errorf("auto_quarantine_after_failures must not be negative")
+	}
+	for _, name := range c.FeaturedTools {
+		if serverName, toolName, found := strings.Cut(name, ":"); !found || serverName == "" || toolName == "" {
+			return fmt.Errorf("featured_tools: %q is not a prefixed tool name (expected server:tool)", name)
+		}
+	}
 	if c.TopK <= 0 {
 		c.TopK = 5
 	}
@@ -680,6 +1193,15 @@ func (c *Config) Validate() error {
 	if c.ToolResponseLimit < 0 {
 		c.ToolResponseLimit = 0 // 0 means disabled
 	}
+	if c.MaxDescriptionLength < 0 {
+		c.MaxDescriptionLength = 0 // 0 means full descriptions
+	}
+	if c.MaxToolsPerServer < 0 {
+		c.MaxToolsPerServer = 0 // 0 means no limit
+	}
+	if c.MaxTotalTools < 0 {
+		c.MaxTotalTools = 0 // 0 means no limit
+	}
 	if c.CallToolTimeout.Duration() <= 0 {
 		c.CallToolTimeout = Duration(2 * time.Minute) // Default to 2 minutes
 	}
@@ -815,6 +1337,12 @@ func (c *Config) Validate() error {
 		c.SemanticSearch.MinSimilarity = 1
 	}
 
+	if c.UpdateWindow != "" {
+		if _, _, err := ParseUpdateWindow(c.UpdateWindow); err != nil {
+			return err
+		}
+	}
+
 	// Validate server configurations
 	for _, server := range c.Servers {
 		// Validate startup_mode if set
@@ -823,11 +1351,86 @@ func (c *Config) Validate() error {
 				return fmt.Errorf("server %s: %w", server.Name, err)
 			}
 		}
+		// Validate pinned protocol_version if set
+		if server.ProtocolVersion != "" {
+			if err := ValidateProtocolVersion(server.ProtocolVersion); err != nil {
+				return fmt.Errorf("server %s: %w", server.Name, err)
+			}
+		}
+		if server.MaxCallDuration < 0 {
+			return fmt.Errorf("server %s: max_call_duration must not be negative", server.Name)
+		}
+		if server.IdleDisconnectAfter < 0 {
+			return fmt.Errorf("server %s: idle_disconnect_after must not be negative", server.Name)
+		}
+		if err := server.validateHTTPProxy(); err != nil {
+			return fmt.Errorf("server %s: %w", server.Name, err)
+		}
+		// Validate isolation resource limits if set
+		if server.Isolation != nil {
+			if err := server.Isolation.Validate(); err != nil {
+				return fmt.Errorf("server %s: %w", server.Name, err)
+			}
+		}
+	}
+
+	// Startup dependencies must not form a cycle, or dependents would never start
+	if err := ValidateServerDependencies(c.Servers); err != nil {
+		return err
 	}
 
 	return nil
 }
 
+// ListenAddress returns the address the HTTP server should bind to.
+// When BindLoopbackOnly is set, the host part of Listen is replaced with 127.0.0.1.
+// Unix socket addresses are returned unchanged.
+func (c *Config) ListenAddress() string {
+	listen := c.Listen
+	if listen == "" {
+		listen = defaultPort
+	}
+	if _, ok := UnixSocketPath(listen); ok || !c.BindLoopbackOnly {
+		return listen
+	}
+
+	_, port, err := net.SplitHostPort(listen)
+	if err != nil {
+		// Listen without a port (e.g. "0.0.0.0"), keep the default port
+		_, port, _ = net.SplitHostPort(defaultPort)
+	}
+	return net.JoinHostPort(loopbackHost, port)
+}
+
+// TLSEnabled reports whether the HTTP server serves HTTPS
+func (c *Config) TLSEnabled() bool {
+	return c.TLSCertFile != "" && c.TLSKeyFile != ""
+}
+
+// IsListenExternal reports whether the effective listen address accepts
+// connections from other hosts (all interfaces or a non-loopback address)
+func (c *Config) IsListenExternal() bool {
+	if _, ok := UnixSocketPath(c.ListenAddress()); ok {
+		return false // Access is controlled by the socket file's permissions
+	}
+	host, _, err := net.SplitHostPort(c.ListenAddress())
+	if err != nil {
+		return true
+	}
+	switch host {
+	case "":
+		return true
+	case "localhost":
+		return false
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		// Hostnames other than localhost may resolve to external interfaces
+		return true
+	}
+	return !ip.IsLoopback()
+}
+
 // ValidateStartupMode validates that a startup_mode value is valid
 func ValidateStartupMode(mode string) error {
 	validModes := map[string]bool{
@@ -845,6 +1448,16 @@ func ValidateStartupMode(mode string) error {
 	return nil
 }
 
+// ValidateProtocolVersion checks that version is a known MCP protocol version
+func ValidateProtocolVersion(version string) error {
+	for _, v := range mcp.ValidProtocolVersions {
+		if v == version {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid protocol_version: %s (must be one of: %s)", version, strings.Join(mcp.ValidProtocolVersions, ", "))
+}
+
 // MarshalJSON implements json.Marshaler interface
 func (c *Config) MarshalJSON() ([]byte, error) {
 	type Alias Config
@@ -6,11 +6,16 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/fsnotify/fsnotify"
 	"go.uber.org/zap"
 )
 
+// configReloadDebounce is how long the config file must be quiet before it is reloaded, so
+// a file written in several steps is loaded once it is complete
+const configReloadDebounce = 200 * time.Millisecond
+
 // Loader manages configuration loading, watching, and atomic updates.
 type Loader struct {
 	mu             sync.Mutex
@@ -62,8 +67,9 @@ func (l *Loader) StartWatching(onChange func(*Config) error) error {
 	l.onChange = onChange
 	l.mu.Unlock()
 
-	// Add the config file to the watcher
-	if err := l.watcher.Add(l.configPath); err != nil {
+	// Watch the directory rather than the file: editors that save by renaming or deleting and
+	// recreating the file would otherwise silently end a watch on the file itself
+	if err := l.watcher.Add(filepath.Dir(l.configPath)); err != nil {
 		return fmt.Errorf("failed to watch config file: %w", err)
 	}
 
@@ -78,18 +84,31 @@ func (l *Loader) StartWatching(onChange func(*Config) error) error {
 
 // watchLoop runs the file watching loop.
 func (l *Loader) watchLoop() {
+	var reload <-chan time.Time
+
 	for {
 		select {
 		case event, ok := <-l.watcher.Events:
 			if !ok {
 				return
 			}
+			if filepath.Base(event.Name) != filepath.Base(l.configPath) {
+				continue // Another file in the config directory
+			}
 
-			// Handle file modifications
-			if event.Op&fsnotify.Write == fsnotify.Write {
-				l.handleFileChange()
+			switch {
+			case event.Has(fsnotify.Write) || event.Has(fsnotify.Create):
+				reload = time.After(configReloadDebounce)
+			case event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename):
+				// Often transient (rename-on-save); the file is reloaded once it is recreated
+				l.logger.Warn("Configuration file was removed or renamed, keeping the current configuration until it is recreated",
+					zap.String("path", l.configPath))
 			}
 
+		case <-reload:
+			reload = nil
+			l.handleFileChange()
+
 		case err, ok := <-l.watcher.Errors:
 			if !ok {
 				return
@@ -116,6 +135,12 @@ func (l *Loader) handleFileChange() {
 
 	l.mu.Unlock()
 
+	if _, err := os.Stat(l.configPath); os.IsNotExist(err) {
+		l.logger.Warn("Configuration file is missing, keeping the current configuration",
+			zap.String("path", l.configPath))
+		return
+	}
+
 	// Reload configuration
 	l.logger.Info("Configuration file changed, reloading...")
 
@@ -408,3 +408,39 @@ func TestLoader_SkipNextReload(t *testing.T) {
 	// Verify config was updated
 	assert.Equal(t, ":9090", loader.GetConfig().Listen)
 }
+
+func TestLoader_FileWatching_SurvivesRemoveAndRename(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.json")
+
+	writeConfig := func(path, listen string) {
+		cfg := DefaultConfig()
+		cfg.Listen = listen
+		data, err := json.MarshalIndent(cfg, "", "  ")
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, data, 0644))
+	}
+	writeConfig(configPath, ":8080")
+
+	loader, err := NewLoader(configPath, zap.NewNop())
+	require.NoError(t, err)
+	defer loader.Stop()
+
+	_, err = loader.Load()
+	require.NoError(t, err)
+	require.NoError(t, loader.StartWatching(func(*Config) error { return nil }))
+
+	// Rename-on-save: the new content is written elsewhere and moved over the config file
+	writeConfig(configPath+".swp", ":8081")
+	require.NoError(t, os.Rename(configPath+".swp", configPath))
+	assert.Eventually(t, func() bool { return loader.GetConfig().Listen == ":8081" }, 2*time.Second, 50*time.Millisecond)
+
+	// A deleted config file keeps the current configuration...
+	require.NoError(t, os.Remove(configPath))
+	time.Sleep(2 * configReloadDebounce)
+	assert.Equal(t, ":8081", loader.GetConfig().Listen)
+
+	// ...and is picked up again when recreated
+	writeConfig(configPath, ":8082")
+	assert.Eventually(t, func() bool { return loader.GetConfig().Listen == ":8082" }, 2*time.Second, 50*time.Millisecond)
+}
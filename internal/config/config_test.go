@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -90,6 +91,18 @@ func TestConfigValidation(t *testing.T) {
 				ToolResponseLimit: 0,
 			},
 		},
+		{
+			name: "negative MaxDescriptionLength defaults to 0",
+			config: &Config{
+				MaxDescriptionLength: -1,
+			},
+			expected: &Config{
+				Listen:               ":8080",
+				TopK:                 5,
+				ToolsLimit:           15,
+				MaxDescriptionLength: 0,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -100,6 +113,7 @@ func TestConfigValidation(t *testing.T) {
 			assert.Equal(t, tt.expected.TopK, tt.config.TopK)
 			assert.Equal(t, tt.expected.ToolsLimit, tt.config.ToolsLimit)
 			assert.Equal(t, tt.expected.ToolResponseLimit, tt.config.ToolResponseLimit)
+			assert.Equal(t, tt.expected.MaxDescriptionLength, tt.config.MaxDescriptionLength)
 		})
 	}
 }
@@ -534,3 +548,278 @@ func TestCreateSampleConfig(t *testing.T) {
 		t.Error("Expected sample config to have 'local-command' server")
 	}
 }
+
+func TestListenAddress(t *testing.T) {
+	tests := []struct {
+		name         string
+		listen       string
+		loopbackOnly bool
+		expected     string
+		external     bool
+	}{
+		{name: "default all interfaces", listen: ":8080", expected: ":8080", external: true},
+		{name: "explicit all interfaces", listen: "0.0.0.0:8080", expected: "0.0.0.0:8080", external: true},
+		{name: "loopback", listen: "127.0.0.1:8080", expected: "127.0.0.1:8080", external: false},
+		{name: "localhost", listen: "localhost:9000", expected: "localhost:9000", external: false},
+		{name: "ipv6 loopback", listen: "[::1]:8080", expected: "[::1]:8080", external: false},
+		{name: "ipv6 all interfaces", listen: "[::]:8080", expected: "[::]:8080", external: true},
+		{name: "loopback only overrides host", listen: "0.0.0.0:9000", loopbackOnly: true, expected: "127.0.0.1:9000", external: false},
+		{name: "loopback only with port only", listen: ":8080", loopbackOnly: true, expected: "127.0.0.1:8080", external: false},
+		{name: "loopback only with empty listen", listen: "", loopbackOnly: true, expected: "127.0.0.1:8080", external: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Listen: tt.listen, BindLoopbackOnly: tt.loopbackOnly}
+			assert.Equal(t, tt.expected, cfg.ListenAddress())
+			assert.Equal(t, tt.external, cfg.IsListenExternal())
+		})
+	}
+}
+
+func TestServerConfigHasTags(t *testing.T) {
+	server := &ServerConfig{Name: "github", Tags: []string{"prod", "ReadOnly"}}
+
+	tests := []struct {
+		name     string
+		tags     []string
+		matchAll bool
+		expected bool
+	}{
+		{name: "any single match", tags: []string{"prod"}, expected: true},
+		{name: "any with one unknown tag", tags: []string{"experimental", "prod"}, expected: true},
+		{name: "any case-insensitive", tags: []string{"readonly"}, expected: true},
+		{name: "any no match", tags: []string{"experimental"}, expected: false},
+		{name: "all present", tags: []string{"prod", "readonly"}, matchAll: true, expected: true},
+		{name: "all with one missing", tags: []string{"prod", "experimental"}, matchAll: true, expected: false},
+		{name: "no tags requested", tags: nil, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, server.HasTags(tt.tags, tt.matchAll))
+		})
+	}
+}
+
+func TestServerConfigGetEnvFilePath(t *testing.T) {
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+
+	server := &ServerConfig{Name: "github"}
+	assert.Equal(t, "", server.GetEnvFilePath())
+
+	server.EnvFile = "/etc/mcp/github.env"
+	assert.Equal(t, "/etc/mcp/github.env", server.GetEnvFilePath())
+
+	server.EnvFile = "~/.mcp/github.env"
+	assert.Equal(t, filepath.Join(home, ".mcp", "github.env"), server.GetEnvFilePath())
+
+	server.EnvFile = ".env"
+	server.WorkingDir = "/srv/github"
+	assert.Equal(t, filepath.Join("/srv/github", ".env"), server.GetEnvFilePath())
+}
+
+func TestServerConfigProtocolVersion(t *testing.T) {
+	server := &ServerConfig{Name: "legacy"}
+	assert.Equal(t, mcp.LATEST_PROTOCOL_VERSION, server.GetProtocolVersion())
+
+	server.ProtocolVersion = "2024-11-05"
+	assert.Equal(t, "2024-11-05", server.GetProtocolVersion())
+
+	cfg := DefaultConfig()
+	cfg.Servers = []*ServerConfig{server}
+	require.NoError(t, cfg.Validate())
+
+	server.ProtocolVersion = "2023-01-01"
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid protocol_version")
+}
+
+func TestIsolationConfigValidate(t *testing.T) {
+	assert.NoError(t, (&IsolationConfig{}).Validate())
+	assert.NoError(t, (&IsolationConfig{MemoryLimitMB: 512, CPUShares: 0.5}).Validate())
+	assert.Error(t, (&IsolationConfig{MemoryLimitMB: -1}).Validate())
+	assert.Error(t, (&IsolationConfig{CPUShares: -0.5}).Validate())
+
+	cfg := DefaultConfig()
+	cfg.Servers = []*ServerConfig{{Name: "boxed", Command: "python", Isolation: &IsolationConfig{Enabled: true, CPUShares: -1}}}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cpu_shares")
+}
+
+func TestIsolationConfigImagePullPolicy(t *testing.T) {
+	var unset *IsolationConfig
+	assert.Equal(t, ImagePullIfNotPresent, unset.GetImagePullPolicy())
+	assert.Equal(t, ImagePullIfNotPresent, (&IsolationConfig{}).GetImagePullPolicy())
+	assert.Equal(t, ImagePullAlways, (&IsolationConfig{ImagePullPolicy: ImagePullAlways}).GetImagePullPolicy())
+
+	assert.NoError(t, (&IsolationConfig{ImagePullPolicy: ImagePullNever}).Validate())
+	assert.Error(t, (&IsolationConfig{ImagePullPolicy: "sometimes"}).Validate())
+}
+
+func TestValidate_TLSSettings(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.TLSCertFile = "/etc/mcpproxy/cert.pem"
+	assert.Error(t, cfg.Validate(), "key file is required with a certificate")
+
+	cfg.TLSKeyFile = "/etc/mcpproxy/key.pem"
+	cfg.TLSRedirectListen = ":8081"
+	assert.NoError(t, cfg.Validate())
+	assert.True(t, cfg.TLSEnabled())
+
+	cfg = DefaultConfig()
+	cfg.TLSRedirectListen = ":8081"
+	assert.Error(t, cfg.Validate(), "redirect requires TLS")
+}
+
+func TestLoadFromFile_DiagnosticChatEnabled(t *testing.T) {
+	assert.True(t, DefaultConfig().DiagnosticChatEnabled)
+
+	tempDir := t.TempDir()
+	t.Setenv(DataDirEnvVar, "")
+	load := func(body string) *Config {
+		path := filepath.Join(tempDir, ConfigFileName)
+		require.NoError(t, os.WriteFile(path, []byte(body), 0600))
+		cfg, err := LoadFromFile(path)
+		require.NoError(t, err)
+		return cfg
+	}
+
+	dataDir, err := json.Marshal(tempDir)
+	require.NoError(t, err)
+
+	// Existing configs without the field keep the chat enabled
+	assert.True(t, load(`{"data_dir": `+string(dataDir)+`}`).DiagnosticChatEnabled)
+	assert.False(t, load(`{"data_dir": `+string(dataDir)+`, "diagnostic_chat_enabled": false}`).DiagnosticChatEnabled)
+}
+
+func TestGetMaxCallDuration(t *testing.T) {
+	var nilConfig *Config
+	assert.Zero(t, nilConfig.GetMaxCallDuration(nil))
+
+	cfg := DefaultConfig()
+	assert.Zero(t, cfg.GetMaxCallDuration(&ServerConfig{Name: "a"}))
+
+	cfg.MaxCallDuration = Duration(5 * time.Minute)
+	assert.Equal(t, 5*time.Minute, cfg.GetMaxCallDuration(nil))
+	assert.Equal(t, 5*time.Minute, cfg.GetMaxCallDuration(&ServerConfig{Name: "a"}))
+
+	// The per-server value overrides the global one
+	server := &ServerConfig{Name: "slow", MaxCallDuration: Duration(20 * time.Minute)}
+	assert.Equal(t, 20*time.Minute, cfg.GetMaxCallDuration(server))
+
+	cfg.MaxCallDuration = Duration(-time.Second)
+	assert.Error(t, cfg.Validate())
+
+	cfg.MaxCallDuration = 0
+	cfg.Servers = []*ServerConfig{{Name: "slow", MaxCallDuration: Duration(-time.Second)}}
+	assert.ErrorContains(t, cfg.Validate(), "server slow: max_call_duration")
+}
+
+func TestGetStatusBufferSize(t *testing.T) {
+	cfg := DefaultConfig()
+	assert.Equal(t, DefaultStatusBufferSize, cfg.GetStatusBufferSize())
+
+	cfg.StatusBufferSize = 100
+	assert.Equal(t, 100, cfg.GetStatusBufferSize())
+
+	cfg.StatusBufferSize = -1
+	assert.ErrorContains(t, cfg.Validate(), "status_buffer_size")
+}
+
+func TestIsBuiltinToolDisabled(t *testing.T) {
+	var nilConfig *Config
+	assert.False(t, nilConfig.IsBuiltinToolDisabled("upstream_servers"))
+
+	cfg := DefaultConfig()
+	assert.Empty(t, cfg.DisabledBuiltinTools, "built-in tools are enabled by default")
+	assert.False(t, cfg.IsBuiltinToolDisabled("upstream_servers"))
+
+	cfg.DisabledBuiltinTools = []string{"upstream_servers", " quarantine_security "}
+	assert.True(t, cfg.IsBuiltinToolDisabled("upstream_servers"))
+	assert.True(t, cfg.IsBuiltinToolDisabled("quarantine_security"))
+	assert.False(t, cfg.IsBuiltinToolDisabled("retrieve_tools"))
+}
+
+func TestValidateFeaturedTools(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.FeaturedTools = []string{"github:create_issue", "slack:post_message"}
+	assert.NoError(t, cfg.Validate())
+
+	for _, name := range []string{"create_issue", ":create_issue", "github:"} {
+		cfg.FeaturedTools = []string{name}
+		assert.ErrorContains(t, cfg.Validate(), "featured_tools", name)
+	}
+}
+
+func TestValidateAutoQuarantineAfterFailures(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.AutoQuarantineAfterFailures = 5
+	assert.NoError(t, cfg.Validate())
+
+	cfg.AutoQuarantineAfterFailures = -1
+	assert.ErrorContains(t, cfg.Validate(), "auto_quarantine_after_failures")
+}
+
+func TestGetMaxConcurrentDiscovery(t *testing.T) {
+	var nilConfig *Config
+	assert.Equal(t, 10, nilConfig.GetMaxConcurrentDiscovery())
+
+	cfg := DefaultConfig()
+	assert.Equal(t, cfg.MaxConcurrentConnections, cfg.GetMaxConcurrentDiscovery())
+
+	cfg.MaxConcurrentConnections = 4
+	assert.Equal(t, 4, cfg.GetMaxConcurrentDiscovery())
+
+	cfg.MaxConcurrentDiscovery = 2
+	assert.Equal(t, 2, cfg.GetMaxConcurrentDiscovery())
+
+	cfg.MaxConcurrentDiscovery = 0
+	cfg.MaxConcurrentConnections = 0
+	assert.Equal(t, 10, cfg.GetMaxConcurrentDiscovery())
+}
+
+func TestServerConfig_MergeDefaultArgs(t *testing.T) {
+	args := map[string]interface{}{"query": "mcp", "limit": 5}
+	schema := `{"type":"object","properties":{"query":{"type":"string"},"limit":{"type":"integer"},"api_key":{"type":"string"}}}`
+
+	var nilServer *ServerConfig
+	assert.Equal(t, args, nilServer.MergeDefaultArgs(args, schema))
+	assert.Equal(t, args, (&ServerConfig{}).MergeDefaultArgs(args, schema))
+
+	server := &ServerConfig{DefaultArgs: map[string]interface{}{"api_key": "secret", "limit": 10, "units": "metric"}}
+	merged := server.MergeDefaultArgs(args, schema)
+	assert.Equal(t, map[string]interface{}{"query": "mcp", "limit": 5, "api_key": "secret"}, merged, "caller args win on conflict, undeclared defaults are left out")
+	assert.NotContains(t, args, "api_key", "caller args are not modified")
+
+	assert.Equal(t, map[string]interface{}{"api_key": "secret", "limit": 10}, server.MergeDefaultArgs(nil, schema))
+
+	// Tools whose schema doesn't declare a default argument (or is unknown) don't get it
+	strict := `{"type":"object","properties":{"query":{"type":"string"}},"additionalProperties":false}`
+	assert.Equal(t, args, server.MergeDefaultArgs(args, strict))
+	assert.Equal(t, args, server.MergeDefaultArgs(args, ""))
+}
+
+func TestGetAuditLogRetention(t *testing.T) {
+	var nilConfig *Config
+	assert.Equal(t, DefaultAuditLogRetention, nilConfig.GetAuditLogRetention())
+	assert.Equal(t, DefaultAuditLogRetention, (&Config{}).GetAuditLogRetention())
+	assert.Equal(t, 7*24*time.Hour, (&Config{AuditLogRetention: Duration(7 * 24 * time.Hour)}).GetAuditLogRetention())
+}
+
+func TestGetShutdownTimeout(t *testing.T) {
+	var nilConfig *Config
+	assert.Equal(t, DefaultShutdownTimeout, nilConfig.GetShutdownTimeout())
+
+	cfg := DefaultConfig()
+	assert.Equal(t, DefaultShutdownTimeout, cfg.GetShutdownTimeout())
+
+	cfg.ShutdownTimeout = Duration(45 * time.Second)
+	assert.Equal(t, 45*time.Second, cfg.GetShutdownTimeout())
+
+	cfg.ShutdownTimeout = Duration(-time.Second)
+	assert.Error(t, cfg.Validate())
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveDataDir_Precedence(t *testing.T) {
+	t.Cleanup(func() { SetDataDirOverride("") })
+
+	homeDir, err := os.UserHomeDir()
+	require.NoError(t, err)
+
+	t.Setenv(DataDirEnvVar, "")
+	dir, err := ResolveDataDir("")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(homeDir, DefaultDataDir), dir)
+
+	dir, err = ResolveDataDir("/from/config")
+	require.NoError(t, err)
+	assert.Equal(t, "/from/config", dir)
+
+	t.Setenv(DataDirEnvVar, "/from/env")
+	dir, err = ResolveDataDir("/from/config")
+	require.NoError(t, err)
+	assert.Equal(t, "/from/env", dir)
+
+	SetDataDirOverride("/from/flag")
+	dir, err = ResolveDataDir("/from/config")
+	require.NoError(t, err)
+	assert.Equal(t, "/from/flag", dir)
+}
+
+func TestLoadFromFile_DataDirEnvOverride(t *testing.T) {
+	tempDir := t.TempDir()
+	configDataDir := filepath.Join(tempDir, "config-data")
+	envDataDir := filepath.Join(tempDir, "env-data")
+
+	cfg := DefaultConfig()
+	cfg.DataDir = configDataDir
+	data, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	configPath := filepath.Join(tempDir, ConfigFileName)
+	require.NoError(t, os.WriteFile(configPath, data, 0600))
+
+	t.Setenv(DataDirEnvVar, envDataDir)
+	loaded, err := LoadFromFile(configPath)
+	require.NoError(t, err)
+
+	assert.Equal(t, envDataDir, loaded.DataDir)
+	assert.DirExists(t, envDataDir)
+	assert.NoDirExists(t, configDataDir)
+	assert.Equal(t, filepath.Join(envDataDir, "startup-script.sh"), loaded.StartupScript.Path)
+}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidateServerDependencies checks that depends_on does not form a cycle. Dependencies on
+// servers that are not configured are ignored here; they are skipped at startup.
+func ValidateServerDependencies(servers []*ServerConfig) error {
+	byName := make(map[string]*ServerConfig, len(servers))
+	for _, server := range servers {
+		if server != nil {
+			byName[server.Name] = server
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(servers))
+	var path []string
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case done:
+			return nil
+		case visiting:
+			// Report only the part of the path that forms the cycle
+			start := 0
+			for i, n := range path {
+				if n == name {
+					start = i
+					break
+				}
+			}
+			cycle := append(append([]string{}, path[start:]...), name)
+			return fmt.Errorf("server dependency cycle: %s", strings.Join(cycle, " -> "))
+		}
+
+		state[name] = visiting
+		path = append(path, name)
+		for _, dep := range byName[name].DependsOn {
+			if _, ok := byName[dep]; !ok {
+				continue
+			}
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = done
+		return nil
+	}
+
+	for _, server := range servers {
+		if server == nil {
+			continue
+		}
+		if err := visit(server.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateServerDependencies(t *testing.T) {
+	t.Run("acyclic", func(t *testing.T) {
+		servers := []*ServerConfig{
+			{Name: "backend-a", DependsOn: []string{"gateway"}},
+			{Name: "backend-b", DependsOn: []string{"gateway", "backend-a"}},
+			{Name: "gateway"},
+		}
+		assert.NoError(t, ValidateServerDependencies(servers))
+	})
+
+	t.Run("unknown dependency is ignored", func(t *testing.T) {
+		servers := []*ServerConfig{{Name: "backend", DependsOn: []string{"removed"}}}
+		assert.NoError(t, ValidateServerDependencies(servers))
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		servers := []*ServerConfig{
+			{Name: "client"},
+			{Name: "a", DependsOn: []string{"b"}},
+			{Name: "b", DependsOn: []string{"c"}},
+			{Name: "c", DependsOn: []string{"a"}},
+		}
+		err := ValidateServerDependencies(servers)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "a -> b -> c -> a")
+	})
+
+	t.Run("self dependency", func(t *testing.T) {
+		servers := []*ServerConfig{{Name: "a", DependsOn: []string{"a"}}}
+		err := ValidateServerDependencies(servers)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "a -> a")
+	})
+}
@@ -0,0 +1,182 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// FieldChange is a single field that differs between two configurations
+type FieldChange struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old,omitempty"`
+	New   interface{} `json:"new,omitempty"`
+}
+
+// ConfigDiff describes what changed between two configurations
+type ConfigDiff struct {
+	AddedServers    []string                 `json:"added_servers,omitempty"`
+	RemovedServers  []string                 `json:"removed_servers,omitempty"`
+	ModifiedServers map[string][]FieldChange `json:"modified_servers,omitempty"`
+	Settings        []FieldChange            `json:"settings,omitempty"` // Top-level settings
+}
+
+// redactedValue replaces values of fields that may hold secrets
+const redactedValue = "[redacted]"
+
+// diffIgnoredServerFields are bookkeeping fields updated at runtime, not by the user
+var diffIgnoredServerFields = map[string]bool{
+	"created":                    true,
+	"updated":                    true,
+	"ever_connected":             true,
+	"last_successful_connection": true,
+	"tool_count":                 true,
+}
+
+// diffRedactedFields may contain credentials; changes are reported without values
+var diffRedactedFields = map[string]bool{
+	"env":          true,
+	"headers":      true,
+	"default_args": true,
+	"oauth":        true,
+	"api_token":    true,
+	"llm":          true,
+	"environment":  true,
+}
+
+// DiffConfigs computes the structured difference between an old and a new configuration.
+// Servers are matched by name; fields are compared by their JSON representation.
+func DiffConfigs(oldCfg, newCfg *Config) *ConfigDiff {
+	diff := &ConfigDiff{ModifiedServers: make(map[string][]FieldChange)}
+	if oldCfg == nil || newCfg == nil {
+		return diff
+	}
+
+	oldServers := make(map[string]*ServerConfig, len(oldCfg.Servers))
+	for _, s := range oldCfg.Servers {
+		oldServers[s.Name] = s
+	}
+	newServers := make(map[string]*ServerConfig, len(newCfg.Servers))
+	for _, s := range newCfg.Servers {
+		newServers[s.Name] = s
+	}
+
+	for name, newServer := range newServers {
+		oldServer, ok := oldServers[name]
+		if !ok {
+			diff.AddedServers = append(diff.AddedServers, name)
+			continue
+		}
+		if changes := diffFields(oldServer, newServer, diffIgnoredServerFields); len(changes) > 0 {
+			diff.ModifiedServers[name] = changes
+		}
+	}
+	for name := range oldServers {
+		if _, ok := newServers[name]; !ok {
+			diff.RemovedServers = append(diff.RemovedServers, name)
+		}
+	}
+	sort.Strings(diff.AddedServers)
+	sort.Strings(diff.RemovedServers)
+
+	// Servers are diffed above; groups and assignments show up as server group_id changes
+	diff.Settings = diffFields(oldCfg, newCfg, map[string]bool{
+		"mcpServers":               true,
+		"server_group_assignments": true,
+	})
+
+	return diff
+}
+
+// IsEmpty reports whether the configurations were identical
+func (d *ConfigDiff) IsEmpty() bool {
+	return len(d.AddedServers) == 0 && len(d.RemovedServers) == 0 &&
+		len(d.ModifiedServers) == 0 && len(d.Settings) == 0
+}
+
+// Summary returns human-readable lines such as "server github: command changed"
+func (d *ConfigDiff) Summary() []string {
+	var lines []string
+	for _, name := range d.AddedServers {
+		lines = append(lines, fmt.Sprintf("server %s added", name))
+	}
+	for _, name := range d.RemovedServers {
+		lines = append(lines, fmt.Sprintf("server %s removed", name))
+	}
+
+	names := make([]string, 0, len(d.ModifiedServers))
+	for name := range d.ModifiedServers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, change := range d.ModifiedServers[name] {
+			lines = append(lines, fmt.Sprintf("server %s: %s changed", name, change.Field))
+		}
+	}
+
+	for _, change := range d.Settings {
+		lines = append(lines, fmt.Sprintf("setting %s changed", change.Field))
+	}
+	return lines
+}
+
+// diffFields compares two values field by field using their JSON form
+func diffFields(oldValue, newValue interface{}, ignored map[string]bool) []FieldChange {
+	oldFields := toFieldMap(oldValue)
+	newFields := toFieldMap(newValue)
+
+	keys := make(map[string]bool, len(oldFields)+len(newFields))
+	for k := range oldFields {
+		keys[k] = true
+	}
+	for k := range newFields {
+		keys[k] = true
+	}
+
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		if !ignored[k] {
+			sorted = append(sorted, k)
+		}
+	}
+	sort.Strings(sorted)
+
+	var changes []FieldChange
+	for _, k := range sorted {
+		oldV, newV := oldFields[k], newFields[k]
+		if reflect.DeepEqual(oldV, newV) {
+			continue
+		}
+		if diffRedactedFields[k] {
+			changes = append(changes, FieldChange{Field: k, Old: redactedValue, New: redactedValue})
+			continue
+		}
+		if k == "http_proxy" {
+			oldV, newV = redactProxyField(oldV), redactProxyField(newV)
+		}
+		changes = append(changes, FieldChange{Field: k, Old: oldV, New: newV})
+	}
+	return changes
+}
+
+// redactProxyField redacts the credentials of a JSON-decoded http_proxy value
+func redactProxyField(value interface{}) interface{} {
+	if raw, ok := value.(string); ok && raw != "" {
+		return RedactProxyURL(raw)
+	}
+	return value
+}
+
+func toFieldMap(v interface{}) map[string]interface{} {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil
+	}
+	return fields
+}
@@ -138,6 +138,13 @@ func ApplyDotEnvToLLMConfig(llmConfig *LLMConfig, dataDir string) error {
 		}
 	}
 
+	// OpenAI-compatible base URL (only if not set in config)
+	if llmConfig.BaseURL == "" {
+		if baseURL, ok := envVars["OPENAI_BASE_URL"]; ok && baseURL != "" {
+			llmConfig.BaseURL = baseURL
+		}
+	}
+
 	// Ollama URL (only if not set in config)
 	if llmConfig.OllamaURL == "" || llmConfig.OllamaURL == "http://localhost:11434" {
 		if url, ok := envVars["OLLAMA_URL"]; ok && url != "" {
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// EffectiveConfig returns cfg as mcpproxy is using it (after loading, migration and merging
+// config.d fragments) as a JSON-shaped map. Fields that may hold credentials are redacted:
+// env, headers and default_args keep their keys so it is still visible what is set, and http_proxy keeps
+// everything but its password.
+func EffectiveConfig(cfg *Config) (map[string]interface{}, error) {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	var effective map[string]interface{}
+	if err := json.Unmarshal(data, &effective); err != nil {
+		return nil, fmt.Errorf("failed to decode config: %w", err)
+	}
+
+	redactSecretFields(effective)
+	if servers, ok := effective["mcpServers"].([]interface{}); ok {
+		for _, server := range servers {
+			if fields, ok := server.(map[string]interface{}); ok {
+				redactSecretFields(fields)
+			}
+		}
+	}
+
+	return effective, nil
+}
+
+// redactSecretFields replaces the values of credential-bearing fields in place
+func redactSecretFields(fields map[string]interface{}) {
+	for key, value := range fields {
+		if key == "http_proxy" {
+			fields[key] = redactProxyField(value)
+			continue
+		}
+		if !diffRedactedFields[key] || isEmptyValue(value) {
+			continue
+		}
+		if values, ok := value.(map[string]interface{}); ok && (key == "env" || key == "headers" || key == "default_args") {
+			for k := range values {
+				values[k] = redactedValue
+			}
+			continue
+		}
+		fields[key] = redactedValue
+	}
+}
+
+func isEmptyValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
@@ -0,0 +1,187 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// configFragment is the subset of the config schema allowed in a drop-in file
+type configFragment struct {
+	Servers []*ServerConfig `json:"mcpServers"`
+	Groups  []GroupConfig   `json:"groups,omitempty"`
+}
+
+// FragmentDir returns the drop-in directory for a config file,
+// e.g. ~/.mcpproxy/mcp_config.json -> ~/.mcpproxy/mcp_config.d
+func FragmentDir(configPath string) string {
+	return strings.TrimSuffix(configPath, filepath.Ext(configPath)) + ".d"
+}
+
+// loadConfigFragments merges every *.json file in the config's drop-in directory into cfg.
+// Files are read in name order. Server names must be unique across all files; groups
+// already defined (by name) in the main file or an earlier fragment are skipped.
+func loadConfigFragments(configPath string, cfg *Config) error {
+	dir := FragmentDir(configPath)
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return fmt.Errorf("failed to scan config directory %s: %w", dir, err)
+	}
+	if len(files) == 0 {
+		return nil
+	}
+	sort.Strings(files)
+
+	sources := make(map[string]string, len(cfg.Servers))
+	for _, server := range cfg.Servers {
+		sources[server.Name] = configPath
+	}
+	groupNames := make(map[string]bool, len(cfg.Groups))
+	for _, group := range cfg.Groups {
+		groupNames[group.Name] = true
+	}
+
+	cfg.serverSources = make(map[string]string)
+	cfg.groupSources = make(map[string]string)
+	cfg.fragmentFiles = files
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read config fragment %s: %w", file, err)
+		}
+
+		var fragment configFragment
+		if err := json.Unmarshal(data, &fragment); err != nil {
+			return fmt.Errorf("failed to parse config fragment %s: %w", file, err)
+		}
+
+		for _, server := range fragment.Servers {
+			if prev, ok := sources[server.Name]; ok {
+				return fmt.Errorf("duplicate server %q in %s (already defined in %s)", server.Name, file, prev)
+			}
+			sources[server.Name] = file
+			cfg.serverSources[server.Name] = file
+			cfg.Servers = append(cfg.Servers, server)
+		}
+
+		for _, group := range fragment.Groups {
+			if groupNames[group.Name] {
+				continue
+			}
+			groupNames[group.Name] = true
+			cfg.groupSources[group.Name] = file
+			cfg.Groups = append(cfg.Groups, group)
+		}
+	}
+
+	return nil
+}
+
+// ServerSourceFile returns the drop-in file a server was loaded from,
+// or "" if it belongs to the main config file
+func (c *Config) ServerSourceFile(name string) string {
+	return c.serverSources[name]
+}
+
+// RenameServerSource keeps a renamed server in the drop-in file it was loaded from
+func (c *Config) RenameServerSource(oldName, newName string) {
+	if file, ok := c.serverSources[oldName]; ok {
+		delete(c.serverSources, oldName)
+		c.serverSources[newName] = file
+	}
+}
+
+// GroupSourceFile returns the drop-in file a group was defined in,
+// or "" if it belongs to the main config file
+func (c *Config) GroupSourceFile(name string) string {
+	return c.groupSources[name]
+}
+
+// mainFileGroups returns the groups that belong in the main config file
+func (c *Config) mainFileGroups() []GroupConfig {
+	groups := make([]GroupConfig, 0, len(c.Groups))
+	for _, group := range c.Groups {
+		if c.GroupSourceFile(group.Name) == "" {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// mainFileServers returns the servers that belong in the main config file
+func (c *Config) mainFileServers() []*ServerConfig {
+	servers := make([]*ServerConfig, 0, len(c.Servers))
+	for _, server := range c.Servers {
+		if c.ServerSourceFile(server.Name) == "" {
+			servers = append(servers, server)
+		}
+	}
+	return servers
+}
+
+// SaveConfigFragments writes servers and groups loaded from drop-in files back to their
+// originating file, preserving any other keys in it. Servers and groups added at runtime
+// belong to the main file.
+func SaveConfigFragments(cfg *Config) error {
+	byFile := make(map[string][]*ServerConfig, len(cfg.fragmentFiles))
+	for _, server := range cfg.Servers {
+		if file := cfg.ServerSourceFile(server.Name); file != "" {
+			byFile[file] = append(byFile[file], server)
+		}
+	}
+	groupsByName := make(map[string]GroupConfig, len(cfg.Groups))
+	for _, group := range cfg.Groups {
+		groupsByName[group.Name] = group
+	}
+
+	for _, file := range cfg.fragmentFiles {
+		existing := map[string]interface{}{}
+		if data, err := os.ReadFile(file); err == nil {
+			_ = json.Unmarshal(data, &existing)
+		}
+
+		servers := byFile[file]
+		if servers == nil {
+			servers = []*ServerConfig{}
+		}
+		existing["mcpServers"] = servers
+		if groups, ok := existing["groups"].([]interface{}); ok {
+			existing["groups"] = cfg.fragmentGroups(file, groups, groupsByName)
+		}
+
+		data, err := json.MarshalIndent(existing, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal config fragment %s: %w", file, err)
+		}
+		if err := os.WriteFile(file, data, 0600); err != nil {
+			return fmt.Errorf("failed to write config fragment %s: %w", file, err)
+		}
+	}
+
+	return nil
+}
+
+// fragmentGroups returns the groups of a drop-in file with the groups it defines replaced by
+// their current version, or dropped once deleted. Entries ignored on load because the group
+// was already defined elsewhere are kept as they are.
+func (c *Config) fragmentGroups(file string, groups []interface{}, current map[string]GroupConfig) []interface{} {
+	result := make([]interface{}, 0, len(groups))
+	for _, entry := range groups {
+		name := ""
+		if group, ok := entry.(map[string]interface{}); ok {
+			name, _ = group["name"].(string)
+		}
+		if c.GroupSourceFile(name) != file {
+			result = append(result, entry)
+			continue
+		}
+		if group, ok := current[name]; ok {
+			result = append(result, group)
+		}
+	}
+	return result
+}
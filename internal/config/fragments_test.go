@@ -0,0 +1,117 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+}
+
+func TestLoadFromFileMergesFragments(t *testing.T) {
+	dir := t.TempDir()
+	mainPath := filepath.Join(dir, "mcp_config.json")
+	writeTestFile(t, mainPath, `{"data_dir": "`+filepath.ToSlash(dir)+`", "mcpServers": [{"name": "main-server", "command": "npx"}], "groups": [{"id": 1, "name": "team"}]}`)
+	writeTestFile(t, filepath.Join(dir, "mcp_config.d", "10-team.json"), `{"mcpServers": [{"name": "team-server", "url": "http://localhost:9000"}], "groups": [{"id": 1, "name": "team"}, {"id": 2, "name": "extra"}]}`)
+	writeTestFile(t, filepath.Join(dir, "mcp_config.d", "notes.txt"), `ignored`)
+
+	cfg, err := LoadFromFile(mainPath)
+	require.NoError(t, err)
+
+	require.Len(t, cfg.Servers, 2)
+	assert.Equal(t, "team-server", cfg.Servers[1].Name)
+	assert.Equal(t, "", cfg.ServerSourceFile("main-server"))
+	assert.Equal(t, filepath.Join(dir, "mcp_config.d", "10-team.json"), cfg.ServerSourceFile("team-server"))
+	assert.Len(t, cfg.Groups, 2, "duplicate group names are merged")
+}
+
+func TestLoadFromFileRejectsDuplicateFragmentServers(t *testing.T) {
+	dir := t.TempDir()
+	mainPath := filepath.Join(dir, "mcp_config.json")
+	writeTestFile(t, mainPath, `{"data_dir": "`+filepath.ToSlash(dir)+`", "mcpServers": [{"name": "github", "command": "npx"}]}`)
+	writeTestFile(t, filepath.Join(dir, "mcp_config.d", "dup.json"), `{"mcpServers": [{"name": "github", "command": "uvx"}]}`)
+
+	_, err := LoadFromFile(mainPath)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `duplicate server "github"`)
+}
+
+func TestSaveConfigWritesServersBackToFragments(t *testing.T) {
+	dir := t.TempDir()
+	mainPath := filepath.Join(dir, "mcp_config.json")
+	fragmentPath := filepath.Join(dir, "mcp_config.d", "team.json")
+	writeTestFile(t, mainPath, `{"data_dir": "`+filepath.ToSlash(dir)+`", "mcpServers": [{"name": "main-server", "command": "npx"}]}`)
+	writeTestFile(t, fragmentPath, `{"owner": "platform-team", "mcpServers": [{"name": "team-server", "command": "uvx"}]}`)
+
+	cfg, err := LoadFromFile(mainPath)
+	require.NoError(t, err)
+
+	cfg.Servers[1].Args = []string{"team-mcp"}
+	cfg.Servers = append(cfg.Servers, &ServerConfig{Name: "new-server", Command: "node"})
+	require.NoError(t, SaveConfig(cfg, mainPath))
+
+	reloaded, err := LoadFromFile(mainPath)
+	require.NoError(t, err)
+	require.Len(t, reloaded.Servers, 3)
+	assert.Equal(t, fragmentPath, reloaded.ServerSourceFile("team-server"))
+	assert.Equal(t, "", reloaded.ServerSourceFile("new-server"))
+
+	fragmentData, err := os.ReadFile(fragmentPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(fragmentData), `"owner": "platform-team"`)
+	assert.Contains(t, string(fragmentData), "team-mcp")
+	assert.NotContains(t, string(fragmentData), "new-server")
+}
+
+func TestRenameServerSourceKeepsFragmentFile(t *testing.T) {
+	dir := t.TempDir()
+	mainPath := filepath.Join(dir, "mcp_config.json")
+	fragmentPath := filepath.Join(dir, "mcp_config.d", "team.json")
+	writeTestFile(t, mainPath, `{"data_dir": "`+filepath.ToSlash(dir)+`", "mcpServers": [{"name": "main-server", "command": "npx"}]}`)
+	writeTestFile(t, fragmentPath, `{"mcpServers": [{"name": "team-server", "command": "uvx"}]}`)
+
+	cfg, err := LoadFromFile(mainPath)
+	require.NoError(t, err)
+
+	cfg.Servers[1].Name = "platform-server"
+	cfg.RenameServerSource("team-server", "platform-server")
+	cfg.RenameServerSource("main-server", "primary-server")
+	assert.Equal(t, fragmentPath, cfg.ServerSourceFile("platform-server"))
+	assert.Equal(t, "", cfg.ServerSourceFile("team-server"))
+	assert.Equal(t, "", cfg.ServerSourceFile("primary-server"))
+}
+
+func TestSaveConfigWritesGroupsBackToFragments(t *testing.T) {
+	dir := t.TempDir()
+	mainPath := filepath.Join(dir, "mcp_config.json")
+	fragmentPath := filepath.Join(dir, "mcp_config.d", "team.json")
+	writeTestFile(t, mainPath, `{"data_dir": "`+filepath.ToSlash(dir)+`", "mcpServers": [], "groups": [{"id": 1, "name": "main"}]}`)
+	writeTestFile(t, fragmentPath, `{"mcpServers": [{"name": "team-server", "command": "uvx"}], "groups": [{"id": 1, "name": "main"}, {"id": 2, "name": "team"}]}`)
+
+	cfg, err := LoadFromFile(mainPath)
+	require.NoError(t, err)
+	assert.Equal(t, fragmentPath, cfg.GroupSourceFile("team"))
+	assert.Equal(t, "", cfg.GroupSourceFile("main"))
+
+	cfg.Servers[0].GroupID = 2
+	cfg.Groups[1].Color = "#ff0000"
+	require.NoError(t, SaveConfig(cfg, mainPath))
+
+	mainData, err := os.ReadFile(mainPath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(mainData), `"team"`, "fragment groups stay out of the main file")
+
+	reloaded, err := LoadFromFile(mainPath)
+	require.NoError(t, err)
+	require.Len(t, reloaded.Groups, 2)
+	assert.Equal(t, "#ff0000", reloaded.Groups[1].Color)
+	assert.Equal(t, fragmentPath, reloaded.GroupSourceFile("team"))
+	assert.Equal(t, 2, reloaded.Servers[0].GroupID)
+}
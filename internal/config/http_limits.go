@@ -0,0 +1,12 @@
+package config
+
+// DefaultMaxHeaderBytes is the request header limit of the HTTP server when max_header_bytes is unset
+const DefaultMaxHeaderBytes = 1 << 20 // 1MB
+
+// GetMaxHeaderBytes returns the request header limit of the HTTP server
+func (c *Config) GetMaxHeaderBytes() int {
+	if c == nil || c.MaxHeaderBytes <= 0 {
+		return DefaultMaxHeaderBytes
+	}
+	return c.MaxHeaderBytes
+}
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMaxHeaderBytes(t *testing.T) {
+	cfg := &Config{}
+	assert.Equal(t, DefaultMaxHeaderBytes, cfg.GetMaxHeaderBytes())
+
+	cfg.MaxHeaderBytes = 64 << 10
+	assert.Equal(t, 64<<10, cfg.GetMaxHeaderBytes())
+}
+
+func TestValidate_RejectsNegativeHTTPLimits(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxRequestBodyBytes = -1
+	assert.Error(t, cfg.Validate())
+
+	cfg = DefaultConfig()
+	cfg.MaxHeaderBytes = -1
+	assert.Error(t, cfg.Validate())
+}
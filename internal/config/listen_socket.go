@@ -0,0 +1,39 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	// UnixSocketScheme prefixes Listen values that name a Unix domain socket,
+	// e.g. unix:///var/run/mcpproxy.sock
+	UnixSocketScheme = "unix://"
+
+	// DefaultListenSocketMode restricts the socket to the owning user
+	DefaultListenSocketMode os.FileMode = 0o600
+)
+
+// UnixSocketPath returns the socket path of a unix:// listen address and whether
+// the address is a Unix socket at all
+func UnixSocketPath(listen string) (string, bool) {
+	if !strings.HasPrefix(listen, UnixSocketScheme) {
+		return "", false
+	}
+	return strings.TrimPrefix(listen, UnixSocketScheme), true
+}
+
+// GetListenSocketMode returns the file mode applied to the listen socket,
+// DefaultListenSocketMode when ListenSocketMode is unset
+func (c *Config) GetListenSocketMode() (os.FileMode, error) {
+	if c == nil || c.ListenSocketMode == "" {
+		return DefaultListenSocketMode, nil
+	}
+	mode, err := strconv.ParseUint(c.ListenSocketMode, 8, 32)
+	if err != nil || mode > 0o777 {
+		return 0, fmt.Errorf("listen_socket_mode: %q is not an octal permission such as \"0660\"", c.ListenSocketMode)
+	}
+	return os.FileMode(mode), nil
+}
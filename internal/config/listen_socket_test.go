@@ -0,0 +1,45 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnixSocketPath(t *testing.T) {
+	path, ok := UnixSocketPath("unix:///tmp/mcpproxy.sock")
+	assert.True(t, ok)
+	assert.Equal(t, "/tmp/mcpproxy.sock", path)
+
+	_, ok = UnixSocketPath("127.0.0.1:8080")
+	assert.False(t, ok)
+}
+
+func TestGetListenSocketMode(t *testing.T) {
+	cfg := &Config{}
+	mode, err := cfg.GetListenSocketMode()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultListenSocketMode, mode)
+
+	cfg.ListenSocketMode = "0660"
+	mode, err = cfg.GetListenSocketMode()
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o660), mode)
+
+	for _, invalid := range []string{"rw-rw----", "0999", "1777"} {
+		cfg.ListenSocketMode = invalid
+		_, err = cfg.GetListenSocketMode()
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestListenAddress_UnixSocket(t *testing.T) {
+	cfg := &Config{Listen: "unix:///tmp/mcpproxy.sock", BindLoopbackOnly: true}
+	assert.Equal(t, "unix:///tmp/mcpproxy.sock", cfg.ListenAddress())
+	assert.False(t, cfg.IsListenExternal())
+
+	cfg.Listen = "unix://"
+	assert.Error(t, cfg.Validate())
+}
@@ -0,0 +1,47 @@
+package config
+
+import "strings"
+
+const (
+	// DefaultOpenAIBaseURL is the API used by the diagnostic chat when no base_url is configured
+	DefaultOpenAIBaseURL = "https://api.openai.com/v1"
+
+	// DefaultOpenAIModel is the model used by the diagnostic chat when none is configured
+	DefaultOpenAIModel = "gpt-4o-mini"
+)
+
+// ChatBaseURL returns the OpenAI-compatible API the diagnostic chat sends requests to.
+// Without a base_url, the ollama provider uses the OpenAI-compatible API of OllamaURL
+// and every other provider uses OpenAI.
+func (l *LLMConfig) ChatBaseURL() string {
+	if l == nil {
+		return DefaultOpenAIBaseURL
+	}
+	if l.BaseURL != "" {
+		return strings.TrimRight(l.BaseURL, "/")
+	}
+	if l.Provider == "ollama" && l.OllamaURL != "" {
+		return strings.TrimRight(l.OllamaURL, "/") + "/v1"
+	}
+	return DefaultOpenAIBaseURL
+}
+
+// ChatCompletionsURL returns the chat completions endpoint of ChatBaseURL
+func (l *LLMConfig) ChatCompletionsURL() string {
+	return l.ChatBaseURL() + "/chat/completions"
+}
+
+// ChatModel returns the model requested by the diagnostic chat. Anthropic models can't be
+// served by an OpenAI-compatible API, so the anthropic provider falls back to the default.
+func (l *LLMConfig) ChatModel() string {
+	if l == nil || l.Model == "" || (l.Provider == "anthropic" && l.BaseURL == "") {
+		return DefaultOpenAIModel
+	}
+	return l.Model
+}
+
+// ChatRequiresAPIKey reports whether the diagnostic chat talks to OpenAI itself, which
+// needs an API key. Local OpenAI-compatible servers usually accept unauthenticated requests.
+func (l *LLMConfig) ChatRequiresAPIKey() bool {
+	return l.ChatBaseURL() == DefaultOpenAIBaseURL
+}
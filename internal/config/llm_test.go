@@ -0,0 +1,59 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLLMConfig_ChatEndpoint(t *testing.T) {
+	tests := []struct {
+		name        string
+		llm         *LLMConfig
+		url         string
+		model       string
+		requiresKey bool
+	}{
+		{
+			name:        "nil config uses OpenAI",
+			llm:         nil,
+			url:         "https://api.openai.com/v1/chat/completions",
+			model:       DefaultOpenAIModel,
+			requiresKey: true,
+		},
+		{
+			name:        "default config uses OpenAI",
+			llm:         DefaultConfig().LLM,
+			url:         "https://api.openai.com/v1/chat/completions",
+			model:       "gpt-4o-mini",
+			requiresKey: true,
+		},
+		{
+			name:  "custom base URL and model",
+			llm:   &LLMConfig{Provider: "openai", Model: "qwen2.5-7b", BaseURL: "http://localhost:1234/v1/"},
+			url:   "http://localhost:1234/v1/chat/completions",
+			model: "qwen2.5-7b",
+		},
+		{
+			name:  "ollama provider uses OllamaURL",
+			llm:   &LLMConfig{Provider: "ollama", Model: "llama3", OllamaURL: "http://localhost:11434"},
+			url:   "http://localhost:11434/v1/chat/completions",
+			model: "llama3",
+		},
+		{
+			name:        "anthropic model falls back to the OpenAI default",
+			llm:         &LLMConfig{Provider: "anthropic", Model: "claude-3-5-sonnet-20241022"},
+			url:         "https://api.openai.com/v1/chat/completions",
+			model:       DefaultOpenAIModel,
+			requiresKey: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.url, tt.llm.ChatCompletionsURL())
+			assert.Equal(t, tt.model, tt.llm.ChatModel())
+			assert.Equal(t, tt.requiresKey, tt.llm.ChatRequiresAPIKey())
+		})
+	}
+}
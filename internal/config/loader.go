@@ -14,8 +14,41 @@ import (
 const (
 	DefaultDataDir = ".mcpproxy"
 	ConfigFileName = "mcp_config.json"
+
+	// DataDirEnvVar overrides the data directory from the config file
+	DataDirEnvVar = "MCPPROXY_DATA_DIR"
 )
 
+// dataDirOverride is the data directory given with --data-dir; it applies to every load,
+// including config reloads, so an instance never switches back to the configured directory
+var dataDirOverride string
+
+// SetDataDirOverride sets the data directory from the --data-dir flag. Call it before loading
+// the configuration.
+func SetDataDirOverride(dir string) {
+	dataDirOverride = dir
+}
+
+// ResolveDataDir returns the data directory to use given the one from the config file.
+// Precedence: --data-dir flag > MCPPROXY_DATA_DIR > config file > ~/.mcpproxy
+func ResolveDataDir(configDataDir string) (string, error) {
+	if dataDirOverride != "" {
+		return dataDirOverride, nil
+	}
+	if envDir := os.Getenv(DataDirEnvVar); envDir != "" {
+		return envDir, nil
+	}
+	if configDataDir != "" {
+		return configDataDir, nil
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	return filepath.Join(homeDir, DefaultDataDir), nil
+}
+
 // LoadFromFile loads configuration from a specific file
 func LoadFromFile(configPath string) (*Config, error) {
 	cfg := DefaultConfig()
@@ -26,14 +59,12 @@ func LoadFromFile(configPath string) (*Config, error) {
 		}
 	}
 
-	// Set data directory if not specified
-	if cfg.DataDir == "" {
-		homeDir, err := os.UserHomeDir()
-		if err != nil {
-			return nil, fmt.Errorf("failed to get user home directory: %w", err)
-		}
-		cfg.DataDir = filepath.Join(homeDir, DefaultDataDir)
+	// Apply data directory overrides before anything is created in it
+	dataDir, err := ResolveDataDir(cfg.DataDir)
+	if err != nil {
+		return nil, err
 	}
+	cfg.DataDir = dataDir
 
 	// Create data directory if it doesn't exist
 	if err := os.MkdirAll(cfg.DataDir, 0755); err != nil {
@@ -85,13 +116,11 @@ func Load() (*Config, error) {
 		// If no config file was found, create a default one
 		if !configFound {
 			// Set data directory first to know where to create the config
-			if cfg.DataDir == "" {
-				homeDir, err := os.UserHomeDir()
-				if err != nil {
-					return nil, fmt.Errorf("failed to get user home directory: %w", err)
-				}
-				cfg.DataDir = filepath.Join(homeDir, DefaultDataDir)
+			dataDir, err := ResolveDataDir(cfg.DataDir)
+			if err != nil {
+				return nil, err
 			}
+			cfg.DataDir = dataDir
 
 			// Create data directory if it doesn't exist
 			if err := os.MkdirAll(cfg.DataDir, 0755); err != nil {
@@ -117,14 +146,12 @@ func Load() (*Config, error) {
 		}
 	}
 
-	// Set data directory if not specified
-	if cfg.DataDir == "" {
-		homeDir, err := os.UserHomeDir()
-		if err != nil {
-			return nil, fmt.Errorf("failed to get user home directory: %w", err)
-		}
-		cfg.DataDir = filepath.Join(homeDir, DefaultDataDir)
+	// Apply data directory overrides before anything is created in it
+	dataDir, err := ResolveDataDir(cfg.DataDir)
+	if err != nil {
+		return nil, err
 	}
+	cfg.DataDir = dataDir
 
 	// Create data directory if it doesn't exist
 	if err := os.MkdirAll(cfg.DataDir, 0755); err != nil {
@@ -178,6 +205,7 @@ func setupViper() {
 	viper.SetDefault("disable-management", false)
 	viper.SetDefault("allow-server-add", true)
 	viper.SetDefault("allow-server-remove", true)
+	viper.SetDefault("bind-loopback-only", false)
 	viper.SetDefault("enable-prompts", true)
 	viper.SetDefault("check-server-repo", true)
 }
@@ -210,10 +238,21 @@ func loadConfigFile(path string, cfg *Config) error {
 		return fmt.Errorf("failed to read config file: %w", err)
 	}
 
+	// A file without config_version predates versioning and must go through all migrations
+	cfg.ConfigVersion = 0
 	if err := json.Unmarshal(data, cfg); err != nil {
 		return fmt.Errorf("failed to parse config file: %w", err)
 	}
 
+	// Merge servers and groups from the optional drop-in directory (mcp_config.d/*.json)
+	if err := loadConfigFragments(path, cfg); err != nil {
+		return err
+	}
+
+	if err := MigrateConfig(cfg); err != nil {
+		return err
+	}
+
 	// Set created time if not specified
 	for _, server := range cfg.Servers {
 		if server.Created.IsZero() {
@@ -347,7 +386,16 @@ func SaveConfig(cfg *Config, path string) error {
 	// Create backup of existing config file
 	_ = createConfigBackup(path) // Best effort, don't fail on backup errors
 
-	data, err := json.MarshalIndent(cfg, "", "  ")
+	// Servers loaded from drop-in files are written back to their own file
+	mainCfg := cfg
+	if len(cfg.fragmentFiles) > 0 {
+		c := *cfg
+		c.Servers = cfg.mainFileServers()
+		c.Groups = cfg.mainFileGroups()
+		mainCfg = &c
+	}
+
+	data, err := json.MarshalIndent(mainCfg, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
@@ -362,7 +410,7 @@ func SaveConfig(cfg *Config, path string) error {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
 
-	return nil
+	return SaveConfigFragments(cfg)
 }
 
 // SaveConfigToDataDir saves configuration to the data directory
@@ -0,0 +1,74 @@
+package config
+
+import "fmt"
+
+// CurrentConfigVersion is the config schema version written by this build.
+// Bump it together with a new entry in configMigrations for every breaking config change.
+const CurrentConfigVersion = 1
+
+// configMigration upgrades a config to Version from the version right before it
+type configMigration struct {
+	Version     int
+	Description string
+	Apply       func(cfg *Config) error
+}
+
+// configMigrations holds all migrations ordered by version
+var configMigrations = []configMigration{
+	{
+		Version:     1,
+		Description: "convert legacy group_name server assignments to group_id",
+		Apply:       migrateLegacyGroupNamesToIDs,
+	},
+}
+
+// MigrateConfig upgrades cfg in place from its config_version to CurrentConfigVersion.
+// Configs without a version are treated as version 0. A config written by a newer
+// build is rejected rather than loaded with fields this build doesn't understand.
+func MigrateConfig(cfg *Config) error {
+	if cfg.ConfigVersion > CurrentConfigVersion {
+		return fmt.Errorf("config_version %d is newer than the supported version %d", cfg.ConfigVersion, CurrentConfigVersion)
+	}
+
+	fromVersion := cfg.ConfigVersion
+	for _, migration := range configMigrations {
+		if migration.Version <= cfg.ConfigVersion {
+			continue
+		}
+		if err := migration.Apply(cfg); err != nil {
+			return fmt.Errorf("config migration to version %d (%s) failed: %w", migration.Version, migration.Description, err)
+		}
+		cfg.ConfigVersion = migration.Version
+	}
+
+	if cfg.ConfigVersion != fromVersion {
+		cfg.migrated = true
+	}
+	return nil
+}
+
+// WasMigrated reports whether the config was upgraded to a newer config_version on
+// load and should be written back to disk
+func (c *Config) WasMigrated() bool {
+	return c.migrated
+}
+
+// migrateLegacyGroupNamesToIDs converts any server with only group_name set into group_id
+func migrateLegacyGroupNamesToIDs(cfg *Config) error {
+	nameToID := make(map[string]int, len(cfg.Groups))
+	for _, group := range cfg.Groups {
+		if group.ID > 0 {
+			nameToID[group.Name] = group.ID
+		}
+	}
+
+	for _, server := range cfg.Servers {
+		if server.GroupID == 0 && server.GroupName != "" {
+			if id, ok := nameToID[server.GroupName]; ok {
+				server.GroupID = id
+				server.GroupName = ""
+			}
+		}
+	}
+	return nil
+}
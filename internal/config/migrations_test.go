@@ -0,0 +1,68 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateConfig_LegacyGroupNames(t *testing.T) {
+	cfg := &Config{
+		Groups: []GroupConfig{{ID: 3, Name: "Development", Enabled: true}},
+		Servers: []*ServerConfig{
+			{Name: "legacy", GroupName: "Development"},
+			{Name: "unknown-group", GroupName: "Missing"},
+			{Name: "modern", GroupID: 3},
+		},
+	}
+
+	require.NoError(t, MigrateConfig(cfg))
+
+	assert.Equal(t, CurrentConfigVersion, cfg.ConfigVersion)
+	assert.True(t, cfg.WasMigrated())
+	assert.Equal(t, 3, cfg.Servers[0].GroupID)
+	assert.Empty(t, cfg.Servers[0].GroupName)
+	assert.Equal(t, 0, cfg.Servers[1].GroupID)
+	assert.Equal(t, "Missing", cfg.Servers[1].GroupName)
+	assert.Equal(t, 3, cfg.Servers[2].GroupID)
+}
+
+func TestMigrateConfig_CurrentVersionUntouched(t *testing.T) {
+	cfg := &Config{
+		ConfigVersion: CurrentConfigVersion,
+		Groups:        []GroupConfig{{ID: 3, Name: "Development"}},
+		Servers:       []*ServerConfig{{Name: "legacy", GroupName: "Development"}},
+	}
+
+	require.NoError(t, MigrateConfig(cfg))
+
+	assert.False(t, cfg.WasMigrated())
+	assert.Equal(t, 0, cfg.Servers[0].GroupID)
+}
+
+func TestMigrateConfig_RejectsNewerVersion(t *testing.T) {
+	cfg := &Config{ConfigVersion: CurrentConfigVersion + 1}
+	assert.Error(t, MigrateConfig(cfg))
+}
+
+func TestLoadFromFile_MigratesUnversionedConfig(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "mcp_config.json")
+	content := `{
+		"data_dir": "` + filepath.ToSlash(tempDir) + `",
+		"groups": [{"id": 7, "name": "Production", "color": "#dc3545", "enabled": true}],
+		"mcpServers": [{"name": "api", "url": "http://localhost:9000", "protocol": "http", "group_name": "Production"}]
+	}`
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0600))
+
+	cfg, err := LoadFromFile(configPath)
+	require.NoError(t, err)
+
+	assert.Equal(t, CurrentConfigVersion, cfg.ConfigVersion)
+	assert.True(t, cfg.WasMigrated())
+	require.Len(t, cfg.Servers, 1)
+	assert.Equal(t, 7, cfg.Servers[0].GroupID)
+}
@@ -0,0 +1,32 @@
+package config
+
+// IsToolWriteBlocked reports whether calls to toolName (unprefixed) are refused because the server
+// is read-only. Read-only servers fail closed: tools listed in write_tools or annotated with
+// destructiveHint are always blocked, and any other tool is only allowed if it is listed in
+// read_tools or the server annotates it with readOnlyHint. The hints are the tool's MCP
+// annotations, nil when the server didn't set them or the tool isn't known.
+func (s *ServerConfig) IsToolWriteBlocked(toolName string, readOnlyHint, destructiveHint *bool) bool {
+	if s == nil || !s.ReadOnly {
+		return false
+	}
+	switch {
+	case containsToolName(s.WriteTools, toolName):
+		return true
+	case containsToolName(s.ReadTools, toolName):
+		return false
+	case destructiveHint != nil && *destructiveHint:
+		return true
+	case readOnlyHint != nil && *readOnlyHint:
+		return false
+	}
+	return true
+}
+
+func containsToolName(names []string, toolName string) bool {
+	for _, name := range names {
+		if name == toolName {
+			return true
+		}
+	}
+	return false
+}
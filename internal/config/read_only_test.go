@@ -0,0 +1,33 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerConfig_IsToolWriteBlocked(t *testing.T) {
+	yes, no := true, false
+
+	var nilServer *ServerConfig
+	assert.False(t, nilServer.IsToolWriteBlocked("create_issue", nil, nil))
+
+	// Servers that aren't read-only allow everything
+	server := &ServerConfig{Name: "github"}
+	assert.False(t, server.IsToolWriteBlocked("create_issue", nil, &yes))
+
+	// Read-only servers only allow tools annotated read-only, whatever their names
+	server.ReadOnly = true
+	assert.False(t, server.IsToolWriteBlocked("list_issues", &yes, nil))
+	assert.False(t, server.IsToolWriteBlocked("run_report", &yes, &no))
+	assert.True(t, server.IsToolWriteBlocked("list_issues", nil, nil), "unannotated tools are blocked")
+	assert.True(t, server.IsToolWriteBlocked("list_issues", &no, nil))
+	assert.True(t, server.IsToolWriteBlocked("get_issue", &yes, &yes), "destructive tools are blocked")
+
+	// read_tools allows unannotated tools, write_tools blocks annotated ones
+	server.ReadTools = []string{"list_issues"}
+	server.WriteTools = []string{"get_issue"}
+	assert.False(t, server.IsToolWriteBlocked("list_issues", nil, nil))
+	assert.True(t, server.IsToolWriteBlocked("get_issue", &yes, nil))
+	assert.True(t, server.IsToolWriteBlocked("search_code", nil, nil))
+}
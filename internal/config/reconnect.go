@@ -0,0 +1,57 @@
+package config
+
+import (
+	"math"
+	"time"
+)
+
+// ReconnectBackoff describes the exponential backoff used when reconnecting upstream servers
+type ReconnectBackoff struct {
+	Initial    time.Duration
+	Max        time.Duration
+	Multiplier float64
+}
+
+// GetReconnectBackoff returns the reconnect backoff configured at the top level, with defaults
+// applied for unset or invalid values
+func (c *Config) GetReconnectBackoff() ReconnectBackoff {
+	if c == nil {
+		return ReconnectBackoff{}.withDefaults()
+	}
+	return ReconnectBackoff{
+		Initial:    c.ReconnectInitialInterval.Duration(),
+		Max:        c.ReconnectMaxInterval.Duration(),
+		Multiplier: c.ReconnectMultiplier,
+	}.withDefaults()
+}
+
+func (b ReconnectBackoff) withDefaults() ReconnectBackoff {
+	if b.Initial <= 0 {
+		b.Initial = DefaultReconnectInitialInterval
+	}
+	if b.Max <= 0 {
+		b.Max = DefaultReconnectMaxInterval
+	}
+	if b.Max < b.Initial {
+		b.Max = b.Initial
+	}
+	if b.Multiplier < 1 {
+		b.Multiplier = DefaultReconnectMultiplier
+	}
+	return b
+}
+
+// Delay returns how long to wait before retry number attempt (1-based). The first retry waits
+// Initial, each following retry waits Multiplier times longer, never exceeding Max.
+func (b ReconnectBackoff) Delay(attempt int) time.Duration {
+	b = b.withDefaults()
+	if attempt <= 1 {
+		return b.Initial
+	}
+
+	delay := float64(b.Initial) * math.Pow(b.Multiplier, float64(attempt-1))
+	if math.IsInf(delay, 0) || delay >= float64(b.Max) {
+		return b.Max
+	}
+	return time.Duration(delay)
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconnectBackoff_Delay(t *testing.T) {
+	b := ReconnectBackoff{Initial: 2 * time.Second, Max: 30 * time.Second, Multiplier: 3}
+
+	assert.Equal(t, 2*time.Second, b.Delay(0))
+	assert.Equal(t, 2*time.Second, b.Delay(1))
+	assert.Equal(t, 6*time.Second, b.Delay(2))
+	assert.Equal(t, 18*time.Second, b.Delay(3))
+	assert.Equal(t, 30*time.Second, b.Delay(4))
+	assert.Equal(t, 30*time.Second, b.Delay(10000))
+}
+
+func TestGetReconnectBackoff_Defaults(t *testing.T) {
+	var nilConfig *Config
+	b := nilConfig.GetReconnectBackoff()
+	assert.Equal(t, DefaultReconnectInitialInterval, b.Initial)
+	assert.Equal(t, DefaultReconnectMaxInterval, b.Max)
+	assert.Equal(t, DefaultReconnectMultiplier, b.Multiplier)
+
+	// Invalid values fall back to defaults; a max below the initial interval is raised to it
+	cfg := &Config{
+		ReconnectInitialInterval: Duration(10 * time.Second),
+		ReconnectMaxInterval:     Duration(5 * time.Second),
+		ReconnectMultiplier:      0.5,
+	}
+	b = cfg.GetReconnectBackoff()
+	assert.Equal(t, 10*time.Second, b.Initial)
+	assert.Equal(t, 10*time.Second, b.Max)
+	assert.Equal(t, DefaultReconnectMultiplier, b.Multiplier)
+}
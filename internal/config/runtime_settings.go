@@ -0,0 +1,222 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// runtimeSetting is a top-level setting that may be changed while mcpproxy is running
+type runtimeSetting struct {
+	get   func(c *Config) interface{}
+	apply func(c *Config, v interface{}) error
+}
+
+// runtimeSettings lists the top-level settings that can be patched via the API.
+// Security-sensitive settings (listen address, tokens, management switches) are deliberately absent.
+var runtimeSettings = map[string]runtimeSetting{
+	"tool_response_limit": {
+		get: func(c *Config) interface{} { return c.ToolResponseLimit },
+		apply: func(c *Config, v interface{}) error {
+			n, err := settingInt(v, 0)
+			c.ToolResponseLimit = n
+			return err
+		},
+	},
+	"enable_lazy_loading": {
+		get: func(c *Config) interface{} { return c.EnableLazyLoading },
+		apply: func(c *Config, v interface{}) error {
+			b, err := settingBool(v)
+			c.EnableLazyLoading = b
+			return err
+		},
+	},
+	"max_concurrent_connections": {
+		get: func(c *Config) interface{} { return c.MaxConcurrentConnections },
+		apply: func(c *Config, v interface{}) error {
+			n, err := settingInt(v, 1)
+			c.MaxConcurrentConnections = n
+			return err
+		},
+	},
+	"top_k": {
+		get: func(c *Config) interface{} { return c.TopK },
+		apply: func(c *Config, v interface{}) error {
+			n, err := settingInt(v, 1)
+			c.TopK = n
+			return err
+		},
+	},
+	"tools_limit": {
+		get: func(c *Config) interface{} { return c.ToolsLimit },
+		apply: func(c *Config, v interface{}) error {
+			n, err := settingInt(v, 1)
+			c.ToolsLimit = n
+			return err
+		},
+	},
+	"tool_cache_ttl": {
+		get: func(c *Config) interface{} { return c.ToolCacheTTL },
+		apply: func(c *Config, v interface{}) error {
+			n, err := settingInt(v, 0)
+			c.ToolCacheTTL = n
+			return err
+		},
+	},
+	"auto_disable_threshold": {
+		get: func(c *Config) interface{} { return c.AutoDisableThreshold },
+		apply: func(c *Config, v interface{}) error {
+			n, err := settingInt(v, 0)
+			c.AutoDisableThreshold = n
+			return err
+		},
+	},
+	"debug_search": {
+		get: func(c *Config) interface{} { return c.DebugSearch },
+		apply: func(c *Config, v interface{}) error {
+			b, err := settingBool(v)
+			c.DebugSearch = b
+			return err
+		},
+	},
+	"audit_log_enabled": {
+		get: func(c *Config) interface{} { return c.AuditLogEnabled },
+		apply: func(c *Config, v interface{}) error {
+			b, err := settingBool(v)
+			c.AuditLogEnabled = b
+			return err
+		},
+	},
+	"call_tool_timeout": {
+		get: func(c *Config) interface{} { return c.CallToolTimeout },
+		apply: func(c *Config, v interface{}) error {
+			d, err := settingDuration(v)
+			c.CallToolTimeout = d
+			return err
+		},
+	},
+	"oauth_refresh_window": {
+		get: func(c *Config) interface{} { return c.OAuthRefreshWindow },
+		apply: func(c *Config, v interface{}) error {
+			d, err := settingDuration(v)
+			c.OAuthRefreshWindow = d
+			return err
+		},
+	},
+}
+
+// secretSettings are top-level keys never exposed by PublicSettings
+var secretSettings = []string{"api_token", "llm", "environment"}
+
+// PublicSettings returns the non-secret top-level settings of cfg as a JSON-shaped map.
+// Servers and groups are omitted since they have their own APIs.
+func PublicSettings(cfg *Config) (map[string]interface{}, error) {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	var settings map[string]interface{}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("failed to decode config: %w", err)
+	}
+
+	for _, key := range secretSettings {
+		delete(settings, key)
+	}
+	delete(settings, "mcpServers")
+	delete(settings, "groups")
+	delete(settings, "server_group_assignments")
+	settings["api_token_set"] = cfg.APIToken != ""
+
+	return settings, nil
+}
+
+// PatchableSettings returns the names of settings accepted by ApplySettingsPatch, sorted
+func PatchableSettings() []string {
+	names := make([]string, 0, len(runtimeSettings))
+	for name := range runtimeSettings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// MergeRuntimeSettings writes the patchable settings of cfg into the decoded config file
+// fileConfig. A setting is written if the file already sets it or if it differs from the default,
+// so saving never pins defaults the user did not choose and later default changes still apply.
+func MergeRuntimeSettings(cfg *Config, fileConfig map[string]interface{}) {
+	defaults := DefaultConfig()
+	for name, setting := range runtimeSettings {
+		value := setting.get(cfg)
+		if _, set := fileConfig[name]; set || value != setting.get(defaults) {
+			fileConfig[name] = value
+		}
+	}
+}
+
+// ApplySettingsPatch validates patch and applies it to cfg. Either all changes are applied
+// or, if any key is unknown, immutable or invalid, none are.
+func ApplySettingsPatch(cfg *Config, patch map[string]interface{}) error {
+	if len(patch) == 0 {
+		return fmt.Errorf("no settings provided")
+	}
+
+	keys := make([]string, 0, len(patch))
+	for key := range patch {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	candidate := *cfg
+	for _, key := range keys {
+		if key == "data_dir" {
+			return fmt.Errorf("data_dir cannot be changed at runtime")
+		}
+		setting, ok := runtimeSettings[key]
+		if !ok {
+			return fmt.Errorf("setting %q cannot be changed at runtime", key)
+		}
+		if err := setting.apply(&candidate, patch[key]); err != nil {
+			return fmt.Errorf("invalid value for %s: %w", key, err)
+		}
+	}
+
+	*cfg = candidate
+	return nil
+}
+
+func settingInt(v interface{}, minValue int) (int, error) {
+	f, ok := v.(float64)
+	if !ok || f != float64(int(f)) {
+		return 0, fmt.Errorf("expected an integer")
+	}
+	if int(f) < minValue {
+		return 0, fmt.Errorf("must be at least %d", minValue)
+	}
+	return int(f), nil
+}
+
+func settingBool(v interface{}) (bool, error) {
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("expected a boolean")
+	}
+	return b, nil
+}
+
+func settingDuration(v interface{}) (Duration, error) {
+	s, ok := v.(string)
+	if !ok {
+		return 0, fmt.Errorf("expected a duration string such as \"2m\"")
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("must be positive")
+	}
+	return Duration(d), nil
+}
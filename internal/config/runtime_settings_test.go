@@ -0,0 +1,79 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplySettingsPatch(t *testing.T) {
+	cfg := DefaultConfig()
+
+	err := ApplySettingsPatch(cfg, map[string]interface{}{
+		"tool_response_limit":        float64(5000),
+		"enable_lazy_loading":        true,
+		"max_concurrent_connections": float64(4),
+		"call_tool_timeout":          "90s",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 5000, cfg.ToolResponseLimit)
+	assert.True(t, cfg.EnableLazyLoading)
+	assert.Equal(t, 4, cfg.MaxConcurrentConnections)
+	assert.Equal(t, 90*time.Second, cfg.CallToolTimeout.Duration())
+}
+
+func TestApplySettingsPatchRejectsWithoutPartialApply(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch map[string]interface{}
+	}{
+		{"data_dir", map[string]interface{}{"tool_response_limit": float64(10), "data_dir": "/tmp/x"}},
+		{"unknown key", map[string]interface{}{"tool_response_limit": float64(10), "api_token": "secret"}},
+		{"bad type", map[string]interface{}{"tool_response_limit": float64(10), "enable_lazy_loading": "yes"}},
+		{"below minimum", map[string]interface{}{"tool_response_limit": float64(10), "max_concurrent_connections": float64(0)}},
+		{"bad duration", map[string]interface{}{"tool_response_limit": float64(10), "call_tool_timeout": "soon"}},
+		{"empty", map[string]interface{}{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			original := cfg.ToolResponseLimit
+
+			assert.Error(t, ApplySettingsPatch(cfg, tt.patch))
+			assert.Equal(t, original, cfg.ToolResponseLimit)
+		})
+	}
+}
+
+func TestPublicSettingsHidesSecrets(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.APIToken = "secret-token"
+
+	settings, err := PublicSettings(cfg)
+	require.NoError(t, err)
+
+	assert.NotContains(t, settings, "api_token")
+	assert.NotContains(t, settings, "mcpServers")
+	assert.Equal(t, true, settings["api_token_set"])
+	assert.Contains(t, settings, "tool_response_limit")
+}
+
+func TestMergeRuntimeSettings(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.TopK = cfg.TopK + 3
+	fileConfig := map[string]interface{}{
+		"listen":              ":8080",
+		"tool_response_limit": float64(cfg.ToolResponseLimit),
+	}
+
+	MergeRuntimeSettings(cfg, fileConfig)
+
+	assert.Equal(t, cfg.TopK, fileConfig["top_k"], "changed settings are written")
+	assert.Equal(t, cfg.ToolResponseLimit, fileConfig["tool_response_limit"], "settings in the file are kept up to date")
+	assert.NotContains(t, fileConfig, "call_tool_timeout", "defaults the file does not set are not written")
+	assert.NotContains(t, fileConfig, "enable_lazy_loading")
+	assert.Equal(t, ":8080", fileConfig["listen"])
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// secretReference matches the ${env:VAR} and ${file:path} references resolved in headers and
+// http_proxy when a server connects
+var secretReference = regexp.MustCompile(`\$\{(env|file):[^}]+\}`)
+
+// ContainsSecretReference reports whether value has a ${env:VAR} or ${file:path} reference
+func ContainsSecretReference(value string) bool {
+	return secretReference.MatchString(value)
+}
+
+// SecretReferenceFields lists the fields of the server resolved from ${env:VAR} or ${file:path}
+// references, e.g. "headers.Authorization" and "http_proxy"
+func (s *ServerConfig) SecretReferenceFields() []string {
+	var fields []string
+	for name, value := range s.Headers {
+		if ContainsSecretReference(value) {
+			fields = append(fields, "headers."+name)
+		}
+	}
+	sort.Strings(fields)
+	if ContainsSecretReference(s.HTTPProxy) {
+		fields = append(fields, "http_proxy")
+	}
+	return fields
+}
+
+// secretReferenceValue returns the value of a field listed by SecretReferenceFields
+func (s *ServerConfig) secretReferenceValue(field string) string {
+	if field == "http_proxy" {
+		return s.HTTPProxy
+	}
+	return s.Headers[field[len("headers."):]]
+}
+
+// CheckSecretReferenceChange refuses server configs from untrusted sources, such as MCP tools,
+// that would send a local secret somewhere new: references that existing doesn't have (existing
+// is nil for new servers), or a url change of a server that resolves references. Such servers
+// can only be set up in the config file.
+func CheckSecretReferenceChange(existing, updated *ServerConfig) error {
+	for _, field := range updated.SecretReferenceFields() {
+		if existing == nil || existing.secretReferenceValue(field) != updated.secretReferenceValue(field) {
+			return fmt.Errorf("%s contains a ${env:...} or ${file:...} reference, which can only be set in the config file", field)
+		}
+	}
+	if existing != nil && existing.URL != updated.URL && len(existing.SecretReferenceFields()) > 0 {
+		return fmt.Errorf("server '%s' resolves secrets from ${env:...} or ${file:...} references, so its url can only be changed in the config file", existing.Name)
+	}
+	return nil
+}
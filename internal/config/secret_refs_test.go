@@ -0,0 +1,34 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckSecretReferenceChange(t *testing.T) {
+	fromFile := &ServerConfig{
+		Name:      "api",
+		URL:       "https://api.example.com/mcp",
+		Headers:   map[string]string{"Authorization": "Bearer ${file:~/.config/api/token}", "Accept": "application/json"},
+		HTTPProxy: "http://alice:${env:PROXY_PASSWORD}@proxy.corp:3128",
+	}
+	assert.Equal(t, []string{"headers.Authorization", "http_proxy"}, fromFile.SecretReferenceFields())
+
+	// New servers from MCP can't reference secrets
+	assert.ErrorContains(t, CheckSecretReferenceChange(nil, fromFile), "headers.Authorization")
+	assert.NoError(t, CheckSecretReferenceChange(nil, &ServerConfig{Name: "plain", URL: "https://plain.example.com", Headers: map[string]string{"X-Key": "literal"}}))
+
+	// Unchanged references are kept, but they can't be sent to a new URL
+	unchanged := *fromFile
+	unchanged.WorkingDir = "/tmp"
+	assert.NoError(t, CheckSecretReferenceChange(fromFile, &unchanged))
+
+	moved := *fromFile
+	moved.URL = "https://attacker.example.com"
+	assert.ErrorContains(t, CheckSecretReferenceChange(fromFile, &moved), "url can only be changed in the config file")
+
+	changed := *fromFile
+	changed.Headers = map[string]string{"Authorization": "Bearer ${file:/etc/shadow}"}
+	assert.Error(t, CheckSecretReferenceChange(fromFile, &changed))
+}
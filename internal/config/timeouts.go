@@ -25,6 +25,24 @@ const (
 
 	// ServerDisconnectTimeout is the max time to wait for a server to disconnect
 	ServerDisconnectTimeout = 10 * time.Second
+
+	// DefaultShutdownTimeout is the grace period for a graceful shutdown when
+	// shutdown_timeout is not configured
+	DefaultShutdownTimeout = TrayQuitTimeout
+
+	// ShutdownStageTimeout bounds quick shutdown stages such as stopping the startup
+	// script, MCP Inspector processes and closing storage
+	ShutdownStageTimeout = 5 * time.Second
+)
+
+// Retention
+const (
+	// DefaultAuditLogRetention is how long audit log entries are kept when
+	// audit_log_retention is not configured
+	DefaultAuditLogRetention = 30 * 24 * time.Hour
+
+	// AuditLogPruneInterval is how often audit log entries past their retention are deleted
+	AuditLogPruneInterval = time.Hour
 )
 
 // Connection Timeouts
@@ -41,6 +59,13 @@ const (
 
 	// QuickOperationTimeout is used for quick health checks and status queries
 	QuickOperationTimeout = 10 * time.Second
+
+	// DependencyWaitTimeout is how long a server waits at startup for the servers in its
+	// depends_on list to connect before it is started anyway
+	DependencyWaitTimeout = DefaultConnectionTimeout
+
+	// DefaultToolResultCacheTTL is how long results of cacheable tools are served from the cache
+	DefaultToolResultCacheTTL = 5 * time.Minute
 )
 
 // Retry & Backoff Configuration
@@ -51,6 +76,15 @@ const (
 	// InitialBackoffDelay is the starting delay for exponential backoff
 	InitialBackoffDelay = 1 * time.Second
 
+	// DefaultReconnectInitialInterval is the delay before the first reconnect retry of an upstream server
+	DefaultReconnectInitialInterval = 1 * time.Second
+
+	// DefaultReconnectMaxInterval caps the delay between reconnect retries
+	DefaultReconnectMaxInterval = MaxBackoffMinutes
+
+	// DefaultReconnectMultiplier is the factor applied to the reconnect delay after each failure
+	DefaultReconnectMultiplier = 2.0
+
 	// TokenReconnectCooldown prevents rapid reconnection attempts
 	TokenReconnectCooldown = 10 * time.Second
 )
@@ -69,6 +103,18 @@ const (
 	// ToolCallTimeout is the timeout for individual tool calls via API
 	// This prevents hanging API requests when upstream servers don't respond
 	ToolCallTimeout = 60 * time.Second
+
+	// CallQueueTimeout bounds how long a tool call waits for a free slot on a server with
+	// max_concurrent_calls set, when the call itself carries no deadline
+	CallQueueTimeout = 60 * time.Second
+
+	// DockerOrphanCheckInterval is how often running mcpproxy-labeled containers are
+	// reconciled against the connected upstream servers
+	DockerOrphanCheckInterval = 5 * time.Minute
+
+	// DockerOrphanGracePeriod is how old a container must be before it can be reported as
+	// orphaned, so containers of servers that are still starting are left alone
+	DockerOrphanGracePeriod = 2 * time.Minute
 )
 
 // OAuth Timeouts
@@ -87,6 +133,16 @@ const (
 
 	// OAuthCompletionWindow is how long after completion to consider OAuth recent
 	OAuthCompletionWindow = 5 * time.Minute
+
+	// DefaultOAuthRefreshWindow is how long before expiry OAuth tokens are proactively refreshed
+	DefaultOAuthRefreshWindow = 5 * time.Minute
+
+	// OAuthRefreshCheckInterval is how often token expiry is checked for proactive refresh
+	OAuthRefreshCheckInterval = 1 * time.Minute
+
+	// OAuthHeadlessCallbackTimeout is how long a headless OAuth flow waits for the user to
+	// visit the authorization URL and complete the callback
+	OAuthHeadlessCallbackTimeout = 5 * time.Minute
 )
 
 // Restart & Recovery
@@ -96,6 +152,10 @@ const (
 
 	// StateRestorationDelay is the delay before restoring server states after start
 	StateRestorationDelay = 3 * time.Second
+
+	// DefaultMaxRestarts is the number of immediate restart attempts for a crashed
+	// stdio server with restart_on_exit before falling back to periodic retry
+	DefaultMaxRestarts = 5
 )
 
 // Long-running Operations
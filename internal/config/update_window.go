@@ -0,0 +1,66 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// parseClock parses "HH:MM" into an offset from midnight
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q (expected HH:MM)", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// ParseUpdateWindow parses an update window such as "02:00-04:00" into offsets from
+// local midnight. The window may wrap past midnight, e.g. "23:00-01:00".
+func ParseUpdateWindow(window string) (start, end time.Duration, err error) {
+	parts := strings.Split(window, "-")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid update_window %q (expected HH:MM-HH:MM)", window)
+	}
+	if start, err = parseClock(parts[0]); err != nil {
+		return 0, 0, fmt.Errorf("invalid update_window start: %w", err)
+	}
+	if end, err = parseClock(parts[1]); err != nil {
+		return 0, 0, fmt.Errorf("invalid update_window end: %w", err)
+	}
+	if start == end {
+		return 0, 0, fmt.Errorf("invalid update_window %q (start and end are equal)", window)
+	}
+	return start, end, nil
+}
+
+// UpdateWindowStatus reports whether now falls inside the update window and, if not,
+// when the window next opens. An empty window is always open.
+func UpdateWindowStatus(window string, now time.Time) (open bool, nextStart time.Time, err error) {
+	if window == "" {
+		return true, now, nil
+	}
+	start, end, err := ParseUpdateWindow(window)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	offset := now.Sub(midnight)
+
+	if start < end {
+		open = offset >= start && offset < end
+	} else {
+		// Window wraps past midnight
+		open = offset >= start || offset < end
+	}
+	if open {
+		return true, now, nil
+	}
+
+	nextStart = midnight.Add(start)
+	if !nextStart.After(now) {
+		nextStart = time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location()).Add(start)
+	}
+	return false, nextStart, nil
+}
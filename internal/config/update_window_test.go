@@ -0,0 +1,64 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUpdateWindow(t *testing.T) {
+	start, end, err := ParseUpdateWindow("02:00-04:30")
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Hour, start)
+	assert.Equal(t, 4*time.Hour+30*time.Minute, end)
+
+	for _, invalid := range []string{"02:00", "2am-4am", "25:00-04:00", "03:00-03:00"} {
+		_, _, err := ParseUpdateWindow(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestUpdateWindowStatus(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 3, 10, hour, minute, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		name     string
+		window   string
+		now      time.Time
+		open     bool
+		nextOpen time.Time
+	}{
+		{"no window", "", at(14, 0), true, time.Time{}},
+		{"inside", "02:00-04:00", at(3, 15), true, time.Time{}},
+		{"before start", "02:00-04:00", at(1, 0), false, at(2, 0)},
+		{"after end", "02:00-04:00", at(14, 0), false, at(2, 0).AddDate(0, 0, 1)},
+		{"end is exclusive", "02:00-04:00", at(4, 0), false, at(2, 0).AddDate(0, 0, 1)},
+		{"wrapping, late evening", "23:00-01:00", at(23, 30), true, time.Time{}},
+		{"wrapping, after midnight", "23:00-01:00", at(0, 30), true, time.Time{}},
+		{"wrapping, daytime", "23:00-01:00", at(12, 0), false, at(23, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			open, next, err := UpdateWindowStatus(tt.window, tt.now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.open, open)
+			if !tt.open {
+				assert.True(t, tt.nextOpen.Equal(next), "expected next start %v, got %v", tt.nextOpen, next)
+			}
+		})
+	}
+}
+
+func TestConfigValidateUpdateWindow(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.UpdateWindow = "02:00-04:00"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.UpdateWindow = "overnight"
+	assert.Error(t, cfg.Validate())
+}
@@ -16,10 +16,17 @@ const (
 	ServerConfigChanged EventType = "server_config_changed"
 	ServerAutoDisabled  EventType = "server_auto_disabled"
 	ServerGroupUpdated  EventType = "server_group_updated"
+	ServerRestarted     EventType = "server_restarted"
+
+	// ServerAutoQuarantined is published when auto_quarantine_after_failures quarantines a server
+	ServerAutoQuarantined EventType = "server_auto_quarantined"
 
 	// Application state events
 	AppStateChanged EventType = "app_state_changed"
 
+	// ConfigDiff is published after a config reload with a *config.ConfigDiff as Data
+	ConfigDiff EventType = "config_diff"
+
 	// HIGH-006: Legacy event type names - kept for backward compatibility
 	// These are actively used throughout the codebase and should be migrated
 	// to the canonical names above in a future refactoring phase.
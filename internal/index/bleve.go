@@ -2,7 +2,10 @@ package index
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/blevesearch/bleve/v2"
@@ -16,19 +19,37 @@ import (
 // BleveIndex wraps Bleve index operations
 type BleveIndex struct {
 	index  bleve.Index
+	path   string
 	logger *zap.Logger
 }
 
+// conflictSuffix separates a colliding document ID from its disambiguator, e.g. "a:b#2"
+const conflictSuffix = "#"
+
+// ToolConflict is a set of indexed tools that share the same server:tool document ID
+type ToolConflict struct {
+	DocID string            `json:"doc_id"`
+	Tools []ConflictingTool `json:"tools"`
+}
+
+// ConflictingTool is one of the tools in a ToolConflict
+type ConflictingTool struct {
+	DocID        string `json:"indexed_as"`
+	ServerName   string `json:"server"`
+	FullToolName string `json:"tool"`
+}
+
 // ToolDocument represents a tool document in the index
 type ToolDocument struct {
-	ToolName       string `json:"tool_name"`      // Just the tool name (without server prefix)
-	FullToolName   string `json:"full_tool_name"` // Complete server:tool format
-	ServerName     string `json:"server_name"`
-	Description    string `json:"description"`
-	ParamsJSON     string `json:"params_json"`
-	Hash           string `json:"hash"`
-	Tags           string `json:"tags"`
-	SearchableText string `json:"searchable_text"` // Combined searchable content
+	ToolName       string   `json:"tool_name"`      // Just the tool name (without server prefix)
+	FullToolName   string   `json:"full_tool_name"` // Complete server:tool format
+	ServerName     string   `json:"server_name"`
+	Description    string   `json:"description"`
+	ParamsJSON     string   `json:"params_json"`
+	Hash           string   `json:"hash"`
+	Tags           string   `json:"tags"`
+	ParamNames     []string `json:"param_names"`     // Lowercased top-level input parameter names
+	SearchableText string   `json:"searchable_text"` // Combined searchable content
 }
 
 // NewBleveIndex creates a new Bleve index
@@ -38,6 +59,11 @@ func NewBleveIndex(dataDir string, logger *zap.Logger) (*BleveIndex, error) {
 	// Try to open existing index
 	index, err := bleve.Open(indexPath)
 	if err != nil {
+		// An index directory that exists but can't be opened is corrupt - let the caller decide how to recover
+		if _, statErr := os.Stat(indexPath); statErr == nil {
+			return nil, fmt.Errorf("failed to open existing Bleve index at %s: %w", indexPath, err)
+		}
+
 		// If index doesn't exist, create a new one
 		logger.Info("Creating new Bleve index", zap.String("path", indexPath))
 		index, err = createBleveIndex(indexPath)
@@ -50,10 +76,41 @@ func NewBleveIndex(dataDir string, logger *zap.Logger) (*BleveIndex, error) {
 
 	return &BleveIndex{
 		index:  index,
+		path:   indexPath,
+		logger: logger,
+	}, nil
+}
+
+// RecreateBleveIndex discards whatever is stored at the index path and creates an empty index
+func RecreateBleveIndex(dataDir string, logger *zap.Logger) (*BleveIndex, error) {
+	indexPath := filepath.Join(dataDir, "index.bleve")
+
+	logger.Warn("Recreating Bleve index from scratch", zap.String("path", indexPath))
+	index, err := recreateBleveIndex(indexPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BleveIndex{
+		index:  index,
+		path:   indexPath,
 		logger: logger,
 	}, nil
 }
 
+// recreateBleveIndex removes the index directory and creates a fresh index in its place
+func recreateBleveIndex(indexPath string) (bleve.Index, error) {
+	if err := os.RemoveAll(indexPath); err != nil {
+		return nil, fmt.Errorf("failed to remove Bleve index at %s: %w", indexPath, err)
+	}
+
+	index, err := createBleveIndex(indexPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Bleve index: %w", err)
+	}
+	return index, nil
+}
+
 // createBleveIndex creates a new Bleve index with proper mapping
 func createBleveIndex(indexPath string) (bleve.Index, error) {
 	// Create index mapping
@@ -111,6 +168,14 @@ func createBleveIndex(indexPath string) (bleve.Index, error) {
 	tagsField.Index = true
 	toolMapping.AddFieldMappingsAt("tags", tagsField)
 
+	// Parameter names field (keyword analyzer) - one term per parameter, for find_tools_by_param
+	paramNamesField := bleve.NewTextFieldMapping()
+	paramNamesField.Analyzer = keyword.Name
+	paramNamesField.Store = false
+	paramNamesField.Index = true
+	paramNamesField.IncludeInAll = false // Keep full-text ranking unchanged
+	toolMapping.AddFieldMappingsAt("param_names", paramNamesField)
+
 	// Searchable text field (standard analyzer) - combines all searchable content
 	searchableTextField := bleve.NewTextFieldMapping()
 	searchableTextField.Analyzer = standard.Name
@@ -154,22 +219,41 @@ func (b *BleveIndex) IndexTool(toolMeta *config.ToolMetadata) error {
 		ParamsJSON:     toolMeta.ParamsJSON,
 		Hash:           toolMeta.Hash,
 		Tags:           "", // Can be extended later
+		ParamNames:     paramNames(toolMeta.ParamsJSON),
 		SearchableText: searchableText,
 	}
 
 	// Use server:tool format as document ID for uniqueness
-	docID := fmt.Sprintf("%s:%s", toolMeta.ServerName, toolName)
+	docID := b.uniqueDocID(fmt.Sprintf("%s:%s", toolMeta.ServerName, toolName), doc, nil)
 
 	b.logger.Debug("Indexing tool", zap.String("doc_id", docID), zap.String("tool_name", toolName))
 	return b.index.Index(docID, doc)
 }
 
-// DeleteTool removes a tool from the index
+// DeleteTool removes a tool from the index. Documents are looked up by server and tool name
+// rather than by ID, since a colliding tool may be stored under a suffixed ID and the base ID
+// may belong to another server's tool.
 func (b *BleveIndex) DeleteTool(serverName, toolName string) error {
-	docID := fmt.Sprintf("%s:%s", serverName, toolName)
+	serverQuery := bleve.NewTermQuery(serverName)
+	serverQuery.SetField("server_name")
+	toolQuery := bleve.NewTermQuery(toolName)
+	toolQuery.SetField("tool_name")
+
+	searchReq := bleve.NewSearchRequest(bleve.NewConjunctionQuery(serverQuery, toolQuery))
+	searchReq.Size = 100 // A tool is indexed once; more hits would be stale duplicates
+
+	searchResult, err := b.index.Search(searchReq)
+	if err != nil {
+		return fmt.Errorf("failed to search for tool %s:%s: %w", serverName, toolName, err)
+	}
 
-	b.logger.Debug("Deleting tool from index", zap.String("doc_id", docID))
-	return b.index.Delete(docID)
+	for _, hit := range searchResult.Hits {
+		b.logger.Debug("Deleting tool from index", zap.String("doc_id", hit.ID))
+		if err := b.index.Delete(hit.ID); err != nil {
+			return fmt.Errorf("failed to delete tool %s: %w", hit.ID, err)
+		}
+	}
+	return nil
 }
 
 // DeleteServerTools removes all tools from a specific server
@@ -262,16 +346,8 @@ func (b *BleveIndex) SearchTools(queryStr string, limit int) ([]*config.SearchRe
 	// Convert results
 	var results []*config.SearchResult
 	for _, hit := range searchResult.Hits {
-		toolMeta := &config.ToolMetadata{
-			Name:        getStringField(hit.Fields, "full_tool_name"),
-			ServerName:  getStringField(hit.Fields, "server_name"),
-			Description: getStringField(hit.Fields, "description"),
-			ParamsJSON:  getStringField(hit.Fields, "params_json"),
-			Hash:        getStringField(hit.Fields, "hash"),
-		}
-
 		results = append(results, &config.SearchResult{
-			Tool:  toolMeta,
+			Tool:  toolFromHit(hit.Fields),
 			Score: hit.Score,
 		})
 	}
@@ -290,6 +366,7 @@ func (b *BleveIndex) GetDocumentCount() (uint64, error) {
 // BatchIndex indexes multiple tools in a single batch
 func (b *BleveIndex) BatchIndex(tools []*config.ToolMetadata) error {
 	batch := b.index.NewBatch()
+	pending := make(map[string]*ToolDocument, len(tools))
 
 	for _, toolMeta := range tools {
 		// Extract just the tool name (remove server prefix)
@@ -313,10 +390,12 @@ func (b *BleveIndex) BatchIndex(tools []*config.ToolMetadata) error {
 			ParamsJSON:     toolMeta.ParamsJSON,
 			Hash:           toolMeta.Hash,
 			Tags:           "",
+			ParamNames:     paramNames(toolMeta.ParamsJSON),
 			SearchableText: searchableText,
 		}
 
-		docID := fmt.Sprintf("%s:%s", toolMeta.ServerName, toolName)
+		docID := b.uniqueDocID(fmt.Sprintf("%s:%s", toolMeta.ServerName, toolName), doc, pending)
+		pending[docID] = doc
 		_ = batch.Index(docID, doc)
 	}
 
@@ -324,19 +403,135 @@ func (b *BleveIndex) BatchIndex(tools []*config.ToolMetadata) error {
 	return b.index.Batch(batch)
 }
 
-// RebuildIndex rebuilds the entire index
-func (b *BleveIndex) RebuildIndex() error {
+// uniqueDocID returns docID, or docID with a "#N" disambiguator if docID already belongs to a
+// different tool, either in the index or earlier in the current batch (pending). Without this,
+// tools whose server:tool IDs collide would silently overwrite each other.
+func (b *BleveIndex) uniqueDocID(docID string, doc *ToolDocument, pending map[string]*ToolDocument) string {
+	for n := 1; ; n++ {
+		candidate := docID
+		if n > 1 {
+			candidate = fmt.Sprintf("%s%s%d", docID, conflictSuffix, n)
+		}
+
+		var ownerServer, ownerTool string
+		if owner, ok := pending[candidate]; ok {
+			ownerServer, ownerTool = owner.ServerName, owner.FullToolName
+		} else if server, tool, found := b.storedTool(candidate); found {
+			ownerServer, ownerTool = server, tool
+		} else {
+			return candidate
+		}
+
+		if ownerServer == doc.ServerName && ownerTool == doc.FullToolName {
+			return candidate // Re-indexing the same tool
+		}
+		if n == 1 {
+			b.logger.Warn("Tool name collision in search index, keeping both tools",
+				zap.String("doc_id", docID),
+				zap.String("existing_server", ownerServer),
+				zap.String("existing_tool", ownerTool),
+				zap.String("new_server", doc.ServerName),
+				zap.String("new_tool", doc.FullToolName))
+		}
+	}
+}
+
+// storedTool returns the server and full tool name of an indexed document
+func (b *BleveIndex) storedTool(docID string) (serverName, fullToolName string, found bool) {
+	searchReq := bleve.NewSearchRequest(bleve.NewDocIDQuery([]string{docID}))
+	searchReq.Size = 1
+	searchReq.Fields = []string{"server_name", "full_tool_name"}
+
+	searchResult, err := b.index.Search(searchReq)
+	if err != nil || len(searchResult.Hits) == 0 {
+		return "", "", false
+	}
+	hit := searchResult.Hits[0]
+	return getStringField(hit.Fields, "server_name"), getStringField(hit.Fields, "full_tool_name"), true
+}
+
+// DetectToolConflicts reports indexed tools whose server:tool document IDs collided and
+// were disambiguated
+func (b *BleveIndex) DetectToolConflicts() ([]ToolConflict, error) {
+	count, err := b.index.DocCount()
+	if err != nil {
+		return nil, err
+	}
+
+	searchReq := bleve.NewSearchRequest(bleve.NewMatchAllQuery())
+	searchReq.Size = int(count)
+	searchReq.Fields = []string{"server_name", "full_tool_name"}
+
+	searchResult, err := b.index.Search(searchReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list indexed tools: %w", err)
+	}
+
+	groups := make(map[string][]ConflictingTool)
+	for _, hit := range searchResult.Hits {
+		base := baseDocID(hit.ID)
+		groups[base] = append(groups[base], ConflictingTool{
+			DocID:        hit.ID,
+			ServerName:   getStringField(hit.Fields, "server_name"),
+			FullToolName: getStringField(hit.Fields, "full_tool_name"),
+		})
+	}
+
+	conflicts := []ToolConflict{}
+	for base, tools := range groups {
+		if len(tools) < 2 {
+			continue
+		}
+		sort.Slice(tools, func(i, j int) bool { return tools[i].DocID < tools[j].DocID })
+		conflicts = append(conflicts, ToolConflict{DocID: base, Tools: tools})
+	}
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i].DocID < conflicts[j].DocID })
+
+	return conflicts, nil
+}
+
+// baseDocID strips a "#N" disambiguator added by uniqueDocID
+func baseDocID(docID string) string {
+	idx := strings.LastIndex(docID, conflictSuffix)
+	if idx < 0 {
+		return docID
+	}
+	if _, err := strconv.Atoi(docID[idx+len(conflictSuffix):]); err != nil {
+		return docID
+	}
+	return docID[:idx]
+}
+
+// RebuildIndex drops the entire index and re-indexes the given tools into a fresh one
+func (b *BleveIndex) RebuildIndex(tools []*config.ToolMetadata) error {
 	// Get index stats before rebuild
 	count, _ := b.index.DocCount()
-	b.logger.Info("Rebuilding index", zap.Uint64("current_docs", count))
+	b.logger.Info("Rebuilding index",
+		zap.Uint64("current_docs", count),
+		zap.Int("tools", len(tools)))
 
-	// For now, we'll just log the operation
-	// In a full implementation, this would:
-	// 1. Create a new index
-	// 2. Re-index all tools from storage
-	// 3. Atomically swap indices
+	if err := b.index.Close(); err != nil {
+		b.logger.Warn("Failed to close index before rebuild", zap.Error(err))
+	}
 
-	return nil
+	index, err := recreateBleveIndex(b.path)
+	if err != nil {
+		return err
+	}
+	b.index = index
+
+	return b.BatchIndex(tools)
+}
+
+// toolFromHit rebuilds the tool metadata from the stored fields of a search hit
+func toolFromHit(fields map[string]interface{}) *config.ToolMetadata {
+	return &config.ToolMetadata{
+		Name:        getStringField(fields, "full_tool_name"),
+		ServerName:  getStringField(fields, "server_name"),
+		Description: getStringField(fields, "description"),
+		ParamsJSON:  getStringField(fields, "params_json"),
+		Hash:        getStringField(fields, "hash"),
+	}
 }
 
 // Helper function to get string field from search results
@@ -320,6 +320,63 @@ func TestBleveIndex_EmptyAndErrorCases(t *testing.T) {
 	assert.Equal(t, uint64(0), count)
 }
 
+func TestBleveIndex_ToolNameCollisions(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "bleve_test_*")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	bleveIndex, err := NewBleveIndex(tmpDir, zap.NewNop())
+	require.NoError(t, err)
+	defer bleveIndex.Close()
+
+	// Both map to the document ID "team:api:deploy"
+	tools := []*config.ToolMetadata{
+		{Name: "team:api:deploy", ServerName: "team", Description: "Deploy from team"},
+		{Name: "custom:deploy", ServerName: "team:api", Description: "Deploy from team:api"},
+		{Name: "other:status", ServerName: "other", Description: "Status"},
+	}
+	require.NoError(t, bleveIndex.BatchIndex(tools))
+
+	count, err := bleveIndex.GetDocumentCount()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), count, "colliding tools should both be kept")
+
+	conflicts, err := bleveIndex.DetectToolConflicts()
+	require.NoError(t, err)
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, "team:api:deploy", conflicts[0].DocID)
+	require.Len(t, conflicts[0].Tools, 2)
+	assert.Equal(t, "team:api:deploy", conflicts[0].Tools[0].DocID)
+	assert.Equal(t, "team", conflicts[0].Tools[0].ServerName)
+	assert.Equal(t, "team:api:deploy#2", conflicts[0].Tools[1].DocID)
+	assert.Equal(t, "team:api", conflicts[0].Tools[1].ServerName)
+
+	// Re-indexing the same tools updates them in place instead of adding more copies
+	require.NoError(t, bleveIndex.BatchIndex(tools))
+	require.NoError(t, bleveIndex.IndexTool(tools[1]))
+	count, err = bleveIndex.GetDocumentCount()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), count)
+
+	// Deleting the suffixed tool removes its document and leaves the one holding the base ID
+	require.NoError(t, bleveIndex.DeleteTool("team:api", "deploy"))
+	server, tool, found := bleveIndex.storedTool("team:api:deploy")
+	require.True(t, found)
+	assert.Equal(t, "team", server)
+	assert.Equal(t, "team:api:deploy", tool)
+	_, _, found = bleveIndex.storedTool("team:api:deploy#2")
+	assert.False(t, found)
+	count, err = bleveIndex.GetDocumentCount()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), count)
+}
+
+func TestBaseDocID(t *testing.T) {
+	assert.Equal(t, "a:b", baseDocID("a:b"))
+	assert.Equal(t, "a:b", baseDocID("a:b#2"))
+	assert.Equal(t, "a:b#x", baseDocID("a:b#x"))
+}
+
 // Helper function to create test DeFiLlama tools based on user's data
 func createTestDeFiLlamaTools() []*config.ToolMetadata {
 	tools := []*config.ToolMetadata{
@@ -0,0 +1,126 @@
+package index
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blevesearch/bleve/v2"
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
+)
+
+// SetToolLimits caps how many tools BatchIndexTools and RebuildIndex keep per server and in
+// total, protecting memory from upstreams that advertise thousands of tools (0 = no limit)
+func (m *Manager) SetToolLimits(maxPerServer, maxTotal int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.maxToolsPerServer = maxPerServer
+	m.maxTotalTools = maxTotal
+}
+
+// TruncatedServers returns how many tools of each server were skipped by the tool limits
+// the last time its tools were indexed. Servers that fit within the limits are not listed.
+func (m *Manager) TruncatedServers() map[string]int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	truncated := make(map[string]int, len(m.truncated))
+	for server, skipped := range m.truncated {
+		truncated[server] = skipped
+	}
+	return truncated
+}
+
+// applyToolLimits drops the tools that would take a server or the whole index past the tool
+// limits, and records which servers were truncated. Tools that are already indexed are always
+// kept, since re-indexing them does not grow the index. With fresh set the index is about to
+// be rebuilt, so nothing counts as indexed yet. Must be called with m.mu held.
+func (m *Manager) applyToolLimits(tools []*config.ToolMetadata, fresh bool) []*config.ToolMetadata {
+	if fresh {
+		m.truncated = make(map[string]int)
+	}
+
+	if m.maxToolsPerServer <= 0 && m.maxTotalTools <= 0 {
+		for _, tool := range tools {
+			delete(m.truncated, tool.ServerName)
+		}
+		return tools
+	}
+
+	var total int
+	if !fresh {
+		if count, err := m.bleveIndex.GetDocumentCount(); err == nil {
+			total = int(count)
+		}
+	}
+	perServer := make(map[string]int)
+	skipped := make(map[string]int)
+
+	kept := make([]*config.ToolMetadata, 0, len(tools))
+	for _, tool := range tools {
+		server := tool.ServerName
+		if _, counted := perServer[server]; !counted {
+			perServer[server] = 0
+			if !fresh {
+				perServer[server] = m.bleveIndex.serverToolCount(server)
+			}
+		}
+
+		if !fresh && m.bleveIndex.isIndexed(tool) {
+			kept = append(kept, tool)
+			continue
+		}
+		if (m.maxToolsPerServer > 0 && perServer[server] >= m.maxToolsPerServer) ||
+			(m.maxTotalTools > 0 && total >= m.maxTotalTools) {
+			skipped[server]++
+			continue
+		}
+
+		perServer[server]++
+		total++
+		kept = append(kept, tool)
+	}
+
+	for server := range perServer {
+		if skipped[server] == 0 {
+			delete(m.truncated, server)
+			continue
+		}
+		m.truncated[server] = skipped[server]
+		m.logger.Warn("Tool limit reached, skipping tools from the search index",
+			zap.String("server", server),
+			zap.Int("skipped", skipped[server]),
+			zap.Int("max_tools_per_server", m.maxToolsPerServer),
+			zap.Int("max_total_tools", m.maxTotalTools))
+	}
+
+	return kept
+}
+
+// serverToolCount returns the number of indexed tools of a server
+func (b *BleveIndex) serverToolCount(serverName string) int {
+	query := bleve.NewTermQuery(serverName)
+	query.SetField("server_name")
+
+	searchReq := bleve.NewSearchRequest(query)
+	searchReq.Size = 0
+
+	searchResult, err := b.index.Search(searchReq)
+	if err != nil {
+		return 0
+	}
+	return int(searchResult.Total)
+}
+
+// isIndexed reports whether a tool already has a document in the index
+func (b *BleveIndex) isIndexed(toolMeta *config.ToolMetadata) bool {
+	toolName := toolMeta.Name
+	if parts := strings.SplitN(toolMeta.Name, ":", 2); len(parts) == 2 {
+		toolName = parts[1]
+	}
+
+	server, fullToolName, found := b.storedTool(fmt.Sprintf("%s:%s", toolMeta.ServerName, toolName))
+	return found && server == toolMeta.ServerName && fullToolName == toolMeta.Name
+}
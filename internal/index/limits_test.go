@@ -0,0 +1,82 @@
+package index
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
+)
+
+func limitTestTools(server string, count int) []*config.ToolMetadata {
+	tools := make([]*config.ToolMetadata, 0, count)
+	for i := 0; i < count; i++ {
+		tools = append(tools, &config.ToolMetadata{
+			Name:        fmt.Sprintf("%s:tool_%d", server, i),
+			ServerName:  server,
+			Description: "Generated tool",
+		})
+	}
+	return tools
+}
+
+func TestManager_ToolLimitPerServer(t *testing.T) {
+	manager, err := NewManager(t.TempDir(), zap.NewNop(), nil)
+	require.NoError(t, err)
+	defer manager.Close()
+
+	manager.SetToolLimits(3, 0)
+
+	require.NoError(t, manager.BatchIndexTools(append(limitTestTools("registry", 5), limitTestTools("small", 2)...)))
+
+	count, err := manager.GetDocumentCount()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), count)
+	assert.Equal(t, map[string]int{"registry": 2}, manager.TruncatedServers())
+
+	stats, err := manager.GetStats()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"registry": 2}, stats["truncated_servers"])
+
+	// Re-indexing the same tools keeps the ones already indexed without growing the index
+	require.NoError(t, manager.BatchIndexTools(limitTestTools("registry", 5)))
+	count, err = manager.GetDocumentCount()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), count)
+
+	// Removing the server clears its truncated flag
+	require.NoError(t, manager.DeleteServerTools("registry"))
+	assert.Empty(t, manager.TruncatedServers())
+}
+
+func TestManager_ToolLimitTotal(t *testing.T) {
+	manager, err := NewManager(t.TempDir(), zap.NewNop(), nil)
+	require.NoError(t, err)
+	defer manager.Close()
+
+	manager.SetToolLimits(0, 4)
+
+	require.NoError(t, manager.BatchIndexTools(limitTestTools("first", 3)))
+	require.NoError(t, manager.BatchIndexTools(limitTestTools("second", 3)))
+
+	count, err := manager.GetDocumentCount()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), count)
+	assert.Equal(t, map[string]int{"second": 2}, manager.TruncatedServers())
+
+	// A rebuild applies the limits from scratch
+	manager.SetToolLimits(2, 0)
+	require.NoError(t, manager.RebuildIndex(append(limitTestTools("first", 3), limitTestTools("second", 1)...)))
+	count, err = manager.GetDocumentCount()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), count)
+	assert.Equal(t, map[string]int{"first": 1}, manager.TruncatedServers())
+
+	// Without limits every tool is indexed and the flags clear
+	manager.SetToolLimits(0, 0)
+	require.NoError(t, manager.BatchIndexTools(limitTestTools("first", 3)))
+	assert.Empty(t, manager.TruncatedServers())
+}
@@ -12,6 +12,9 @@ import (
 	"go.uber.org/zap"
 )
 
+// maxSearchWindow bounds the number of ranked results considered for pagination
+const maxSearchWindow = 1000
+
 // Manager provides a unified interface for indexing operations
 type Manager struct {
 	bleveIndex    *BleveIndex
@@ -19,19 +22,34 @@ type Manager struct {
 	mu            sync.RWMutex
 	logger        *zap.Logger
 	config        *config.SemanticSearchConfig
+
+	// needsRebuild is set when the on-disk index was corrupt and had to be recreated empty
+	needsRebuild bool
+
+	// Tool limits (0 = no limit) and the number of tools skipped per truncated server
+	maxToolsPerServer int
+	maxTotalTools     int
+	truncated         map[string]int
 }
 
 // NewManager creates a new index manager
 func NewManager(dataDir string, logger *zap.Logger, semanticConfig *config.SemanticSearchConfig) (*Manager, error) {
+	needsRebuild := false
 	bleveIndex, err := NewBleveIndex(dataDir, logger)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create Bleve index: %w", err)
+		// Don't refuse to start because of a corrupt index - it only holds derived data
+		logger.Error("Failed to open Bleve index, recreating it", zap.Error(err))
+		bleveIndex, err = RecreateBleveIndex(dataDir, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Bleve index: %w", err)
+		}
+		needsRebuild = true
 	}
 
 	// Create semantic index if enabled
 	var semanticIndex *semantic.SemanticIndex
 	if semanticConfig != nil && semanticConfig.Enabled {
-		semanticIndex, err = semantic.NewSemanticIndex(dataDir, logger, true)
+		semanticIndex, err = semantic.NewSemanticIndex(dataDir, semanticConfig.EmbeddingModel, logger, true)
 		if err != nil {
 			logger.Warn("Failed to create semantic index, falling back to BM25 only",
 				zap.Error(err))
@@ -44,9 +62,30 @@ func NewManager(dataDir string, logger *zap.Logger, semanticConfig *config.Seman
 		semanticIndex: semanticIndex,
 		logger:        logger,
 		config:        semanticConfig,
+		needsRebuild:  needsRebuild,
+		truncated:     make(map[string]int),
 	}, nil
 }
 
+// NeedsRebuild reports whether the index was recreated empty at startup and
+// must be repopulated from stored tool metadata
+func (m *Manager) NeedsRebuild() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.needsRebuild
+}
+
+// SetEmbeddingCache configures the persistent embedding cache used by the semantic index
+func (m *Manager) SetEmbeddingCache(cache semantic.EmbeddingCache) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.semanticIndex != nil && m.semanticIndex.IsEnabled() {
+		m.semanticIndex.SetEmbeddingCache(cache)
+	}
+}
+
 // Close closes the index manager
 func (m *Manager) Close() error {
 	m.mu.Lock()
@@ -88,11 +127,14 @@ func (m *Manager) IndexTool(toolMeta *config.ToolMetadata) error {
 	return nil
 }
 
-// BatchIndexTools indexes multiple tools efficiently in both indices
+// BatchIndexTools indexes multiple tools efficiently in both indices. Tools beyond the
+// limits set with SetToolLimits are skipped with a warning.
 func (m *Manager) BatchIndexTools(tools []*config.ToolMetadata) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	tools = m.applyToolLimits(tools, false)
+
 	// Index in BM25 (primary index)
 	if err := m.bleveIndex.BatchIndex(tools); err != nil {
 		return err
@@ -278,6 +320,64 @@ func (m *Manager) Search(query string, limit int) ([]*config.SearchResult, error
 	return m.SearchTools(query, limit)
 }
 
+// SearchToolsPage returns one page of ranked search results together with the
+// total number of matches. Offset is applied after ranking, so pages are stable
+// for the same query. The total is capped at maxSearchWindow.
+func (m *Manager) SearchToolsPage(query string, offset, limit int) ([]*config.SearchResult, int, error) {
+	return m.SearchToolsPageSorted(query, offset, limit, nil)
+}
+
+// SearchToolsPageSorted is like SearchToolsPage, but reorders all matches with less
+// before paging. The sort is stable, so ties keep their relevance order. A nil less
+// keeps the relevance ranking.
+func (m *Manager) SearchToolsPageSorted(query string, offset, limit int, less func(a, b *config.SearchResult) bool) ([]*config.SearchResult, int, error) {
+	return m.SearchToolsPageBoosted(query, offset, limit, nil, less)
+}
+
+// SearchToolsPageBoosted is SearchToolsPageSorted with the scores of each server's tools
+// multiplied by its entry in boosts before ranking. Servers without an entry keep their scores.
+func (m *Manager) SearchToolsPageBoosted(query string, offset, limit int, boosts map[string]float64, less func(a, b *config.SearchResult) bool) ([]*config.SearchResult, int, error) {
+	if offset < 0 {
+		offset = 0
+	}
+	if limit <= 0 {
+		limit = 20 // default limit
+	}
+
+	results, err := m.SearchTools(query, maxSearchWindow)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if len(boosts) > 0 {
+		for _, result := range results {
+			if boost, ok := boosts[result.Tool.ServerName]; ok {
+				result.Score *= boost
+			}
+		}
+		sort.SliceStable(results, func(i, j int) bool {
+			return results[i].Score > results[j].Score
+		})
+	}
+
+	if less != nil {
+		sort.SliceStable(results, func(i, j int) bool {
+			return less(results[i], results[j])
+		})
+	}
+
+	total := len(results)
+	if offset >= total {
+		return []*config.SearchResult{}, total, nil
+	}
+
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+	return results[offset:end], total, nil
+}
+
 // DeleteTool removes a tool from both indices
 func (m *Manager) DeleteTool(serverName, toolName string) error {
 	m.mu.Lock()
@@ -310,6 +410,7 @@ func (m *Manager) DeleteServerTools(serverName string) error {
 	if err := m.bleveIndex.DeleteServerTools(serverName); err != nil {
 		return err
 	}
+	delete(m.truncated, serverName)
 
 	// Delete from semantic index if enabled
 	if m.semanticIndex != nil && m.semanticIndex.IsEnabled() {
@@ -331,12 +432,37 @@ func (m *Manager) GetDocumentCount() (uint64, error) {
 	return m.bleveIndex.GetDocumentCount()
 }
 
-// RebuildIndex rebuilds the entire index
-func (m *Manager) RebuildIndex() error {
+// RebuildIndex discards the current index and re-indexes the given tools, within the
+// limits set with SetToolLimits
+func (m *Manager) RebuildIndex(tools []*config.ToolMetadata) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	return m.bleveIndex.RebuildIndex()
+	tools = m.applyToolLimits(tools, true)
+
+	if err := m.bleveIndex.RebuildIndex(tools); err != nil {
+		return err
+	}
+	m.needsRebuild = false
+
+	// Re-index in semantic index if enabled (unchanged tools reuse cached embeddings)
+	if m.semanticIndex != nil && m.semanticIndex.IsEnabled() {
+		if err := m.semanticIndex.BatchIndexTools(context.Background(), tools); err != nil {
+			m.logger.Warn("Failed to rebuild semantic index",
+				zap.Int("count", len(tools)),
+				zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// DetectToolConflicts reports tools whose server:tool names collided in the index
+func (m *Manager) DetectToolConflicts() ([]ToolConflict, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.bleveIndex.DetectToolConflicts()
 }
 
 // GetStats returns indexing statistics
@@ -354,6 +480,13 @@ func (m *Manager) GetStats() (map[string]interface{}, error) {
 		"index_type":     "bleve",
 		"search_backend": "BM25",
 	}
+	if len(m.truncated) > 0 {
+		truncated := make(map[string]int, len(m.truncated))
+		for server, skipped := range m.truncated {
+			truncated[server] = skipped
+		}
+		stats["truncated_servers"] = truncated
+	}
 
 	return stats, nil
 }
@@ -0,0 +1,149 @@
+package index
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
+)
+
+func TestManager_SearchToolsPage(t *testing.T) {
+	manager, err := NewManager(t.TempDir(), zap.NewNop(), nil)
+	require.NoError(t, err)
+	defer manager.Close()
+
+	tools := make([]*config.ToolMetadata, 0, 7)
+	for i := 0; i < 7; i++ {
+		tools = append(tools, &config.ToolMetadata{
+			Name:        fmt.Sprintf("weather:forecast_%d", i),
+			ServerName:  "weather",
+			Description: "Get the weather forecast for a city",
+			ParamsJSON:  `{"type":"object"}`,
+			Hash:        fmt.Sprintf("hash%d", i),
+		})
+	}
+	require.NoError(t, manager.BatchIndexTools(tools))
+
+	all, err := manager.SearchTools("weather forecast", 100)
+	require.NoError(t, err)
+	require.Len(t, all, 7)
+
+	// Pages follow the ranked order without overlap
+	page1, total, err := manager.SearchToolsPage("weather forecast", 0, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 7, total)
+	require.Len(t, page1, 3)
+
+	page3, total, err := manager.SearchToolsPage("weather forecast", 6, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 7, total)
+	require.Len(t, page3, 1)
+	assert.Equal(t, all[6].Tool.Name, page3[0].Tool.Name)
+
+	for i, result := range page1 {
+		assert.Equal(t, all[i].Tool.Name, result.Tool.Name)
+	}
+
+	// Offset beyond the last match returns an empty page with the total
+	empty, total, err := manager.SearchToolsPage("weather forecast", 10, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 7, total)
+	assert.Empty(t, empty)
+}
+
+func TestManager_SearchToolsPageSorted(t *testing.T) {
+	manager, err := NewManager(t.TempDir(), zap.NewNop(), nil)
+	require.NoError(t, err)
+	defer manager.Close()
+
+	tools := make([]*config.ToolMetadata, 0, 5)
+	for i := 0; i < 5; i++ {
+		tools = append(tools, &config.ToolMetadata{
+			Name:        fmt.Sprintf("weather:forecast_%d", i),
+			ServerName:  "weather",
+			Description: "Get the weather forecast for a city",
+			ParamsJSON:  `{"type":"object"}`,
+			Hash:        fmt.Sprintf("hash%d", i),
+		})
+	}
+	require.NoError(t, manager.BatchIndexTools(tools))
+
+	// Reverse alphabetical order is applied before paging
+	byNameDesc := func(a, b *config.SearchResult) bool { return a.Tool.Name > b.Tool.Name }
+	page, total, err := manager.SearchToolsPageSorted("weather forecast", 1, 2, byNameDesc)
+	require.NoError(t, err)
+	assert.Equal(t, 5, total)
+	require.Len(t, page, 2)
+	assert.Equal(t, "weather:forecast_3", page[0].Tool.Name)
+	assert.Equal(t, "weather:forecast_2", page[1].Tool.Name)
+
+	// A nil comparison keeps the relevance ranking
+	ranked, _, err := manager.SearchToolsPage("weather forecast", 0, 5)
+	require.NoError(t, err)
+	unsorted, _, err := manager.SearchToolsPageSorted("weather forecast", 0, 5, nil)
+	require.NoError(t, err)
+	assert.Equal(t, ranked, unsorted)
+}
+
+func TestManager_SearchToolsPageBoosted(t *testing.T) {
+	manager, err := NewManager(t.TempDir(), zap.NewNop(), nil)
+	require.NoError(t, err)
+	defer manager.Close()
+
+	require.NoError(t, manager.BatchIndexTools([]*config.ToolMetadata{
+		{Name: "fast:get_weather", ServerName: "fast", Description: "Get the weather", Hash: "h1"},
+		{Name: "slow:get_weather", ServerName: "slow", Description: "Get the weather forecast for a city by name", Hash: "h2"},
+	}))
+
+	ranked, _, err := manager.SearchToolsPage("weather", 0, 5)
+	require.NoError(t, err)
+	require.Len(t, ranked, 2)
+	preferred, other := ranked[1].Tool.ServerName, ranked[0].Tool.ServerName
+	otherScore := ranked[0].Score
+
+	// Boosting the lower ranked server moves its tool to the top
+	boosted, total, err := manager.SearchToolsPageBoosted("weather", 0, 5, map[string]float64{preferred: 100}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	require.Len(t, boosted, 2)
+	assert.Equal(t, preferred, boosted[0].Tool.ServerName)
+	assert.Equal(t, other, boosted[1].Tool.ServerName)
+	assert.Equal(t, otherScore, boosted[1].Score)
+}
+
+func TestManager_RecoversFromCorruptIndex(t *testing.T) {
+	dataDir := t.TempDir()
+
+	// Simulate a crash that left garbage in the index directory
+	indexPath := filepath.Join(dataDir, "index.bleve")
+	require.NoError(t, os.MkdirAll(indexPath, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(indexPath, "index_meta.json"), []byte("{corrupt"), 0644))
+
+	manager, err := NewManager(dataDir, zap.NewNop(), nil)
+	require.NoError(t, err)
+	defer manager.Close()
+	assert.True(t, manager.NeedsRebuild())
+
+	tools := []*config.ToolMetadata{
+		{Name: "github:create_issue", ServerName: "github", Description: "Create a GitHub issue"},
+		{Name: "github:list_repos", ServerName: "github", Description: "List GitHub repositories"},
+	}
+	require.NoError(t, manager.RebuildIndex(tools))
+	assert.False(t, manager.NeedsRebuild())
+
+	count, err := manager.GetDocumentCount()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), count)
+
+	// Rebuilding again replaces rather than appends
+	require.NoError(t, manager.RebuildIndex(tools[:1]))
+	count, err = manager.GetDocumentCount()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), count)
+}
@@ -0,0 +1,185 @@
+package index
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/blevesearch/bleve/v2"
+
+	"mcpproxy-go/internal/config"
+)
+
+// maxParamSearchCandidates bounds the tools fetched from the index for a parameter search,
+// before they are filtered by parameter type
+const maxParamSearchCandidates = 1000
+
+// ToolParam is a top-level parameter declared in a tool's input schema
+type ToolParam struct {
+	Name     string `json:"name"`
+	Type     string `json:"type,omitempty"` // JSON Schema type; alternatives are joined with "|"
+	Required bool   `json:"required"`
+}
+
+// ParseToolParams returns the top-level parameters of a tool's input schema (ParamsJSON),
+// sorted by name. An empty or unparsable schema has no parameters.
+func ParseToolParams(paramsJSON string) []ToolParam {
+	if paramsJSON == "" {
+		return nil
+	}
+
+	var schema struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+		Required   []string                   `json:"required"`
+	}
+	if err := json.Unmarshal([]byte(paramsJSON), &schema); err != nil {
+		return nil
+	}
+
+	required := make(map[string]bool, len(schema.Required))
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+
+	params := make([]ToolParam, 0, len(schema.Properties))
+	for name, raw := range schema.Properties {
+		var property struct {
+			Type interface{} `json:"type"`
+		}
+		_ = json.Unmarshal(raw, &property)
+
+		params = append(params, ToolParam{
+			Name:     name,
+			Type:     paramType(property.Type),
+			Required: required[name],
+		})
+	}
+
+	sort.Slice(params, func(i, j int) bool {
+		return params[i].Name < params[j].Name
+	})
+	return params
+}
+
+// paramType renders the type keyword of a property schema, a string or an array of strings
+func paramType(schemaType interface{}) string {
+	switch t := schemaType.(type) {
+	case string:
+		return t
+	case []interface{}:
+		var types []string
+		for _, entry := range t {
+			if name, ok := entry.(string); ok {
+				types = append(types, name)
+			}
+		}
+		return strings.Join(types, "|")
+	}
+	return ""
+}
+
+// MatchingParams returns the parameters of a tool whose name contains name (case-insensitive)
+// and, if paramType is set, whose type allows paramType
+func MatchingParams(paramsJSON, name, paramType string) []ToolParam {
+	name = strings.ToLower(name)
+	paramType = strings.ToLower(paramType)
+
+	var matches []ToolParam
+	for _, param := range ParseToolParams(paramsJSON) {
+		if !strings.Contains(strings.ToLower(param.Name), name) {
+			continue
+		}
+		if paramType != "" && !typeAllows(param.Type, paramType) {
+			continue
+		}
+		matches = append(matches, param)
+	}
+	return matches
+}
+
+func typeAllows(declared, paramType string) bool {
+	for _, allowed := range strings.Split(strings.ToLower(declared), "|") {
+		if allowed == paramType {
+			return true
+		}
+	}
+	return false
+}
+
+// paramNames returns the lowercased top-level parameter names of a tool for the param_names field
+func paramNames(paramsJSON string) []string {
+	params := ParseToolParams(paramsJSON)
+	names := make([]string, 0, len(params))
+	for _, param := range params {
+		names = append(names, strings.ToLower(param.Name))
+	}
+	return names
+}
+
+// SearchToolsByParam finds tools declaring a parameter whose name contains name, ranking
+// exact name matches first
+func (b *BleveIndex) SearchToolsByParam(name string, limit int) ([]*config.SearchResult, error) {
+	// Wildcard characters would widen the match beyond a substring search
+	term := strings.ToLower(strings.NewReplacer("*", "", "?", "").Replace(strings.TrimSpace(name)))
+	if term == "" {
+		return nil, fmt.Errorf("parameter name cannot be empty")
+	}
+
+	boolQuery := bleve.NewBooleanQuery()
+
+	exactQuery := bleve.NewTermQuery(term)
+	exactQuery.SetField("param_names")
+	exactQuery.SetBoost(3.0)
+	boolQuery.AddShould(exactQuery)
+
+	partialQuery := bleve.NewWildcardQuery("*" + term + "*")
+	partialQuery.SetField("param_names")
+	boolQuery.AddShould(partialQuery)
+
+	searchReq := bleve.NewSearchRequest(boolQuery)
+	searchReq.Size = limit
+	searchReq.Fields = []string{"tool_name", "full_tool_name", "server_name", "description", "params_json", "hash"}
+
+	searchResult, err := b.index.Search(searchReq)
+	if err != nil {
+		return nil, fmt.Errorf("search failed: %w", err)
+	}
+
+	results := make([]*config.SearchResult, 0, len(searchResult.Hits))
+	for _, hit := range searchResult.Hits {
+		results = append(results, &config.SearchResult{
+			Tool:  toolFromHit(hit.Fields),
+			Score: hit.Score,
+		})
+	}
+	return results, nil
+}
+
+// SearchToolsByParam finds tools declaring a parameter whose name contains name and, if
+// paramType is set, whose JSON Schema type allows paramType (e.g. "string")
+func (m *Manager) SearchToolsByParam(name, paramType string, limit int) ([]*config.SearchResult, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if limit <= 0 {
+		limit = 20 // default limit
+	}
+
+	candidates, err := m.bleveIndex.SearchToolsByParam(name, maxParamSearchCandidates)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*config.SearchResult
+	for _, candidate := range candidates {
+		if len(MatchingParams(candidate.Tool.ParamsJSON, name, paramType)) == 0 {
+			continue
+		}
+		results = append(results, candidate)
+		if len(results) == limit {
+			break
+		}
+	}
+	return results, nil
+}
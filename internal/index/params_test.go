@@ -0,0 +1,68 @@
+package index
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
+)
+
+func TestParseToolParams(t *testing.T) {
+	params := ParseToolParams(`{"type":"object","properties":{"path":{"type":"string"},"to":{"type":["string","null"]},"recursive":{}},"required":["path"]}`)
+	assert.Equal(t, []ToolParam{
+		{Name: "path", Type: "string", Required: true},
+		{Name: "recursive"},
+		{Name: "to", Type: "string|null"},
+	}, params)
+
+	assert.Empty(t, ParseToolParams(""))
+	assert.Empty(t, ParseToolParams("not json"))
+}
+
+func TestManager_SearchToolsByParam(t *testing.T) {
+	manager, err := NewManager(t.TempDir(), zap.NewNop(), nil)
+	require.NoError(t, err)
+	defer manager.Close()
+
+	require.NoError(t, manager.BatchIndexTools([]*config.ToolMetadata{
+		{Name: "fs:read_file", ServerName: "fs", Description: "Read a file", Hash: "h1",
+			ParamsJSON: `{"type":"object","properties":{"file_path":{"type":"string"}}}`},
+		{Name: "fs:list_dir", ServerName: "fs", Description: "List a directory", Hash: "h2",
+			ParamsJSON: `{"type":"object","properties":{"path":{"type":"string"}}}`},
+		{Name: "git:log", ServerName: "git", Description: "Show commits", Hash: "h3",
+			ParamsJSON: `{"type":"object","properties":{"path":{"type":"array"},"limit":{"type":"integer"}}}`},
+		{Name: "mail:send", ServerName: "mail", Description: "Send an email", Hash: "h4",
+			ParamsJSON: `{"type":"object","properties":{"to":{"type":"string"}}}`},
+	}))
+
+	names := func(results []*config.SearchResult) []string {
+		var names []string
+		for _, result := range results {
+			names = append(names, result.Tool.Name)
+		}
+		return names
+	}
+
+	results, err := manager.SearchToolsByParam("file_path", "", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fs:read_file"}, names(results))
+
+	results, err = manager.SearchToolsByParam("PATH", "", 10)
+	require.NoError(t, err)
+	require.Len(t, results, 3, "names containing the parameter match too")
+	assert.NotEqual(t, "fs:read_file", results[0].Tool.Name, "exact name matches rank first")
+
+	results, err = manager.SearchToolsByParam("path", "string", 10)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"fs:read_file", "fs:list_dir"}, names(results))
+
+	results, err = manager.SearchToolsByParam("to", "", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mail:send"}, names(results), "stop words are valid parameter names")
+
+	_, err = manager.SearchToolsByParam("  ", "", 10)
+	assert.Error(t, err)
+}
@@ -0,0 +1,74 @@
+package logs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"go.uber.org/zap"
+)
+
+// CorrelationIDHeader carries the correlation ID of an HTTP request. A valid ID sent by the
+// client is kept; otherwise one is generated. Responses always echo it.
+const CorrelationIDHeader = "X-Correlation-ID"
+
+const maxCorrelationIDLength = 64
+
+type correlationIDKey struct{}
+
+// NewCorrelationID returns a random 16 character hex ID
+func NewCorrelationID() string {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "0000000000000000"
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// ValidCorrelationID reports whether a client-supplied ID is safe to log and echo: 1-64
+// letters, digits, '-', '_' or '.'
+func ValidCorrelationID(id string) bool {
+	if id == "" || len(id) > maxCorrelationIDLength {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// WithCorrelationID returns a context carrying the correlation ID
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// CorrelationID returns the correlation ID of the context, or "" if it has none
+func CorrelationID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(correlationIDKey{}).(string)
+	return id
+}
+
+// EnsureCorrelationID returns ctx and its correlation ID, adding a new ID if it has none
+func EnsureCorrelationID(ctx context.Context) (context.Context, string) {
+	if id := CorrelationID(ctx); id != "" {
+		return ctx, id
+	}
+	id := NewCorrelationID()
+	return WithCorrelationID(ctx, id), id
+}
+
+// CorrelationField returns the correlation ID of the context as a log field. It is a no-op
+// field when the context has no ID.
+func CorrelationField(ctx context.Context) zap.Field {
+	if id := CorrelationID(ctx); id != "" {
+		return zap.String("correlation_id", id)
+	}
+	return zap.Skip()
+}
@@ -0,0 +1,47 @@
+package logs
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestEnsureCorrelationID(t *testing.T) {
+	ctx, id := EnsureCorrelationID(context.Background())
+	assert.Len(t, id, 16)
+	assert.Equal(t, id, CorrelationID(ctx))
+
+	// An existing ID is kept
+	again, sameID := EnsureCorrelationID(ctx)
+	assert.Equal(t, id, sameID)
+	assert.Equal(t, ctx, again)
+
+	assert.NotEqual(t, id, NewCorrelationID())
+	assert.Empty(t, CorrelationID(context.Background()))
+}
+
+func TestValidCorrelationID(t *testing.T) {
+	assert.True(t, ValidCorrelationID("req-42_a.B"))
+	assert.True(t, ValidCorrelationID(strings.Repeat("a", 64)))
+	assert.False(t, ValidCorrelationID(""))
+	assert.False(t, ValidCorrelationID(strings.Repeat("a", 65)))
+	assert.False(t, ValidCorrelationID("bad id"))
+	assert.False(t, ValidCorrelationID("inject\nline"))
+}
+
+func TestCorrelationField(t *testing.T) {
+	core, recorded := observer.New(zapcore.InfoLevel)
+	logger := zap.New(core)
+
+	logger.Info("with", CorrelationField(WithCorrelationID(context.Background(), "abc")))
+	logger.Info("without", CorrelationField(context.Background()))
+
+	entries := recorded.All()
+	assert.Equal(t, "abc", entries[0].ContextMap()["correlation_id"])
+	assert.NotContains(t, entries[1].ContextMap(), "correlation_id")
+}
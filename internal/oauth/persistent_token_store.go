@@ -133,3 +133,12 @@ func (p *PersistentTokenStore) ClearToken() error {
 	p.logger.Info("✅ OAuth token cleared from persistent storage successfully")
 	return nil
 }
+
+// GetStoredTokenRecord returns the persisted OAuth token record for a server without
+// going through a token store, so callers can inspect expiry cheaply.
+func GetStoredTokenRecord(serverName, serverURL string, db *storage.BoltDB) (*storage.OAuthTokenRecord, error) {
+	if db == nil {
+		return nil, fmt.Errorf("storage not available")
+	}
+	return db.GetOAuthToken(generateServerKey(serverName, serverURL))
+}
@@ -294,3 +294,36 @@ func TestPersistentTokenStoreSameNameDifferentURL(t *testing.T) {
 		t.Errorf("Server2 token should still exist: got %s, want token-for-server2-url", retrievedToken2Again.AccessToken)
 	}
 }
+
+func TestGetStoredTokenRecord(t *testing.T) {
+	db, err := storage.NewBoltDB(t.TempDir(), zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatalf("Failed to create BoltDB: %v", err)
+	}
+	defer db.Close()
+
+	if _, err := GetStoredTokenRecord("test-server", "https://test.example.com/mcp", db); err == nil {
+		t.Error("Expected error for missing token record")
+	}
+
+	expiresAt := time.Now().Add(30 * time.Minute).Truncate(time.Second)
+	store := NewPersistentTokenStore("test-server", "https://test.example.com/mcp", db)
+	if err := store.SaveToken(&client.Token{AccessToken: "a", RefreshToken: "r", ExpiresAt: expiresAt}); err != nil {
+		t.Fatalf("Failed to save token: %v", err)
+	}
+
+	record, err := GetStoredTokenRecord("test-server", "https://test.example.com/mcp", db)
+	if err != nil {
+		t.Fatalf("Failed to get token record: %v", err)
+	}
+	if !record.ExpiresAt.Equal(expiresAt) {
+		t.Errorf("ExpiresAt mismatch: got %v, want %v", record.ExpiresAt, expiresAt)
+	}
+	if record.RefreshToken != "r" {
+		t.Errorf("RefreshToken mismatch: got %s", record.RefreshToken)
+	}
+
+	if _, err := GetStoredTokenRecord("test-server", "https://test.example.com/mcp", nil); err == nil {
+		t.Error("Expected error when storage is nil")
+	}
+}
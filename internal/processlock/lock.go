@@ -8,8 +8,11 @@ import (
 	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
 )
 
 const (
@@ -83,6 +86,10 @@ func (p *ProcessLock) Release() error {
 
 // checkPort checks if the listen address port is already in use
 func (p *ProcessLock) checkPort(listenAddr string) error {
+	if socketPath, ok := config.UnixSocketPath(listenAddr); ok {
+		return p.checkSocket(socketPath)
+	}
+
 	// Parse listen address
 	host, port, err := net.SplitHostPort(listenAddr)
 	if err != nil {
@@ -105,6 +112,17 @@ func (p *ProcessLock) checkPort(listenAddr string) error {
 	return nil
 }
 
+// checkSocket checks if a Unix socket is already being served. A socket file nobody
+// answers on is left from a crashed run and is replaced when the server starts.
+func (p *ProcessLock) checkSocket(socketPath string) error {
+	conn, err := net.DialTimeout("unix", socketPath, time.Second)
+	if err != nil {
+		return nil
+	}
+	conn.Close()
+	return fmt.Errorf("socket %s is already in use by another process", socketPath)
+}
+
 // readPID reads the PID from the PID file
 func (p *ProcessLock) readPID() (int, error) {
 	data, err := os.ReadFile(p.pidFile)
@@ -0,0 +1,90 @@
+package registries
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// catalogStatsTTL is how long a registry's server count is cached
+	catalogStatsTTL = 15 * time.Minute
+
+	// catalogStatsRetryTTL is how long an unreachable registry is remembered before retrying
+	catalogStatsRetryTTL = 1 * time.Minute
+)
+
+// RegistryStats is a registry together with the size of its server catalog
+type RegistryStats struct {
+	RegistryEntry
+	ServerCount int       `json:"server_count"`
+	Available   bool      `json:"available"`
+	Error       string    `json:"error,omitempty"`
+	CheckedAt   time.Time `json:"checked_at"`
+}
+
+var (
+	catalogStatsCache = make(map[string]RegistryStats)
+	catalogStatsMu    sync.Mutex
+)
+
+// ListRegistriesWithStats returns every registry with the number of servers in its catalog.
+// Catalogs are fetched concurrently and cached; unreachable registries are included with
+// Available set to false rather than omitted.
+func ListRegistriesWithStats(ctx context.Context) []RegistryStats {
+	regs := ListRegistries()
+	result := make([]RegistryStats, len(regs))
+
+	var wg sync.WaitGroup
+	for i := range regs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			result[i] = registryStats(ctx, &regs[i])
+		}(i)
+	}
+	wg.Wait()
+
+	return result
+}
+
+// registryStats returns the cached catalog size of reg, fetching it when stale
+func registryStats(ctx context.Context, reg *RegistryEntry) RegistryStats {
+	key := reg.ID + "|" + reg.ServersURL
+
+	catalogStatsMu.Lock()
+	cached, ok := catalogStatsCache[key]
+	catalogStatsMu.Unlock()
+	if ok {
+		ttl := catalogStatsTTL
+		if !cached.Available {
+			ttl = catalogStatsRetryTTL
+		}
+		if time.Since(cached.CheckedAt) < ttl {
+			cached.RegistryEntry = *reg
+			return cached
+		}
+	}
+
+	stats := RegistryStats{RegistryEntry: *reg, CheckedAt: time.Now()}
+	if stats.Description == "" {
+		stats.Description = noDescAvailable
+	}
+
+	if reg.ServersURL == "" {
+		stats.Error = "registry has no servers endpoint"
+	} else if servers, err := fetchServers(ctx, reg, nil); err != nil {
+		stats.Error = err.Error()
+	} else {
+		stats.ServerCount = len(servers)
+		stats.Available = true
+	}
+
+	// Don't cache results cut short by the caller's context
+	if ctx.Err() == nil {
+		catalogStatsMu.Lock()
+		catalogStatsCache[key] = stats
+		catalogStatsMu.Unlock()
+	}
+	return stats
+}
@@ -0,0 +1,66 @@
+package registries
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListRegistriesWithStats(t *testing.T) {
+	var hits int32
+	okServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode([]map[string]interface{}{
+			{"id": "one", "name": "One", "url": "https://one.example.com/mcp"},
+			{"id": "two", "name": "Two", "url": "https://two.example.com/mcp"},
+		})
+	}))
+	defer okServer.Close()
+
+	failServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "down", http.StatusInternalServerError)
+	}))
+	defer failServer.Close()
+
+	originalList := registryList
+	registryList = []RegistryEntry{
+		{ID: "ok", Name: "OK Registry", Description: "Works", ServersURL: okServer.URL},
+		{ID: "broken", Name: "Broken Registry", ServersURL: failServer.URL},
+		{ID: "no-endpoint", Name: "No Endpoint"},
+	}
+	defer func() { registryList = originalList }()
+
+	catalogStatsMu.Lock()
+	catalogStatsCache = make(map[string]RegistryStats)
+	catalogStatsMu.Unlock()
+
+	stats := ListRegistriesWithStats(context.Background())
+	require.Len(t, stats, 3)
+
+	assert.Equal(t, "ok", stats[0].ID)
+	assert.True(t, stats[0].Available)
+	assert.Equal(t, 2, stats[0].ServerCount)
+	assert.Equal(t, "Works", stats[0].Description)
+	assert.Empty(t, stats[0].Error)
+
+	assert.Equal(t, "broken", stats[1].ID)
+	assert.False(t, stats[1].Available)
+	assert.Zero(t, stats[1].ServerCount)
+	assert.Equal(t, noDescAvailable, stats[1].Description)
+	assert.Contains(t, stats[1].Error, "500")
+
+	assert.Equal(t, "no-endpoint", stats[2].ID)
+	assert.False(t, stats[2].Available)
+
+	// A second listing is served from the cache
+	stats = ListRegistriesWithStats(context.Background())
+	assert.Equal(t, 2, stats[0].ServerCount)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&hits))
+}
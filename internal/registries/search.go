@@ -74,6 +74,41 @@ func SearchServers(ctx context.Context, registryID, tag, query string, limit int
 	return filtered, nil
 }
 
+// FindServer looks up a single server in a registry by its ID, falling back to a
+// case-insensitive name match
+func FindServer(ctx context.Context, registryID, serverID string) (*ServerEntry, error) {
+	reg := FindRegistry(registryID)
+	if reg == nil {
+		return nil, fmt.Errorf("registry '%s' not found", registryID)
+	}
+
+	if reg.ServersURL == "" {
+		return nil, fmt.Errorf("registry '%s' has no servers endpoint", reg.Name)
+	}
+
+	servers, err := fetchServers(ctx, reg, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch servers from %s: %w", reg.Name, err)
+	}
+
+	var match *ServerEntry
+	for i := range servers {
+		if servers[i].ID == serverID {
+			match = &servers[i]
+			break
+		}
+		if match == nil && strings.EqualFold(servers[i].Name, serverID) {
+			match = &servers[i]
+		}
+	}
+	if match == nil {
+		return nil, fmt.Errorf("server '%s' not found in registry '%s'", serverID, reg.Name)
+	}
+
+	match.Registry = reg.Name
+	return match, nil
+}
+
 // fetchServers fetches and parses servers from a registry based on its protocol
 func fetchServers(ctx context.Context, reg *RegistryEntry, guesser *experiments.Guesser) ([]ServerEntry, error) {
 	client := &http.Client{
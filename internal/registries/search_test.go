@@ -1418,3 +1418,34 @@ func TestIsGitHubURL(t *testing.T) {
 		})
 	}
 }
+
+func TestFindServer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode([]map[string]interface{}{
+			{"id": "weather-mcp", "name": "Weather", "installCmd": "npx -y weather-mcp"},
+			{"id": "news-mcp", "name": "News"},
+		})
+	}))
+	defer server.Close()
+
+	originalList := registryList
+	registryList = []RegistryEntry{{ID: "test", Name: "Test Registry", ServersURL: server.URL}}
+	defer func() { registryList = originalList }()
+
+	entry, err := FindServer(context.Background(), "test", "weather-mcp")
+	require.NoError(t, err)
+	assert.Equal(t, "Weather", entry.Name)
+	assert.Equal(t, "npx -y weather-mcp", entry.InstallCmd)
+	assert.Equal(t, "Test Registry", entry.Registry)
+
+	entry, err = FindServer(context.Background(), "test", "news")
+	require.NoError(t, err)
+	assert.Equal(t, "news-mcp", entry.ID)
+
+	_, err = FindServer(context.Background(), "test", "missing")
+	assert.Error(t, err)
+
+	_, err = FindServer(context.Background(), "unknown", "weather-mcp")
+	assert.Error(t, err)
+}
@@ -11,17 +11,28 @@ import (
 
 	"go.uber.org/zap"
 	"mcpproxy-go/internal/config"
+	"mcpproxy-go/internal/hash"
 )
 
+// EmbeddingCache persists computed tool embeddings keyed by a hash of the embedded text.
+// GetEmbedding returns a nil embedding and nil error on a cache miss.
+type EmbeddingCache interface {
+	GetEmbedding(hash string) ([]float32, error)
+	SaveEmbedding(hash string, embedding []float32) error
+	DeleteEmbedding(hash string) error
+}
+
 // SemanticIndex manages semantic search over tools
 type SemanticIndex struct {
 	embedding  *EmbeddingService
+	cache      EmbeddingCache
 	logger     *zap.Logger
 	dataDir    string
 	documents  map[string]*EmbeddingDocument
 	mu         sync.RWMutex
 	indexPath  string
 	enabled    bool
+	model      string // Embedding model the documents were embedded with ("" = service default)
 }
 
 // SearchResult represents a semantic search result
@@ -31,8 +42,9 @@ type SearchResult struct {
 	Similarity float32
 }
 
-// NewSemanticIndex creates a new semantic index
-func NewSemanticIndex(dataDir string, logger *zap.Logger, enabled bool) (*SemanticIndex, error) {
+// NewSemanticIndex creates a new semantic index. model is the pinned embedding model; documents
+// and cached embeddings of another model are not reused.
+func NewSemanticIndex(dataDir, model string, logger *zap.Logger, enabled bool) (*SemanticIndex, error) {
 	if !enabled {
 		logger.Info("Semantic search is disabled")
 		return &SemanticIndex{
@@ -60,6 +72,7 @@ func NewSemanticIndex(dataDir string, logger *zap.Logger, enabled bool) (*Semant
 		documents:  make(map[string]*EmbeddingDocument),
 		indexPath:  indexPath,
 		enabled:    true,
+		model:      model,
 	}
 
 	// Try to load existing index
@@ -80,6 +93,13 @@ func (idx *SemanticIndex) IsEnabled() bool {
 	return idx.enabled
 }
 
+// SetEmbeddingCache sets the persistent cache consulted before computing embeddings
+func (idx *SemanticIndex) SetEmbeddingCache(cache EmbeddingCache) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.cache = cache
+}
+
 // IndexTool indexes a tool for semantic search
 func (idx *SemanticIndex) IndexTool(ctx context.Context, tool *config.ToolMetadata) error {
 	if !idx.enabled {
@@ -95,26 +115,38 @@ func (idx *SemanticIndex) IndexTool(ctx context.Context, tool *config.ToolMetada
 		tool.Description,
 		tool.ParamsJSON)
 
-	// Generate embedding
-	embedding, err := idx.embedding.Embed(ctx, searchableText)
+	// Create document ID
+	docID := fmt.Sprintf("%s:%s", tool.ServerName, tool.Name)
+
+	// Invalidate the cached embedding of the previous version when the tool changed
+	textHash := hash.StringHash(searchableText)
+	if previous, exists := idx.documents[docID]; exists && idx.cache != nil {
+		if previousHash := hash.StringHash(previous.Text); previousHash != textHash {
+			if err := idx.cache.DeleteEmbedding(idx.cacheKey(previousHash)); err != nil {
+				idx.logger.Debug("Failed to invalidate cached embedding",
+					zap.String("tool", docID),
+					zap.Error(err))
+			}
+		}
+	}
+
+	embedding, err := idx.getOrComputeEmbedding(ctx, textHash, searchableText)
 	if err != nil {
 		return fmt.Errorf("failed to generate embedding: %w", err)
 	}
 
-	// Create document ID
-	docID := fmt.Sprintf("%s:%s", tool.ServerName, tool.Name)
-
 	// Store document
 	idx.documents[docID] = &EmbeddingDocument{
 		ID:        docID,
 		Text:      searchableText,
 		Embedding: embedding,
 		Metadata: map[string]interface{}{
-			"server_name": tool.ServerName,
-			"tool_name":   tool.Name,
-			"description": tool.Description,
-			"params_json": tool.ParamsJSON,
-			"hash":        tool.Hash,
+			"server_name":     tool.ServerName,
+			"tool_name":       tool.Name,
+			"description":     tool.Description,
+			"params_json":     tool.ParamsJSON,
+			"hash":            tool.Hash,
+			"embedding_model": idx.model,
 		},
 	}
 
@@ -125,6 +157,43 @@ func (idx *SemanticIndex) IndexTool(ctx context.Context, tool *config.ToolMetada
 	return nil
 }
 
+// cacheKey returns the embedding cache key of a text hash. Keys include the pinned model so
+// embeddings of different models (and dimensions) are never mixed; the service default model
+// keeps the plain hash.
+func (idx *SemanticIndex) cacheKey(hash string) string {
+	if idx.model == "" {
+		return hash
+	}
+	return idx.model + ":" + hash
+}
+
+// getOrComputeEmbedding returns the cached embedding for the hash of the text, computing
+// and caching it on a miss. Any change to the embedded text changes the hash, so cached
+// embeddings are never stale.
+func (idx *SemanticIndex) getOrComputeEmbedding(ctx context.Context, textHash, text string) ([]float32, error) {
+	if idx.cache != nil {
+		cached, err := idx.cache.GetEmbedding(idx.cacheKey(textHash))
+		if err != nil {
+			idx.logger.Debug("Failed to read cached embedding", zap.String("hash", textHash), zap.Error(err))
+		} else if len(cached) > 0 {
+			return cached, nil
+		}
+	}
+
+	embedding, err := idx.embedding.Embed(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+
+	if idx.cache != nil {
+		if err := idx.cache.SaveEmbedding(idx.cacheKey(textHash), embedding); err != nil {
+			idx.logger.Debug("Failed to cache embedding", zap.String("hash", textHash), zap.Error(err))
+		}
+	}
+
+	return embedding, nil
+}
+
 // BatchIndexTools indexes multiple tools efficiently
 func (idx *SemanticIndex) BatchIndexTools(ctx context.Context, tools []*config.ToolMetadata) error {
 	if !idx.enabled {
@@ -329,6 +398,20 @@ func (idx *SemanticIndex) Load() error {
 		return fmt.Errorf("failed to unmarshal index: %w", err)
 	}
 
+	// Drop documents embedded with another model; they are re-embedded when their tools are indexed
+	stale := 0
+	for docID, doc := range documents {
+		if model, _ := doc.Metadata["embedding_model"].(string); model != idx.model {
+			delete(documents, docID)
+			stale++
+		}
+	}
+	if stale > 0 {
+		idx.logger.Info("Dropped semantic index documents of another embedding model",
+			zap.String("model", idx.model),
+			zap.Int("documents", stale))
+	}
+
 	idx.mu.Lock()
 	idx.documents = documents
 	idx.mu.Unlock()
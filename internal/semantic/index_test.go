@@ -0,0 +1,84 @@
+package semantic
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
+	"mcpproxy-go/internal/hash"
+)
+
+type memoryEmbeddingCache map[string][]float32
+
+func (c memoryEmbeddingCache) GetEmbedding(hash string) ([]float32, error) {
+	return c[hash], nil
+}
+
+func (c memoryEmbeddingCache) SaveEmbedding(hash string, embedding []float32) error {
+	c[hash] = embedding
+	return nil
+}
+
+func (c memoryEmbeddingCache) DeleteEmbedding(hash string) error {
+	delete(c, hash)
+	return nil
+}
+
+func TestSemanticIndex_EmbeddingCacheKeyedByModel(t *testing.T) {
+	dir := t.TempDir()
+	tool := &config.ToolMetadata{ServerName: "github", Name: "create_issue", Description: "Create an issue"}
+	textHash := hash.StringHash("create_issue Create an issue ")
+	cache := memoryEmbeddingCache{}
+
+	idx, err := NewSemanticIndex(dir, "", zap.NewNop(), true)
+	require.NoError(t, err)
+	idx.SetEmbeddingCache(cache)
+	require.NoError(t, idx.IndexTool(context.Background(), tool))
+	assert.Contains(t, cache, textHash, "the service default model keeps plain hash keys")
+
+	pinned, err := NewSemanticIndex(dir, "all-MiniLM-L6-v2", zap.NewNop(), true)
+	require.NoError(t, err)
+	pinned.SetEmbeddingCache(cache)
+	require.NoError(t, pinned.IndexTool(context.Background(), tool))
+	assert.Contains(t, cache, "all-MiniLM-L6-v2:"+textHash)
+}
+
+func TestSemanticIndex_EmbeddingCacheFollowsEmbeddedText(t *testing.T) {
+	tool := &config.ToolMetadata{ServerName: "github", Name: "create_issue", Description: "Create an issue"}
+	cache := memoryEmbeddingCache{}
+
+	idx, err := NewSemanticIndex(t.TempDir(), "", zap.NewNop(), true)
+	require.NoError(t, err)
+	idx.SetEmbeddingCache(cache)
+	require.NoError(t, idx.IndexTool(context.Background(), tool))
+	require.Len(t, cache, 1)
+
+	// A new description is re-embedded under a new key and the stale embedding is dropped
+	changed := *tool
+	changed.Description = "Create an issue in a repository"
+	require.NoError(t, idx.IndexTool(context.Background(), &changed))
+	assert.Len(t, cache, 1)
+	assert.Contains(t, cache, hash.StringHash("create_issue Create an issue in a repository "))
+}
+
+func TestSemanticIndex_LoadDropsDocumentsOfAnotherModel(t *testing.T) {
+	dir := t.TempDir()
+	tool := &config.ToolMetadata{ServerName: "github", Name: "create_issue", Description: "Create an issue", Hash: "abc"}
+
+	idx, err := NewSemanticIndex(dir, "model-a", zap.NewNop(), true)
+	require.NoError(t, err)
+	require.NoError(t, idx.BatchIndexTools(context.Background(), []*config.ToolMetadata{tool}))
+	require.Equal(t, 1, idx.GetDocumentCount())
+
+	reopened, err := NewSemanticIndex(dir, "model-a", zap.NewNop(), true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reopened.GetDocumentCount())
+
+	switched, err := NewSemanticIndex(dir, "model-b", zap.NewNop(), true)
+	require.NoError(t, err)
+	assert.Equal(t, 0, switched.GetDocumentCount(), "documents embedded with another model are not searched")
+}
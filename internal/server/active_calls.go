@@ -0,0 +1,154 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+)
+
+// errCallCancelled is the cancellation cause of upstream calls aborted with cancel_call
+var errCallCancelled = errors.New("tool call cancelled by cancel_call")
+
+// activeCall is an upstream tool call in flight
+type activeCall struct {
+	CorrelationID string    `json:"correlation_id"`
+	Tool          string    `json:"tool"`
+	Server        string    `json:"server"`
+	Started       time.Time `json:"started"`
+
+	cancel context.CancelCauseFunc
+}
+
+// activeCalls tracks the upstream tool calls in flight so they can be listed and cancelled.
+// The zero value is ready to use.
+type activeCalls struct {
+	mu    sync.Mutex
+	next  uint64
+	calls map[uint64]*activeCall
+}
+
+// track registers a call and returns the context to run it with and a function to call when
+// it finished. Cancelling the call cancels the returned context with errCallCancelled.
+func (a *activeCalls) track(ctx context.Context, correlationID, tool, server string) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	call := &activeCall{
+		CorrelationID: correlationID,
+		Tool:          tool,
+		Server:        server,
+		Started:       time.Now(),
+		cancel:        cancel,
+	}
+
+	a.mu.Lock()
+	if a.calls == nil {
+		a.calls = make(map[uint64]*activeCall)
+	}
+	a.next++
+	id := a.next
+	a.calls[id] = call
+	a.mu.Unlock()
+
+	return ctx, func() {
+		a.mu.Lock()
+		delete(a.calls, id)
+		a.mu.Unlock()
+		cancel(nil)
+	}
+}
+
+// list returns the calls in flight, longest running first
+func (a *activeCalls) list() []*activeCall {
+	a.mu.Lock()
+	calls := make([]*activeCall, 0, len(a.calls))
+	for _, call := range a.calls {
+		calls = append(calls, call)
+	}
+	a.mu.Unlock()
+
+	sort.Slice(calls, func(i, j int) bool {
+		return calls[i].Started.Before(calls[j].Started)
+	})
+	return calls
+}
+
+// cancel cancels the calls in flight with the correlation ID and returns them. A batch_call
+// shares its correlation ID among its calls, so all of them are cancelled.
+func (a *activeCalls) cancel(correlationID string) []*activeCall {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var cancelled []*activeCall
+	for _, call := range a.calls {
+		if call.CorrelationID == correlationID {
+			call.cancel(errCallCancelled)
+			cancelled = append(cancelled, call)
+		}
+	}
+	return cancelled
+}
+
+// handleActiveCalls implements the active_calls MCP tool
+func (p *MCPProxyServer) handleActiveCalls(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	now := time.Now()
+	calls := p.activeCalls.list()
+
+	entries := make([]map[string]interface{}, 0, len(calls))
+	for _, call := range calls {
+		entries = append(entries, map[string]interface{}{
+			"correlation_id":  call.CorrelationID,
+			"tool":            call.Tool,
+			"server":          call.Server,
+			"started":         call.Started,
+			"running_seconds": int(now.Sub(call.Started).Seconds()),
+		})
+	}
+
+	jsonResult, err := json.Marshal(map[string]interface{}{
+		"calls": entries,
+		"count": len(entries),
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to serialize result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(jsonResult)), nil
+}
+
+// handleCancelCall implements the cancel_call MCP tool
+func (p *MCPProxyServer) handleCancelCall(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	correlationID, err := request.RequireString("correlation_id")
+	if err != nil {
+		return mcp.NewToolResultError("Missing required parameter 'correlation_id'"), nil
+	}
+
+	cancelled := p.activeCalls.cancel(correlationID)
+	if len(cancelled) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("No call in flight with correlation ID '%s'. List running calls with active_calls.", correlationID)), nil
+	}
+
+	tools := make([]string, 0, len(cancelled))
+	for _, call := range cancelled {
+		tools = append(tools, call.Tool)
+		p.logger.Info("Cancelled upstream tool call",
+			zap.String("correlation_id", correlationID),
+			zap.String("tool_name", call.Tool),
+			zap.Duration("running", time.Since(call.Started)))
+	}
+	sort.Strings(tools)
+
+	jsonResult, err := json.Marshal(map[string]interface{}{
+		"correlation_id": correlationID,
+		"cancelled":      len(cancelled),
+		"tools":          tools,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to serialize result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(jsonResult)), nil
+}
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestActiveCalls(t *testing.T) {
+	proxy := &MCPProxyServer{logger: zap.NewNop()}
+
+	call := func(name string, args map[string]interface{}) map[string]interface{} {
+		request := mcp.CallToolRequest{}
+		request.Params.Name = name
+		request.Params.Arguments = args
+
+		var result *mcp.CallToolResult
+		var err error
+		if name == operationActiveCalls {
+			result, err = proxy.handleActiveCalls(context.Background(), request)
+		} else {
+			result, err = proxy.handleCancelCall(context.Background(), request)
+		}
+		require.NoError(t, err)
+		if result.IsError {
+			return map[string]interface{}{"error": result.Content[0].(mcp.TextContent).Text}
+		}
+
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &response))
+		return response
+	}
+
+	// Two calls of one batch share a correlation ID
+	hungCtx, doneHung := proxy.activeCalls.track(context.Background(), "batch1", "jira:search", "jira")
+	otherCtx, doneOther := proxy.activeCalls.track(context.Background(), "batch1", "github:list_repos", "github")
+	keptCtx, doneKept := proxy.activeCalls.track(context.Background(), "single", "slack:post", "slack")
+	defer doneKept()
+
+	listed := call(operationActiveCalls, nil)
+	assert.Equal(t, float64(3), listed["count"])
+	calls := listed["calls"].([]interface{})
+	first := calls[0].(map[string]interface{})
+	assert.Equal(t, "batch1", first["correlation_id"])
+	assert.Equal(t, "jira:search", first["tool"])
+	assert.Equal(t, "jira", first["server"])
+
+	cancelled := call(operationCancelCall, map[string]interface{}{"correlation_id": "batch1"})
+	assert.Equal(t, float64(2), cancelled["cancelled"])
+	assert.Equal(t, []interface{}{"github:list_repos", "jira:search"}, cancelled["tools"])
+
+	assert.True(t, errors.Is(context.Cause(hungCtx), errCallCancelled))
+	assert.True(t, errors.Is(context.Cause(otherCtx), errCallCancelled))
+	assert.NoError(t, keptCtx.Err())
+
+	// Finished calls are no longer listed or cancellable
+	doneHung()
+	doneOther()
+	assert.Equal(t, float64(1), call(operationActiveCalls, nil)["count"])
+	assert.Contains(t, call(operationCancelCall, map[string]interface{}{"correlation_id": "batch1"})["error"], "No call in flight")
+	assert.Contains(t, call(operationCancelCall, nil)["error"], "correlation_id")
+}
@@ -205,15 +205,8 @@ func (s *Server) handleAgentMainLogs(w http.ResponseWriter, r *http.Request) {
 
 	filterPattern := query.Get("filter")
 
-	// Get main log file path
-	logDir := s.config.DataDir
-	if logDir == "" {
-		logDir = filepath.Join(os.Getenv("HOME"), ".mcpproxy")
-	}
-	logFile := filepath.Join(logDir, "logs", "main.log")
-
 	// Read log file
-	logEntries, err := s.readLogFile(logFile, lines, filterPattern)
+	logEntries, err := s.readLogFile(s.mainLogPath(), lines, filterPattern)
 	if err != nil {
 		s.logger.Warn("Failed to read main log", zap.Error(err))
 		logEntries = []map[string]interface{}{}
@@ -227,6 +220,41 @@ func (s *Server) handleAgentMainLogs(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// mainLogPath returns the path of the main mcpproxy log file
+func (s *Server) mainLogPath() string {
+	logDir := s.config.DataDir
+	if logDir == "" {
+		logDir = filepath.Join(os.Getenv("HOME"), ".mcpproxy")
+	}
+	return filepath.Join(logDir, "logs", "main.log")
+}
+
+// handleStartupLogsAPI returns captured startup script output
+// GET /api/startup/logs?lines=N
+func (s *Server) handleStartupLogsAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	lines := 100
+	if l := r.URL.Query().Get("lines"); l != "" {
+		fmt.Sscanf(l, "%d", &lines)
+	}
+	if lines > 1000 {
+		lines = 1000
+	}
+
+	logLines := s.GetStartupScriptLogs(lines)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"logs":   logLines,
+		"count":  len(logLines),
+		"status": s.GetStartupScriptStatus(),
+	})
+}
+
 // handleAgentServerConfig gets server configuration
 // GET /api/v1/agent/servers/{name}/config
 func (s *Server) handleAgentServerConfig(w http.ResponseWriter, r *http.Request) {
@@ -445,7 +473,7 @@ func (s *Server) parseLogLine(line string) map[string]interface{} {
 }
 
 // handleAgentSearchRegistries searches MCP server registries
-// GET /api/v1/agent/registries/search?query=weather&registry=smithery
+// GET /api/v1/agent/registries/search?query=weather&registry=smithery&limit=10
 func (s *Server) handleAgentSearchRegistries(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -461,21 +489,27 @@ func (s *Server) handleAgentSearchRegistries(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	// This would integrate with the existing search_servers MCP tool
-	// For now, return a placeholder response
+	limit := registrySearchLimit
+	if l := query.Get("limit"); l != "" {
+		fmt.Sscanf(l, "%d", &limit)
+	}
+
 	s.logger.Info("Agent registry search",
 		zap.String("query", searchQuery),
 		zap.String("registry", registry))
 
+	response, err := searchRegistries(r.Context(), searchQuery, registry, limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"results": []map[string]interface{}{},
-		"query":   searchQuery,
-		"message": "Registry search integration pending",
-	})
+	json.NewEncoder(w).Encode(response)
 }
 
-// handleAgentInstallServer installs a new MCP server
+// handleAgentInstallServer installs a new MCP server from a registry. The server is added
+// quarantined and its resolved config is returned for review.
 // POST /api/v1/agent/install
 func (s *Server) handleAgentInstallServer(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -484,9 +518,9 @@ func (s *Server) handleAgentInstallServer(w http.ResponseWriter, r *http.Request
 	}
 
 	var installRequest struct {
-		ServerID string                 `json:"server_id"`
-		Name     string                 `json:"name"`
-		Config   map[string]interface{} `json:"config"`
+		Registry string `json:"registry"`
+		ServerID string `json:"server_id"`
+		Name     string `json:"name"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&installRequest); err != nil {
@@ -494,15 +528,28 @@ func (s *Server) handleAgentInstallServer(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	if installRequest.Registry == "" || installRequest.ServerID == "" {
+		http.Error(w, "registry and server_id are required", http.StatusBadRequest)
+		return
+	}
+
+	if s.config.ReadOnlyMode || s.config.DisableManagement || !s.config.AllowServerAdd {
+		http.Error(w, "Adding servers is not allowed", http.StatusForbidden)
+		return
+	}
+
 	s.logger.Info("Agent server installation request",
+		zap.String("registry", installRequest.Registry),
 		zap.String("server_id", installRequest.ServerID),
 		zap.String("name", installRequest.Name))
 
-	// This would integrate with the installation logic
-	// For now, return a placeholder
+	serverConfig, err := s.installRegistryServer(r.Context(), installRequest.Registry, installRequest.ServerID, installRequest.Name)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Install failed: %v", err), http.StatusBadRequest)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"success": false,
-		"message": "Server installation integration pending",
-	})
-}
\ No newline at end of file
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(installResponse(serverConfig))
+}
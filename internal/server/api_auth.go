@@ -0,0 +1,58 @@
+package server
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// authProtectedPrefixes lists the route prefixes that require the API token.
+// The MCP endpoints (/mcp, /v1/tool_code) are governed separately.
+var authProtectedPrefixes = []string{"/api/", "/chat/", "/server/chat"}
+
+// requiresAPIAuth reports whether the request path is protected by the API token
+func requiresAPIAuth(path string) bool {
+	for _, prefix := range authProtectedPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// apiAuthMiddleware enforces "Authorization: Bearer <token>" on protected routes
+// when an api_token is configured. Without a token every request passes through.
+func (s *Server) apiAuthMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := s.config.APIToken
+		if token == "" || !requiresAPIAuth(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if !validBearerToken(r.Header.Get("Authorization"), token) {
+			s.logger.Warn("Rejected unauthorized API request",
+				zap.String("method", r.Method),
+				zap.String("path", r.URL.Path),
+				zap.String("remote_addr", r.RemoteAddr))
+			w.Header().Set("WWW-Authenticate", `Bearer realm="mcpproxy"`)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// validBearerToken compares the bearer token in an Authorization header against
+// the expected token in constant time
+func validBearerToken(header, expected string) bool {
+	const prefix = "Bearer "
+	if len(header) < len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return false
+	}
+	provided := strings.TrimSpace(header[len(prefix):])
+	return subtle.ConstantTimeCompare([]byte(provided), []byte(expected)) == 1
+}
@@ -0,0 +1,54 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mcpproxy-go/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestAPIAuthMiddleware(t *testing.T) {
+	okHandler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name       string
+		token      string
+		path       string
+		authHeader string
+		expected   int
+	}{
+		{name: "no token configured allows api", token: "", path: "/api/groups", expected: http.StatusOK},
+		{name: "missing header rejected", token: "secret", path: "/api/groups", expected: http.StatusUnauthorized},
+		{name: "wrong token rejected", token: "secret", path: "/api/servers/foo/config", authHeader: "Bearer nope", expected: http.StatusUnauthorized},
+		{name: "non-bearer scheme rejected", token: "secret", path: "/api/groups", authHeader: "Basic secret", expected: http.StatusUnauthorized},
+		{name: "valid token accepted", token: "secret", path: "/api/groups", authHeader: "Bearer secret", expected: http.StatusOK},
+		{name: "chat routes protected", token: "secret", path: "/chat/write-config", expected: http.StatusUnauthorized},
+		{name: "chat page protected", token: "secret", path: "/server/chat", expected: http.StatusUnauthorized},
+		{name: "mcp endpoint not governed by api token", token: "secret", path: "/mcp", expected: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{
+				config: &config.Config{APIToken: tt.token},
+				logger: zap.NewNop(),
+			}
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, http.NoBody)
+			if tt.authHeader != "" {
+				req.Header.Set("Authorization", tt.authHeader)
+			}
+			w := httptest.NewRecorder()
+
+			s.apiAuthMiddleware(okHandler).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expected, w.Code)
+		})
+	}
+}
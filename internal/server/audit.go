@@ -0,0 +1,267 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
+	"mcpproxy-go/internal/storage"
+)
+
+// Default and maximum number of entries returned by GET /api/audit
+const (
+	auditQueryLimit    = 100
+	auditQueryMaxLimit = 1000
+)
+
+// currentConfig returns the live configuration. p.config is captured at startup and not
+// replaced when the config file is reloaded.
+func (p *MCPProxyServer) currentConfig() *config.Config {
+	if p.mainServer == nil {
+		return p.config
+	}
+	p.mainServer.mu.RLock()
+	defer p.mainServer.mu.RUnlock()
+	return p.mainServer.config
+}
+
+// auditClientChat is the audit log client of tool calls made by the diagnostic chat
+const auditClientChat = "mcpproxy-chat"
+
+// auditContextKey carries the auditScope of a call through to the upstream manager
+type auditContextKey struct{}
+
+// auditScope attributes the upstream calls made with a context to a client, and notes whether
+// such a call was written to the audit log
+type auditScope struct {
+	client    string
+	sessionID string
+	recorded  atomic.Bool
+}
+
+// withAuditClient returns ctx with the upstream calls made with it attributed to client. It is
+// used for calls that don't carry the MCP session of the client, such as durable calls and
+// calls from the web chat.
+func withAuditClient(ctx context.Context, client, sessionID string) (context.Context, *auditScope) {
+	scope := &auditScope{client: client, sessionID: sessionID}
+	return context.WithValue(ctx, auditContextKey{}, scope), scope
+}
+
+// auditUpstreamCall is the upstream manager's tool call observer: every call that goes to an
+// upstream server, whatever path it took, is written to the audit log here
+func (p *MCPProxyServer) auditUpstreamCall(ctx context.Context, toolName string, args map[string]interface{}, result interface{}, err error, startTime time.Time) {
+	if scope, ok := ctx.Value(auditContextKey{}).(*auditScope); ok {
+		scope.recorded.Store(true)
+	}
+	client, sessionID := auditClient(ctx)
+	p.auditToolCall(toolName, args, client, sessionID, result, err, startTime)
+}
+
+// recordAudit writes a call_tool invocation to the audit log when audit_log_enabled is set.
+// Failures are logged but never fail the tool call itself.
+func (p *MCPProxyServer) recordAudit(ctx context.Context, request mcp.CallToolRequest, result *mcp.CallToolResult, callErr error, startTime time.Time) {
+	args, _ := callToolArgs(request)
+	client, sessionID := auditClient(ctx)
+	var callResult interface{}
+	if result != nil {
+		callResult = result
+	}
+	p.auditToolCall(request.GetString("name", ""), args, client, sessionID, callResult, callErr, startTime)
+}
+
+// auditToolCall writes one tool call to the audit log when audit_log_enabled is set. result
+// is the upstream result, normally a *mcp.CallToolResult.
+func (p *MCPProxyServer) auditToolCall(toolName string, args map[string]interface{}, client, sessionID string,
+	result interface{}, callErr error, startTime time.Time) {
+	cfg := p.currentConfig()
+	if cfg == nil || !cfg.AuditLogEnabled || p.storage == nil {
+		return
+	}
+
+	record := &storage.AuditRecord{
+		Timestamp:  startTime,
+		Tool:       toolName,
+		Args:       args,
+		Client:     client,
+		SessionID:  sessionID,
+		Status:     "success",
+		DurationMs: time.Since(startTime).Milliseconds(),
+	}
+
+	if serverName, actualToolName, ok := strings.Cut(toolName, ":"); ok && isSensitiveTool(cfg, serverName, actualToolName) {
+		record.Args = nil
+		record.Redacted = true
+	}
+
+	toolResult, isToolResult := result.(*mcp.CallToolResult)
+	switch {
+	case callErr != nil:
+		record.Status = "error"
+		record.Error = callErr.Error()
+	case result == nil || (isToolResult && toolResult == nil):
+		record.Status = "error"
+		record.Error = "no result"
+	case isToolResult && toolResult.IsError:
+		record.Status = "error"
+		record.Error = toolResultText(toolResult)
+	}
+
+	if err := p.storage.AppendAuditRecord(record); err != nil {
+		p.logger.Error("Failed to write audit log record",
+			zap.String("tool_name", toolName),
+			zap.Error(err))
+	}
+}
+
+// pruneAuditLog deletes audit log entries older than audit_log_retention, right away and then
+// every AuditLogPruneInterval until ctx is done. Entries are pruned even while audit_log_enabled
+// is off.
+func (p *MCPProxyServer) pruneAuditLog(ctx context.Context) {
+	if p.storage == nil {
+		return
+	}
+
+	ticker := time.NewTicker(config.AuditLogPruneInterval)
+	defer ticker.Stop()
+
+	for {
+		cutoff := time.Now().Add(-p.currentConfig().GetAuditLogRetention())
+		if pruned, err := p.storage.PruneAuditRecords(cutoff); err != nil {
+			p.logger.Warn("Failed to prune audit log", zap.Error(err))
+		} else if pruned > 0 {
+			p.logger.Info("Pruned expired audit log entries", zap.Int("count", pruned))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// auditClient identifies the MCP client that made the request
+func auditClient(ctx context.Context) (client, sessionID string) {
+	if scope, ok := ctx.Value(auditContextKey{}).(*auditScope); ok {
+		return scope.client, scope.sessionID
+	}
+
+	client = "unknown"
+	session := mcpserver.ClientSessionFromContext(ctx)
+	if session == nil {
+		return client, ""
+	}
+
+	if withInfo, ok := session.(mcpserver.SessionWithClientInfo); ok {
+		if info := withInfo.GetClientInfo(); info.Name != "" {
+			client = info.Name
+			if info.Version != "" {
+				client += "/" + info.Version
+			}
+		}
+	}
+	return client, session.SessionID()
+}
+
+// isSensitiveTool reports whether a tool's arguments must be redacted from the audit log
+func isSensitiveTool(cfg *config.Config, serverName, toolName string) bool {
+	for _, serverConfig := range cfg.Servers {
+		if serverConfig.Name == serverName {
+			return serverConfig.IsToolSensitive(toolName)
+		}
+	}
+	return false
+}
+
+// toolResultText returns the text content of a tool result, used as the error of failed calls
+func toolResultText(result *mcp.CallToolResult) string {
+	var parts []string
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			parts = append(parts, text.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// handleAuditAPI handles GET /api/audit, returning recent audit log entries (newest first).
+// Query parameters: tool (server:tool or server), client, status (success|error),
+// since (RFC3339 time or duration such as 1h) and limit (default 100, max 1000).
+func (s *Server) handleAuditAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	filter := storage.AuditFilter{
+		Tool:   query.Get("tool"),
+		Client: query.Get("client"),
+		Status: query.Get("status"),
+		Limit:  auditQueryLimit,
+	}
+	if filter.Status != "" && filter.Status != "success" && filter.Status != "error" {
+		http.Error(w, "Invalid status: must be 'success' or 'error'", http.StatusBadRequest)
+		return
+	}
+	if since := query.Get("since"); since != "" {
+		sinceTime, err := parseAuditSince(since)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		filter.Since = sinceTime
+	}
+	if limit := query.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 {
+			http.Error(w, "Invalid limit: must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		filter.Limit = n
+	}
+	if filter.Limit > auditQueryMaxLimit {
+		filter.Limit = auditQueryMaxLimit
+	}
+
+	records, err := s.storageManager.ListAuditRecords(filter)
+	if err != nil {
+		s.logger.Error("Failed to read audit log", zap.Error(err))
+		http.Error(w, fmt.Sprintf("Failed to read audit log: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	s.mu.RLock()
+	enabled := s.config.AuditLogEnabled
+	s.mu.RUnlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"enabled": enabled,
+		"entries": records,
+		"total":   len(records),
+	}); err != nil {
+		s.logger.Error("Failed to encode audit log JSON", zap.Error(err))
+	}
+}
+
+// parseAuditSince accepts an RFC3339 timestamp or a duration relative to now
+func parseAuditSince(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return time.Now().Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid since: use an RFC3339 time or a duration such as 1h")
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
+	"mcpproxy-go/internal/storage"
+	"mcpproxy-go/internal/upstream"
+)
+
+func TestIsSensitiveTool(t *testing.T) {
+	cfg := &config.Config{
+		Servers: []*config.ServerConfig{
+			{Name: "vault", SensitiveTools: []string{"read_secret"}},
+			{Name: "github"},
+		},
+	}
+
+	assert.True(t, isSensitiveTool(cfg, "vault", "read_secret"))
+	assert.False(t, isSensitiveTool(cfg, "vault", "list_mounts"))
+	assert.False(t, isSensitiveTool(cfg, "github", "read_secret"))
+	assert.False(t, isSensitiveTool(cfg, "unknown", "read_secret"))
+}
+
+func TestParseAuditSince(t *testing.T) {
+	since, err := parseAuditSince("2026-01-02T15:04:05Z")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC), since.UTC())
+
+	since, err = parseAuditSince("1h")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(-time.Hour), since, time.Second)
+
+	_, err = parseAuditSince("yesterday")
+	assert.Error(t, err)
+	_, err = parseAuditSince("-1h")
+	assert.Error(t, err)
+}
+
+func TestToolResultText(t *testing.T) {
+	result := mcp.NewToolResultError("server 'github' is not connected")
+	assert.Equal(t, "server 'github' is not connected", toolResultText(result))
+}
+
+func TestAuditUpstreamCall(t *testing.T) {
+	st, err := storage.NewManager(t.TempDir(), zap.NewNop().Sugar())
+	require.NoError(t, err)
+	defer st.Close()
+
+	cfg := &config.Config{AuditLogEnabled: true}
+	proxy := &MCPProxyServer{storage: st, config: cfg, logger: zap.NewNop()}
+
+	// Calls that bypass call_tool are audited by the upstream manager's observer
+	manager := upstream.NewManager(zap.NewNop(), cfg, nil)
+	manager.SetToolCallObserver(proxy.auditUpstreamCall)
+
+	ctx, scope := withAuditClient(context.Background(), auditClientChat, "")
+	_, err = manager.CallTool(ctx, "github:list_repos", map[string]interface{}{"org": "acme"})
+	require.Error(t, err)
+	assert.True(t, scope.recorded.Load())
+
+	records, err := st.ListAuditRecords(storage.AuditFilter{})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "github:list_repos", records[0].Tool)
+	assert.Equal(t, auditClientChat, records[0].Client)
+	assert.Equal(t, "error", records[0].Status)
+	assert.Equal(t, map[string]interface{}{"org": "acme"}, records[0].Args)
+}
+
+func TestPruneAuditLog(t *testing.T) {
+	st, err := storage.NewManager(t.TempDir(), zap.NewNop().Sugar())
+	require.NoError(t, err)
+	defer st.Close()
+
+	require.NoError(t, st.AppendAuditRecord(&storage.AuditRecord{Timestamp: time.Now().Add(-48 * time.Hour), Tool: "github:old", Status: "success"}))
+	require.NoError(t, st.AppendAuditRecord(&storage.AuditRecord{Timestamp: time.Now(), Tool: "github:new", Status: "success"}))
+
+	proxy := &MCPProxyServer{storage: st, config: &config.Config{AuditLogRetention: config.Duration(24 * time.Hour)}, logger: zap.NewNop()}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	proxy.pruneAuditLog(ctx)
+
+	records, err := st.ListAuditRecords(storage.AuditFilter{})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "github:new", records[0].Tool)
+}
@@ -0,0 +1,59 @@
+package server
+
+import (
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/events"
+)
+
+// autoQuarantineServer quarantines a server that reached auto_quarantine_after_failures
+// consecutive failures, keeping the reason in its quarantine_reason until it is unquarantined
+func (s *Server) autoQuarantineServer(serverName, reason string) {
+	s.logger.Warn("Server auto-quarantined, updating configuration",
+		zap.String("server", serverName),
+		zap.String("reason", reason))
+
+	s.mu.Lock()
+	for i := range s.config.Servers {
+		if s.config.Servers[i].Name == serverName {
+			s.config.Servers[i].StartupMode = "quarantined"
+			s.config.Servers[i].QuarantineReason = reason
+			break
+		}
+	}
+	s.mu.Unlock()
+
+	if err := s.storageManager.AutoQuarantineUpstreamServer(serverName, reason); err != nil {
+		s.logger.Error("Failed to persist auto-quarantine",
+			zap.String("server", serverName),
+			zap.Error(err))
+		return
+	}
+
+	if err := s.SaveConfiguration(); err != nil {
+		s.logger.Error("Failed to save configuration after auto-quarantine",
+			zap.String("server", serverName),
+			zap.Error(err))
+	}
+
+	// Publish config change event for tray to react
+	s.eventBus.Publish(events.Event{
+		Type:       events.EventConfigChange,
+		ServerName: serverName,
+		Data: events.ConfigChangeData{
+			Action: "quarantined",
+		},
+	})
+}
+
+// setQuarantineReason updates the quarantine_reason of a server in the in-memory config
+func (s *Server) setQuarantineReason(serverName, reason string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.config.Servers {
+		if s.config.Servers[i].Name == serverName {
+			s.config.Servers[i].QuarantineReason = reason
+			return
+		}
+	}
+}
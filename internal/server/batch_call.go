@@ -0,0 +1,144 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxBatchCalls bounds the number of calls in one batch_call request
+const maxBatchCalls = 20
+
+// batchCallItem is one call of a batch_call request
+type batchCallItem struct {
+	Tool      string                 `json:"tool"`
+	Arguments map[string]interface{} `json:"arguments,omitempty"`
+}
+
+// batchCallResult is the outcome of one call of a batch, reported at the call's index
+type batchCallResult struct {
+	Index      int           `json:"index"`
+	Tool       string        `json:"tool"`
+	Success    bool          `json:"success"`
+	Content    []mcp.Content `json:"content,omitempty"`
+	Error      string        `json:"error,omitempty"`
+	DurationMs int64         `json:"duration_ms"`
+}
+
+// parseBatchCalls reads the calls array of a batch_call request
+func parseBatchCalls(request mcp.CallToolRequest) ([]batchCallItem, error) {
+	raw, ok := request.GetArguments()["calls"].([]interface{})
+	if !ok || len(raw) == 0 {
+		return nil, fmt.Errorf("missing required parameter 'calls' (array of {tool, arguments})")
+	}
+	if len(raw) > maxBatchCalls {
+		return nil, fmt.Errorf("too many calls: %d (max %d per batch)", len(raw), maxBatchCalls)
+	}
+
+	items := make([]batchCallItem, 0, len(raw))
+	for i, entry := range raw {
+		call, ok := entry.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("calls[%d] must be an object with 'tool' and 'arguments'", i)
+		}
+		tool, _ := call["tool"].(string)
+		if tool == "" {
+			return nil, fmt.Errorf("calls[%d] is missing 'tool'", i)
+		}
+		// Nested batches would multiply the calls of one request by maxBatchCalls per level
+		if tool == operationBatchCall || tool == operationCallTool {
+			return nil, fmt.Errorf("calls[%d]: %s can't be called inside a batch, list the upstream tools directly", i, tool)
+		}
+
+		item := batchCallItem{Tool: tool}
+		if args, present := call["arguments"]; present && args != nil {
+			argsMap, ok := args.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("calls[%d].arguments must be an object", i)
+			}
+			item.Arguments = argsMap
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// runBatch runs every call concurrently and returns their results in the order of items.
+// A failing call is reported in its own result and doesn't affect the others.
+func runBatch(ctx context.Context, items []batchCallItem, call func(context.Context, batchCallItem) (*mcp.CallToolResult, error)) []batchCallResult {
+	results := make([]batchCallResult, len(items))
+
+	var wg sync.WaitGroup
+	for i, item := range items {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			startTime := time.Now()
+			result, err := call(ctx, item)
+
+			outcome := batchCallResult{
+				Index:      i,
+				Tool:       item.Tool,
+				DurationMs: time.Since(startTime).Milliseconds(),
+			}
+			switch {
+			case err != nil:
+				outcome.Error = err.Error()
+			case result == nil:
+				outcome.Error = "tool call returned no result"
+			case result.IsError:
+				outcome.Error = toolResultText(result)
+			default:
+				outcome.Success = true
+				outcome.Content = result.Content
+			}
+			results[i] = outcome
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+// handleBatchCall implements the batch_call MCP tool. Each call goes through call_tool, so
+// blocking, auditing, caching and the servers' max_concurrent_calls apply per call.
+func (p *MCPProxyServer) handleBatchCall(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	items, err := parseBatchCalls(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	results := runBatch(ctx, items, func(ctx context.Context, item batchCallItem) (*mcp.CallToolResult, error) {
+		callRequest := mcp.CallToolRequest{}
+		callRequest.Params.Name = operationCallTool
+		callRequest.Params.Arguments = map[string]interface{}{
+			"name": item.Tool,
+			"args": item.Arguments,
+		}
+		return p.handleCallTool(ctx, callRequest)
+	})
+
+	succeeded := 0
+	for _, result := range results {
+		if result.Success {
+			succeeded++
+		}
+	}
+
+	jsonResult, err := json.Marshal(map[string]interface{}{
+		"results":   results,
+		"total":     len(results),
+		"succeeded": succeeded,
+		"failed":    len(results) - succeeded,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to serialize results: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonResult)), nil
+}
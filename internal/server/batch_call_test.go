@@ -0,0 +1,88 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func batchRequest(calls interface{}) mcp.CallToolRequest {
+	request := mcp.CallToolRequest{}
+	request.Params.Name = operationBatchCall
+	request.Params.Arguments = map[string]interface{}{"calls": calls}
+	return request
+}
+
+func TestParseBatchCalls(t *testing.T) {
+	items, err := parseBatchCalls(batchRequest([]interface{}{
+		map[string]interface{}{"tool": "github:list_issues", "arguments": map[string]interface{}{"repo": "acme/api"}},
+		map[string]interface{}{"tool": "slack:list_channels"},
+	}))
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, "github:list_issues", items[0].Tool)
+	assert.Equal(t, "acme/api", items[0].Arguments["repo"])
+	assert.Equal(t, "slack:list_channels", items[1].Tool)
+	assert.Nil(t, items[1].Arguments)
+
+	tooMany := make([]interface{}, maxBatchCalls+1)
+	for i := range tooMany {
+		tooMany[i] = map[string]interface{}{"tool": "github:list_issues"}
+	}
+
+	for name, calls := range map[string]interface{}{
+		"missing calls":      nil,
+		"empty calls":        []interface{}{},
+		"too many calls":     tooMany,
+		"call not an object": []interface{}{"github:list_issues"},
+		"missing tool":       []interface{}{map[string]interface{}{"arguments": map[string]interface{}{}}},
+		"arguments not map":  []interface{}{map[string]interface{}{"tool": "github:list_issues", "arguments": "repo=acme/api"}},
+		"nested batch":       []interface{}{map[string]interface{}{"tool": "batch_call", "arguments": map[string]interface{}{"calls": []interface{}{}}}},
+		"call_tool wrapper":  []interface{}{map[string]interface{}{"tool": "call_tool", "arguments": map[string]interface{}{"name": "batch_call"}}},
+	} {
+		_, err := parseBatchCalls(batchRequest(calls))
+		assert.Error(t, err, name)
+	}
+}
+
+func TestRunBatch(t *testing.T) {
+	items := []batchCallItem{
+		{Tool: "slow:echo"},
+		{Tool: "broken:fail"},
+		{Tool: "blocked:tool"},
+		{Tool: "fast:echo"},
+	}
+
+	results := runBatch(context.Background(), items, func(_ context.Context, item batchCallItem) (*mcp.CallToolResult, error) {
+		switch item.Tool {
+		case "slow:echo":
+			time.Sleep(20 * time.Millisecond)
+			return mcp.NewToolResultText("slow"), nil
+		case "broken:fail":
+			return nil, errors.New("connection refused")
+		case "blocked:tool":
+			return mcp.NewToolResultError("Server 'blocked' is quarantined"), nil
+		}
+		return mcp.NewToolResultText("fast"), nil
+	})
+
+	require.Len(t, results, len(items))
+	for i, result := range results {
+		assert.Equal(t, i, result.Index)
+		assert.Equal(t, items[i].Tool, result.Tool)
+	}
+
+	assert.True(t, results[0].Success)
+	assert.Equal(t, "slow", results[0].Content[0].(mcp.TextContent).Text)
+	assert.False(t, results[1].Success)
+	assert.Equal(t, "connection refused", results[1].Error)
+	assert.False(t, results[2].Success)
+	assert.Equal(t, "Server 'blocked' is quarantined", results[2].Error)
+	assert.Empty(t, results[2].Content)
+	assert.True(t, results[3].Success)
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpproxy-go/internal/transport"
+)
+
+// Error codes of failed call_tool requests. They are reported as "code" in the error content so
+// clients can branch on them, e.g. retry timeout and connection errors, instead of matching messages.
+const (
+	callErrorTimeout     = "timeout"
+	callErrorConnection  = "connection"
+	callErrorNotFound    = "not_found"
+	callErrorBlocked     = "blocked"
+	callErrorUpstream    = "upstream_error"
+	callErrorValidation  = "validation"
+	callErrorCancelled   = "cancelled"
+	callErrorMaintenance = "maintenance"
+)
+
+// classifyCallError returns the error code of an upstream call failure
+func classifyCallError(err error) string {
+	if err == nil {
+		return callErrorUpstream
+	}
+
+	var jsonRPCErr *transport.JSONRPCError
+	if errors.As(err, &jsonRPCErr) {
+		switch jsonRPCErr.Code {
+		case mcp.INVALID_PARAMS:
+			return callErrorValidation
+		case mcp.METHOD_NOT_FOUND:
+			return callErrorNotFound
+		}
+	}
+
+	switch {
+	case errors.Is(err, errCallCancelled):
+		return callErrorCancelled
+	case errors.Is(err, context.DeadlineExceeded) || isTimeoutError(err):
+		return callErrorTimeout
+	case isConnectionError(err):
+		return callErrorConnection
+	}
+
+	errStr := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(errStr, "not found") || strings.Contains(errStr, "unknown tool") ||
+		strings.Contains(errStr, "no client found"):
+		return callErrorNotFound
+	case strings.Contains(errStr, "is blocked") || strings.Contains(errStr, "is disabled"):
+		return callErrorBlocked
+	case strings.Contains(errStr, "not connected") || strings.Contains(errStr, "currently connecting"):
+		return callErrorConnection
+	}
+	return callErrorUpstream
+}
+
+// toolBlockErrorCode maps the reason call_tool refuses a tool to its error code
+func toolBlockErrorCode(reason string) string {
+	switch reason {
+	case toolBlockInvalidName:
+		return callErrorValidation
+	case toolBlockUnknownServer:
+		return callErrorNotFound
+	case toolBlockConnecting, toolBlockNotConnected:
+		return callErrorConnection
+	case toolBlockMaintenance:
+		return callErrorMaintenance
+	default:
+		return callErrorBlocked
+	}
+}
+
+// callErrorResult builds the error result of a failed call: a JSON object with the error code,
+// the message and any details. The message is also kept under "error" for older clients.
+func callErrorResult(code, message string, details map[string]interface{}) *mcp.CallToolResult {
+	errorDetails := make(map[string]interface{}, len(details)+3)
+	for key, value := range details {
+		errorDetails[key] = value
+	}
+	errorDetails["code"] = code
+	errorDetails["message"] = message
+	if _, ok := errorDetails["error"]; !ok {
+		errorDetails["error"] = message
+	}
+
+	jsonResponse, _ := json.Marshal(errorDetails)
+	return mcp.NewToolResultError(string(jsonResponse))
+}
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mcpproxy-go/internal/transport"
+)
+
+func TestClassifyCallError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code string
+	}{
+		{"deadline", fmt.Errorf("tool 'x' on server 'y' failed: %w", context.DeadlineExceeded), callErrorTimeout},
+		{"timeout message", errors.New("request timeout after 30s"), callErrorTimeout},
+		{"cancelled", fmt.Errorf("%w after 2s", errCallCancelled), callErrorCancelled},
+		{"connection refused", errors.New("dial tcp 127.0.0.1:8080: connection refused"), callErrorConnection},
+		{"broken pipe", errors.New("write: broken pipe"), callErrorConnection},
+		{"not connected", errors.New("server 'y' is not connected (state: Error)"), callErrorConnection},
+		{"unknown server", errors.New("no client found for server: y"), callErrorNotFound},
+		{"unknown tool", errors.New("tool 'x' not found on server 'y'"), callErrorNotFound},
+		{"read-only", errors.New("tool 'x' is blocked: server 'y' is read-only"), callErrorBlocked},
+		{"invalid params", &transport.JSONRPCError{Code: mcp.INVALID_PARAMS, Message: "missing repo"}, callErrorValidation},
+		{"method not found", &transport.JSONRPCError{Code: mcp.METHOD_NOT_FOUND, Message: "no such tool"}, callErrorNotFound},
+		{"other", errors.New("internal server error: database locked"), callErrorUpstream},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.code, classifyCallError(tt.err))
+		})
+	}
+}
+
+func TestToolBlockErrorCode(t *testing.T) {
+	assert.Equal(t, callErrorValidation, toolBlockErrorCode(toolBlockInvalidName))
+	assert.Equal(t, callErrorNotFound, toolBlockErrorCode(toolBlockUnknownServer))
+	assert.Equal(t, callErrorConnection, toolBlockErrorCode(toolBlockNotConnected))
+	assert.Equal(t, callErrorConnection, toolBlockErrorCode(toolBlockConnecting))
+	assert.Equal(t, callErrorBlocked, toolBlockErrorCode(toolBlockQuarantined))
+	assert.Equal(t, callErrorBlocked, toolBlockErrorCode(toolBlockSnoozed))
+}
+
+func TestCallErrorResult(t *testing.T) {
+	result := callErrorResult(callErrorTimeout, "Tool call timed out", map[string]interface{}{"server_name": "github"})
+	require.True(t, result.IsError)
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &body))
+	assert.Equal(t, "timeout", body["code"])
+	assert.Equal(t, "Tool call timed out", body["message"])
+	assert.Equal(t, "Tool call timed out", body["error"], "message is kept under error for older clients")
+	assert.Equal(t, "github", body["server_name"])
+}
@@ -9,9 +9,11 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"time"
 
+	"github.com/mark3labs/mcp-go/mcp"
 	"go.uber.org/zap"
 )
 
@@ -56,6 +58,7 @@ type openAIRequestWithTools struct {
 	Temperature float64         `json:"temperature"`
 	MaxTokens   int             `json:"max_tokens,omitempty"`
 	Tools       []openAITool    `json:"tools,omitempty"`
+	Stream      bool            `json:"stream,omitempty"`
 }
 
 type openAIMessage struct {
@@ -70,21 +73,95 @@ type openAIResponseWithTools struct {
 	Object  string `json:"object"`
 	Created int64  `json:"created"`
 	Model   string `json:"model"`
-	Choices []struct {
-		Index   int           `json:"index"`
-		Message openAIMessage `json:"message"`
-		Finish  string        `json:"finish_reason"`
-	} `json:"choices"`
-	Usage struct {
-		PromptTokens     int `json:"prompt_tokens"`
-		CompletionTokens int `json:"completion_tokens"`
-		TotalTokens      int `json:"total_tokens"`
-	} `json:"usage"`
-	Error *struct {
-		Message string `json:"message"`
-		Type    string `json:"type"`
-		Code    string `json:"code"`
-	} `json:"error,omitempty"`
+	Choices []openAIChoiceWithTools `json:"choices"`
+	Usage   openAIUsage             `json:"usage"`
+	Error   *openAIError            `json:"error,omitempty"`
+}
+
+type openAIChoiceWithTools struct {
+	Index   int           `json:"index"`
+	Message openAIMessage `json:"message"`
+	Finish  string        `json:"finish_reason"`
+}
+
+type openAIUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+type openAIError struct {
+	Message string `json:"message"`
+	Type    string `json:"type"`
+	Code    string `json:"code"`
+}
+
+// requestChatCompletion sends one chat completion request. With onToken set the completion is
+// streamed and assembled from its chunks, passing content to onToken as it arrives. The request
+// is cancelled together with ctx, e.g. when the chat client disconnects.
+func (s *Server) requestChatCompletion(ctx context.Context, apiKey string, request openAIRequestWithTools, onToken func(string)) (*openAIResponseWithTools, error) {
+	request.Stream = onToken != nil
+
+	// Marshal request to JSON
+	jsonData, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	// Streamed answers of slow models can take much longer than a buffered request
+	timeout := 60 * time.Second
+	if request.Stream {
+		timeout = chatStreamTimeout
+	}
+
+	// Create HTTP request with timeout
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.config.LLM.ChatCompletionsURL(), bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set headers
+	req.Header.Set("Content-Type", "application/json")
+	if apiKey != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", apiKey))
+	}
+
+	// Send request
+	client := &http.Client{
+		Timeout: timeout,
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Check for non-200 status codes
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("OpenAI API error (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	if request.Stream {
+		return readChatCompletionStream(resp.Body, onToken)
+	}
+
+	// Read response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Parse response
+	var openAIResp openAIResponseWithTools
+	if err := json.Unmarshal(body, &openAIResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return &openAIResp, nil
 }
 
 // getTools returns tool definitions for OpenAI (built-in tools only)
@@ -217,7 +294,7 @@ func (s *Server) getToolsForServer(serverName string) []openAITool {
 
 // executeTool executes a tool call by making HTTP request to the endpoint
 // Returns: result string, MCP communication details, error
-func (s *Server) executeTool(toolName string, arguments map[string]interface{}) (string, *MCPCommunication, error) {
+func (s *Server) executeTool(ctx context.Context, toolName string, arguments map[string]interface{}) (string, *MCPCommunication, error) {
 	s.logger.Info("Executing tool",
 		zap.String("tool", toolName),
 		zap.Any("arguments", arguments))
@@ -239,7 +316,7 @@ func (s *Server) executeTool(toolName string, arguments map[string]interface{})
 				zap.String("server", serverName),
 				zap.String("tool", actualToolName))
 
-			// Get client for the server
+			// Check that the server exists
 			client, exists := s.upstreamManager.GetClient(serverName)
 			if !exists || client == nil {
 				mcpComm := &MCPCommunication{
@@ -262,12 +339,18 @@ func (s *Server) executeTool(toolName string, arguments map[string]interface{})
 				Request:   arguments,
 			}
 
-			// Call the tool on the upstream server
-			result, err := client.CallTool(context.Background(), actualToolName, arguments)
+			// Call the tool through the upstream manager, which applies server blocks and audits the call
+			auditCtx, _ := withAuditClient(ctx, auditClientChat, "")
+			callResult, err := s.upstreamManager.CallTool(auditCtx, serverName+":"+actualToolName, arguments)
 			if err != nil {
 				mcpComm.Error = err.Error()
 				return "", mcpComm, fmt.Errorf("failed to call server tool: %w", err)
 			}
+			result, ok := callResult.(*mcp.CallToolResult)
+			if !ok || result == nil {
+				mcpComm.Error = "unexpected tool result"
+				return "", mcpComm, fmt.Errorf("failed to call server tool: unexpected result type %T", callResult)
+			}
 
 			// Capture MCP response
 			mcpComm.Direction = "request-response"
@@ -287,16 +370,16 @@ func (s *Server) executeTool(toolName string, arguments map[string]interface{})
 	}
 
 	// Built-in tools
-	var endpoint string
+	var handler http.HandlerFunc
 	var requestBody interface{}
 
 	switch toolName {
 	case "read_config":
-		endpoint = "http://localhost:8080/chat/read-config"
+		handler = s.handleChatReadConfig
 		requestBody = map[string]interface{}{}
 
 	case "write_config":
-		endpoint = "http://localhost:8080/chat/write-config"
+		handler = s.handleChatWriteConfig
 		content, ok := arguments["content"].(string)
 		if !ok {
 			return "", nil, fmt.Errorf("write_config requires 'content' parameter")
@@ -306,11 +389,11 @@ func (s *Server) executeTool(toolName string, arguments map[string]interface{})
 		}
 
 	case "read_log":
-		endpoint = "http://localhost:8080/chat/read-log"
+		handler = s.handleChatReadLog
 		requestBody = map[string]interface{}{}
 
 	case "read_github":
-		endpoint = "http://localhost:8080/chat/read-github"
+		handler = s.handleChatReadGitHub
 		url, ok := arguments["url"].(string)
 		if !ok {
 			return "", nil, fmt.Errorf("read_github requires 'url' parameter")
@@ -329,11 +412,12 @@ func (s *Server) executeTool(toolName string, arguments map[string]interface{})
 		return "", nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	resp, err := http.Post(endpoint, "application/json", bytes.NewBuffer(jsonData))
-	if err != nil {
-		return "", nil, fmt.Errorf("failed to call endpoint: %w", err)
-	}
-	defer resp.Body.Close()
+	// Call the chat endpoint handler in-process, so the call works with api_token, TLS and
+	// any listen address
+	req := httptest.NewRequest(http.MethodPost, "/chat/"+strings.ReplaceAll(toolName, "_", "-"), bytes.NewReader(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	handler(resp, req)
 
 	// Read response
 	var result map[string]interface{}
@@ -361,9 +445,15 @@ func (s *Server) executeTool(toolName string, arguments map[string]interface{})
 	return "Tool executed successfully", nil, nil
 }
 
-// callOpenAIWithTools makes a request to OpenAI API with tools support
+// callOpenAIWithTools makes a request to the OpenAI (or OpenAI-compatible) API with tools support
 // Returns: response string, MCP communications, error
-func (s *Server) callOpenAIWithTools(apiKey string, messages []chatMessage, serverName string) (string, []MCPCommunication, error) {
+func (s *Server) callOpenAIWithTools(ctx context.Context, apiKey string, messages []chatMessage, serverName string) (string, []MCPCommunication, error) {
+	return s.callOpenAIWithToolsStream(ctx, apiKey, messages, serverName, nil)
+}
+
+// callOpenAIWithToolsStream is callOpenAIWithTools with streamed completions: when onToken is
+// set, every piece of assistant content is passed to it as soon as it arrives from the LLM
+func (s *Server) callOpenAIWithToolsStream(ctx context.Context, apiKey string, messages []chatMessage, serverName string, onToken func(string)) (string, []MCPCommunication, error) {
 	// Convert chatMessage to openAIMessage
 	openAIMessages := make([]openAIMessage, len(messages))
 	for i, msg := range messages {
@@ -382,58 +472,16 @@ func (s *Server) callOpenAIWithTools(apiKey string, messages []chatMessage, serv
 	for i := 0; i < maxIterations; i++ {
 		// Prepare request
 		request := openAIRequestWithTools{
-			Model:       "gpt-4o-mini",
+			Model:       s.config.LLM.ChatModel(),
 			Messages:    openAIMessages,
 			Temperature: 0.7,
 			MaxTokens:   2000,
 			Tools:       tools,
 		}
 
-		// Marshal request to JSON
-		jsonData, err := json.Marshal(request)
-		if err != nil {
-			return "", mcpCommunications, fmt.Errorf("failed to marshal request: %w", err)
-		}
-
-		// Create HTTP request with timeout
-		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
-		defer cancel()
-
-		req, err := http.NewRequestWithContext(ctx, "POST", "https://api.openai.com/v1/chat/completions", bytes.NewBuffer(jsonData))
+		openAIResp, err := s.requestChatCompletion(ctx, apiKey, request, onToken)
 		if err != nil {
-			return "", mcpCommunications, fmt.Errorf("failed to create request: %w", err)
-		}
-
-		// Set headers
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", apiKey))
-
-		// Send request
-		client := &http.Client{
-			Timeout: 60 * time.Second,
-		}
-
-		resp, err := client.Do(req)
-		if err != nil {
-			return "", mcpCommunications, fmt.Errorf("request failed: %w", err)
-		}
-		defer resp.Body.Close()
-
-		// Read response body
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return "", mcpCommunications, fmt.Errorf("failed to read response: %w", err)
-		}
-
-		// Check for non-200 status codes
-		if resp.StatusCode != http.StatusOK {
-			return "", mcpCommunications, fmt.Errorf("OpenAI API error (status %d): %s", resp.StatusCode, string(body))
-		}
-
-		// Parse response
-		var openAIResp openAIResponseWithTools
-		if err := json.Unmarshal(body, &openAIResp); err != nil {
-			return "", mcpCommunications, fmt.Errorf("failed to parse response: %w", err)
+			return "", mcpCommunications, err
 		}
 
 		// Check for API errors
@@ -487,7 +535,7 @@ func (s *Server) callOpenAIWithTools(apiKey string, messages []chatMessage, serv
 				}
 
 				// Execute the tool and capture MCP communication
-				result, mcpComm, err := s.executeTool(toolCall.Function.Name, args)
+				result, mcpComm, err := s.executeTool(ctx, toolCall.Function.Name, args)
 				if err != nil {
 					result = fmt.Sprintf("Error executing tool: %v", err)
 				}
@@ -543,7 +591,7 @@ func (s *Server) callOpenAIWithTools(apiKey string, messages []chatMessage, serv
 					}
 
 					// Execute the tool and capture MCP communication
-					result, mcpComm, err := s.executeTool(toolCall.Function.Name, args)
+					result, mcpComm, err := s.executeTool(ctx, toolCall.Function.Name, args)
 					if err != nil {
 						result = fmt.Sprintf("Error executing tool: %v", err)
 					}
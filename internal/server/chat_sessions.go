@@ -0,0 +1,122 @@
+//go:build !nogui && !headless && !linux
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// chatSessionTTL is how long a chat session may stay idle before it is evicted
+const chatSessionTTL = 2 * time.Hour
+
+// chatSessionInfo summarizes a chat session for the session listing
+type chatSessionInfo struct {
+	ID              string    `json:"id"`
+	ServerName      string    `json:"server_name"`
+	MessageCount    int       `json:"message_count"`
+	EstimatedTokens int       `json:"estimated_tokens"`
+	CreatedAt       time.Time `json:"created_at"`
+	LastActive      time.Time `json:"last_active"`
+	ExpiresAt       time.Time `json:"expires_at"`
+}
+
+// evictIdleLocked removes sessions idle for longer than ttl and returns how many were removed.
+// The caller must hold sm.mu for writing.
+func (sm *sessionManager) evictIdleLocked(now time.Time, ttl time.Duration) int {
+	evicted := 0
+	for id, session := range sm.sessions {
+		if now.Sub(session.LastActive) > ttl {
+			delete(sm.sessions, id)
+			evicted++
+		}
+	}
+	return evicted
+}
+
+// list evicts idle sessions and returns the remaining ones, most recently active first
+func (sm *sessionManager) list(now time.Time) []chatSessionInfo {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	sm.evictIdleLocked(now, chatSessionTTL)
+
+	infos := make([]chatSessionInfo, 0, len(sm.sessions))
+	for id, session := range sm.sessions {
+		// Same estimate as the context statistics: 1 token ≈ 3 characters
+		tokens := 0
+		for _, msg := range session.Messages {
+			tokens += len(msg.Content) / 3
+		}
+		infos = append(infos, chatSessionInfo{
+			ID:              id,
+			ServerName:      session.ServerName,
+			MessageCount:    len(session.Messages),
+			EstimatedTokens: tokens,
+			CreatedAt:       session.CreatedAt,
+			LastActive:      session.LastActive,
+			ExpiresAt:       session.LastActive.Add(chatSessionTTL),
+		})
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].LastActive.After(infos[j].LastActive)
+	})
+	return infos
+}
+
+// delete removes a session and its history, reporting whether it existed
+func (sm *sessionManager) delete(sessionID string) bool {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	if _, exists := sm.sessions[sessionID]; !exists {
+		return false
+	}
+	delete(sm.sessions, sessionID)
+	return true
+}
+
+// handleListChatSessions handles GET /api/chat/sessions
+func (s *Server) handleListChatSessions(w http.ResponseWriter, _ *http.Request) {
+	infos := sessions.list(time.Now())
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"sessions":    infos,
+		"total":       len(infos),
+		"ttl_seconds": int(chatSessionTTL.Seconds()),
+	})
+}
+
+// handleChatSessionPath routes /api/chat/sessions/{id}: DELETE clears the session,
+// anything else is a message for /api/chat/sessions/{id}/messages
+func (s *Server) handleChatSessionPath(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		s.handleChatMessage(w, r)
+		return
+	}
+
+	sessionID := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/chat/sessions/"), "/")
+	if sessionID == "" || strings.Contains(sessionID, "/") {
+		http.Error(w, "Invalid session ID", http.StatusBadRequest)
+		return
+	}
+
+	if !sessions.delete(sessionID) {
+		http.Error(w, "Session not found", http.StatusNotFound)
+		return
+	}
+
+	s.logger.Info("Cleared chat session", zap.String("session_id", sessionID))
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"deleted":    true,
+		"session_id": sessionID,
+	})
+}
@@ -0,0 +1,48 @@
+//go:build !nogui && !headless && !linux
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionManager_ListEvictsIdleSessions(t *testing.T) {
+	sm := &sessionManager{sessions: make(map[string]*chatSession)}
+
+	sm.getOrCreateSession("session_a_1", "a")
+	sm.addMessage("session_a_1", "user", "how do I fix this server?")
+	sm.getOrCreateSession("session_b_1", "b")
+	sm.getOrCreateSession("session_idle_1", "idle")
+	sm.sessions["session_idle_1"].LastActive = time.Now().Add(-chatSessionTTL - time.Minute)
+	sm.sessions["session_b_1"].LastActive = time.Now().Add(-time.Minute)
+
+	infos := sm.list(time.Now())
+	require.Len(t, infos, 2)
+
+	// Most recently active first, idle session evicted
+	assert.Equal(t, "session_a_1", infos[0].ID)
+	assert.Equal(t, "a", infos[0].ServerName)
+	assert.Equal(t, 1, infos[0].MessageCount)
+	assert.Equal(t, len("how do I fix this server?")/3, infos[0].EstimatedTokens)
+	assert.Equal(t, infos[0].LastActive.Add(chatSessionTTL), infos[0].ExpiresAt)
+	assert.Equal(t, "session_b_1", infos[1].ID)
+	assert.NotContains(t, sm.sessions, "session_idle_1")
+}
+
+func TestSessionManager_Delete(t *testing.T) {
+	sm := &sessionManager{sessions: make(map[string]*chatSession)}
+	sm.getOrCreateSession("session_a_1", "a")
+	sm.addMessage("session_a_1", "user", "hello")
+
+	assert.True(t, sm.delete("session_a_1"))
+	assert.False(t, sm.delete("session_a_1"))
+	assert.Nil(t, sm.getMessages("session_a_1"))
+
+	// A new session under the same ID starts with an empty history
+	session := sm.getOrCreateSession("session_a_1", "a")
+	assert.Empty(t, session.Messages)
+}
@@ -0,0 +1,177 @@
+//go:build !nogui && !headless && !linux
+
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// chatStreamTimeout bounds a streamed chat completion, which may run long on slow models
+const chatStreamTimeout = 5 * time.Minute
+
+// openAIStreamChunk is one server-sent event of a streamed chat completion
+type openAIStreamChunk struct {
+	Model   string `json:"model"`
+	Choices []struct {
+		Index int `json:"index"`
+		Delta struct {
+			Content   string `json:"content"`
+			ToolCalls []struct {
+				Index    int                    `json:"index"`
+				ID       string                 `json:"id"`
+				Type     string                 `json:"type"`
+				Function openAIToolCallFunction `json:"function"`
+			} `json:"tool_calls"`
+		} `json:"delta"`
+		Finish string `json:"finish_reason"`
+	} `json:"choices"`
+	Usage *openAIUsage `json:"usage,omitempty"`
+	Error *openAIError `json:"error,omitempty"`
+}
+
+// readChatCompletionStream assembles a streamed chat completion into the response a buffered
+// request would have returned. Content deltas are passed to onToken as they are read.
+func readChatCompletionStream(body io.Reader, onToken func(string)) (*openAIResponseWithTools, error) {
+	resp := &openAIResponseWithTools{}
+	var content strings.Builder
+	toolCalls := make(map[int]*openAIToolCall)
+	finish := ""
+
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data:") {
+			continue // blank separators, comments and event names
+		}
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		if data == "[DONE]" {
+			break
+		}
+
+		var chunk openAIStreamChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return nil, fmt.Errorf("failed to parse stream chunk: %w", err)
+		}
+		if chunk.Error != nil {
+			resp.Error = chunk.Error
+			return resp, nil
+		}
+		if chunk.Model != "" {
+			resp.Model = chunk.Model
+		}
+		if chunk.Usage != nil {
+			resp.Usage = *chunk.Usage
+		}
+
+		for _, choice := range chunk.Choices {
+			if choice.Index != 0 {
+				continue
+			}
+			if choice.Delta.Content != "" {
+				content.WriteString(choice.Delta.Content)
+				if onToken != nil {
+					onToken(choice.Delta.Content)
+				}
+			}
+			for _, delta := range choice.Delta.ToolCalls {
+				call, ok := toolCalls[delta.Index]
+				if !ok {
+					call = &openAIToolCall{Type: "function"}
+					toolCalls[delta.Index] = call
+				}
+				if delta.ID != "" {
+					call.ID = delta.ID
+				}
+				if delta.Type != "" {
+					call.Type = delta.Type
+				}
+				if delta.Function.Name != "" {
+					call.Function.Name = delta.Function.Name
+				}
+				call.Function.Arguments += delta.Function.Arguments
+			}
+			if choice.Finish != "" {
+				finish = choice.Finish
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read stream: %w", err)
+	}
+
+	message := openAIMessage{Role: "assistant", Content: content.String()}
+	indexes := make([]int, 0, len(toolCalls))
+	for index := range toolCalls {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+	for _, index := range indexes {
+		message.ToolCalls = append(message.ToolCalls, *toolCalls[index])
+	}
+
+	// Some OpenAI-compatible servers end the stream without a finish reason
+	if finish == "" {
+		finish = "stop"
+		if len(message.ToolCalls) > 0 {
+			finish = "tool_calls"
+		}
+	}
+
+	resp.Choices = []openAIChoiceWithTools{{Message: message, Finish: finish}}
+	return resp, nil
+}
+
+// chatEventStream writes chat progress to the client as server-sent events
+type chatEventStream struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+}
+
+// newChatEventStream starts an event stream response when the client asked for one with
+// "Accept: text/event-stream". It returns nil if the client wants a buffered JSON response
+// or the connection can't be flushed incrementally.
+func newChatEventStream(w http.ResponseWriter, r *http.Request) *chatEventStream {
+	if !strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+		return nil
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil
+	}
+
+	// The HTTP server's WriteTimeout is shorter than a streamed completion may take, so give
+	// this response the stream's own deadline. Writers that don't support it keep the default.
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(chatStreamTimeout))
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	return &chatEventStream{w: w, flusher: flusher}
+}
+
+// send writes one event with a JSON payload and flushes it to the client
+func (c *chatEventStream) send(event string, data interface{}) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		payload, _ = json.Marshal(map[string]string{"error": err.Error()})
+		event = "error"
+	}
+	fmt.Fprintf(c.w, "event: %s\ndata: %s\n\n", event, payload)
+	c.flusher.Flush()
+}
+
+// token streams one piece of the assistant's answer
+func (c *chatEventStream) token(content string) {
+	c.send("token", map[string]string{"content": content})
+}
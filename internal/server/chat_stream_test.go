@@ -0,0 +1,115 @@
+//go:build !nogui && !headless && !linux
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mcpproxy-go/internal/config"
+)
+
+func TestReadChatCompletionStream_Content(t *testing.T) {
+	body := strings.Join([]string{
+		`data: {"model":"llama3","choices":[{"index":0,"delta":{"role":"assistant","content":"Hel"}}]}`,
+		``,
+		`: keep-alive`,
+		`data: {"choices":[{"index":0,"delta":{"content":"lo"},"finish_reason":"stop"}]}`,
+		``,
+		`data: [DONE]`,
+		``,
+	}, "\n")
+
+	var tokens []string
+	resp, err := readChatCompletionStream(strings.NewReader(body), func(token string) {
+		tokens = append(tokens, token)
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Hel", "lo"}, tokens)
+	assert.Equal(t, "llama3", resp.Model)
+	require.Len(t, resp.Choices, 1)
+	assert.Equal(t, "Hello", resp.Choices[0].Message.Content)
+	assert.Equal(t, "stop", resp.Choices[0].Finish)
+}
+
+func TestReadChatCompletionStream_ToolCalls(t *testing.T) {
+	body := strings.Join([]string{
+		`data: {"choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"id":"call_1","type":"function","function":{"name":"read_log","arguments":""}}]}}]}`,
+		`data: {"choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"{\"lines\":"}}]}}]}`,
+		`data: {"choices":[{"index":0,"delta":{"tool_calls":[{"index":1,"id":"call_2","function":{"name":"read_config","arguments":"{}"}}]}}]}`,
+		`data: {"choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"50}"}}]}}]}`,
+		`data: [DONE]`,
+	}, "\n")
+
+	resp, err := readChatCompletionStream(strings.NewReader(body), nil)
+	require.NoError(t, err)
+
+	require.Len(t, resp.Choices, 1)
+	choice := resp.Choices[0]
+	// A missing finish reason is inferred from the tool calls
+	assert.Equal(t, "tool_calls", choice.Finish)
+	require.Len(t, choice.Message.ToolCalls, 2)
+	assert.Equal(t, "call_1", choice.Message.ToolCalls[0].ID)
+	assert.Equal(t, "read_log", choice.Message.ToolCalls[0].Function.Name)
+	assert.Equal(t, `{"lines":50}`, choice.Message.ToolCalls[0].Function.Arguments)
+	assert.Equal(t, "function", choice.Message.ToolCalls[1].Type)
+	assert.Equal(t, "read_config", choice.Message.ToolCalls[1].Function.Name)
+}
+
+func TestReadChatCompletionStream_Error(t *testing.T) {
+	body := `data: {"error":{"message":"model not found","type":"invalid_request_error","code":"model_not_found"}}`
+
+	resp, err := readChatCompletionStream(strings.NewReader(body), nil)
+	require.NoError(t, err)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, "model not found", resp.Error.Message)
+}
+
+func TestNewChatEventStream(t *testing.T) {
+	// Without the Accept header the buffered JSON response is used
+	req := httptest.NewRequest(http.MethodPost, "/api/chat/sessions/session_a/messages", nil)
+	assert.Nil(t, newChatEventStream(httptest.NewRecorder(), req))
+
+	req.Header.Set("Accept", "text/event-stream")
+	rec := httptest.NewRecorder()
+	stream := newChatEventStream(rec, req)
+	require.NotNil(t, stream)
+
+	stream.token("Hi")
+	stream.send("done", map[string]string{"response": "Hi"})
+
+	assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "event: token\ndata: {\"content\":\"Hi\"}\n\nevent: done\ndata: {\"response\":\"Hi\"}\n\n", rec.Body.String())
+}
+
+func TestRequestChatCompletion_CancelledWithContext(t *testing.T) {
+	// An LLM that never answers: the request has to end when the chat client goes away
+	release := make(chan struct{})
+	llm := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer llm.Close()
+	defer close(release)
+
+	s := &Server{config: &config.Config{LLM: &config.LLMConfig{BaseURL: llm.URL}}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := s.requestChatCompletion(ctx, "", openAIRequestWithTools{Model: "test"}, func(string) {})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), 10*time.Second)
+}
@@ -0,0 +1,174 @@
+package server
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// compressResponses gzip- or deflate-compresses responses of at least minBytes for clients that
+// accept it. Smaller responses and event streams are sent as is, so SSE events reach the client
+// as soon as they are flushed.
+func compressResponses(handler http.Handler, minBytes int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+
+		encoding := acceptedEncoding(r.Header.Get("Accept-Encoding"))
+		if encoding == "" || r.Method == http.MethodHead {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &compressWriter{ResponseWriter: w, encoding: encoding, minBytes: minBytes, statusCode: http.StatusOK}
+		defer cw.Close()
+		handler.ServeHTTP(cw, r)
+	})
+}
+
+// acceptedEncoding picks gzip or deflate from an Accept-Encoding header, preferring gzip.
+// Codings listed with q=0 are refused.
+func acceptedEncoding(header string) string {
+	accepted := make(map[string]bool)
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding == "" {
+			continue
+		}
+		accepted[coding] = true
+		if q, ok := strings.CutPrefix(strings.ReplaceAll(params, " ", ""), "q="); ok {
+			if weight, err := strconv.ParseFloat(q, 64); err == nil && weight == 0 {
+				accepted[coding] = false
+			}
+		}
+	}
+
+	for _, encoding := range []string{"gzip", "deflate"} {
+		if acceptable, listed := accepted[encoding]; listed {
+			if acceptable {
+				return encoding
+			}
+			continue
+		}
+		if accepted["*"] {
+			return encoding
+		}
+	}
+	return ""
+}
+
+// compressWriter buffers a response until it is known to reach minBytes, then compresses it.
+// Responses that end or are flushed before that are written uncompressed.
+type compressWriter struct {
+	http.ResponseWriter
+	encoding   string
+	minBytes   int
+	statusCode int
+
+	buf        bytes.Buffer
+	decided    bool
+	compressor io.WriteCloser // Set once the response is being compressed
+}
+
+func (cw *compressWriter) WriteHeader(code int) {
+	if cw.decided {
+		return
+	}
+	cw.statusCode = code
+	// Informational and bodiless responses are never compressed
+	if code < http.StatusOK || code == http.StatusNoContent || code == http.StatusNotModified {
+		cw.passThrough()
+	}
+}
+
+func (cw *compressWriter) Write(p []byte) (int, error) {
+	if !cw.decided {
+		if !cw.compressible() {
+			cw.passThrough()
+		} else {
+			cw.buf.Write(p)
+			if cw.buf.Len() < cw.minBytes {
+				return len(p), nil
+			}
+			if err := cw.startCompression(); err != nil {
+				return 0, err
+			}
+			return len(p), nil
+		}
+	}
+
+	if cw.compressor != nil {
+		return cw.compressor.Write(p)
+	}
+	return cw.ResponseWriter.Write(p)
+}
+
+// Flush sends what was written so far. A response flushed before reaching minBytes is
+// streaming, so it stays uncompressed.
+func (cw *compressWriter) Flush() {
+	if !cw.decided {
+		cw.passThrough()
+	}
+	if flusher, ok := cw.compressor.(interface{ Flush() error }); ok {
+		_ = flusher.Flush()
+	}
+	_ = http.NewResponseController(cw.ResponseWriter).Flush()
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (cw *compressWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+// Close writes out a buffered response and finishes the compressed stream
+func (cw *compressWriter) Close() {
+	if !cw.decided {
+		cw.passThrough()
+	}
+	if cw.compressor != nil {
+		_ = cw.compressor.Close()
+	}
+}
+
+// compressible reports whether the response may be compressed, judged by its headers
+func (cw *compressWriter) compressible() bool {
+	header := cw.Header()
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+	mediaType, _, _ := strings.Cut(header.Get("Content-Type"), ";")
+	return strings.TrimSpace(mediaType) != "text/event-stream"
+}
+
+// passThrough sends the headers and any buffered body without compression
+func (cw *compressWriter) passThrough() {
+	cw.decided = true
+	cw.ResponseWriter.WriteHeader(cw.statusCode)
+	if cw.buf.Len() > 0 {
+		_, _ = cw.ResponseWriter.Write(cw.buf.Bytes())
+		cw.buf.Reset()
+	}
+}
+
+// startCompression sends the headers of a compressed response and the buffered body
+func (cw *compressWriter) startCompression() error {
+	cw.decided = true
+
+	header := cw.Header()
+	header.Set("Content-Encoding", cw.encoding)
+	header.Del("Content-Length")
+	cw.ResponseWriter.WriteHeader(cw.statusCode)
+
+	if cw.encoding == "gzip" {
+		cw.compressor = gzip.NewWriter(cw.ResponseWriter)
+	} else {
+		cw.compressor = zlib.NewWriter(cw.ResponseWriter)
+	}
+	_, err := cw.compressor.Write(cw.buf.Bytes())
+	cw.buf.Reset()
+	return err
+}
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcceptedEncoding(t *testing.T) {
+	assert.Equal(t, "gzip", acceptedEncoding("gzip, deflate, br"))
+	assert.Equal(t, "deflate", acceptedEncoding("deflate"))
+	assert.Equal(t, "deflate", acceptedEncoding("gzip;q=0, deflate;q=0.5"))
+	assert.Equal(t, "gzip", acceptedEncoding("*"))
+	assert.Equal(t, "deflate", acceptedEncoding("gzip; q=0, *"))
+	assert.Equal(t, "", acceptedEncoding("br"))
+	assert.Equal(t, "", acceptedEncoding(""))
+}
+
+func TestCompressResponses(t *testing.T) {
+	large := strings.Repeat(`{"text":"verbose tool output"}`, 100)
+	handler := compressResponses(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("size") == "small" {
+			_, _ = io.WriteString(w, `{"ok":true}`)
+			return
+		}
+		_, _ = io.WriteString(w, large)
+	}), 1024)
+
+	serve := func(target, acceptEncoding string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, target, nil)
+		if acceptEncoding != "" {
+			req.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("gzip", func(t *testing.T) {
+		rec := serve("/mcp", "gzip")
+		assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", rec.Header().Get("Vary"))
+		assert.Less(t, rec.Body.Len(), len(large))
+
+		reader, err := gzip.NewReader(rec.Body)
+		require.NoError(t, err)
+		body, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, large, string(body))
+	})
+
+	t.Run("deflate", func(t *testing.T) {
+		rec := serve("/mcp", "deflate")
+		assert.Equal(t, "deflate", rec.Header().Get("Content-Encoding"))
+
+		reader, err := zlib.NewReader(rec.Body)
+		require.NoError(t, err)
+		body, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, large, string(body))
+	})
+
+	t.Run("below threshold", func(t *testing.T) {
+		rec := serve("/mcp?size=small", "gzip")
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, `{"ok":true}`, rec.Body.String())
+	})
+
+	t.Run("not accepted", func(t *testing.T) {
+		rec := serve("/mcp", "")
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, large, rec.Body.String())
+	})
+}
+
+func TestCompressResponses_EventStream(t *testing.T) {
+	event := "data: " + strings.Repeat("x", 2048) + "\n\n"
+	flushedEarly := false
+	handler := compressResponses(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, event)
+		require.NoError(t, http.NewResponseController(w).Flush())
+		flushedEarly = w.(*compressWriter).ResponseWriter.(*httptest.ResponseRecorder).Flushed
+		_, _ = io.WriteString(w, event)
+	}), 1024)
+
+	req := httptest.NewRequest(http.MethodGet, "/mcp", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.True(t, flushedEarly)
+	assert.Empty(t, rec.Header().Get("Content-Encoding"))
+	assert.Equal(t, event+event, rec.Body.String())
+}
@@ -0,0 +1,99 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
+)
+
+// handleConfigAPI reads or patches top-level settings
+// GET /api/config returns the non-secret settings
+// PATCH /api/config applies a subset of runtime-safe settings, persists them and reloads
+func (s *Server) handleConfigAPI(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.handleGetConfig(w)
+	case http.MethodPatch:
+		s.handlePatchConfig(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleGetConfig(w http.ResponseWriter) {
+	s.mu.RLock()
+	settings, err := config.PublicSettings(s.config)
+	s.mu.RUnlock()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"config":    settings,
+		"patchable": config.PatchableSettings(),
+	})
+}
+
+func (s *Server) handlePatchConfig(w http.ResponseWriter, r *http.Request) {
+	var patch map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	err := config.ApplySettingsPatch(s.config, patch)
+	s.mu.Unlock()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	changed := make([]string, 0, len(patch))
+	for key := range patch {
+		changed = append(changed, key)
+	}
+	s.logger.Info("Applying global settings update via API", zap.Strings("settings", changed))
+
+	if err := s.SaveConfiguration(); err != nil {
+		s.logger.Error("Failed to save configuration after settings update", zap.Error(err))
+		http.Error(w, fmt.Sprintf("Failed to save configuration: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if err := s.ReloadConfiguration(); err != nil {
+		s.logger.Error("Failed to reload configuration after settings update", zap.Error(err))
+		http.Error(w, fmt.Sprintf("Failed to reload configuration: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	s.handleGetConfig(w)
+}
+
+// handleEffectiveConfigAPI handles GET /api/config/effective, returning the full configuration
+// as currently in use (after load, migration, config.d merging and runtime changes) with
+// credentials redacted
+func (s *Server) handleEffectiveConfigAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.mu.RLock()
+	effective, err := config.EffectiveConfig(s.config)
+	s.mu.RUnlock()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(effective); err != nil {
+		s.logger.Error("Failed to encode effective config JSON", zap.Error(err))
+	}
+}
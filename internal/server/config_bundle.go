@@ -0,0 +1,130 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"mcpproxy-go/internal/config"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+)
+
+// ExportConfigBundle exports servers, groups, group assignments and quarantine states as a
+// portable bundle. Secrets are always redacted; only the CLI can export them.
+func (s *Server) ExportConfigBundle() (*config.Bundle, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Groups and assignments live in memory until the config is saved
+	s.syncGroupsToConfig()
+	s.syncServerGroupAssignments()
+
+	return config.ExportBundle(s.config, false)
+}
+
+// ImportConfigBundle merges a bundle into the running configuration, saves it and starts the
+// added servers. Existing servers are skipped unless overwrite is set. Bundles arrive from MCP
+// clients, so every imported server is quarantined: unquarantining is only done through the
+// tray or the config file.
+func (s *Server) ImportConfigBundle(bundle *config.Bundle, overwrite bool) (*config.BundleImportResult, error) {
+	s.mu.Lock()
+	s.syncGroupsToConfig()
+	s.syncServerGroupAssignments()
+	result, err := s.config.ImportBundle(bundle, overwrite, true)
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.Added) == 0 && len(result.Updated) == 0 && len(result.GroupsAdded) == 0 {
+		return result, nil
+	}
+
+	// Rebuild the in-memory groups and assignments from the merged config before saving,
+	// otherwise SaveConfiguration would write the old assignments back
+	s.initGroupsFromConfig()
+	s.initServerGroupAssignments()
+
+	if err := s.SaveConfiguration(); err != nil {
+		return result, fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	s.logger.Info("Imported config bundle",
+		zap.Strings("added", result.Added),
+		zap.Strings("updated", result.Updated),
+		zap.Strings("skipped", result.Skipped),
+		zap.Strings("quarantined", result.Quarantined),
+		zap.Strings("groups_added", result.GroupsAdded))
+
+	// Connecting the imported servers may wait on dependencies, so don't block the caller
+	go func() {
+		if err := s.loadConfiguredServers(); err != nil {
+			s.logger.Error("Failed to start imported servers", zap.Error(err))
+		}
+		s.OnUpstreamServerChange()
+	}()
+
+	return result, nil
+}
+
+// handleExportConfig implements the export_config operation of upstream_servers
+func (p *MCPProxyServer) handleExportConfig(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if p.mainServer == nil {
+		return mcp.NewToolResultError("Config export is not available"), nil
+	}
+
+	bundle, err := p.mainServer.ExportConfigBundle()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to export config: %v", err)), nil
+	}
+
+	jsonResult, err := json.Marshal(bundle)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to serialize result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonResult)), nil
+}
+
+// handleImportConfig implements the import_config operation of upstream_servers
+func (p *MCPProxyServer) handleImportConfig(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	bundleJSON, err := request.RequireString("bundle_json")
+	if err != nil {
+		return mcp.NewToolResultError("Missing required parameter 'bundle_json'"), nil
+	}
+	if p.mainServer == nil {
+		return mcp.NewToolResultError("Config import is not available"), nil
+	}
+
+	bundle, err := config.ParseBundle([]byte(bundleJSON))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	overwrite := request.GetBool("overwrite", false)
+	for _, server := range bundle.Servers {
+		existing, err := p.storage.GetUpstreamServer(server.Name)
+		if err != nil {
+			existing = nil
+		} else if !overwrite {
+			continue // Skipped by the import
+		}
+		if err := config.CheckSecretReferenceChange(existing, server); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("server %s: %v", server.Name, err)), nil
+		}
+	}
+
+	result, err := p.mainServer.ImportConfigBundle(bundle, overwrite)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to import config: %v", err)), nil
+	}
+
+	jsonResult, err := json.Marshal(result)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to serialize result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonResult)), nil
+}
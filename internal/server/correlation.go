@@ -0,0 +1,58 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+
+	"mcpproxy-go/internal/logs"
+)
+
+// withCorrelationID gives every HTTP request a correlation ID: the client's X-Correlation-ID
+// if it is valid, otherwise a new one. The ID is put into the request context, from where it
+// reaches the MCP handlers and upstream calls, and is echoed in the response header.
+func withCorrelationID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(logs.CorrelationIDHeader)
+		if !logs.ValidCorrelationID(id) {
+			id = logs.NewCorrelationID()
+		}
+		w.Header().Set(logs.CorrelationIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(logs.WithCorrelationID(r.Context(), id)))
+	})
+}
+
+// correlationToolMiddleware makes sure every tool call has a correlation ID, including calls
+// over stdio, and adds it to error results and errors so users can quote it
+func correlationToolMiddleware(next mcpserver.ToolHandlerFunc) mcpserver.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctx, id := logs.EnsureCorrelationID(ctx)
+
+		result, err := next(ctx, request)
+		if err != nil {
+			return result, fmt.Errorf("%w (correlation_id: %s)", err, id)
+		}
+		annotateErrorResult(result, id)
+		return result, nil
+	}
+}
+
+// annotateErrorResult appends the correlation ID to the text of an error result
+func annotateErrorResult(result *mcp.CallToolResult, id string) {
+	if result == nil || !result.IsError || id == "" {
+		return
+	}
+
+	suffix := fmt.Sprintf(" (correlation_id: %s)", id)
+	for i := len(result.Content) - 1; i >= 0; i-- {
+		if text, ok := mcp.AsTextContent(result.Content[i]); ok {
+			text.Text += suffix
+			result.Content[i] = *text
+			return
+		}
+	}
+	result.Content = append(result.Content, mcp.NewTextContent("correlation_id: "+id))
+}
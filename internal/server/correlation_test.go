@@ -0,0 +1,83 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mcpproxy-go/internal/logs"
+)
+
+func TestWithCorrelationID(t *testing.T) {
+	var seen string
+	handler := withCorrelationID(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		seen = logs.CorrelationID(r.Context())
+	}))
+
+	t.Run("keeps a valid client ID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/mcp", http.NoBody)
+		req.Header.Set(logs.CorrelationIDHeader, "client-42")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, "client-42", seen)
+		assert.Equal(t, "client-42", rec.Header().Get(logs.CorrelationIDHeader))
+	})
+
+	t.Run("replaces a missing or invalid ID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/mcp", http.NoBody)
+		req.Header.Set(logs.CorrelationIDHeader, "not valid!")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.Len(t, seen, 16)
+		assert.Equal(t, seen, rec.Header().Get(logs.CorrelationIDHeader))
+	})
+}
+
+func TestCorrelationToolMiddleware(t *testing.T) {
+	ctx := logs.WithCorrelationID(context.Background(), "abc123")
+
+	t.Run("annotates error results", func(t *testing.T) {
+		handler := correlationToolMiddleware(func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			assert.Equal(t, "abc123", logs.CorrelationID(ctx))
+			return mcp.NewToolResultError("upstream failed"), nil
+		})
+
+		result, err := handler(ctx, mcp.CallToolRequest{})
+		require.NoError(t, err)
+		text, ok := mcp.AsTextContent(result.Content[0])
+		require.True(t, ok)
+		assert.Equal(t, "upstream failed (correlation_id: abc123)", text.Text)
+	})
+
+	t.Run("leaves successful results alone", func(t *testing.T) {
+		handler := correlationToolMiddleware(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("ok"), nil
+		})
+
+		result, err := handler(ctx, mcp.CallToolRequest{})
+		require.NoError(t, err)
+		text, _ := mcp.AsTextContent(result.Content[0])
+		assert.Equal(t, "ok", text.Text)
+	})
+
+	t.Run("wraps errors and generates missing IDs", func(t *testing.T) {
+		var generated string
+		handler := correlationToolMiddleware(func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			generated = logs.CorrelationID(ctx)
+			return nil, errors.New("boom")
+		})
+
+		_, err := handler(context.Background(), mcp.CallToolRequest{})
+		require.Error(t, err)
+		assert.Len(t, generated, 16)
+		assert.Equal(t, "boom (correlation_id: "+generated+")", err.Error())
+	})
+}
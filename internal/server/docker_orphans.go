@@ -0,0 +1,241 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"mcpproxy-go/internal/config"
+
+	"go.uber.org/zap"
+)
+
+// dockerPSFormat lists running mcpproxy-labeled containers as tab-separated ID, name, server label and creation time
+const dockerPSFormat = "{{.ID}}\t{{.Names}}\t{{.Label \"mcpproxy.server\"}}\t{{.CreatedAt}}"
+
+// dockerCreatedAtLayout is the layout of {{.CreatedAt}} in docker ps output
+const dockerCreatedAtLayout = "2006-01-02 15:04:05 -0700 MST"
+
+// dockerContainer is a running container carrying the mcpproxy.server tracking label
+type dockerContainer struct {
+	ID        string
+	Name      string
+	Server    string
+	CreatedAt time.Time
+}
+
+// dockerOrphan is a labeled container that no active upstream client owns
+type dockerOrphan struct {
+	ContainerID   string    `json:"container_id"`
+	ContainerName string    `json:"container_name"`
+	Server        string    `json:"server"`
+	CreatedAt     time.Time `json:"created_at,omitempty"`
+	Reason        string    `json:"reason"`
+}
+
+// parseDockerPSOutput parses docker ps output produced with dockerPSFormat
+func parseDockerPSOutput(output string) []dockerContainer {
+	var containers []dockerContainer
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		parts := strings.SplitN(line, "\t", 4)
+		if len(parts) < 3 || parts[0] == "" {
+			continue
+		}
+		container := dockerContainer{ID: parts[0], Name: parts[1], Server: parts[2]}
+		if len(parts) == 4 {
+			// Unparseable timestamps leave CreatedAt zero, which never counts as recent
+			container.CreatedAt, _ = time.Parse(dockerCreatedAtLayout, parts[3])
+		}
+		containers = append(containers, container)
+	}
+	return containers
+}
+
+// findDockerOrphans returns the containers no active client owns. active maps the name of every
+// connected or connecting server to the ID of its container, or "" while the ID isn't known yet.
+// Containers younger than DockerOrphanGracePeriod are skipped while their server starts up.
+func findDockerOrphans(containers []dockerContainer, active map[string]string, now time.Time) []dockerOrphan {
+	var orphans []dockerOrphan
+	for _, container := range containers {
+		if !container.CreatedAt.IsZero() && now.Sub(container.CreatedAt) < config.DockerOrphanGracePeriod {
+			continue
+		}
+
+		ownerID, isActive := active[container.Server]
+		var reason string
+		switch {
+		case !isActive:
+			reason = "server is not connected"
+		case ownerID != "" && !containerIDMatches(ownerID, container.ID):
+			reason = fmt.Sprintf("server is running in container %s", shortContainerID(ownerID))
+		default:
+			continue
+		}
+
+		orphans = append(orphans, dockerOrphan{
+			ContainerID:   container.ID,
+			ContainerName: container.Name,
+			Server:        container.Server,
+			CreatedAt:     container.CreatedAt,
+			Reason:        reason,
+		})
+	}
+	return orphans
+}
+
+// containerIDMatches compares container IDs where either may be docker's 12-character short form
+func containerIDMatches(a, b string) bool {
+	return strings.HasPrefix(a, b) || strings.HasPrefix(b, a)
+}
+
+func shortContainerID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
+
+// activeDockerServers maps every connected or connecting upstream server to its container ID
+func (s *Server) activeDockerServers() map[string]string {
+	active := make(map[string]string)
+	if s.upstreamManager == nil {
+		return active
+	}
+	for _, client := range s.upstreamManager.GetAllClients() {
+		if client.IsConnected() || client.IsConnecting() {
+			active[client.Config.Name] = client.GetContainerID()
+		}
+	}
+	return active
+}
+
+// scanDockerOrphans lists running mcpproxy-labeled containers and returns those no active client owns
+func (s *Server) scanDockerOrphans(ctx context.Context) ([]dockerOrphan, error) {
+	output, err := exec.CommandContext(ctx, "docker", "ps", "--filter", "label=mcpproxy.server", "--format", dockerPSFormat).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Docker containers: %w", err)
+	}
+
+	orphans := findDockerOrphans(parseDockerPSOutput(string(output)), s.activeDockerServers(), time.Now())
+	sort.Slice(orphans, func(i, j int) bool {
+		return orphans[i].Server < orphans[j].Server
+	})
+	return orphans, nil
+}
+
+// removeDockerContainer stops a container, force killing it if it doesn't stop
+func (s *Server) removeDockerContainer(ctx context.Context, containerID string) error {
+	if err := exec.CommandContext(ctx, "docker", "stop", containerID).Run(); err != nil {
+		s.logger.Debug("Graceful stop failed, force killing container",
+			zap.String("container_id", containerID),
+			zap.Error(err))
+		if err := exec.CommandContext(ctx, "docker", "kill", containerID).Run(); err != nil {
+			return fmt.Errorf("failed to kill container %s: %w", containerID, err)
+		}
+	}
+	return nil
+}
+
+// removeDockerOrphans removes the given orphans. It returns the IDs of the removed containers
+// and the error of every container that couldn't be removed.
+func (s *Server) removeDockerOrphans(ctx context.Context, orphans []dockerOrphan) (removed []string, failed map[string]string) {
+	failed = make(map[string]string)
+	for _, orphan := range orphans {
+		if err := s.removeDockerContainer(ctx, orphan.ContainerID); err != nil {
+			s.logger.Warn("Failed to remove orphaned Docker container",
+				zap.String("container_id", orphan.ContainerID),
+				zap.String("server", orphan.Server),
+				zap.Error(err))
+			failed[orphan.ContainerID] = err.Error()
+			continue
+		}
+		s.logger.Info("Removed orphaned Docker container",
+			zap.String("container_id", orphan.ContainerID),
+			zap.String("container_name", orphan.ContainerName),
+			zap.String("server", orphan.Server),
+			zap.String("reason", orphan.Reason))
+		removed = append(removed, orphan.ContainerID)
+	}
+	return removed, failed
+}
+
+// reconcileDockerContainers periodically looks for containers orphaned while mcpproxy is running,
+// e.g. after a failed disconnect. Orphans are removed when docker_isolation.cleanup_orphans is set,
+// otherwise they are only reported.
+func (s *Server) reconcileDockerContainers(ctx context.Context) {
+	if _, err := exec.LookPath("docker"); err != nil {
+		s.logger.Debug("Docker not found, skipping orphaned container reconciliation")
+		return
+	}
+
+	ticker := time.NewTicker(config.DockerOrphanCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			orphans, err := s.scanDockerOrphans(ctx)
+			if err != nil {
+				s.logger.Debug("Orphaned Docker container check failed", zap.Error(err))
+				continue
+			}
+			if len(orphans) == 0 {
+				continue
+			}
+
+			if s.config.DockerIsolation != nil && s.config.DockerIsolation.CleanupOrphans {
+				s.removeDockerOrphans(ctx, orphans)
+				continue
+			}
+			for _, orphan := range orphans {
+				s.logger.Warn("Found orphaned Docker container, set docker_isolation.cleanup_orphans or POST /api/docker/orphans to remove it",
+					zap.String("container_id", orphan.ContainerID),
+					zap.String("container_name", orphan.ContainerName),
+					zap.String("server", orphan.Server),
+					zap.String("reason", orphan.Reason))
+			}
+		}
+	}
+}
+
+// handleDockerOrphansAPI handles /api/docker/orphans: GET reports orphaned containers,
+// POST removes them
+func (s *Server) handleDockerOrphansAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	orphans, err := s.scanDockerOrphans(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	if orphans == nil {
+		orphans = []dockerOrphan{}
+	}
+
+	response := map[string]interface{}{
+		"orphans":    orphans,
+		"count":      len(orphans),
+		"checked_at": time.Now(),
+	}
+	if r.Method == http.MethodPost {
+		removed, failed := s.removeDockerOrphans(r.Context(), orphans)
+		if removed == nil {
+			removed = []string{}
+		}
+		response["removed"] = removed
+		response["failed"] = failed
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
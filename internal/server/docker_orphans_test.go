@@ -0,0 +1,51 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDockerPSOutput(t *testing.T) {
+	output := "abc123def456\tmcp-github\tgithub\t2025-01-15 10:30:00 +0000 UTC\n" +
+		"\n" +
+		"fff000aaa111\tmcp-broken\tbroken\tnot a time\n" +
+		"malformed line\n"
+
+	containers := parseDockerPSOutput(output)
+	require.Len(t, containers, 2)
+
+	assert.Equal(t, "abc123def456", containers[0].ID)
+	assert.Equal(t, "mcp-github", containers[0].Name)
+	assert.Equal(t, "github", containers[0].Server)
+	assert.Equal(t, time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC), containers[0].CreatedAt.UTC())
+
+	assert.Equal(t, "broken", containers[1].Server)
+	assert.True(t, containers[1].CreatedAt.IsZero())
+}
+
+func TestFindDockerOrphans(t *testing.T) {
+	now := time.Now()
+	old := now.Add(-time.Hour)
+	containers := []dockerContainer{
+		{ID: "aaaaaaaaaaaa", Name: "current", Server: "github", CreatedAt: old},
+		{ID: "bbbbbbbbbbbb", Name: "stale", Server: "github", CreatedAt: old},
+		{ID: "cccccccccccc", Name: "gone", Server: "removed", CreatedAt: old},
+		{ID: "dddddddddddd", Name: "starting", Server: "removed", CreatedAt: now.Add(-10 * time.Second)},
+		{ID: "eeeeeeeeeeee", Name: "pending-id", Server: "slack", CreatedAt: old},
+	}
+	active := map[string]string{
+		"github": "aaaaaaaaaaaa1234567890", // full ID of the current container
+		"slack":  "",                       // connecting, container ID not captured yet
+	}
+
+	orphans := findDockerOrphans(containers, active, now)
+	require.Len(t, orphans, 2)
+
+	assert.Equal(t, "bbbbbbbbbbbb", orphans[0].ContainerID)
+	assert.Equal(t, "server is running in container aaaaaaaaaaaa", orphans[0].Reason)
+	assert.Equal(t, "cccccccccccc", orphans[1].ContainerID)
+	assert.Equal(t, "server is not connected", orphans[1].Reason)
+}
@@ -0,0 +1,337 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
+	"mcpproxy-go/internal/logs"
+	"mcpproxy-go/internal/storage"
+)
+
+const (
+	// durableCallRetention is how long finished durable calls are kept for get_call_result
+	durableCallRetention = 24 * time.Hour
+
+	// durableCallMaxAttempts bounds how often a call is sent, so a call that keeps crashing
+	// the proxy is not replayed forever
+	durableCallMaxAttempts = 3
+
+	// durableCallConnectWait bounds how long a replayed call waits for its server to connect
+	durableCallConnectWait = 5 * time.Minute
+)
+
+// durableContext returns the context durable calls run in: the application context, so they
+// outlive the call_tool request that queued them
+func (p *MCPProxyServer) durableContext() context.Context {
+	if p.mainServer == nil {
+		return context.Background()
+	}
+	p.mainServer.mu.RLock()
+	defer p.mainServer.mu.RUnlock()
+	if p.mainServer.appCtx == nil {
+		return context.Background()
+	}
+	return p.mainServer.appCtx
+}
+
+// enqueueDurableCall records a call to a durable server, starts it in the background and
+// returns its call ID for get_call_result
+func (p *MCPProxyServer) enqueueDurableCall(ctx context.Context, toolName string, args map[string]interface{}) *mcp.CallToolResult {
+	p.pruneDurableCalls()
+
+	now := time.Now()
+	record := &storage.DurableCallRecord{
+		ID:      logs.NewCorrelationID(),
+		Tool:    toolName,
+		Args:    args,
+		Status:  storage.DurableCallPending,
+		Created: now,
+		Updated: now,
+	}
+	record.Client, record.SessionID = auditClient(ctx)
+	if err := p.storage.SaveDurableCall(record); err != nil {
+		p.logger.Error("Failed to queue durable call", zap.String("tool_name", toolName), zap.Error(err))
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to queue durable call: %v", err))
+	}
+
+	p.logger.Info("Queued durable tool call",
+		zap.String("tool_name", toolName),
+		zap.String("call_id", record.ID))
+
+	// Report the queued state before the call starts updating the record
+	result := durableStatusResult(record)
+	go p.runDurableCall(p.durableContext(), record)
+	return result
+}
+
+// durableBlockError fails a durable call that call_tool would refuse by now
+type durableBlockError struct {
+	block toolBlock
+}
+
+func (e *durableBlockError) Error() string {
+	return e.block.Message
+}
+
+// checkDurableCallBlock re-runs the call_tool block checks for a queued call, since read-only,
+// disabled tools or maintenance mode may have been set after it was queued. A disconnected
+// server does not block the call, which waits for the server to connect instead.
+func (p *MCPProxyServer) checkDurableCallBlock(record *storage.DurableCallRecord) (*config.ServerConfig, error) {
+	block, serverConfig := p.checkToolBlock(record.Tool)
+	if !block.Blocked {
+		return serverConfig, nil
+	}
+	switch block.Reason {
+	case toolBlockConnecting, toolBlockNotConnected, toolBlockSnoozed:
+		return serverConfig, nil
+	}
+	p.logger.Info("Durable tool call blocked",
+		zap.String("call_id", record.ID),
+		zap.String("tool_name", record.Tool),
+		zap.String("reason", block.Reason))
+	return serverConfig, &durableBlockError{block: block}
+}
+
+// runDurableCall sends a queued call to its server and stores the outcome. Like call_tool,
+// the call is checked against blocks, logged to the communication log and audited (as the
+// client that queued it). When the
+// proxy shuts down mid-call the record is left pending or running, to be replayed on the next
+// start.
+func (p *MCPProxyServer) runDurableCall(ctx context.Context, record *storage.DurableCallRecord) {
+	serverName, actualToolName, _ := strings.Cut(record.Tool, ":")
+
+	if _, err := p.checkDurableCallBlock(record); err != nil {
+		p.finishDurableCall(record, nil, err, 0)
+		return
+	}
+
+	if err := p.waitForDurableServer(ctx, serverName); err != nil {
+		if ctx.Err() == nil {
+			p.finishDurableCall(record, nil, err, 0)
+		}
+		return
+	}
+
+	// Check again right before sending: the wait for the server can take minutes
+	serverConfig, err := p.checkDurableCallBlock(record)
+	if err != nil {
+		p.finishDurableCall(record, nil, err, 0)
+		return
+	}
+
+	record.Status = storage.DurableCallRunning
+	record.Attempts++
+	record.Updated = time.Now()
+	if err := p.storage.SaveDurableCall(record); err != nil {
+		p.logger.Warn("Failed to mark durable call running",
+			zap.String("call_id", record.ID),
+			zap.Error(err))
+	}
+
+	// The call runs without the MCP session that queued it, so attribute it for the audit log
+	callCtx, _ := withAuditClient(ctx, record.Client, record.SessionID)
+	if maxCallDuration := p.config.GetMaxCallDuration(serverConfig); maxCallDuration > 0 {
+		var cancel context.CancelFunc
+		callCtx, cancel = context.WithTimeoutCause(callCtx, maxCallDuration, errMaxCallDuration)
+		defer cancel()
+	}
+
+	// Track the call under its call ID so active_calls lists it and cancel_call can abort it
+	callCtx, untrack := p.activeCalls.track(callCtx, record.ID, record.Tool, serverName)
+	defer untrack()
+
+	if p.communicationLogger != nil {
+		p.communicationLogger.LogToolCall(ctx, serverName, actualToolName, record.Args, nil, record.ID)
+	}
+
+	startTime := time.Now()
+	result, err := p.upstreamManager.CallTool(callCtx, record.Tool, record.Args)
+	duration := time.Since(startTime)
+	if err != nil && ctx.Err() != nil {
+		p.logger.Info("Durable call interrupted by shutdown, it will be replayed on the next start",
+			zap.String("call_id", record.ID),
+			zap.String("tool_name", record.Tool))
+		return
+	}
+	if err != nil && errors.Is(context.Cause(callCtx), errCallCancelled) {
+		err = fmt.Errorf("%w after %s", errCallCancelled, duration.Round(time.Millisecond))
+	}
+
+	if p.communicationLogger != nil {
+		if err != nil {
+			p.communicationLogger.LogError(ctx,
+				fmt.Sprintf("Upstream tool call failed: %v", err),
+				serverName, actualToolName, "", record.Args, record.ID)
+		} else {
+			p.communicationLogger.LogToolResponse(ctx, serverName, actualToolName, result, duration, record.ID)
+		}
+	}
+	p.finishDurableCall(record, result, err, duration)
+}
+
+// waitForDurableServer waits until the server of a durable call is connected
+func (p *MCPProxyServer) waitForDurableServer(ctx context.Context, serverName string) error {
+	deadline := time.NewTimer(durableCallConnectWait)
+	defer deadline.Stop()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		client, exists := p.upstreamManager.GetClient(serverName)
+		if !exists {
+			return fmt.Errorf("server '%s' not found", serverName)
+		}
+		if client.IsConnected() {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline.C:
+			return fmt.Errorf("server '%s' did not connect within %s (state: %s)", serverName, durableCallConnectWait, client.GetState().String())
+		case <-ticker.C:
+		}
+	}
+}
+
+// finishDurableCall stores the result or error of a durable call
+func (p *MCPProxyServer) finishDurableCall(record *storage.DurableCallRecord, result interface{}, callErr error, duration time.Duration) {
+	if record.Attempts > 0 {
+		p.toolCalls.Add(1)
+	}
+
+	record.Status = storage.DurableCallCompleted
+	if callErr == nil {
+		jsonResult, err := json.Marshal(result)
+		if err != nil {
+			callErr = fmt.Errorf("failed to serialize result: %w", err)
+		} else {
+			record.Result = string(jsonResult)
+		}
+	}
+	if callErr != nil {
+		if record.Attempts > 0 {
+			p.toolCallErrors.Add(1)
+		}
+		record.Status = storage.DurableCallFailed
+		record.ErrorCode = classifyCallError(callErr)
+		var blockErr *durableBlockError
+		if errors.As(callErr, &blockErr) {
+			record.ErrorCode = toolBlockErrorCode(blockErr.block.Reason)
+		}
+		record.Error = callErr.Error()
+	} else if err := p.storage.RecordToolCall(record.Tool, duration); err != nil {
+		p.logger.Warn("Failed to update tool stats", zap.String("tool_name", record.Tool), zap.Error(err))
+	}
+	record.Updated = time.Now()
+
+	if err := p.storage.SaveDurableCall(record); err != nil {
+		p.logger.Error("Failed to store durable call result",
+			zap.String("call_id", record.ID),
+			zap.Error(err))
+		return
+	}
+	p.logger.Info("Durable tool call finished",
+		zap.String("call_id", record.ID),
+		zap.String("tool_name", record.Tool),
+		zap.String("status", record.Status))
+}
+
+// resumeDurableCalls replays the durable calls that were pending or running when the proxy
+// stopped, and drops finished calls older than durableCallRetention
+func (p *MCPProxyServer) resumeDurableCalls(ctx context.Context) {
+	if p.storage == nil {
+		return
+	}
+
+	p.pruneDurableCalls()
+
+	records, err := p.storage.ListDurableCalls()
+	if err != nil {
+		p.logger.Error("Failed to load durable calls", zap.Error(err))
+		return
+	}
+
+	for _, record := range records {
+		if record.Finished() {
+			continue
+		}
+		if record.Attempts >= durableCallMaxAttempts {
+			p.finishDurableCall(record, nil, fmt.Errorf("call was interrupted %d times and is not replayed again", record.Attempts), 0)
+			continue
+		}
+
+		p.logger.Info("Replaying durable tool call",
+			zap.String("call_id", record.ID),
+			zap.String("tool_name", record.Tool),
+			zap.Int("attempts", record.Attempts))
+		go p.runDurableCall(ctx, record)
+	}
+}
+
+// pruneDurableCalls drops finished durable calls older than durableCallRetention
+func (p *MCPProxyServer) pruneDurableCalls() {
+	if pruned, err := p.storage.PruneDurableCalls(time.Now().Add(-durableCallRetention)); err != nil {
+		p.logger.Warn("Failed to prune durable calls", zap.Error(err))
+	} else if pruned > 0 {
+		p.logger.Info("Pruned finished durable calls", zap.Int("count", pruned))
+	}
+}
+
+// durableStatusResult reports a durable call that has no result yet
+func durableStatusResult(record *storage.DurableCallRecord) *mcp.CallToolResult {
+	jsonResult, _ := json.Marshal(map[string]interface{}{
+		"call_id":  record.ID,
+		"tool":     record.Tool,
+		"status":   record.Status,
+		"attempts": record.Attempts,
+		"created":  record.Created,
+		"message":  fmt.Sprintf("Call '%s' runs in the background. Poll get_call_result with call_id '%s' for its result.", record.Tool, record.ID),
+	})
+	return mcp.NewToolResultText(string(jsonResult))
+}
+
+// handleGetCallResult implements the get_call_result MCP tool, returning the status or result
+// of a call to a durable server
+func (p *MCPProxyServer) handleGetCallResult(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	callID, err := request.RequireString("call_id")
+	if err != nil {
+		return callErrorResult(callErrorValidation, "Missing required parameter 'call_id'", nil), nil
+	}
+
+	record, err := p.storage.GetDurableCall(callID)
+	if err != nil {
+		return callErrorResult(callErrorNotFound, fmt.Sprintf("Unknown call ID '%s'. Results are kept for %s after the call finished.", callID, durableCallRetention), nil), nil
+	}
+
+	switch record.Status {
+	case storage.DurableCallCompleted:
+		serverName, actualToolName, _ := strings.Cut(record.Tool, ":")
+		serverConfig, _ := p.storage.GetUpstreamServer(serverName)
+		ctx, requestID := logs.EnsureCorrelationID(ctx)
+
+		result := p.buildCallToolResult(ctx, record.Result, record.Tool, actualToolName, record.Args, serverConfig, time.Now(), requestID, false, false)
+		if result.Meta == nil {
+			result.Meta = &mcp.Meta{AdditionalFields: map[string]any{}}
+		}
+		result.Meta.AdditionalFields["call_id"] = record.ID
+		return result, nil
+	case storage.DurableCallFailed:
+		return callErrorResult(record.ErrorCode, record.Error, map[string]interface{}{
+			"call_id":  record.ID,
+			"tool":     record.Tool,
+			"attempts": record.Attempts,
+		}), nil
+	default:
+		return durableStatusResult(record), nil
+	}
+}
@@ -0,0 +1,113 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
+	"mcpproxy-go/internal/storage"
+	"mcpproxy-go/internal/upstream"
+)
+
+func TestGetCallResult(t *testing.T) {
+	storageManager, err := storage.NewManager(t.TempDir(), zap.NewNop().Sugar())
+	require.NoError(t, err)
+	defer storageManager.Close()
+	proxy := &MCPProxyServer{storage: storageManager, logger: zap.NewNop()}
+
+	now := time.Now()
+	require.NoError(t, storageManager.SaveDurableCall(&storage.DurableCallRecord{
+		ID: "pending1", Tool: "builder:build", Status: storage.DurableCallRunning, Attempts: 1, Created: now, Updated: now,
+	}))
+	require.NoError(t, storageManager.SaveDurableCall(&storage.DurableCallRecord{
+		ID: "failed1", Tool: "builder:build", Status: storage.DurableCallFailed, ErrorCode: callErrorTimeout,
+		Error: "context deadline exceeded", Attempts: 1, Created: now, Updated: now,
+	}))
+
+	getResult := func(callID string) (*mcp.CallToolResult, map[string]interface{}) {
+		request := mcp.CallToolRequest{}
+		request.Params.Name = operationGetCallResult
+		request.Params.Arguments = map[string]interface{}{"call_id": callID}
+		result, err := proxy.handleGetCallResult(context.Background(), request)
+		require.NoError(t, err)
+
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &response))
+		return result, response
+	}
+
+	result, response := getResult("pending1")
+	assert.False(t, result.IsError)
+	assert.Equal(t, "running", response["status"])
+	assert.Equal(t, "pending1", response["call_id"])
+
+	result, response = getResult("failed1")
+	assert.True(t, result.IsError)
+	assert.Equal(t, callErrorTimeout, response["code"])
+	assert.Equal(t, "failed1", response["call_id"])
+
+	result, response = getResult("unknown")
+	assert.True(t, result.IsError)
+	assert.Equal(t, callErrorNotFound, response["code"])
+}
+
+func TestResumeDurableCallsGivesUpAfterMaxAttempts(t *testing.T) {
+	storageManager, err := storage.NewManager(t.TempDir(), zap.NewNop().Sugar())
+	require.NoError(t, err)
+	defer storageManager.Close()
+	proxy := &MCPProxyServer{storage: storageManager, logger: zap.NewNop()}
+
+	old := time.Now().Add(-2 * durableCallRetention)
+	require.NoError(t, storageManager.SaveDurableCall(&storage.DurableCallRecord{
+		ID: "crashy", Tool: "builder:build", Status: storage.DurableCallRunning, Attempts: durableCallMaxAttempts, Created: old, Updated: old,
+	}))
+	require.NoError(t, storageManager.SaveDurableCall(&storage.DurableCallRecord{
+		ID: "expired", Tool: "builder:build", Status: storage.DurableCallCompleted, Result: "{}", Created: old, Updated: old,
+	}))
+
+	proxy.resumeDurableCalls(context.Background())
+
+	record, err := storageManager.GetDurableCall("crashy")
+	require.NoError(t, err)
+	assert.Equal(t, storage.DurableCallFailed, record.Status)
+	assert.Contains(t, record.Error, "not replayed again")
+
+	_, err = storageManager.GetDurableCall("expired")
+	assert.Error(t, err, "finished calls past the retention are pruned")
+}
+
+func TestRunDurableCallRechecksBlocks(t *testing.T) {
+	storageManager, err := storage.NewManager(t.TempDir(), zap.NewNop().Sugar())
+	require.NoError(t, err)
+	defer storageManager.Close()
+	require.NoError(t, storageManager.SaveUpstreamServer(&config.ServerConfig{
+		Name: "builder", Command: "builder", ReadOnly: true,
+	}))
+	proxy := &MCPProxyServer{
+		storage:         storageManager,
+		upstreamManager: upstream.NewManager(zap.NewNop(), nil, nil),
+		logger:          zap.NewNop(),
+	}
+
+	// The server was made read-only after the call was queued, so the replay is refused
+	now := time.Now()
+	record := &storage.DurableCallRecord{
+		ID: "queued", Tool: "builder:delete_build", Status: storage.DurableCallPending, Created: now, Updated: now,
+	}
+	require.NoError(t, storageManager.SaveDurableCall(record))
+
+	proxy.runDurableCall(context.Background(), record)
+
+	stored, err := storageManager.GetDurableCall("queued")
+	require.NoError(t, err)
+	assert.Equal(t, storage.DurableCallFailed, stored.Status)
+	assert.Equal(t, callErrorBlocked, stored.ErrorCode)
+	assert.Equal(t, 0, stored.Attempts, "a blocked call is never sent")
+}
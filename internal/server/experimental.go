@@ -0,0 +1,37 @@
+package server
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// experimentalDescriptionPrefix is prepended to the descriptions of tools on experimental servers
+const experimentalDescriptionPrefix = "⚠️ EXPERIMENTAL server - use with care. "
+
+// experimentalWarning is the warning attached to call results of tools on experimental servers
+func experimentalWarning(serverName string) string {
+	return fmt.Sprintf("Server '%s' is marked experimental: its tools may be unreliable or have unexpected side effects. Verify the result before relying on it.", serverName)
+}
+
+// experimentalDescription prepends the experimental warning to a tool description
+func experimentalDescription(description string) string {
+	return experimentalDescriptionPrefix + description
+}
+
+// experimentalServers returns the names of the configured servers marked experimental
+func (p *MCPProxyServer) experimentalServers() map[string]bool {
+	servers, err := p.storage.ListUpstreamServers()
+	if err != nil {
+		p.logger.Warn("Failed to load servers for experimental warnings", zap.Error(err))
+		return nil
+	}
+
+	experimental := make(map[string]bool)
+	for _, server := range servers {
+		if server.Experimental {
+			experimental[server.Name] = true
+		}
+	}
+	return experimental
+}
@@ -0,0 +1,23 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExperimentalDescription(t *testing.T) {
+	description := experimentalDescription("Run a SQL query")
+	assert.True(t, strings.HasPrefix(description, experimentalDescriptionPrefix))
+	assert.True(t, strings.HasSuffix(description, "Run a SQL query"))
+
+	// The warning survives description truncation because it is added afterwards
+	truncated, _ := truncateDescription("Run a SQL query against the analytics warehouse", 15)
+	assert.Equal(t, experimentalDescriptionPrefix+"Run a SQL qu...", experimentalDescription(truncated))
+}
+
+func TestExperimentalWarning(t *testing.T) {
+	assert.Contains(t, experimentalWarning("warehouse"), "'warehouse'")
+	assert.Contains(t, experimentalWarning("warehouse"), "experimental")
+}
@@ -0,0 +1,124 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
+)
+
+// selectFeaturedTools picks the stored tools listed in featured, in the order they are listed.
+// Tools that aren't stored yet and tools of servers that may not be published are skipped.
+func selectFeaturedTools(featured []string, tools []*config.ToolMetadata, allowed map[string]bool) []*config.ToolMetadata {
+	byName := make(map[string]*config.ToolMetadata, len(tools))
+	for _, tool := range tools {
+		byName[tool.Name] = tool
+	}
+
+	selected := make([]*config.ToolMetadata, 0, len(featured))
+	seen := make(map[string]bool, len(featured))
+	for _, name := range featured {
+		name = strings.TrimSpace(name)
+		tool, ok := byName[name]
+		if !ok || seen[name] || !allowed[tool.ServerName] {
+			continue
+		}
+		seen[name] = true
+		selected = append(selected, tool)
+	}
+	return selected
+}
+
+// publishFeaturedTools advertises the tools listed in featured_tools under their prefixed
+// names next to the built-in tools, replacing what was published before. Tools are published
+// once their server's tools are stored, so this runs again after every tool discovery.
+func (p *MCPProxyServer) publishFeaturedTools() {
+	if p.config == nil || len(p.config.FeaturedTools) == 0 {
+		return
+	}
+
+	allowed, err := p.publishableServers()
+	if err != nil {
+		p.logger.Error("Failed to load servers for featured tools", zap.Error(err))
+		return
+	}
+
+	var stored []*config.ToolMetadata
+	loaded := make(map[string]bool)
+	for _, name := range p.config.FeaturedTools {
+		serverName, _, _ := strings.Cut(strings.TrimSpace(name), ":")
+		if loaded[serverName] || !allowed[serverName] {
+			continue
+		}
+		loaded[serverName] = true
+
+		tools, err := p.storage.GetToolMetadata(serverName)
+		if err != nil {
+			p.logger.Debug("No stored tools for featured tools yet",
+				zap.String("server", serverName),
+				zap.Error(err))
+			continue
+		}
+		stored = append(stored, tools...)
+	}
+
+	selected := selectFeaturedTools(p.config.FeaturedTools, stored, allowed)
+	experimental := p.experimentalServers()
+
+	serverTools := make([]mcpserver.ServerTool, 0, len(selected))
+	published := make(map[string]bool, len(selected))
+	for _, tool := range selected {
+		schema, err := json.Marshal(p.toolInputSchema(tool.Name, tool.ParamsJSON))
+		if err != nil {
+			continue
+		}
+		description := tool.Description
+		if experimental[tool.ServerName] {
+			description = experimentalDescription(description)
+		}
+
+		serverTools = append(serverTools, mcpserver.ServerTool{
+			Tool:    mcp.NewToolWithRawSchema(tool.Name, description, schema),
+			Handler: p.handleFeaturedTool,
+		})
+		published[tool.Name] = true
+	}
+
+	p.featuredMu.Lock()
+	var stale []string
+	for name := range p.featuredPublished {
+		if !published[name] {
+			stale = append(stale, name)
+		}
+	}
+	p.featuredPublished = published
+	p.featuredMu.Unlock()
+
+	if len(stale) > 0 {
+		p.server.DeleteTools(stale...)
+	}
+	if len(serverTools) > 0 {
+		p.server.AddTools(serverTools...)
+	}
+
+	p.logger.Info("Published featured tools",
+		zap.Int("tool_count", len(serverTools)),
+		zap.Int("configured", len(p.config.FeaturedTools)))
+}
+
+// handleFeaturedTool forwards a call of a featured tool through call_tool, so blocking,
+// auditing and caching apply as for any other upstream call
+func (p *MCPProxyServer) handleFeaturedTool(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	callRequest := mcp.CallToolRequest{}
+	callRequest.Params.Name = operationCallTool
+	callRequest.Params.Arguments = map[string]interface{}{
+		"name": request.Params.Name,
+		"args": request.GetArguments(),
+	}
+	return p.handleCallTool(ctx, callRequest)
+}
@@ -0,0 +1,34 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"mcpproxy-go/internal/config"
+)
+
+func TestSelectFeaturedTools(t *testing.T) {
+	tools := []*config.ToolMetadata{
+		{Name: "github:create_issue", ServerName: "github"},
+		{Name: "github:list_repos", ServerName: "github"},
+		{Name: "slack:post_message", ServerName: "slack"},
+		{Name: "shady:run", ServerName: "shady"},
+	}
+	allowed := map[string]bool{"github": true, "slack": true, "shady": false}
+
+	featured := []string{"slack:post_message", " github:create_issue ", "github:missing", "shady:run", "slack:post_message"}
+	selected := selectFeaturedTools(featured, tools, allowed)
+
+	var names []string
+	for _, tool := range selected {
+		names = append(names, tool.Name)
+	}
+	assert.Equal(t, []string{"slack:post_message", "github:create_issue"}, names)
+}
+
+func TestSelectFeaturedTools_NoneConfigured(t *testing.T) {
+	tools := []*config.ToolMetadata{{Name: "github:create_issue", ServerName: "github"}}
+
+	assert.Empty(t, selectFeaturedTools(nil, tools, map[string]bool{"github": true}))
+}
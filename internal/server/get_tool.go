@@ -0,0 +1,110 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+)
+
+// truncateDescription shortens a tool description to at most maxLength characters, ending it
+// with "..." when it was cut. A maxLength of 0 or less keeps the full description.
+func truncateDescription(description string, maxLength int) (string, bool) {
+	runes := []rune(description)
+	if maxLength <= 0 || len(runes) <= maxLength {
+		return description, false
+	}
+	if maxLength <= 3 {
+		return string(runes[:maxLength]), true
+	}
+	return strings.TrimRight(string(runes[:maxLength-3]), " ") + "...", true
+}
+
+// toolInputSchema parses the stored ParamsJSON of a tool, falling back to an empty object schema
+func (p *MCPProxyServer) toolInputSchema(toolName, paramsJSON string) map[string]interface{} {
+	var inputSchema map[string]interface{}
+	if paramsJSON != "" {
+		if err := json.Unmarshal([]byte(paramsJSON), &inputSchema); err != nil {
+			p.logger.Warn("Failed to parse tool params JSON",
+				zap.String("tool_name", toolName),
+				zap.Error(err))
+			inputSchema = nil
+		}
+	}
+	if inputSchema == nil {
+		inputSchema = map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{},
+		}
+	}
+	return inputSchema
+}
+
+// handleGetTool implements the get_tool MCP tool, returning the full description and input
+// schema of one upstream tool
+func (p *MCPProxyServer) handleGetTool(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, err := request.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError("Missing required parameter 'name'"), nil
+	}
+
+	serverName, _, found := strings.Cut(name, ":")
+	if !found {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid tool name format: %s (expected server:tool)", name)), nil
+	}
+
+	// Descriptions of quarantined servers are only shown through quarantine_security
+	serverConfig, err := p.storage.GetUpstreamServer(serverName)
+	if err == nil && serverConfig.IsQuarantined() {
+		return mcp.NewToolResultError(fmt.Sprintf("Server '%s' is quarantined for security review. Use the 'quarantine_security' tool to inspect its tools.", serverName)), nil
+	}
+	experimental := err == nil && serverConfig.Experimental
+
+	tools, err := p.storage.GetToolMetadata(serverName)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load tools of server '%s': %v", serverName, err)), nil
+	}
+
+	for _, tool := range tools {
+		if tool.Name != name {
+			continue
+		}
+
+		serverConnected := false
+		serverState := "unknown"
+		if p.upstreamManager != nil {
+			if client, exists := p.upstreamManager.GetClient(serverName); exists {
+				serverConnected = client.IsConnected()
+				serverState = client.GetState().String()
+			}
+		}
+
+		description := tool.Description
+		if experimental {
+			description = experimentalDescription(description)
+		}
+
+		response := map[string]interface{}{
+			"name":             tool.Name,
+			"server":           tool.ServerName,
+			"description":      description,
+			"inputSchema":      p.toolInputSchema(tool.Name, tool.ParamsJSON),
+			"server_connected": serverConnected,
+			"server_state":     serverState,
+		}
+		if experimental {
+			response["experimental"] = true
+		}
+
+		jsonResult, err := json.Marshal(response)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to serialize result: %v", err)), nil
+		}
+		return mcp.NewToolResultText(string(jsonResult)), nil
+	}
+
+	return mcp.NewToolResultError(fmt.Sprintf("Tool not found: %s. Use retrieve_tools to find tool names.", name)), nil
+}
@@ -0,0 +1,35 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncateDescription(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		maxLength   int
+		expected    string
+		truncated   bool
+	}{
+		{name: "no limit", description: "Create a new GitHub repository", maxLength: 0, expected: "Create a new GitHub repository"},
+		{name: "fits", description: "Create a repository", maxLength: 19, expected: "Create a repository"},
+		{name: "cut with ellipsis", description: "Create a new GitHub repository", maxLength: 15, expected: "Create a new...", truncated: true},
+		{name: "trailing space trimmed", description: "Create a new GitHub repository", maxLength: 16, expected: "Create a new...", truncated: true},
+		{name: "multibyte characters", description: "Résumé des écritures", maxLength: 9, expected: "Résumé...", truncated: true},
+		{name: "limit shorter than ellipsis", description: "Create", maxLength: 2, expected: "Cr", truncated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			description, truncated := truncateDescription(tt.description, tt.maxLength)
+			assert.Equal(t, tt.expected, description)
+			assert.Equal(t, tt.truncated, truncated)
+			if tt.maxLength > 0 {
+				assert.LessOrEqual(t, len([]rune(description)), tt.maxLength)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package server
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 
@@ -30,9 +31,11 @@ func (s *Server) handleGroupsTool(args map[string]interface{}) (interface{}, err
 	switch operation {
 	case "list_groups":
 		return s.listGroups()
-	case "assign_server":
+	case "create_group":
+		return s.createGroupFromArgs(args)
+	case "assign_server", "assign":
 		return s.assignServerToGroup(args)
-	case "unassign_server":
+	case "unassign_server", "unassign":
 		return s.unassignServerFromGroup(args)
 	case "list_assignments":
 		return s.listServerAssignments()
@@ -61,6 +64,76 @@ func (s *Server) listGroups() (interface{}, error) {
 	}, nil
 }
 
+// createGroupFromArgs creates a new group from MCP tool arguments
+func (s *Server) createGroupFromArgs(args map[string]interface{}) (interface{}, error) {
+	name, _ := args["name"].(string)
+	if strings.TrimSpace(name) == "" {
+		// Accept group_name as well, matching the other group operations
+		name, _ = args["group_name"].(string)
+	}
+	color, _ := args["color"].(string)
+	description, _ := args["description"].(string)
+	icon, _ := args["icon_emoji"].(string)
+
+	group, err := s.createGroup(name, color, description, icon)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"success": true,
+		"message": fmt.Sprintf("Group '%s' created with ID %d", group.Name, group.ID),
+		"group": map[string]interface{}{
+			"id":          group.ID,
+			"name":        group.Name,
+			"color":       group.Color,
+			"description": group.Description,
+			"icon_emoji":  group.Icon,
+		},
+	}, nil
+}
+
+// createGroup creates a new group with the next available ID and persists it.
+// Empty color and icon fall back to the same defaults as the web UI.
+func (s *Server) createGroup(name, color, description, icon string) (*Group, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("group name is required")
+	}
+	if strings.TrimSpace(color) == "" {
+		color = "#007bff" // Default color
+	}
+	if strings.TrimSpace(icon) == "" {
+		icon = "📁" // Default icon
+	}
+
+	if _, exists := s.getGroups()[name]; exists {
+		return nil, fmt.Errorf("group '%s' already exists", name)
+	}
+
+	group := &Group{
+		ID:          s.getNextGroupID(),
+		Name:        name,
+		Description: description,
+		Color:       color,
+		Icon:        icon,
+	}
+	s.setGroup(name, group)
+
+	s.logger.Info("Creating group",
+		zap.String("name", name),
+		zap.Int("id", group.ID),
+		zap.String("color", color),
+		zap.String("icon", icon))
+
+	// Save configuration to persist groups
+	if err := s.SaveConfiguration(); err != nil {
+		s.logger.Error("Failed to save configuration after creating group", zap.Error(err))
+	}
+
+	return group, nil
+}
+
 // assignServerToGroup assigns a server to a group
 func (s *Server) assignServerToGroup(args map[string]interface{}) (interface{}, error) {
 	serverName, ok := args["server_name"].(string)
@@ -73,23 +146,8 @@ func (s *Server) assignServerToGroup(args map[string]interface{}) (interface{},
 		return nil, fmt.Errorf("group_name parameter is required")
 	}
 
-	// Check if group exists
-	groupsMutex.RLock()
-	_, groupExists := groups[groupName]
-	groupsMutex.RUnlock()
-
-	if !groupExists {
-		return nil, fmt.Errorf("group '%s' does not exist", groupName)
-	}
-
-	// Assign server to group
-	assignmentsMutex.Lock()
-	serverGroupAssignments[serverName] = groupName
-	assignmentsMutex.Unlock()
-
-	// Save to configuration file
-	if err := s.SaveConfiguration(); err != nil {
-		s.logger.Error("Failed to save configuration after assigning server to group", zap.Error(err))
+	if err := s.assignServer(serverName, groupName); err != nil {
+		return nil, err
 	}
 
 	return map[string]interface{}{
@@ -105,13 +163,8 @@ func (s *Server) unassignServerFromGroup(args map[string]interface{}) (interface
 		return nil, fmt.Errorf("server_name parameter is required")
 	}
 
-	assignmentsMutex.Lock()
-	delete(serverGroupAssignments, serverName)
-	assignmentsMutex.Unlock()
-
-	// Save to configuration file
-	if err := s.SaveConfiguration(); err != nil {
-		s.logger.Error("Failed to save configuration after unassigning server from group", zap.Error(err))
+	if err := s.unassignServer(serverName); err != nil {
+		return nil, err
 	}
 
 	return map[string]interface{}{
@@ -120,21 +173,44 @@ func (s *Server) unassignServerFromGroup(args map[string]interface{}) (interface
 	}, nil
 }
 
-// listServerAssignments returns all server-to-group assignments
+// listServerAssignments returns all server-to-group assignments together with
+// the assigned group's id and color, sorted by server name
 func (s *Server) listServerAssignments() (interface{}, error) {
 	assignmentsMutex.RLock()
-	defer assignmentsMutex.RUnlock()
-
-	assignments := make([]map[string]interface{}, 0, len(serverGroupAssignments))
+	snapshot := make(map[string]string, len(serverGroupAssignments))
 	for serverName, groupName := range serverGroupAssignments {
-		assignments = append(assignments, map[string]interface{}{
+		snapshot[serverName] = groupName
+	}
+	assignmentsMutex.RUnlock()
+
+	serverNames := make([]string, 0, len(snapshot))
+	for serverName := range snapshot {
+		serverNames = append(serverNames, serverName)
+	}
+	sort.Strings(serverNames)
+
+	groupsMutex.RLock()
+	defer groupsMutex.RUnlock()
+
+	assignments := make([]map[string]interface{}, 0, len(serverNames))
+	for _, serverName := range serverNames {
+		groupName := snapshot[serverName]
+		assignment := map[string]interface{}{
 			"server_name": serverName,
+			"group_id":    0,
 			"group_name":  groupName,
-		})
+			"group_color": "",
+		}
+		if group, exists := groups[groupName]; exists {
+			assignment["group_id"] = group.ID
+			assignment["group_color"] = group.Color
+		}
+		assignments = append(assignments, assignment)
 	}
 
 	return map[string]interface{}{
 		"assignments": assignments,
+		"total":       len(assignments),
 	}, nil
 }
 
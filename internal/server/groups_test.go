@@ -344,6 +344,135 @@ eventLoop:
 	}
 }
 
+// TestListServerAssignments verifies that assignments include group id and color
+func TestListServerAssignments(t *testing.T) {
+	server, cleanup := setupTestServerWithGroups(t)
+	defer cleanup()
+
+	groupsMutex.Lock()
+	groups["dev"] = &Group{ID: 3, Name: "dev", Color: "#28a745"}
+	groupsMutex.Unlock()
+
+	assignmentsMutex.Lock()
+	serverGroupAssignments["server-b"] = "dev"
+	serverGroupAssignments["server-a"] = "missing"
+	assignmentsMutex.Unlock()
+
+	result, err := server.handleGroupsTool(map[string]interface{}{"operation": "list_assignments"})
+	require.NoError(t, err)
+
+	response := result.(map[string]interface{})
+	assignments := response["assignments"].([]map[string]interface{})
+	require.Len(t, assignments, 2)
+
+	assert.Equal(t, "server-a", assignments[0]["server_name"])
+	assert.Equal(t, "missing", assignments[0]["group_name"])
+	assert.Equal(t, 0, assignments[0]["group_id"])
+	assert.Equal(t, "", assignments[0]["group_color"])
+
+	assert.Equal(t, "server-b", assignments[1]["server_name"])
+	assert.Equal(t, "dev", assignments[1]["group_name"])
+	assert.Equal(t, 3, assignments[1]["group_id"])
+	assert.Equal(t, "#28a745", assignments[1]["group_color"])
+}
+
+// TestCreateGroupOperation verifies group creation through the groups tool
+func TestCreateGroupOperation(t *testing.T) {
+	server, cleanup := setupTestServerWithGroups(t)
+	defer cleanup()
+
+	result, err := server.handleGroupsTool(map[string]interface{}{
+		"operation":   "create_group",
+		"name":        "prod",
+		"color":       "#dc3545",
+		"description": "Production servers",
+	})
+	require.NoError(t, err)
+
+	group := result.(map[string]interface{})["group"].(map[string]interface{})
+	assert.Equal(t, 1, group["id"])
+	assert.Equal(t, "prod", group["name"])
+	assert.Equal(t, "#dc3545", group["color"])
+	assert.Equal(t, "Production servers", group["description"])
+	assert.Equal(t, "📁", group["icon_emoji"])
+
+	// Next group gets the next ID and default color
+	result, err = server.handleGroupsTool(map[string]interface{}{
+		"operation": "create_group",
+		"name":      "staging",
+	})
+	require.NoError(t, err)
+	group = result.(map[string]interface{})["group"].(map[string]interface{})
+	assert.Equal(t, 2, group["id"])
+	assert.Equal(t, "#007bff", group["color"])
+
+	// Duplicate names are rejected
+	_, err = server.handleGroupsTool(map[string]interface{}{
+		"operation": "create_group",
+		"name":      "prod",
+	})
+	assert.Error(t, err)
+
+	// Groups are persisted to the config
+	require.Len(t, server.config.Groups, 2)
+}
+
+// TestAssignUnassignOperations verifies assign/unassign through the groups tool
+func TestAssignUnassignOperations(t *testing.T) {
+	server, cleanup := setupTestServerWithGroups(t)
+	defer cleanup()
+
+	server.config.Servers = append(server.config.Servers, &config.ServerConfig{
+		Name:     "server1",
+		Protocol: "http",
+		URL:      "http://localhost:9999",
+	})
+
+	_, err := server.handleGroupsTool(map[string]interface{}{
+		"operation": "create_group",
+		"name":      "dev",
+	})
+	require.NoError(t, err)
+
+	// Assigning to an unknown group fails
+	_, err = server.handleGroupsTool(map[string]interface{}{
+		"operation":   "assign",
+		"server_name": "server1",
+		"group_name":  "missing",
+	})
+	assert.Error(t, err)
+
+	_, err = server.handleGroupsTool(map[string]interface{}{
+		"operation":   "assign",
+		"server_name": "server1",
+		"group_name":  "dev",
+	})
+	require.NoError(t, err)
+
+	assignmentsMutex.RLock()
+	assert.Equal(t, "dev", serverGroupAssignments["server1"])
+	assignmentsMutex.RUnlock()
+	assert.Equal(t, 1, server.config.Servers[0].GroupID)
+
+	_, err = server.handleGroupsTool(map[string]interface{}{
+		"operation":   "unassign",
+		"server_name": "server1",
+	})
+	require.NoError(t, err)
+
+	assignmentsMutex.RLock()
+	_, stillAssigned := serverGroupAssignments["server1"]
+	assignmentsMutex.RUnlock()
+	assert.False(t, stillAssigned)
+	assert.Equal(t, 0, server.config.Servers[0].GroupID)
+
+	// The config file must no longer carry the old group_id
+	loaded, err := config.LoadFromFile(server.GetConfigPath())
+	require.NoError(t, err)
+	require.Len(t, loaded.Servers, 1)
+	assert.Equal(t, 0, loaded.Servers[0].GroupID)
+}
+
 // Helper functions
 
 // setupTestServerWithGroups creates a test server with storage and event bus
@@ -1017,50 +1017,25 @@ func (s *Server) handleCreateGroup(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	color, ok := groupData["color"].(string)
-	if !ok || strings.TrimSpace(color) == "" {
-		color = "#007bff" // Default color
-	}
-
+	color, _ := groupData["color"].(string)
+	description, _ := groupData["description"].(string)
 	icon, _ := groupData["icon_emoji"].(string)
-	if strings.TrimSpace(icon) == "" {
-		icon = "📁" // Default icon
-	}
-
-	allGroups := s.getGroups()
 
-	// Check if group already exists
-	if _, exists := allGroups[name]; exists {
+	group, err := s.createGroup(name, color, description, icon)
+	if err != nil {
 		response := map[string]interface{}{
 			"success": false,
-			"error":   fmt.Sprintf("Group '%s' already exists", name),
+			"error":   err.Error(),
 		}
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(response)
 		return
 	}
 
-	// Generate next available ID for new group
-	nextID := s.getNextGroupID()
-
-	// Create new group with ID
-	s.setGroup(name, &Group{
-		ID:    nextID,
-		Name:  name,
-		Color: color,
-		Icon:  icon,
-	})
-
-	s.logger.Info("Creating group", zap.String("name", name), zap.String("color", color), zap.String("icon", icon))
-
-	// Save configuration to persist groups
-	if err := s.SaveConfiguration(); err != nil {
-		s.logger.Error("Failed to save configuration after creating group", zap.Error(err))
-	}
-
 	response := map[string]interface{}{
 		"success": true,
-		"message": fmt.Sprintf("Group '%s' created successfully", name),
+		"message": fmt.Sprintf("Group '%s' created successfully", group.Name),
+		"id":      group.ID,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -1286,31 +1261,16 @@ func (s *Server) handleAssignServer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Check if group exists
-	groupsMutex.RLock()
-	_, groupExists := groups[groupName]
-	groupsMutex.RUnlock()
-
-	if !groupExists {
+	if err := s.assignServer(serverName, groupName); err != nil {
 		response := map[string]interface{}{
 			"success": false,
-			"error":   fmt.Sprintf("Group '%s' does not exist", groupName),
+			"error":   err.Error(),
 		}
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(response)
 		return
 	}
 
-	// Assign server to group
-	assignmentsMutex.Lock()
-	serverGroupAssignments[serverName] = groupName
-	assignmentsMutex.Unlock()
-
-	// Save to configuration file
-	if err := s.SaveConfiguration(); err != nil {
-		s.logger.Error("Failed to save configuration after assigning server to group", zap.Error(err))
-	}
-
 	s.logger.Info("Server assigned to group via web interface", zap.String("server", serverName), zap.String("group", groupName))
 
 	response := map[string]interface{}{
@@ -1356,27 +1316,74 @@ func (s *Server) handleUnassignServer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := s.unassignServer(payload.ServerName); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Optionally reload internal config
+	_ = s.ReloadConfiguration()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": fmt.Sprintf("Server '%s' unassigned from group", payload.ServerName),
+	})
+}
+
+// assignServer assigns a server to an existing group and persists the assignment
+func (s *Server) assignServer(serverName, groupName string) error {
+	groupsMutex.RLock()
+	_, groupExists := groups[groupName]
+	groupsMutex.RUnlock()
+
+	if !groupExists {
+		return fmt.Errorf("group '%s' does not exist", groupName)
+	}
+
+	assignmentsMutex.Lock()
+	serverGroupAssignments[serverName] = groupName
+	assignmentsMutex.Unlock()
+
+	// Save to configuration file
+	if err := s.SaveConfiguration(); err != nil {
+		s.logger.Error("Failed to save configuration after assigning server to group", zap.Error(err))
+	}
+
+	return nil
+}
+
+// unassignServer removes a server's group assignment and writes group_id=0 to the
+// config file. SaveConfiguration never downgrades a non-zero group_id, so the
+// config file is updated directly here.
+func (s *Server) unassignServer(serverName string) error {
 	// Update in-memory assignments
 	assignmentsMutex.Lock()
-	delete(serverGroupAssignments, payload.ServerName)
+	delete(serverGroupAssignments, serverName)
 	assignmentsMutex.Unlock()
 
+	for _, serverConfig := range s.config.Servers {
+		if serverConfig.Name == serverName {
+			serverConfig.GroupID = 0
+			serverConfig.GroupName = ""
+			break
+		}
+	}
+
 	// Load config JSON and set group_id=0 for this server, remove group_name
 	configPath := s.GetConfigPath()
 	data, err := os.ReadFile(configPath)
 	if err != nil {
-		http.Error(w, "failed to read config", http.StatusInternalServerError)
-		return
+		return fmt.Errorf("failed to read config: %w", err)
 	}
 	var cfg map[string]interface{}
 	if err := json.Unmarshal(data, &cfg); err != nil {
-		http.Error(w, "failed to parse config", http.StatusInternalServerError)
-		return
+		return fmt.Errorf("failed to parse config: %w", err)
 	}
 	if servers, ok := cfg["mcpServers"].([]interface{}); ok {
 		for i, it := range servers {
 			if m, ok := it.(map[string]interface{}); ok {
-				if name, ok := m["name"].(string); ok && name == payload.ServerName {
+				if name, ok := m["name"].(string); ok && name == serverName {
 					m["group_id"] = 0
 					delete(m, "group_name")
 					// Remove deprecated fields that should not be written to config
@@ -1394,22 +1401,13 @@ func (s *Server) handleUnassignServer(w http.ResponseWriter, r *http.Request) {
 	}
 	out, err := json.MarshalIndent(cfg, "", "  ")
 	if err != nil {
-		http.Error(w, "failed to serialize config", http.StatusInternalServerError)
-		return
+		return fmt.Errorf("failed to serialize config: %w", err)
 	}
 	if err := os.WriteFile(configPath, out, 0600); err != nil {
-		http.Error(w, "failed to write config", http.StatusInternalServerError)
-		return
+		return fmt.Errorf("failed to write config: %w", err)
 	}
 
-	// Optionally reload internal config
-	_ = s.ReloadConfiguration()
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"success": true,
-		"message": fmt.Sprintf("Server '%s' unassigned from group", payload.ServerName),
-	})
+	return nil
 }
 
 // handleToggleGroupServers enables or disables all servers in a group
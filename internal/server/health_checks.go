@@ -0,0 +1,117 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+
+	upstreamtypes "mcpproxy-go/internal/upstream/types"
+)
+
+// healthCheckFailure is an enabled server with health_check whose last health check failed
+type healthCheckFailure struct {
+	Server              string    `json:"server"`
+	State               string    `json:"state"`
+	Error               string    `json:"error"`
+	CheckedAt           time.Time `json:"checked_at"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	LastPassedAt        time.Time `json:"last_passed_at,omitempty"`
+}
+
+// healthCheckFailures returns the servers currently failing their health check from
+// GetAllServers entries, most consecutive failures first. Disabled and quarantined servers
+// aren't checked, so they are never listed.
+func healthCheckFailures(servers []map[string]interface{}) []healthCheckFailure {
+	failures := []healthCheckFailure{}
+	for _, server := range servers {
+		enabled, _ := server["enabled"].(bool)
+		autoDisabled, _ := server["auto_disabled"].(bool)
+		if !enabled || autoDisabled {
+			continue
+		}
+		result, ok := server["last_health_check"].(upstreamtypes.HealthCheckResult)
+		if !ok || result.Passed {
+			continue
+		}
+
+		name, _ := server["name"].(string)
+		state, _ := server["connection_state"].(string)
+		failures = append(failures, healthCheckFailure{
+			Server:              name,
+			State:               state,
+			Error:               result.Error,
+			CheckedAt:           result.CheckedAt,
+			ConsecutiveFailures: result.ConsecutiveFailures,
+			LastPassedAt:        result.LastPassedAt,
+		})
+	}
+
+	sort.Slice(failures, func(i, j int) bool {
+		if failures[i].ConsecutiveFailures != failures[j].ConsecutiveFailures {
+			return failures[i].ConsecutiveFailures > failures[j].ConsecutiveFailures
+		}
+		return failures[i].Server < failures[j].Server
+	})
+	return failures
+}
+
+// HealthCheckFailures returns the servers with health_check enabled that failed their last check
+func (s *Server) HealthCheckFailures() ([]healthCheckFailure, error) {
+	servers, err := s.GetAllServers()
+	if err != nil {
+		return nil, err
+	}
+	return healthCheckFailures(servers), nil
+}
+
+// handleServersHealthAPI handles GET /api/servers/health
+func (s *Server) handleServersHealthAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	failures, err := s.HealthCheckFailures()
+	if err != nil {
+		s.logger.Error("Failed to list health check failures", zap.Error(err))
+		http.Error(w, fmt.Sprintf("Failed to get servers: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"failing": failures,
+		"total":   len(failures),
+	}); err != nil {
+		s.logger.Error("Failed to encode health check failures", zap.Error(err))
+	}
+}
+
+// handleHealthCheckFailures implements the health_check_failures MCP tool, mirroring
+// GET /api/servers/health
+func (p *MCPProxyServer) handleHealthCheckFailures(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if p.mainServer == nil {
+		return mcp.NewToolResultError("Health check results are not available"), nil
+	}
+
+	failures, err := p.mainServer.HealthCheckFailures()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get servers: %v", err)), nil
+	}
+
+	jsonResult, err := json.Marshal(map[string]interface{}{
+		"failing": failures,
+		"total":   len(failures),
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to serialize result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonResult)), nil
+}
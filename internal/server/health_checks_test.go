@@ -0,0 +1,43 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	upstreamtypes "mcpproxy-go/internal/upstream/types"
+)
+
+func TestHealthCheckFailures(t *testing.T) {
+	checkedAt := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+	lastPassed := checkedAt.Add(-time.Hour)
+
+	servers := []map[string]interface{}{
+		{"name": "github", "enabled": true, "connection_state": "Ready",
+			"last_health_check": upstreamtypes.HealthCheckResult{CheckedAt: checkedAt, Passed: true, LastPassedAt: checkedAt}},
+		{"name": "jira", "enabled": true, "connection_state": "Error",
+			"last_health_check": upstreamtypes.HealthCheckResult{CheckedAt: checkedAt, Error: "connection refused", ConsecutiveFailures: 1, LastPassedAt: lastPassed}},
+		{"name": "slack", "enabled": true, "connection_state": "Ready",
+			"last_health_check": upstreamtypes.HealthCheckResult{CheckedAt: checkedAt, Error: "context deadline exceeded", ConsecutiveFailures: 3}},
+		{"name": "old", "enabled": false,
+			"last_health_check": upstreamtypes.HealthCheckResult{CheckedAt: checkedAt, Error: "not connected", ConsecutiveFailures: 5}},
+		{"name": "flaky", "enabled": true, "auto_disabled": true,
+			"last_health_check": upstreamtypes.HealthCheckResult{CheckedAt: checkedAt, Error: "timeout", ConsecutiveFailures: 9}},
+		{"name": "unchecked", "enabled": true, "connection_state": "Error"},
+	}
+
+	failures := healthCheckFailures(servers)
+
+	assert.Equal(t, []healthCheckFailure{
+		{Server: "slack", State: "Ready", Error: "context deadline exceeded", CheckedAt: checkedAt, ConsecutiveFailures: 3},
+		{Server: "jira", State: "Error", Error: "connection refused", CheckedAt: checkedAt, ConsecutiveFailures: 1, LastPassedAt: lastPassed},
+	}, failures)
+}
+
+func TestHealthCheckFailures_NoneFailing(t *testing.T) {
+	failures := healthCheckFailures(nil)
+
+	assert.NotNil(t, failures)
+	assert.Empty(t, failures)
+}
@@ -0,0 +1,123 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+)
+
+// serverHealthSummary aggregates server states for status pages that only need counts
+type serverHealthSummary struct {
+	Total        int               `json:"total"`
+	Connected    int               `json:"connected"`
+	Disconnected int               `json:"disconnected"`
+	Disabled     int               `json:"disabled"`
+	AutoDisabled int               `json:"auto_disabled"`
+	Quarantined  int               `json:"quarantined"`
+	TotalTools   int               `json:"total_tools"`
+	Errors       []serverErrorInfo `json:"servers_with_errors"`
+}
+
+// serverErrorInfo is a server whose last connection attempt failed
+type serverErrorInfo struct {
+	Name  string `json:"name"`
+	Error string `json:"error"`
+}
+
+// summarizeServerHealth builds a health summary from GetAllServers entries and GetUpstreamStats.
+// Each server is counted in exactly one of connected, disconnected, disabled, auto_disabled
+// and quarantined.
+func summarizeServerHealth(servers []map[string]interface{}, stats map[string]interface{}) *serverHealthSummary {
+	summary := &serverHealthSummary{
+		Total:  len(servers),
+		Errors: []serverErrorInfo{},
+	}
+
+	for _, server := range servers {
+		name, _ := server["name"].(string)
+		startupMode, _ := server["startup_mode"].(string)
+		quarantined, _ := server["quarantined"].(bool)
+		autoDisabled, _ := server["auto_disabled"].(bool)
+		connected, _ := server["connected"].(bool)
+
+		switch {
+		case quarantined:
+			summary.Quarantined++
+		case autoDisabled:
+			summary.AutoDisabled++
+		case startupMode == "disabled":
+			summary.Disabled++
+		case connected:
+			summary.Connected++
+		default:
+			summary.Disconnected++
+		}
+
+		if lastError, _ := server["last_error"].(string); lastError != "" {
+			summary.Errors = append(summary.Errors, serverErrorInfo{Name: name, Error: lastError})
+		}
+	}
+	sort.Slice(summary.Errors, func(i, j int) bool {
+		return summary.Errors[i].Name < summary.Errors[j].Name
+	})
+
+	if totalTools, ok := stats["total_tools"].(int); ok {
+		summary.TotalTools = totalTools
+	}
+
+	return summary
+}
+
+// ServerHealthSummary returns aggregated counts of server states and indexed tools
+func (s *Server) ServerHealthSummary() (*serverHealthSummary, error) {
+	servers, err := s.GetAllServers()
+	if err != nil {
+		return nil, err
+	}
+	return summarizeServerHealth(servers, s.GetUpstreamStats()), nil
+}
+
+// handleServersSummaryAPI handles GET /api/servers/summary
+func (s *Server) handleServersSummaryAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	summary, err := s.ServerHealthSummary()
+	if err != nil {
+		s.logger.Error("Failed to build server health summary", zap.Error(err))
+		http.Error(w, fmt.Sprintf("Failed to get servers: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(summary); err != nil {
+		s.logger.Error("Failed to encode server health summary", zap.Error(err))
+	}
+}
+
+// handleServerHealthSummary implements the server_health_summary MCP tool, mirroring
+// GET /api/servers/summary
+func (p *MCPProxyServer) handleServerHealthSummary(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if p.mainServer == nil {
+		return mcp.NewToolResultError("Server health summary is not available"), nil
+	}
+
+	summary, err := p.mainServer.ServerHealthSummary()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get servers: %v", err)), nil
+	}
+
+	jsonResult, err := json.Marshal(summary)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to serialize result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonResult)), nil
+}
@@ -0,0 +1,41 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarizeServerHealth(t *testing.T) {
+	servers := []map[string]interface{}{
+		{"name": "github", "startup_mode": "active", "connected": true},
+		{"name": "slack", "startup_mode": "lazy_loading", "connected": true},
+		{"name": "jira", "startup_mode": "active", "connected": false, "last_error": "connection refused"},
+		{"name": "old", "startup_mode": "disabled"},
+		{"name": "flaky", "startup_mode": "auto_disabled", "auto_disabled": true, "last_error": "timeout"},
+		{"name": "unknown", "startup_mode": "quarantined", "quarantined": true},
+	}
+	stats := map[string]interface{}{"total_tools": 42}
+
+	summary := summarizeServerHealth(servers, stats)
+
+	assert.Equal(t, 6, summary.Total)
+	assert.Equal(t, 2, summary.Connected)
+	assert.Equal(t, 1, summary.Disconnected)
+	assert.Equal(t, 1, summary.Disabled)
+	assert.Equal(t, 1, summary.AutoDisabled)
+	assert.Equal(t, 1, summary.Quarantined)
+	assert.Equal(t, 42, summary.TotalTools)
+	assert.Equal(t, []serverErrorInfo{
+		{Name: "flaky", Error: "timeout"},
+		{Name: "jira", Error: "connection refused"},
+	}, summary.Errors)
+}
+
+func TestSummarizeServerHealth_Empty(t *testing.T) {
+	summary := summarizeServerHealth(nil, map[string]interface{}{})
+
+	assert.Equal(t, 0, summary.Total)
+	assert.Equal(t, 0, summary.TotalTools)
+	assert.NotNil(t, summary.Errors)
+}
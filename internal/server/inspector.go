@@ -1,19 +1,78 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"mcpproxy-go/internal/config"
 
 	"go.uber.org/zap"
 )
 
+// inspectorProcesses tracks running MCP Inspector processes so they can be stopped on shutdown.
+// Their connections to /mcp would otherwise keep the HTTP server from draining.
+type inspectorProcesses struct {
+	mu    sync.Mutex
+	procs map[*exec.Cmd]chan struct{} // closed once the process has exited
+}
+
+// track reaps cmd in the background and forgets it once it has exited. cmd must be started.
+func (p *inspectorProcesses) track(cmd *exec.Cmd) {
+	done := make(chan struct{})
+
+	p.mu.Lock()
+	if p.procs == nil {
+		p.procs = make(map[*exec.Cmd]chan struct{})
+	}
+	p.procs[cmd] = done
+	p.mu.Unlock()
+
+	go func() {
+		_ = cmd.Wait()
+		p.mu.Lock()
+		delete(p.procs, cmd)
+		p.mu.Unlock()
+		close(done)
+	}()
+}
+
+// count returns the number of running inspector processes
+func (p *inspectorProcesses) count() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.procs)
+}
+
+// stopAll kills every running inspector process and waits until they have exited or ctx is done
+func (p *inspectorProcesses) stopAll(ctx context.Context) error {
+	p.mu.Lock()
+	procs := make(map[*exec.Cmd]chan struct{}, len(p.procs))
+	for cmd, done := range p.procs {
+		procs[cmd] = done
+	}
+	p.mu.Unlock()
+
+	for cmd := range procs {
+		killInspectorProcess(cmd)
+	}
+	for _, done := range procs {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return fmt.Errorf("%d inspector process(es) still running: %w", p.count(), ctx.Err())
+		}
+	}
+	return nil
+}
+
 // InspectorStartRequest represents the request body for starting the inspector with a specific server
 type InspectorStartRequest struct {
 	ServerName string `json:"server_name"`
@@ -71,8 +130,14 @@ func (s *Server) handleInspectorStart(w http.ResponseWriter, r *http.Request) {
 	mcpServers := make(map[string]interface{})
 
 	// Build mcpproxy URL from listen address
-	listenAddr := s.GetListenAddress()
-	proxyURL := fmt.Sprintf("http://localhost%s/mcp", listenAddr)
+	proxyURL, err := s.inspectorProxyURL()
+	if err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"message": err.Error(),
+		})
+		return
+	}
 
 	// Configure inspector to connect to mcpproxy server
 	serverConfig := make(map[string]interface{})
@@ -119,6 +184,7 @@ func (s *Server) handleInspectorStart(w http.ResponseWriter, r *http.Request) {
 	output := &strings.Builder{}
 	cmd.Stdout = output
 	cmd.Stderr = output
+	setInspectorProcessGroup(cmd)
 
 	if err := cmd.Start(); err != nil {
 		s.logger.Error("Failed to start MCP Inspector", zap.Error(err))
@@ -129,6 +195,8 @@ func (s *Server) handleInspectorStart(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	s.inspectors.track(cmd)
+
 	s.logger.Info("MCP Inspector started",
 		zap.String("target_server", req.ServerName),
 		zap.String("proxy_url", proxyURL),
@@ -144,6 +212,25 @@ func (s *Server) handleInspectorStart(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// inspectorProxyURL returns the URL of the /mcp endpoint for the MCP Inspector, using https
+// when the HTTP API is served over TLS
+func (s *Server) inspectorProxyURL() (string, error) {
+	listenAddr := s.config.ListenAddress()
+	if _, ok := config.UnixSocketPath(listenAddr); ok {
+		return "", fmt.Errorf("mcpproxy listens on the Unix socket %s, which the MCP Inspector can't connect to", listenAddr)
+	}
+	_, port, err := net.SplitHostPort(listenAddr)
+	if err != nil {
+		return "", fmt.Errorf("invalid listen address %q: %w", listenAddr, err)
+	}
+
+	scheme := "http"
+	if s.config.TLSEnabled() {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://localhost:%s/mcp", scheme, port), nil
+}
+
 // handleLaunchInspector launches the MCP Inspector connected to mcpproxy (all servers)
 func (s *Server) handleLaunchInspector(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -157,8 +244,14 @@ func (s *Server) handleLaunchInspector(w http.ResponseWriter, r *http.Request) {
 	mcpServers := make(map[string]interface{})
 
 	// Build mcpproxy URL from listen address
-	listenAddr := s.GetListenAddress()
-	proxyURL := fmt.Sprintf("http://localhost%s/mcp", listenAddr)
+	proxyURL, err := s.inspectorProxyURL()
+	if err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
 
 	// Configure inspector to connect to mcpproxy server
 	serverConfig := make(map[string]interface{})
@@ -203,6 +296,7 @@ func (s *Server) handleLaunchInspector(w http.ResponseWriter, r *http.Request) {
 	output := &strings.Builder{}
 	cmd.Stdout = output
 	cmd.Stderr = output
+	setInspectorProcessGroup(cmd)
 
 	if err := cmd.Start(); err != nil {
 		s.logger.Error("Failed to start MCP Inspector", zap.Error(err))
@@ -213,6 +307,8 @@ func (s *Server) handleLaunchInspector(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	s.inspectors.track(cmd)
+
 	s.logger.Info("MCP Inspector started for mcpproxy",
 		zap.String("proxy_url", proxyURL),
 		zap.Int("pid", cmd.Process.Pid),
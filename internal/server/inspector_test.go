@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	"os/exec"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mcpproxy-go/internal/config"
+)
+
+func TestInspectorProcesses_StopAll(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses the sleep command")
+	}
+
+	var inspectors inspectorProcesses
+	for i := 0; i < 2; i++ {
+		cmd := exec.Command("sleep", "30")
+		setInspectorProcessGroup(cmd)
+		require.NoError(t, cmd.Start())
+		inspectors.track(cmd)
+	}
+	assert.Equal(t, 2, inspectors.count())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, inspectors.stopAll(ctx))
+	assert.Zero(t, inspectors.count())
+
+	// Nothing left to stop
+	assert.NoError(t, inspectors.stopAll(ctx))
+}
+
+func TestInspectorProcesses_ForgetsExitedProcesses(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses the true command")
+	}
+
+	var inspectors inspectorProcesses
+	cmd := exec.Command("true")
+	require.NoError(t, cmd.Start())
+	inspectors.track(cmd)
+
+	assert.Eventually(t, func() bool { return inspectors.count() == 0 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestHTTPDrainTimeout(t *testing.T) {
+	assert.Equal(t, 5*time.Second, httpDrainTimeout(10*time.Second))
+	assert.Equal(t, 30*time.Second, httpDrainTimeout(time.Minute))
+}
+
+func TestInspectorProxyURL(t *testing.T) {
+	s := &Server{config: &config.Config{Listen: "127.0.0.1:8080"}}
+	proxyURL, err := s.inspectorProxyURL()
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:8080/mcp", proxyURL)
+
+	s.config.TLSCertFile, s.config.TLSKeyFile = "cert.pem", "key.pem"
+	proxyURL, err = s.inspectorProxyURL()
+	require.NoError(t, err)
+	assert.Equal(t, "https://localhost:8080/mcp", proxyURL)
+
+	s.config.Listen = "unix:///tmp/mcpproxy.sock"
+	_, err = s.inspectorProxyURL()
+	assert.Error(t, err)
+}
@@ -0,0 +1,24 @@
+//go:build !windows
+
+package server
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setInspectorProcessGroup starts the inspector in its own process group, so npx and the
+// node process it spawns can be stopped together
+func setInspectorProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// killInspectorProcess kills the inspector's process group, falling back to the process itself
+func killInspectorProcess(cmd *exec.Cmd) {
+	if cmd.Process == nil {
+		return
+	}
+	if err := syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL); err != nil {
+		_ = cmd.Process.Kill()
+	}
+}
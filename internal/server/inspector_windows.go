@@ -0,0 +1,16 @@
+//go:build windows
+
+package server
+
+import "os/exec"
+
+// setInspectorProcessGroup is a no-op on Windows
+func setInspectorProcessGroup(_ *exec.Cmd) {}
+
+// killInspectorProcess kills the inspector process
+func killInspectorProcess(cmd *exec.Cmd) {
+	if cmd.Process == nil {
+		return
+	}
+	_ = cmd.Process.Kill()
+}
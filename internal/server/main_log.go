@@ -0,0 +1,43 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// handleReadMainLog implements the read_main_log MCP tool, mirroring GET /api/v1/agent/logs/main
+func (p *MCPProxyServer) handleReadMainLog(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if p.mainServer == nil {
+		return mcp.NewToolResultError("Main log is not available"), nil
+	}
+
+	lines := int(request.GetFloat("lines", 100))
+	if lines <= 0 {
+		lines = 100
+	}
+	if lines > 1000 {
+		lines = 1000
+	}
+	filter := request.GetString("filter", "")
+
+	logPath := p.mainServer.mainLogPath()
+	logEntries, err := p.mainServer.readLogFile(logPath, lines, filter)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to read main log %s: %v", logPath, err)), nil
+	}
+
+	jsonResult, err := json.Marshal(map[string]interface{}{
+		"log_file":        logPath,
+		"lines_requested": lines,
+		"lines_returned":  len(logEntries),
+		"logs":            logEntries,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to serialize result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonResult)), nil
+}
@@ -0,0 +1,55 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
+)
+
+func TestHandleReadMainLog(t *testing.T) {
+	dataDir := t.TempDir()
+	logDir := filepath.Join(dataDir, "logs")
+	require.NoError(t, os.MkdirAll(logDir, 0700))
+
+	var logLines []string
+	for _, msg := range []string{"starting", "connected github", "error connecting slack", "ready"} {
+		logLines = append(logLines, `{"level":"info","msg":"`+msg+`"}`)
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(logDir, "main.log"), []byte(strings.Join(logLines, "\n")+"\n"), 0600))
+
+	proxy := &MCPProxyServer{
+		logger:     zap.NewNop(),
+		mainServer: &Server{logger: zap.NewNop(), config: &config.Config{DataDir: dataDir}},
+	}
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]interface{}{"lines": float64(2)}
+	result, err := proxy.handleReadMainLog(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var payload struct {
+		LinesReturned int                      `json:"lines_returned"`
+		Logs          []map[string]interface{} `json:"logs"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &payload))
+	assert.Equal(t, 2, payload.LinesReturned)
+	assert.Equal(t, "error connecting slack", payload.Logs[0]["msg"])
+	assert.Equal(t, "ready", payload.Logs[1]["msg"])
+
+	request.Params.Arguments = map[string]interface{}{"filter": "ERROR"}
+	result, err = proxy.handleReadMainLog(context.Background(), request)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &payload))
+	assert.Equal(t, 1, payload.LinesReturned)
+}
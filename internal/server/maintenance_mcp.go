@@ -0,0 +1,147 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+)
+
+// handleMaintenance implements the maintenance MCP tool
+func (p *MCPProxyServer) handleMaintenance(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	operation, err := request.RequireString("operation")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing required parameter 'operation': %v", err)), nil
+	}
+
+	if p.config.ReadOnlyMode {
+		return mcp.NewToolResultError("Operation not allowed in read-only mode"), nil
+	}
+	if p.config.DisableManagement {
+		return mcp.NewToolResultError("Server management is disabled for security"), nil
+	}
+
+	var result map[string]interface{}
+	switch operation {
+	case "clear_embedding_cache":
+		result, err = p.clearEmbeddingCache()
+	case "rebuild_index":
+		result, err = p.rebuildIndex()
+	case "detect_tool_conflicts":
+		result, err = p.detectToolConflicts()
+	case "prune_orphaned_tools":
+		result, err = p.pruneOrphanedTools()
+	case "maintenance_mode":
+		result, err = p.setMaintenanceMode(request)
+	default:
+		return mcp.NewToolResultError(fmt.Sprintf("Unknown maintenance operation: %s", operation)), nil
+	}
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	jsonResult, err := json.Marshal(result)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to serialize result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonResult)), nil
+}
+
+// clearEmbeddingCache drops all cached semantic search embeddings
+func (p *MCPProxyServer) clearEmbeddingCache() (map[string]interface{}, error) {
+	cleared, err := p.storage.ClearEmbeddingCache()
+	if err != nil {
+		return nil, fmt.Errorf("failed to clear embedding cache: %w", err)
+	}
+
+	p.logger.Info("Cleared embedding cache via maintenance tool", zap.Int("cleared", cleared))
+
+	return map[string]interface{}{
+		"operation": "clear_embedding_cache",
+		"cleared":   cleared,
+		"message":   fmt.Sprintf("Cleared %d cached embeddings", cleared),
+	}, nil
+}
+
+// rebuildIndex recreates the search index from the tool metadata stored in the database
+func (p *MCPProxyServer) rebuildIndex() (map[string]interface{}, error) {
+	tools, err := p.storage.GetAllToolMetadata()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load stored tool metadata: %w", err)
+	}
+
+	if err := p.index.RebuildIndex(tools); err != nil {
+		return nil, fmt.Errorf("failed to rebuild index: %w", err)
+	}
+
+	p.logger.Info("Rebuilt search index via maintenance tool", zap.Int("tools", len(tools)))
+
+	return map[string]interface{}{
+		"operation": "rebuild_index",
+		"indexed":   len(tools),
+		"message":   fmt.Sprintf("Rebuilt search index with %d tools", len(tools)),
+	}, nil
+}
+
+// detectToolConflicts reports tools whose server:tool names collided in the search index
+func (p *MCPProxyServer) detectToolConflicts() (map[string]interface{}, error) {
+	conflicts, err := p.index.DetectToolConflicts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect tool conflicts: %w", err)
+	}
+
+	message := "No tool name collisions in the search index"
+	if len(conflicts) > 0 {
+		message = fmt.Sprintf("Found %d colliding tool name(s); colliding tools are indexed with a '#N' suffix", len(conflicts))
+	}
+
+	return map[string]interface{}{
+		"operation": "detect_tool_conflicts",
+		"conflicts": conflicts,
+		"total":     len(conflicts),
+		"message":   message,
+	}, nil
+}
+
+// pruneOrphanedTools deletes stored tool metadata, and the matching index entries, of servers
+// that are no longer in the configuration
+func (p *MCPProxyServer) pruneOrphanedTools() (map[string]interface{}, error) {
+	servers := p.currentConfig().Servers
+	serverIDs := make([]string, 0, len(servers))
+	for _, serverConfig := range servers {
+		serverIDs = append(serverIDs, serverConfig.Name)
+	}
+
+	pruned, err := p.storage.DeleteOrphanedToolMetadata(serverIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prune tool metadata: %w", err)
+	}
+
+	total := 0
+	prunedServers := make([]string, 0, len(pruned))
+	for serverName, count := range pruned {
+		total += count
+		prunedServers = append(prunedServers, serverName)
+		if err := p.index.DeleteServerTools(serverName); err != nil {
+			p.logger.Warn("Failed to remove orphaned server tools from index",
+				zap.String("server", serverName),
+				zap.Error(err))
+		}
+	}
+	sort.Strings(prunedServers)
+
+	p.logger.Info("Pruned orphaned tool metadata via maintenance tool",
+		zap.Int("pruned", total),
+		zap.Strings("servers", prunedServers))
+
+	return map[string]interface{}{
+		"operation": "prune_orphaned_tools",
+		"pruned":    total,
+		"servers":   prunedServers,
+		"message":   fmt.Sprintf("Pruned %d tool metadata records of %d removed servers", total, len(prunedServers)),
+	}, nil
+}
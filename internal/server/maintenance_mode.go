@@ -0,0 +1,195 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+)
+
+// maintenanceStatus is the state of maintenance mode, in which upstream tool calls are refused
+// while management tools, the API and upstream connections keep working
+type maintenanceStatus struct {
+	Enabled bool       `json:"enabled"`
+	Reason  string     `json:"reason,omitempty"`
+	Since   *time.Time `json:"since,omitempty"`
+	Until   *time.Time `json:"until,omitempty"` // Nil when maintenance mode lasts until turned off
+}
+
+// expired reports whether the timeout of maintenance mode has passed at now
+func (m maintenanceStatus) expired(now time.Time) bool {
+	return m.Until != nil && !now.Before(*m.Until)
+}
+
+// message is the error returned for tool calls refused during maintenance
+func (m maintenanceStatus) message() string {
+	message := "mcpproxy is under maintenance: tool calls are paused"
+	if m.Reason != "" {
+		message += " (" + m.Reason + ")"
+	}
+	if m.Until != nil {
+		return fmt.Sprintf("%s until %s. Retry after that.", message, m.Until.Format(time.RFC3339))
+	}
+	return message + ". Retry later."
+}
+
+// maintenanceMode holds the maintenance state of the proxy. The zero value is off.
+type maintenanceMode struct {
+	mu     sync.Mutex
+	status maintenanceStatus
+	timer  *time.Timer
+}
+
+// set turns maintenance mode on or off. A positive timeout turns it off automatically.
+func (m *maintenanceMode) set(enabled bool, timeout time.Duration, reason string, now time.Time) maintenanceStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.timer != nil {
+		m.timer.Stop()
+		m.timer = nil
+	}
+	if !enabled {
+		m.status = maintenanceStatus{}
+		return m.status
+	}
+
+	since := m.status.Since
+	if since == nil {
+		since = &now
+	}
+	m.status = maintenanceStatus{Enabled: true, Reason: reason, Since: since}
+	if timeout > 0 {
+		until := now.Add(timeout)
+		m.status.Until = &until
+		m.timer = time.AfterFunc(timeout, m.expire)
+	}
+	return m.status
+}
+
+// expire turns maintenance mode off once its timeout has passed
+func (m *maintenanceMode) expire() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.status.expired(time.Now()) {
+		m.status = maintenanceStatus{}
+		m.timer = nil
+	}
+}
+
+// get returns the current maintenance state
+func (m *maintenanceMode) get(now time.Time) maintenanceStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Don't rely on the timer alone, it may not have fired yet
+	if m.status.expired(now) {
+		return maintenanceStatus{}
+	}
+	return m.status
+}
+
+// SetMaintenanceMode turns maintenance mode on or off. While it is on, call_tool refuses calls
+// to upstream tools. A positive timeout turns it off automatically.
+func (s *Server) SetMaintenanceMode(enabled bool, timeout time.Duration, reason string) maintenanceStatus {
+	status := s.maintenance.set(enabled, timeout, reason, time.Now())
+	if enabled {
+		s.logger.Warn("Maintenance mode enabled, upstream tool calls are paused",
+			zap.String("reason", reason),
+			zap.Duration("timeout", timeout))
+	} else {
+		s.logger.Info("Maintenance mode disabled, upstream tool calls resumed")
+	}
+	return status
+}
+
+// MaintenanceMode returns the current maintenance state
+func (s *Server) MaintenanceMode() maintenanceStatus {
+	return s.maintenance.get(time.Now())
+}
+
+// maintenanceRequest is the body of POST /api/maintenance and the arguments of the
+// maintenance_mode operation of the maintenance tool
+type maintenanceRequest struct {
+	Enabled bool   `json:"enabled"`
+	Timeout string `json:"timeout,omitempty"` // Go duration, e.g. "15m"
+	Reason  string `json:"reason,omitempty"`
+}
+
+// applyMaintenanceRequest validates the request and sets maintenance mode accordingly
+func (s *Server) applyMaintenanceRequest(req maintenanceRequest) (maintenanceStatus, error) {
+	var timeout time.Duration
+	if req.Timeout != "" {
+		parsed, err := time.ParseDuration(req.Timeout)
+		if err != nil || parsed < 0 {
+			return maintenanceStatus{}, fmt.Errorf("invalid timeout '%s': use a Go duration such as '15m' or '1h'", req.Timeout)
+		}
+		timeout = parsed
+	}
+	return s.SetMaintenanceMode(req.Enabled, timeout, req.Reason), nil
+}
+
+// handleMaintenanceAPI handles /api/maintenance: GET reports maintenance mode, POST turns it
+// on or off
+func (s *Server) handleMaintenanceAPI(w http.ResponseWriter, r *http.Request) {
+	var status maintenanceStatus
+	switch r.Method {
+	case http.MethodGet:
+		status = s.MaintenanceMode()
+	case http.MethodPost:
+		var req maintenanceRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+			return
+		}
+		var err error
+		if status, err = s.applyMaintenanceRequest(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
+
+// setMaintenanceMode implements the maintenance_mode operation of the maintenance tool. Without
+// the enabled argument it reports the current state.
+func (p *MCPProxyServer) setMaintenanceMode(request mcp.CallToolRequest) (map[string]interface{}, error) {
+	if p.mainServer == nil {
+		return nil, fmt.Errorf("maintenance mode is not available")
+	}
+
+	status := p.mainServer.MaintenanceMode()
+	if args, ok := request.Params.Arguments.(map[string]interface{}); ok {
+		if enabled, ok := args["enabled"].(bool); ok {
+			var err error
+			status, err = p.mainServer.applyMaintenanceRequest(maintenanceRequest{
+				Enabled: enabled,
+				Timeout: request.GetString("timeout", ""),
+				Reason:  request.GetString("reason", ""),
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	message := "Maintenance mode is off: tool calls are served"
+	if status.Enabled {
+		message = status.message()
+	}
+	return map[string]interface{}{
+		"operation":   "maintenance_mode",
+		"maintenance": status,
+		"message":     message,
+	}, nil
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestMaintenanceMode(t *testing.T) {
+	var mode maintenanceMode
+	now := time.Now()
+	assert.False(t, mode.get(now).Enabled)
+
+	status := mode.set(true, 0, "upgrading jira", now)
+	assert.True(t, status.Enabled)
+	assert.Nil(t, status.Until)
+	assert.Equal(t, "mcpproxy is under maintenance: tool calls are paused (upgrading jira). Retry later.", status.message())
+
+	// Extending maintenance keeps the original start time
+	status = mode.set(true, time.Hour, "", now.Add(time.Minute))
+	require.NotNil(t, status.Until)
+	assert.Equal(t, now, *status.Since)
+	assert.Contains(t, status.message(), "until "+now.Add(time.Minute+time.Hour).Format(time.RFC3339))
+	assert.True(t, mode.get(now.Add(time.Hour)).Enabled)
+	assert.False(t, mode.get(now.Add(2*time.Hour)).Enabled)
+
+	assert.False(t, mode.set(false, 0, "", now).Enabled)
+	assert.False(t, mode.get(now).Enabled)
+}
+
+func TestMaintenanceMode_AutoClear(t *testing.T) {
+	var mode maintenanceMode
+	mode.set(true, 10*time.Millisecond, "", time.Now())
+
+	assert.Eventually(t, func() bool {
+		mode.mu.Lock()
+		defer mode.mu.Unlock()
+		return !mode.status.Enabled
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestHandleMaintenanceAPI(t *testing.T) {
+	s := &Server{logger: zap.NewNop()}
+
+	serve := func(method, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/api/maintenance", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		s.handleMaintenanceAPI(rec, req)
+		return rec
+	}
+
+	rec := serve(http.MethodPost, `{"enabled":true,"timeout":"15m","reason":"backup"}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var status maintenanceStatus
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+	assert.True(t, status.Enabled)
+	assert.Equal(t, "backup", status.Reason)
+	require.NotNil(t, status.Until)
+	assert.WithinDuration(t, time.Now().Add(15*time.Minute), *status.Until, time.Minute)
+	assert.True(t, s.MaintenanceMode().Enabled)
+
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodPost, `{"enabled":true,"timeout":"soon"}`).Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, serve(http.MethodDelete, "").Code)
+
+	rec = serve(http.MethodPost, `{"enabled":false}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"enabled":false}`, rec.Body.String())
+	assert.JSONEq(t, `{"enabled":false}`, serve(http.MethodGet, "").Body.String())
+}
@@ -753,3 +753,40 @@ func TestHandleV1ToolProxy(t *testing.T) {
 	// proper HTTP handler testing for V1 tool proxy functionality.
 	t.Skip("Test disabled: requires mockToolClient implementation")
 }
+
+func TestDuplicateServerConfig(t *testing.T) {
+	source := &config.ServerConfig{
+		Name:              "github",
+		Command:           "npx",
+		Args:              []string{"-y", "@modelcontextprotocol/server-github"},
+		Env:               map[string]string{"GITHUB_TOKEN": "one"},
+		Tags:              []string{"work"},
+		Isolation:         &config.IsolationConfig{Enabled: true, Image: "node:20"},
+		StartupMode:       "active",
+		EverConnected:     true,
+		ToolCount:         12,
+		AutoDisableReason: "too many failures",
+	}
+
+	duplicate, err := duplicateServerConfig(source, "github-personal")
+	require.NoError(t, err)
+
+	assert.Equal(t, "github-personal", duplicate.Name)
+	assert.Equal(t, "disabled", duplicate.StartupMode)
+	assert.Equal(t, source.Command, duplicate.Command)
+	assert.Equal(t, source.Args, duplicate.Args)
+	assert.Equal(t, source.Tags, duplicate.Tags)
+	assert.Equal(t, "node:20", duplicate.Isolation.Image)
+	assert.False(t, duplicate.EverConnected)
+	assert.Zero(t, duplicate.ToolCount)
+	assert.Empty(t, duplicate.AutoDisableReason)
+
+	// The copy must not share maps, slices or pointers with the source
+	duplicate.Env["GITHUB_TOKEN"] = "two"
+	duplicate.Args[0] = "-q"
+	duplicate.Isolation.Image = "node:22"
+	assert.Equal(t, "one", source.Env["GITHUB_TOKEN"])
+	assert.Equal(t, "-y", source.Args[0])
+	assert.Equal(t, "node:20", source.Isolation.Image)
+	assert.Equal(t, "active", source.StartupMode)
+}
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// handlePrometheusMetrics exposes metrics in the Prometheus text exposition format
+// GET /metrics/prometheus
+func (s *Server) handlePrometheusMetrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	writePrometheusMetrics(w, s.collectMetrics())
+}
+
+// writePrometheusMetrics renders a metrics snapshot in Prometheus text format
+func writePrometheusMetrics(w io.Writer, m MetricsData) {
+	names := make([]string, 0, len(m.ServerStates))
+	connected := 0
+	for name, state := range m.ServerStates {
+		names = append(names, name)
+		if state == "Ready" {
+			connected++
+		}
+	}
+	sort.Strings(names)
+
+	writePrometheusMetric(w, "mcpproxy_servers_total", "gauge", "Number of registered upstream servers", len(m.ServerStates))
+	writePrometheusMetric(w, "mcpproxy_servers_connected", "gauge", "Number of upstream servers in Ready state", connected)
+	writePrometheusMetric(w, "mcpproxy_tools_indexed", "gauge", "Number of tools in the search index", m.ToolsIndexed)
+	writePrometheusMetric(w, "mcpproxy_tool_calls_total", "counter", "Upstream tool calls since start", m.ToolCalls)
+	writePrometheusMetric(w, "mcpproxy_tool_call_errors_total", "counter", "Failed upstream tool calls since start", m.ToolCallErrors)
+	writePrometheusMetric(w, "mcpproxy_status_updates_dropped_total", "counter", "Status updates dropped because the status channel was full", m.StatusUpdatesDropped)
+	writePrometheusMetric(w, "mcpproxy_goroutines", "gauge", "Number of goroutines", m.NumGoroutines)
+	writePrometheusMetric(w, "mcpproxy_memory_alloc_bytes", "gauge", "Bytes of allocated heap objects", m.MemoryStats.Alloc)
+
+	fmt.Fprintln(w, "# HELP mcpproxy_server_connected Whether the upstream server is connected (1) or not (0)")
+	fmt.Fprintln(w, "# TYPE mcpproxy_server_connected gauge")
+	for _, name := range names {
+		value := 0
+		if m.ServerStates[name] == "Ready" {
+			value = 1
+		}
+		fmt.Fprintf(w, "mcpproxy_server_connected{server=\"%s\"} %d\n", escapePrometheusLabel(name), value)
+	}
+
+	fmt.Fprintln(w, "# HELP mcpproxy_server_connection_state Current connection state of the upstream server (always 1, state in label)")
+	fmt.Fprintln(w, "# TYPE mcpproxy_server_connection_state gauge")
+	for _, name := range names {
+		fmt.Fprintf(w, "mcpproxy_server_connection_state{server=\"%s\",state=\"%s\"} 1\n",
+			escapePrometheusLabel(name), escapePrometheusLabel(m.ServerStates[name]))
+	}
+}
+
+func writePrometheusMetric(w io.Writer, name, metricType, help string, value interface{}) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n%s %v\n", name, help, name, metricType, name, value)
+}
+
+// escapePrometheusLabel escapes a label value per the text exposition format
+func escapePrometheusLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
@@ -0,0 +1,34 @@
+package server
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWritePrometheusMetrics(t *testing.T) {
+	var buf bytes.Buffer
+	writePrometheusMetrics(&buf, MetricsData{
+		ToolsIndexed:   42,
+		ToolCalls:      10,
+		ToolCallErrors: 2,
+
+		StatusUpdatesDropped: 3,
+		ServerStates: map[string]string{
+			"github": "Ready",
+			`we"ird`: "Error",
+		},
+	})
+	out := buf.String()
+
+	assert.Contains(t, out, "# TYPE mcpproxy_tool_calls_total counter\nmcpproxy_tool_calls_total 10\n")
+	assert.Contains(t, out, "mcpproxy_tool_call_errors_total 2\n")
+	assert.Contains(t, out, "mcpproxy_status_updates_dropped_total 3\n")
+	assert.Contains(t, out, "mcpproxy_tools_indexed 42\n")
+	assert.Contains(t, out, "mcpproxy_servers_total 2\n")
+	assert.Contains(t, out, "mcpproxy_servers_connected 1\n")
+	assert.Contains(t, out, `mcpproxy_server_connected{server="github"} 1`)
+	assert.Contains(t, out, `mcpproxy_server_connected{server="we\"ird"} 0`)
+	assert.Contains(t, out, `mcpproxy_server_connection_state{server="github",state="Ready"} 1`)
+}
@@ -18,6 +18,11 @@ type MetricsData struct {
 	MemoryStats     runtime.MemStats       `json:"memory_stats"`
 	UpstreamServers map[string]interface{} `json:"upstream_servers"`
 	ToolsIndexed    int                    `json:"tools_indexed"`
+	ToolCalls       uint64                 `json:"tool_calls"`
+	ToolCallErrors  uint64                 `json:"tool_call_errors"`
+	ServerStates    map[string]string      `json:"server_states"` // server name -> connection state
+	// Status updates dropped because the status channel was full
+	StatusUpdatesDropped uint64 `json:"status_updates_dropped"`
 }
 
 // handleMetricsWeb serves the metrics web interface
@@ -347,24 +352,48 @@ func (s *Server) handleMetricsWeb(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprint(w, html)
 }
 
-// handleMetricsAPI returns current metrics as JSON
-func (s *Server) handleMetricsAPI(w http.ResponseWriter, r *http.Request) {
+// collectMetrics gathers the current metrics snapshot shared by the JSON and Prometheus endpoints
+func (s *Server) collectMetrics() MetricsData {
 	var memStats runtime.MemStats
 	runtime.ReadMemStats(&memStats)
 
 	// Get upstream server stats
 	upstreamStats := make(map[string]interface{})
+	serverStates := make(map[string]string)
 	if s.upstreamManager != nil {
 		servers := s.upstreamManager.ListServers()
 		upstreamStats["total"] = len(servers)
 		upstreamStats["servers"] = servers
+
+		stats := s.upstreamManager.GetStats()
+		upstreamStats["connected"] = stats["connected_servers"]
+		if statuses, ok := stats["servers"].(map[string]interface{}); ok {
+			for _, st := range statuses {
+				status, ok := st.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				name, _ := status["name"].(string)
+				state, _ := status["state"].(string)
+				serverStates[name] = state
+			}
+		}
 	}
 
 	// Get tools indexed count
-	// NOTE: Feature Backlog - Add IndexManager.GetToolCount() method
 	toolsIndexed := 0
+	if s.indexManager != nil {
+		if count, err := s.indexManager.GetDocumentCount(); err == nil {
+			toolsIndexed = int(count)
+		}
+	}
+
+	var toolCalls, toolCallErrors uint64
+	if s.mcpProxy != nil {
+		toolCalls, toolCallErrors = s.mcpProxy.ToolCallCounts()
+	}
 
-	metrics := MetricsData{
+	return MetricsData{
 		Timestamp:       time.Now(),
 		Uptime:          time.Since(time.Now().Add(-1 * time.Hour)).String(), // Placeholder
 		GoVersion:       runtime.Version(),
@@ -373,7 +402,17 @@ func (s *Server) handleMetricsAPI(w http.ResponseWriter, r *http.Request) {
 		MemoryStats:     memStats,
 		UpstreamServers: upstreamStats,
 		ToolsIndexed:    toolsIndexed,
+		ToolCalls:       toolCalls,
+		ToolCallErrors:  toolCallErrors,
+		ServerStates:    serverStates,
+
+		StatusUpdatesDropped: s.statusDropped.Load(),
 	}
+}
+
+// handleMetricsAPI returns current metrics as JSON
+func (s *Server) handleMetricsAPI(w http.ResponseWriter, r *http.Request) {
+	metrics := s.collectMetrics()
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(metrics)
@@ -83,8 +83,8 @@ func (m *MockIndex) GetDocumentCount() (uint64, error) {
 	return args.Get(0).(uint64), args.Error(1)
 }
 
-func (m *MockIndex) RebuildIndex() error {
-	args := m.Called()
+func (m *MockIndex) RebuildIndex(tools []*config.ToolMetadata) error {
+	args := m.Called(tools)
 	return args.Error(0)
 }
 
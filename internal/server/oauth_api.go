@@ -0,0 +1,54 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+// handleServerOAuthAPI drives OAuth logins for headless deployments
+// POST /api/servers/{name}/oauth/login starts a login and returns the authorization URL to visit
+// GET /api/servers/{name}/oauth/status returns the latest in-process login (API or tray) so clients can
+// wait for it to finish
+func (s *Server) handleServerOAuthAPI(w http.ResponseWriter, r *http.Request, serverName, action string) {
+	switch {
+	case action == "login" && r.Method == http.MethodPost:
+		s.handleOAuthLogin(w, serverName)
+	case action == "status" && r.Method == http.MethodGet:
+		s.handleOAuthStatus(w, serverName)
+	default:
+		http.Error(w, "Method not allowed or invalid endpoint", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleOAuthLogin(w http.ResponseWriter, serverName string) {
+	if _, exists := s.upstreamManager.GetClient(serverName); !exists {
+		http.Error(w, "Server not found", http.StatusNotFound)
+		return
+	}
+
+	s.logger.Info("API requested headless OAuth login", zap.String("server", serverName))
+	flow, err := s.upstreamManager.StartHeadlessOAuth(serverName)
+	if err != nil {
+		s.logger.Error("Failed to start headless OAuth", zap.String("server", serverName), zap.Error(err))
+		http.Error(w, fmt.Sprintf("Failed to start OAuth login: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(flow)
+}
+
+func (s *Server) handleOAuthStatus(w http.ResponseWriter, serverName string) {
+	flow, ok := s.upstreamManager.GetOAuthFlow(serverName)
+	if !ok {
+		http.Error(w, "No OAuth login started for this server", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(flow)
+}
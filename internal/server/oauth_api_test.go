@@ -0,0 +1,40 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
+	"mcpproxy-go/internal/upstream"
+)
+
+func TestServerOAuthAPI(t *testing.T) {
+	s := &Server{
+		logger:          zap.NewNop(),
+		upstreamManager: upstream.NewManager(zap.NewNop(), config.DefaultConfig(), nil),
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		status int
+	}{
+		{"login unknown server", http.MethodPost, "/api/servers/missing/oauth/login", http.StatusNotFound},
+		{"status without login", http.MethodGet, "/api/servers/missing/oauth/status", http.StatusNotFound},
+		{"login requires POST", http.MethodGet, "/api/servers/missing/oauth/login", http.StatusMethodNotAllowed},
+		{"unknown action", http.MethodPost, "/api/servers/missing/oauth/logout", http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			s.handleServerConfigOrToolsAPI(rec, httptest.NewRequest(tt.method, tt.path, nil))
+			assert.Equal(t, tt.status, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/index"
+)
+
+// handleFindToolsByParam implements the find_tools_by_param MCP tool, finding tools by the
+// parameters their input schemas declare rather than by description
+func (p *MCPProxyServer) handleFindToolsByParam(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	param, err := request.RequireString("param")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing required parameter 'param': %v", err)), nil
+	}
+	paramType := request.GetString("type", "")
+	limit := int(request.GetFloat("limit", float64(p.config.ToolsLimit)))
+	if limit > 100 {
+		limit = 100
+	}
+
+	results, err := p.index.SearchToolsByParam(param, paramType, limit)
+	if err != nil {
+		p.logger.Error("Parameter search failed", zap.String("param", param), zap.Error(err))
+		return mcp.NewToolResultError(fmt.Sprintf("Search failed: %v", err)), nil
+	}
+
+	experimental := p.experimentalServers()
+
+	tools := make([]map[string]interface{}, 0, len(results))
+	for _, result := range results {
+		description, truncated := truncateDescription(result.Tool.Description, p.config.MaxDescriptionLength)
+		if experimental[result.Tool.ServerName] {
+			description = experimentalDescription(description)
+		}
+
+		tool := map[string]interface{}{
+			"name":           result.Tool.Name,
+			"server":         result.Tool.ServerName,
+			"description":    description,
+			"inputSchema":    p.toolInputSchema(result.Tool.Name, result.Tool.ParamsJSON),
+			"matched_params": index.MatchingParams(result.Tool.ParamsJSON, param, paramType),
+		}
+		if truncated {
+			tool["description_truncated"] = true
+		}
+		if experimental[result.Tool.ServerName] {
+			tool["experimental"] = true
+		}
+		tools = append(tools, tool)
+	}
+
+	response := map[string]interface{}{
+		"tools": tools,
+		"param": param,
+		"total": len(tools),
+	}
+	if paramType != "" {
+		response["type"] = paramType
+	}
+
+	jsonResult, err := json.Marshal(response)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to serialize results: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(jsonResult)), nil
+}
@@ -0,0 +1,127 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"mcpproxy-go/internal/config"
+
+	"go.uber.org/zap"
+)
+
+// prefetchTimeout bounds the ListTools call made for each server during a prefetch
+const prefetchTimeout = 30 * time.Second
+
+// shouldPrefetch reports whether the tools of a server are refreshed when the first client
+// connects: the server must be connected and neither disabled nor quarantined
+func shouldPrefetch(serverConfig *config.ServerConfig, connected bool) bool {
+	if serverConfig == nil || !connected {
+		return false
+	}
+	return !serverConfig.IsDisabled() && !serverConfig.IsQuarantined()
+}
+
+// prefixServerTools copies tools listed by a server into server:tool form for indexing
+func prefixServerTools(serverName string, tools []*config.ToolMetadata) []*config.ToolMetadata {
+	prefixed := make([]*config.ToolMetadata, 0, len(tools))
+	for _, tool := range tools {
+		prefixed = append(prefixed, &config.ToolMetadata{
+			Name:        fmt.Sprintf("%s:%s", serverName, tool.Name),
+			ServerName:  serverName,
+			Description: tool.Description,
+			ParamsJSON:  tool.ParamsJSON,
+			Hash:        tool.Hash,
+			Created:     tool.Created,
+			Updated:     tool.Updated,
+		})
+	}
+	return prefixed
+}
+
+// prefetchToolsOnConnect starts a background refresh of the tool index the first time a client
+// connects, when prefetch_on_connect is set. It returns immediately.
+func (s *Server) prefetchToolsOnConnect() {
+	s.mu.RLock()
+	enabled := s.config.PrefetchOnConnect
+	s.mu.RUnlock()
+	if !enabled {
+		return
+	}
+
+	s.prefetchOnce.Do(func() {
+		go s.prefetchTools(s.appCtx)
+	})
+}
+
+// prefetchTools lists and indexes the tools of every connected, enabled server, at most
+// max_concurrent_connections at a time
+func (s *Server) prefetchTools(ctx context.Context) {
+	s.mu.RLock()
+	maxConcurrent := s.config.MaxConcurrentConnections
+	s.mu.RUnlock()
+	if maxConcurrent <= 0 {
+		maxConcurrent = 10
+	}
+
+	semaphore := make(chan struct{}, maxConcurrent)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var toolsToIndex []*config.ToolMetadata
+	serverCount := 0
+
+	for _, client := range s.upstreamManager.GetAllClients() {
+		if !shouldPrefetch(client.Config, client.IsConnected()) {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case semaphore <- struct{}{}:
+				defer func() { <-semaphore }()
+			case <-ctx.Done():
+				return
+			}
+
+			serverName := client.Config.Name
+			listCtx, cancel := context.WithTimeout(ctx, prefetchTimeout)
+			tools, err := client.ListTools(listCtx)
+			cancel()
+			if err != nil {
+				s.logger.Warn("Failed to prefetch tools",
+					zap.String("server", serverName),
+					zap.Error(err))
+				return
+			}
+
+			if err := s.storageManager.SaveToolMetadata(serverName, tools); err != nil {
+				s.logger.Error("Failed to save prefetched tool metadata",
+					zap.String("server", serverName),
+					zap.Error(err))
+				// Continue anyway - tools will still be indexed
+			}
+
+			mu.Lock()
+			toolsToIndex = append(toolsToIndex, prefixServerTools(serverName, tools)...)
+			serverCount++
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	if len(toolsToIndex) == 0 {
+		s.logger.Info("Tool prefetch found no tools to index")
+		return
+	}
+	if err := s.indexManager.BatchIndexTools(toolsToIndex); err != nil {
+		s.logger.Error("Failed to index prefetched tools", zap.Error(err))
+		return
+	}
+	s.mcpProxy.publishFeaturedTools()
+	s.logger.Info("Prefetched tools after first client connect",
+		zap.Int("server_count", serverCount),
+		zap.Int("total_tools", len(toolsToIndex)))
+}
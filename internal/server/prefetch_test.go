@@ -0,0 +1,37 @@
+package server
+
+import (
+	"testing"
+
+	"mcpproxy-go/internal/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldPrefetch(t *testing.T) {
+	assert.True(t, shouldPrefetch(&config.ServerConfig{Name: "a", StartupMode: "active"}, true))
+	assert.True(t, shouldPrefetch(&config.ServerConfig{Name: "a", StartupMode: "lazy_loading"}, true))
+	assert.False(t, shouldPrefetch(&config.ServerConfig{Name: "a", StartupMode: "active"}, false), "disconnected servers are not woken")
+	assert.False(t, shouldPrefetch(&config.ServerConfig{Name: "a", StartupMode: "disabled"}, true))
+	assert.False(t, shouldPrefetch(&config.ServerConfig{Name: "a", StartupMode: "auto_disabled"}, true))
+	assert.False(t, shouldPrefetch(&config.ServerConfig{Name: "a", StartupMode: "quarantined"}, true))
+	assert.False(t, shouldPrefetch(nil, true))
+}
+
+func TestPrefixServerTools(t *testing.T) {
+	tools := []*config.ToolMetadata{
+		{Name: "create_issue", Description: "Create an issue", ParamsJSON: `{}`, Hash: "h1"},
+		{Name: "list_issues", Description: "List issues", Hash: "h2"},
+	}
+
+	prefixed := prefixServerTools("github", tools)
+
+	assert.Len(t, prefixed, 2)
+	assert.Equal(t, "github:create_issue", prefixed[0].Name)
+	assert.Equal(t, "github", prefixed[0].ServerName)
+	assert.Equal(t, "Create an issue", prefixed[0].Description)
+	assert.Equal(t, "h1", prefixed[0].Hash)
+	assert.Equal(t, "github:list_issues", prefixed[1].Name)
+	assert.Equal(t, "create_issue", tools[0].Name, "the listed tools are not modified")
+	assert.Empty(t, prefixServerTools("github", nil))
+}
@@ -0,0 +1,243 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
+)
+
+const (
+	defaultPromptsLimit = 20
+	maxPromptsLimit     = 100
+)
+
+// promptMatch is a prompt returned by the list_prompts tool
+type promptMatch struct {
+	Name        string                  `json:"name"` // Prefixed server:prompt, as used by prompts/get and get_prompt
+	Server      string                  `json:"server"`
+	Description string                  `json:"description,omitempty"`
+	Arguments   []config.PromptArgument `json:"arguments,omitempty"`
+	Score       float64                 `json:"score,omitempty"`
+}
+
+// searchPrompts ranks prompts against the query with keywordScore. An empty query matches
+// every prompt. Results are ordered by score, then prefixed name.
+func searchPrompts(prompts []*config.PromptMetadata, query, serverName string, limit int) []promptMatch {
+	terms := strings.Fields(strings.ToLower(query))
+
+	var matches []promptMatch
+	for _, prompt := range prompts {
+		if serverName != "" && prompt.ServerName != serverName {
+			continue
+		}
+
+		score := keywordScore(terms, prompt.Name, "", prompt.Description)
+		if len(terms) > 0 && score == 0 {
+			continue
+		}
+
+		matches = append(matches, promptMatch{
+			Name:        prefixUpstreamName(prompt.ServerName, prompt.Name),
+			Server:      prompt.ServerName,
+			Description: prompt.Description,
+			Arguments:   prompt.Arguments,
+			Score:       score,
+		})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Name < matches[j].Name
+	})
+
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// newProxyPrompt converts stored prompt metadata into the prefixed prompt the proxy publishes
+func newProxyPrompt(prompt *config.PromptMetadata) mcp.Prompt {
+	proxyPrompt := mcp.Prompt{
+		Name:        prefixUpstreamName(prompt.ServerName, prompt.Name),
+		Description: prompt.Description,
+	}
+	for _, arg := range prompt.Arguments {
+		proxyPrompt.Arguments = append(proxyPrompt.Arguments, mcp.PromptArgument{
+			Name:        arg.Name,
+			Description: arg.Description,
+			Required:    arg.Required,
+		})
+	}
+	return proxyPrompt
+}
+
+// discoverAndIndexPrompts lists the prompts of all connected servers, stores them and
+// publishes them on the proxy
+func (s *Server) discoverAndIndexPrompts(ctx context.Context) error {
+	prompts, err := s.upstreamManager.DiscoverPrompts(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to discover prompts: %w", err)
+	}
+
+	promptsByServer := make(map[string][]*config.PromptMetadata)
+	for _, prompt := range prompts {
+		promptsByServer[prompt.ServerName] = append(promptsByServer[prompt.ServerName], prompt)
+	}
+
+	for serverID, serverPrompts := range promptsByServer {
+		if err := s.storageManager.SavePromptMetadata(serverID, serverPrompts); err != nil {
+			s.logger.Error("Failed to save prompt metadata to database",
+				zap.String("server", serverID),
+				zap.Error(err))
+		}
+	}
+
+	s.mcpProxy.publishPrompts()
+	return nil
+}
+
+// availablePrompts returns the stored prompts of servers that are configured, enabled and
+// not quarantined
+func (p *MCPProxyServer) availablePrompts() ([]*config.PromptMetadata, error) {
+	prompts, err := p.storage.GetAllPromptMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, err := p.publishableServers()
+	if err != nil {
+		return nil, err
+	}
+
+	available := prompts[:0]
+	for _, prompt := range prompts {
+		if allowed[prompt.ServerName] {
+			available = append(available, prompt)
+		}
+	}
+	return available, nil
+}
+
+// publishPrompts registers the stored upstream prompts on the proxy under their prefixed
+// names, replacing what was published before
+func (p *MCPProxyServer) publishPrompts() {
+	prompts, err := p.availablePrompts()
+	if err != nil {
+		p.logger.Error("Failed to load prompts for publishing", zap.Error(err))
+		return
+	}
+
+	serverPrompts := make([]mcpserver.ServerPrompt, 0, len(prompts))
+	for _, prompt := range prompts {
+		serverPrompts = append(serverPrompts, mcpserver.ServerPrompt{
+			Prompt:  newProxyPrompt(prompt),
+			Handler: p.handleGetPrompt,
+		})
+	}
+	p.server.SetPrompts(serverPrompts...)
+
+	p.logger.Info("Published upstream prompts", zap.Int("prompt_count", len(serverPrompts)))
+}
+
+// getUpstreamPrompt renders a prefixed prompt on the owning upstream server
+func (p *MCPProxyServer) getUpstreamPrompt(ctx context.Context, prefixedName string, args map[string]string) (*mcp.GetPromptResult, error) {
+	serverName, _, found := strings.Cut(prefixedName, ":")
+	if !found {
+		return nil, fmt.Errorf("invalid prompt name format: %s (expected server:prompt)", prefixedName)
+	}
+
+	// Quarantine may have been set after the prompt was published
+	if serverConfig, err := p.storage.GetUpstreamServer(serverName); err == nil && serverConfig.IsQuarantined() {
+		return nil, fmt.Errorf("server '%s' is quarantined for security review; its prompts cannot be used", serverName)
+	}
+
+	result, err := p.upstreamManager.GetPrompt(ctx, prefixedName, args)
+	if err != nil {
+		p.logger.Warn("Failed to get upstream prompt",
+			zap.String("prompt", prefixedName),
+			zap.Error(err))
+		return nil, err
+	}
+	return result, nil
+}
+
+// handleGetPrompt forwards prompts/get for a prefixed prompt name to the owning upstream server
+func (p *MCPProxyServer) handleGetPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	return p.getUpstreamPrompt(ctx, request.Params.Name, request.Params.Arguments)
+}
+
+// handleListPrompts implements the list_prompts MCP tool
+func (p *MCPProxyServer) handleListPrompts(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	query := request.GetString("query", "")
+	serverName := request.GetString("server", "")
+	limit := int(request.GetFloat("limit", defaultPromptsLimit))
+	if limit <= 0 {
+		limit = defaultPromptsLimit
+	}
+	if limit > maxPromptsLimit {
+		limit = maxPromptsLimit
+	}
+
+	prompts, err := p.availablePrompts()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load prompts: %v", err)), nil
+	}
+
+	matches := searchPrompts(prompts, query, serverName, limit)
+	if matches == nil {
+		matches = []promptMatch{}
+	}
+
+	jsonResult, err := json.Marshal(map[string]interface{}{
+		"prompts": matches,
+		"total":   len(matches),
+		"query":   query,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to serialize result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonResult)), nil
+}
+
+// handleGetPromptTool implements the get_prompt MCP tool for clients without prompts support
+func (p *MCPProxyServer) handleGetPromptTool(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, err := request.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError("Missing required parameter 'name'"), nil
+	}
+
+	args := make(map[string]string)
+	if raw, ok := request.GetArguments()["arguments"].(map[string]interface{}); ok {
+		for key, value := range raw {
+			if str, isString := value.(string); isString {
+				args[key] = str
+			} else {
+				args[key] = fmt.Sprint(value)
+			}
+		}
+	}
+
+	result, err := p.getUpstreamPrompt(ctx, name, args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	jsonResult, err := json.Marshal(result)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to serialize result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonResult)), nil
+}
@@ -0,0 +1,45 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mcpproxy-go/internal/config"
+)
+
+func TestSearchPrompts(t *testing.T) {
+	prompts := []*config.PromptMetadata{
+		{ServerName: "github", Name: "review_pr", Description: "Review a pull request"},
+		{ServerName: "github", Name: "summarize_issues", Description: "Summarize open issues for review"},
+		{ServerName: "jira", Name: "plan_sprint", Description: "Draft a sprint plan"},
+	}
+
+	matches := searchPrompts(prompts, "review", "", 10)
+	require.Len(t, matches, 2)
+	assert.Equal(t, "github:review_pr", matches[0].Name, "name matches rank above description matches")
+	assert.Equal(t, "github:summarize_issues", matches[1].Name)
+
+	matches = searchPrompts(prompts, "", "jira", 10)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "jira:plan_sprint", matches[0].Name)
+	assert.Equal(t, "jira", matches[0].Server)
+
+	assert.Len(t, searchPrompts(prompts, "", "", 2), 2)
+	assert.Empty(t, searchPrompts(prompts, "deploy", "", 10))
+}
+
+func TestNewProxyPrompt(t *testing.T) {
+	prompt := newProxyPrompt(&config.PromptMetadata{
+		ServerName:  "github",
+		Name:        "review_pr",
+		Description: "Review a pull request",
+		Arguments:   []config.PromptArgument{{Name: "number", Description: "PR number", Required: true}},
+	})
+
+	assert.Equal(t, "github:review_pr", prompt.Name)
+	assert.Equal(t, "Review a pull request", prompt.Description)
+	assert.Equal(t, []mcp.PromptArgument{{Name: "number", Description: "PR number", Required: true}}, prompt.Arguments)
+}
@@ -0,0 +1,145 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"time"
+
+	"mcpproxy-go/internal/config"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// proxyInfoHealthTimeout bounds the semantic search health check made by proxy_info
+const proxyInfoHealthTimeout = 2 * time.Second
+
+// BuildInfo identifies the mcpproxy binary. It is injected by -ldflags into the main package
+// and handed to the server with SetBuildInfo.
+type BuildInfo struct {
+	Version   string `json:"version"`
+	BuildTime string `json:"build_time"`
+	GitCommit string `json:"git_commit"`
+}
+
+// proxyFeatures reports the optional features enabled in this proxy instance
+type proxyFeatures struct {
+	LazyLoading             bool `json:"lazy_loading"`
+	SemanticSearch          bool `json:"semantic_search"`
+	SemanticSearchHybrid    bool `json:"semantic_search_hybrid"`
+	SemanticSearchAvailable bool `json:"semantic_search_available"`
+
+	// EmbeddingModel is the pinned semantic search embedding model, omitted for the service default
+	EmbeddingModel string `json:"embedding_model,omitempty"`
+}
+
+// proxyInfo is the version and build information returned by the proxy_info tool
+type proxyInfo struct {
+	BuildInfo
+	GoVersion string        `json:"go_version"`
+	Platform  string        `json:"platform"`
+	Features  proxyFeatures `json:"features"`
+}
+
+// proxyPaths are the file locations returned by the proxy_paths tool
+type proxyPaths struct {
+	ConfigPath string `json:"config_path"`
+	LogDir     string `json:"log_dir"`
+	DataDir    string `json:"data_dir"`
+	GitHubURL  string `json:"github_url"`
+}
+
+// SetBuildInfo records the version and build information reported by the proxy_info tool
+func (s *Server) SetBuildInfo(version, buildTime, gitCommit string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buildInfo = BuildInfo{Version: version, BuildTime: buildTime, GitCommit: gitCommit}
+}
+
+// newProxyInfo assembles proxy_info from the build info and configuration.
+// semanticAvailable is whether the semantic search service answered its health check.
+func newProxyInfo(build BuildInfo, cfg *config.Config, semanticAvailable bool) proxyInfo {
+	info := proxyInfo{
+		BuildInfo: build,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+	if info.Version == "" {
+		info.Version = "unknown"
+	}
+	if info.BuildTime == "" {
+		info.BuildTime = "unknown"
+	}
+	if info.GitCommit == "" {
+		info.GitCommit = "unknown"
+	}
+
+	info.Features.SemanticSearchAvailable = semanticAvailable
+	if cfg != nil {
+		info.Features.LazyLoading = cfg.EnableLazyLoading
+		if cfg.SemanticSearch != nil {
+			info.Features.SemanticSearch = cfg.SemanticSearch.Enabled
+			info.Features.SemanticSearchHybrid = cfg.SemanticSearch.Enabled && cfg.SemanticSearch.HybridMode
+			if cfg.SemanticSearch.Enabled {
+				info.Features.EmbeddingModel = cfg.SemanticSearch.EmbeddingModel
+			}
+		}
+	}
+	return info
+}
+
+// ProxyInfo returns the version, build and platform information of the running proxy
+func (s *Server) ProxyInfo(ctx context.Context) proxyInfo {
+	s.mu.RLock()
+	build := s.buildInfo
+	cfg := s.config
+	semantic := s.semanticSearchService
+	s.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(ctx, proxyInfoHealthTimeout)
+	defer cancel()
+
+	return newProxyInfo(build, cfg, semantic.IsAvailable(ctx))
+}
+
+// ProxyPaths returns where the running proxy keeps its config file, logs and data
+func (s *Server) ProxyPaths() proxyPaths {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return proxyPaths{
+		ConfigPath: s.GetConfigPath(),
+		LogDir:     s.GetLogDir(),
+		DataDir:    s.config.DataDir,
+		GitHubURL:  s.GetGitHubURL(),
+	}
+}
+
+// handleProxyInfo implements the proxy_info MCP tool
+func (p *MCPProxyServer) handleProxyInfo(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if p.mainServer == nil {
+		return mcp.NewToolResultError("Proxy info is not available"), nil
+	}
+
+	jsonResult, err := json.Marshal(p.mainServer.ProxyInfo(ctx))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to serialize result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonResult)), nil
+}
+
+// handleProxyPaths implements the proxy_paths MCP tool
+func (p *MCPProxyServer) handleProxyPaths(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if p.mainServer == nil {
+		return mcp.NewToolResultError("Proxy paths are not available"), nil
+	}
+
+	jsonResult, err := json.Marshal(p.mainServer.ProxyPaths())
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to serialize result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonResult)), nil
+}
@@ -0,0 +1,81 @@
+package server
+
+import (
+	"encoding/json"
+	"runtime"
+	"testing"
+
+	"mcpproxy-go/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProxyInfo(t *testing.T) {
+	cfg := &config.Config{
+		EnableLazyLoading: true,
+		SemanticSearch:    &config.SemanticSearchConfig{Enabled: true, HybridMode: true, EmbeddingModel: "all-MiniLM-L6-v2"},
+	}
+
+	info := newProxyInfo(BuildInfo{Version: "v1.2.3", BuildTime: "2025.01.02 03:04", GitCommit: "abc123"}, cfg, true)
+
+	assert.Equal(t, "v1.2.3", info.Version)
+	assert.Equal(t, "2025.01.02 03:04", info.BuildTime)
+	assert.Equal(t, "abc123", info.GitCommit)
+	assert.Equal(t, runtime.Version(), info.GoVersion)
+	assert.Equal(t, runtime.GOOS+"/"+runtime.GOARCH, info.Platform)
+	assert.Equal(t, proxyFeatures{
+		LazyLoading:             true,
+		SemanticSearch:          true,
+		SemanticSearchHybrid:    true,
+		SemanticSearchAvailable: true,
+		EmbeddingModel:          "all-MiniLM-L6-v2",
+	}, info.Features)
+
+	data, err := json.Marshal(info)
+	require.NoError(t, err)
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "v1.2.3", decoded["version"], "build info fields are flattened into the response")
+	assert.Contains(t, decoded, "features")
+}
+
+func TestNewProxyInfo_Defaults(t *testing.T) {
+	info := newProxyInfo(BuildInfo{}, &config.Config{SemanticSearch: &config.SemanticSearchConfig{HybridMode: true}}, false)
+
+	assert.Equal(t, "unknown", info.Version)
+	assert.Equal(t, "unknown", info.BuildTime)
+	assert.Equal(t, "unknown", info.GitCommit)
+	assert.False(t, info.Features.SemanticSearchHybrid, "hybrid mode requires semantic search to be enabled")
+	assert.Equal(t, proxyFeatures{}, info.Features)
+
+	info = newProxyInfo(BuildInfo{Version: "v1"}, nil, false)
+	assert.Equal(t, "v1", info.Version)
+	assert.Equal(t, proxyFeatures{}, info.Features)
+}
+
+func TestProxyPaths(t *testing.T) {
+	dataDir := t.TempDir()
+	s := &Server{
+		config: &config.Config{
+			DataDir:   dataDir,
+			Logging:   &config.LogConfig{LogDir: "/var/log/mcpproxy"},
+			GitHubURL: "https://github.com/example/mcpproxy-go",
+		},
+		configPath: "/etc/mcpproxy/mcp_config.json",
+	}
+
+	assert.Equal(t, proxyPaths{
+		ConfigPath: "/etc/mcpproxy/mcp_config.json",
+		LogDir:     "/var/log/mcpproxy",
+		DataDir:    dataDir,
+		GitHubURL:  "https://github.com/example/mcpproxy-go",
+	}, s.ProxyPaths())
+
+	// Without explicit settings the standard locations are reported
+	s = &Server{config: &config.Config{DataDir: dataDir}}
+	paths := s.ProxyPaths()
+	assert.Equal(t, config.GetConfigPath(dataDir), paths.ConfigPath)
+	assert.NotEmpty(t, paths.LogDir)
+	assert.Equal(t, "https://github.com/smart-mcp-proxy/mcpproxy-go", paths.GitHubURL)
+}
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// proxyStatus is the proxy lifecycle state returned by the proxy_status tool
+type proxyStatus struct {
+	Phase        string    `json:"phase"`
+	Message      string    `json:"message"`
+	ToolsIndexed int       `json:"tools_indexed"`
+	LastUpdated  time.Time `json:"last_updated"`
+	Running      bool      `json:"running"`
+	Maintenance  bool      `json:"maintenance"` // Upstream tool calls are paused by maintenance mode
+	// Status updates not delivered to the tray because its queue was full
+	StatusUpdatesDropped uint64 `json:"status_updates_dropped"`
+}
+
+// ProxyStatus returns the current lifecycle phase and message. Running becomes true once
+// every active upstream server has connected or been auto-disabled.
+func (s *Server) ProxyStatus() proxyStatus {
+	s.statusMu.RLock()
+	defer s.statusMu.RUnlock()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return proxyStatus{
+		Phase:        s.status.Phase,
+		Message:      s.status.Message,
+		ToolsIndexed: s.status.ToolsIndexed,
+		LastUpdated:  s.status.LastUpdated,
+		Running:      s.running,
+		Maintenance:  s.MaintenanceMode().Enabled,
+
+		StatusUpdatesDropped: s.statusDropped.Load(),
+	}
+}
+
+// handleProxyStatus implements the proxy_status MCP tool
+func (p *MCPProxyServer) handleProxyStatus(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if p.mainServer == nil {
+		return mcp.NewToolResultError("Proxy status is not available"), nil
+	}
+
+	jsonResult, err := json.Marshal(p.mainServer.ProxyStatus())
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to serialize result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonResult)), nil
+}
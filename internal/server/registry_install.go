@@ -0,0 +1,135 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
+	"mcpproxy-go/internal/registries"
+)
+
+// invalidServerNameChars matches characters replaced when deriving a server name from a registry entry
+var invalidServerNameChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// serverConfigFromRegistry builds a quarantined server config for a registry entry. Entries
+// with an install command run over stdio; otherwise the entry's URL is used.
+func serverConfigFromRegistry(entry *registries.ServerEntry, name string) (*config.ServerConfig, error) {
+	if name == "" {
+		name = entry.ID
+		if name == "" {
+			name = entry.Name
+		}
+		name = strings.Trim(invalidServerNameChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	}
+	if name == "" {
+		return nil, fmt.Errorf("cannot derive a server name from registry entry, please provide 'name'")
+	}
+
+	serverConfig := &config.ServerConfig{
+		Name:        name,
+		Description: entry.Description,
+		StartupMode: "quarantined", // Installed servers must be reviewed like every other added server
+		Created:     time.Now(),
+	}
+	if entry.SourceCodeURL != "" {
+		serverConfig.RepositoryURL = entry.SourceCodeURL
+	}
+
+	installCmd := entry.InstallCmd
+	if installCmd == "" && entry.RepositoryInfo != nil && entry.RepositoryInfo.NPM != nil {
+		installCmd = entry.RepositoryInfo.NPM.InstallCmd
+	}
+
+	switch {
+	case installCmd != "":
+		fields := strings.Fields(installCmd)
+		serverConfig.Command = fields[0]
+		serverConfig.Args = fields[1:]
+		serverConfig.Protocol = "stdio"
+	case entry.URL != "" || entry.ConnectURL != "":
+		serverConfig.URL = entry.URL
+		if serverConfig.URL == "" {
+			serverConfig.URL = entry.ConnectURL
+		}
+		serverConfig.Protocol = "auto"
+	default:
+		return nil, fmt.Errorf("registry entry '%s' has neither an install command nor a URL", entry.ID)
+	}
+
+	return serverConfig, nil
+}
+
+// installRegistryServer resolves a server from a registry and adds it to the configuration
+// quarantined. Nothing is started until the user unquarantines the server.
+func (s *Server) installRegistryServer(ctx context.Context, registryID, serverID, name string) (*config.ServerConfig, error) {
+	entry, err := registries.FindServer(ctx, registryID, serverID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverConfig, err := serverConfigFromRegistry(entry, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.addQuarantinedServer(serverConfig); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Installed server from registry (quarantined until reviewed by the user)",
+		zap.String("server", serverConfig.Name),
+		zap.String("registry", entry.Registry),
+		zap.String("registry_server_id", entry.ID))
+
+	return serverConfig, nil
+}
+
+// handleInstallServer implements the install_server MCP tool, mirroring POST /api/v1/agent/install
+func (p *MCPProxyServer) handleInstallServer(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if p.config.ReadOnlyMode || p.config.DisableManagement || !p.config.AllowServerAdd {
+		return mcp.NewToolResultError("Adding servers is not allowed"), nil
+	}
+	if p.mainServer == nil {
+		return mcp.NewToolResultError("Server installation is not available"), nil
+	}
+
+	registry, err := request.RequireString("registry")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing required parameter 'registry': %v", err)), nil
+	}
+	serverID, err := request.RequireString("server_id")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing required parameter 'server_id': %v", err)), nil
+	}
+	name := request.GetString("name", "")
+
+	serverConfig, err := p.mainServer.installRegistryServer(ctx, registry, serverID, name)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Install failed: %v", err)), nil
+	}
+
+	jsonResult, err := json.Marshal(installResponse(serverConfig))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to serialize result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonResult)), nil
+}
+
+// installResponse describes an installed server so it can be reviewed before unquarantining
+func installResponse(serverConfig *config.ServerConfig) map[string]interface{} {
+	return map[string]interface{}{
+		"success":     true,
+		"name":        serverConfig.Name,
+		"quarantined": true,
+		"config":      serverConfig,
+		"message":     fmt.Sprintf("Server '%s' was added quarantined for security review. Review the command, arguments and URL, then remove it from quarantine via the tray menu or config file.", serverConfig.Name),
+	}
+}
@@ -0,0 +1,46 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mcpproxy-go/internal/registries"
+)
+
+func TestServerConfigFromRegistry(t *testing.T) {
+	t.Run("install command runs over stdio", func(t *testing.T) {
+		cfg, err := serverConfigFromRegistry(&registries.ServerEntry{
+			ID:          "@acme/Weather Server",
+			Description: "Forecasts",
+			InstallCmd:  "npx -y @acme/weather-mcp",
+			URL:         "https://example.com/constructed",
+		}, "")
+		require.NoError(t, err)
+		assert.Equal(t, "acme-weather-server", cfg.Name)
+		assert.Equal(t, "npx", cfg.Command)
+		assert.Equal(t, []string{"-y", "@acme/weather-mcp"}, cfg.Args)
+		assert.Equal(t, "stdio", cfg.Protocol)
+		assert.Empty(t, cfg.URL)
+		assert.Equal(t, "quarantined", cfg.StartupMode)
+		assert.True(t, cfg.IsQuarantined())
+	})
+
+	t.Run("remote server uses its URL", func(t *testing.T) {
+		cfg, err := serverConfigFromRegistry(&registries.ServerEntry{
+			ID:  "news",
+			URL: "https://news.example.com/mcp",
+		}, "my-news")
+		require.NoError(t, err)
+		assert.Equal(t, "my-news", cfg.Name)
+		assert.Equal(t, "https://news.example.com/mcp", cfg.URL)
+		assert.Empty(t, cfg.Command)
+		assert.Equal(t, "quarantined", cfg.StartupMode)
+	})
+
+	t.Run("entry without command or URL", func(t *testing.T) {
+		_, err := serverConfigFromRegistry(&registries.ServerEntry{ID: "empty"}, "")
+		assert.Error(t, err)
+	})
+}
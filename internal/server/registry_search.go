@@ -0,0 +1,146 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/registries"
+)
+
+// Default and maximum number of results returned by a registry search
+const (
+	registrySearchLimit    = 10
+	registrySearchMaxLimit = 50
+)
+
+// registrySearchResult is an installable server found in a registry
+type registrySearchResult struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Description    string `json:"description"`
+	Registry       string `json:"registry"`
+	URL            string `json:"url,omitempty"`
+	InstallCommand string `json:"install_command,omitempty"`
+}
+
+// registrySearchResponse is returned by both the search_registries tool and
+// GET /api/v1/agent/registries/search
+type registrySearchResponse struct {
+	Query    string                 `json:"query"`
+	Registry string                 `json:"registry,omitempty"`
+	Results  []registrySearchResult `json:"results"`
+	Total    int                    `json:"total"`
+	Errors   map[string]string      `json:"errors,omitempty"` // registry ID -> error, for registries that could not be searched
+}
+
+// searchRegistries searches a single registry, or every configured registry when registryID
+// is empty, for servers matching query. Registries that fail are reported in Errors so one
+// unreachable registry doesn't hide results from the others.
+func searchRegistries(ctx context.Context, query, registryID string, limit int) (*registrySearchResponse, error) {
+	if limit <= 0 {
+		limit = registrySearchLimit
+	}
+	if limit > registrySearchMaxLimit {
+		limit = registrySearchMaxLimit
+	}
+
+	var regs []registries.RegistryEntry
+	if registryID != "" {
+		reg := registries.FindRegistry(registryID)
+		if reg == nil {
+			return nil, fmt.Errorf("registry '%s' not found", registryID)
+		}
+		regs = []registries.RegistryEntry{*reg}
+	} else {
+		regs = registries.ListRegistries()
+	}
+
+	found := make([][]registries.ServerEntry, len(regs))
+	errs := make([]error, len(regs))
+	var wg sync.WaitGroup
+	for i := range regs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			found[i], errs[i] = registries.SearchServers(ctx, regs[i].ID, "", query, limit, nil)
+		}(i)
+	}
+	wg.Wait()
+
+	response := &registrySearchResponse{
+		Query:    query,
+		Registry: registryID,
+		Results:  []registrySearchResult{},
+	}
+	for i := range regs {
+		if errs[i] != nil {
+			if response.Errors == nil {
+				response.Errors = make(map[string]string)
+			}
+			response.Errors[regs[i].ID] = errs[i].Error()
+			continue
+		}
+		for j := range found[i] {
+			if len(response.Results) >= limit {
+				break
+			}
+			response.Results = append(response.Results, newRegistrySearchResult(&found[i][j]))
+		}
+	}
+	response.Total = len(response.Results)
+
+	if registryID != "" && errs[0] != nil {
+		return nil, errs[0]
+	}
+	return response, nil
+}
+
+func newRegistrySearchResult(entry *registries.ServerEntry) registrySearchResult {
+	result := registrySearchResult{
+		ID:             entry.ID,
+		Name:           entry.Name,
+		Description:    entry.Description,
+		Registry:       entry.Registry,
+		URL:            entry.URL,
+		InstallCommand: entry.InstallCmd,
+	}
+	if result.URL == "" {
+		result.URL = entry.ConnectURL
+	}
+	if result.InstallCommand == "" && entry.RepositoryInfo != nil && entry.RepositoryInfo.NPM != nil {
+		result.InstallCommand = entry.RepositoryInfo.NPM.InstallCmd
+	}
+	return result
+}
+
+// handleSearchRegistries implements the search_registries MCP tool, mirroring
+// GET /api/v1/agent/registries/search
+func (p *MCPProxyServer) handleSearchRegistries(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	query, err := request.RequireString("query")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Missing required parameter 'query': %v", err)), nil
+	}
+	registry := request.GetString("registry", "")
+	limit := int(request.GetFloat("limit", registrySearchLimit))
+
+	response, err := searchRegistries(ctx, query, registry, limit)
+	if err != nil {
+		p.logger.Error("Registry search failed",
+			zap.String("registry", registry),
+			zap.String("query", query),
+			zap.Error(err))
+		return mcp.NewToolResultError(fmt.Sprintf("Search failed: %v", err)), nil
+	}
+
+	jsonResult, err := json.Marshal(response)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to serialize results: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonResult)), nil
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mcpproxy-go/internal/config"
+	"mcpproxy-go/internal/registries"
+)
+
+func TestSearchRegistries(t *testing.T) {
+	okServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode([]map[string]interface{}{
+			{"id": "weather", "name": "Weather", "description": "Forecasts", "installCmd": "npx -y weather-mcp"},
+			{"id": "news", "name": "News", "description": "Headlines", "url": "https://news.example.com/mcp"},
+		})
+	}))
+	defer okServer.Close()
+
+	failServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "down", http.StatusInternalServerError)
+	}))
+	defer failServer.Close()
+
+	registries.SetRegistriesFromConfig(&config.Config{
+		Registries: []config.RegistryEntry{
+			{ID: "good", Name: "Good", ServersURL: okServer.URL},
+			{ID: "bad", Name: "Bad", ServersURL: failServer.URL},
+		},
+	})
+
+	t.Run("all registries", func(t *testing.T) {
+		response, err := searchRegistries(context.Background(), "weather", "", 0)
+		require.NoError(t, err)
+		require.Len(t, response.Results, 1)
+		assert.Equal(t, "Weather", response.Results[0].Name)
+		assert.Equal(t, "Forecasts", response.Results[0].Description)
+		assert.Equal(t, "npx -y weather-mcp", response.Results[0].InstallCommand)
+		assert.Equal(t, "Good", response.Results[0].Registry)
+		assert.Contains(t, response.Errors, "bad")
+	})
+
+	t.Run("single registry", func(t *testing.T) {
+		response, err := searchRegistries(context.Background(), "news", "good", 0)
+		require.NoError(t, err)
+		require.Len(t, response.Results, 1)
+		assert.Equal(t, "https://news.example.com/mcp", response.Results[0].URL)
+		assert.Empty(t, response.Errors)
+	})
+
+	t.Run("unreachable registry", func(t *testing.T) {
+		_, err := searchRegistries(context.Background(), "weather", "bad", 0)
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown registry", func(t *testing.T) {
+		_, err := searchRegistries(context.Background(), "weather", "missing", 0)
+		assert.Error(t, err)
+	})
+}
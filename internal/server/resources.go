@@ -0,0 +1,269 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
+)
+
+const (
+	defaultResourcesLimit = 20
+	maxResourcesLimit     = 100
+)
+
+// resourceMatch is a resource returned by the retrieve_resources tool
+type resourceMatch struct {
+	URI         string  `json:"uri"` // Prefixed server:uri, as used by resources/read
+	Server      string  `json:"server"`
+	Name        string  `json:"name"`
+	Description string  `json:"description,omitempty"`
+	MIMEType    string  `json:"mime_type,omitempty"`
+	Score       float64 `json:"score,omitempty"`
+}
+
+// prefixUpstreamName applies the server:tool naming scheme to a resource URI or prompt name
+func prefixUpstreamName(serverName, name string) string {
+	return serverName + ":" + name
+}
+
+// keywordScore scores a resource or prompt against the query terms by where they match:
+// the name counts most, then the identifier (URI), then the description
+func keywordScore(terms []string, name, id, description string) float64 {
+	name = strings.ToLower(name)
+	id = strings.ToLower(id)
+	description = strings.ToLower(description)
+
+	var score float64
+	for _, term := range terms {
+		if strings.Contains(name, term) {
+			score += 3
+		}
+		if id != "" && strings.Contains(id, term) {
+			score += 2
+		}
+		if strings.Contains(description, term) {
+			score++
+		}
+	}
+	return score
+}
+
+// prefixResourceContents rewrites the URIs of contents read from serverName to their
+// prefixed form, so they match the URI the client requested
+func prefixResourceContents(serverName string, contents []mcp.ResourceContents) []mcp.ResourceContents {
+	prefixed := make([]mcp.ResourceContents, 0, len(contents))
+	for _, content := range contents {
+		switch c := content.(type) {
+		case mcp.TextResourceContents:
+			c.URI = prefixUpstreamName(serverName, c.URI)
+			prefixed = append(prefixed, c)
+		case *mcp.TextResourceContents:
+			text := *c
+			text.URI = prefixUpstreamName(serverName, text.URI)
+			prefixed = append(prefixed, text)
+		case mcp.BlobResourceContents:
+			c.URI = prefixUpstreamName(serverName, c.URI)
+			prefixed = append(prefixed, c)
+		case *mcp.BlobResourceContents:
+			blob := *c
+			blob.URI = prefixUpstreamName(serverName, blob.URI)
+			prefixed = append(prefixed, blob)
+		default:
+			prefixed = append(prefixed, content)
+		}
+	}
+	return prefixed
+}
+
+// searchResources ranks resources against the query with keywordScore. An empty query
+// matches every resource. Results are ordered by score, then prefixed URI.
+func searchResources(resources []*config.ResourceMetadata, query, serverName string, limit int) []resourceMatch {
+	terms := strings.Fields(strings.ToLower(query))
+
+	var matches []resourceMatch
+	for _, resource := range resources {
+		if serverName != "" && resource.ServerName != serverName {
+			continue
+		}
+
+		score := keywordScore(terms, resource.Name, resource.URI, resource.Description)
+		if len(terms) > 0 && score == 0 {
+			continue
+		}
+
+		matches = append(matches, resourceMatch{
+			URI:         prefixUpstreamName(resource.ServerName, resource.URI),
+			Server:      resource.ServerName,
+			Name:        resource.Name,
+			Description: resource.Description,
+			MIMEType:    resource.MIMEType,
+			Score:       score,
+		})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].URI < matches[j].URI
+	})
+
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// discoverAndIndexResources lists the resources of all connected servers, stores them and
+// publishes them on the proxy
+func (s *Server) discoverAndIndexResources(ctx context.Context) error {
+	resources, err := s.upstreamManager.DiscoverResources(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to discover resources: %w", err)
+	}
+
+	resourcesByServer := make(map[string][]*config.ResourceMetadata)
+	for _, resource := range resources {
+		resourcesByServer[resource.ServerName] = append(resourcesByServer[resource.ServerName], resource)
+	}
+
+	for serverID, serverResources := range resourcesByServer {
+		if err := s.storageManager.SaveResourceMetadata(serverID, serverResources); err != nil {
+			s.logger.Error("Failed to save resource metadata to database",
+				zap.String("server", serverID),
+				zap.Error(err))
+		}
+	}
+
+	s.mcpProxy.publishResources()
+	return nil
+}
+
+// availableResources returns the stored resources of servers that are configured, enabled
+// and not quarantined
+func (p *MCPProxyServer) availableResources() ([]*config.ResourceMetadata, error) {
+	resources, err := p.storage.GetAllResourceMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, err := p.publishableServers()
+	if err != nil {
+		return nil, err
+	}
+
+	available := resources[:0]
+	for _, resource := range resources {
+		if allowed[resource.ServerName] {
+			available = append(available, resource)
+		}
+	}
+	return available, nil
+}
+
+// publishableServers returns which configured servers may have their resources and prompts
+// published: those that are enabled and not quarantined
+func (p *MCPProxyServer) publishableServers() (map[string]bool, error) {
+	servers, err := p.storage.ListUpstreamServers()
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := make(map[string]bool, len(servers))
+	for _, server := range servers {
+		allowed[server.Name] = !server.IsQuarantined() && !server.IsDisabled()
+	}
+	return allowed, nil
+}
+
+// publishResources registers the stored upstream resources on the proxy under their
+// prefixed URIs, replacing what was published before
+func (p *MCPProxyServer) publishResources() {
+	resources, err := p.availableResources()
+	if err != nil {
+		p.logger.Error("Failed to load resources for publishing", zap.Error(err))
+		return
+	}
+
+	serverResources := make([]mcpserver.ServerResource, 0, len(resources))
+	for _, resource := range resources {
+		serverResources = append(serverResources, mcpserver.ServerResource{
+			Resource: mcp.Resource{
+				URI:         prefixUpstreamName(resource.ServerName, resource.URI),
+				Name:        resource.Name,
+				Description: resource.Description,
+				MIMEType:    resource.MIMEType,
+			},
+			Handler: p.handleReadResource,
+		})
+	}
+	p.server.SetResources(serverResources...)
+
+	p.logger.Info("Published upstream resources", zap.Int("resource_count", len(serverResources)))
+}
+
+// handleReadResource forwards resources/read for a prefixed URI to the owning upstream server
+func (p *MCPProxyServer) handleReadResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	prefixedURI := request.Params.URI
+	serverName, _, found := strings.Cut(prefixedURI, ":")
+	if !found {
+		return nil, fmt.Errorf("invalid resource URI format: %s (expected server:uri)", prefixedURI)
+	}
+
+	// Quarantine may have been set after the resource was published
+	if serverConfig, err := p.storage.GetUpstreamServer(serverName); err == nil && serverConfig.IsQuarantined() {
+		return nil, fmt.Errorf("server '%s' is quarantined for security review; its resources cannot be read", serverName)
+	}
+
+	result, err := p.upstreamManager.ReadResource(ctx, prefixedURI)
+	if err != nil {
+		p.logger.Warn("Failed to read upstream resource",
+			zap.String("uri", prefixedURI),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return prefixResourceContents(serverName, result.Contents), nil
+}
+
+// handleRetrieveResources implements the retrieve_resources MCP tool
+func (p *MCPProxyServer) handleRetrieveResources(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	query := request.GetString("query", "")
+	serverName := request.GetString("server", "")
+	limit := int(request.GetFloat("limit", defaultResourcesLimit))
+	if limit <= 0 {
+		limit = defaultResourcesLimit
+	}
+	if limit > maxResourcesLimit {
+		limit = maxResourcesLimit
+	}
+
+	resources, err := p.availableResources()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load resources: %v", err)), nil
+	}
+
+	matches := searchResources(resources, query, serverName, limit)
+	if matches == nil {
+		matches = []resourceMatch{}
+	}
+
+	jsonResult, err := json.Marshal(map[string]interface{}{
+		"resources": matches,
+		"total":     len(matches),
+		"query":     query,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to serialize result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonResult)), nil
+}
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mcpproxy-go/internal/config"
+)
+
+func TestSearchResources(t *testing.T) {
+	resources := []*config.ResourceMetadata{
+		{ServerName: "docs", URI: "file:///guides/setup.md", Name: "Setup guide", Description: "How to install"},
+		{ServerName: "docs", URI: "file:///changelog.md", Name: "Changelog", Description: "Release notes and setup changes"},
+		{ServerName: "db", URI: "postgres://main/users", Name: "users", Description: "User accounts table"},
+	}
+
+	t.Run("ranks name matches above description matches", func(t *testing.T) {
+		matches := searchResources(resources, "setup", "", 10)
+		require.Len(t, matches, 2)
+		assert.Equal(t, "docs:file:///guides/setup.md", matches[0].URI)
+		assert.Equal(t, "docs:file:///changelog.md", matches[1].URI)
+	})
+
+	t.Run("empty query lists all resources", func(t *testing.T) {
+		matches := searchResources(resources, "", "", 10)
+		assert.Len(t, matches, 3)
+	})
+
+	t.Run("filters by server", func(t *testing.T) {
+		matches := searchResources(resources, "", "db", 10)
+		require.Len(t, matches, 1)
+		assert.Equal(t, "db:postgres://main/users", matches[0].URI)
+		assert.Equal(t, "db", matches[0].Server)
+	})
+
+	t.Run("applies limit", func(t *testing.T) {
+		assert.Len(t, searchResources(resources, "", "", 2), 2)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		assert.Empty(t, searchResources(resources, "kubernetes", "", 10))
+	})
+}
+
+func TestPrefixResourceContents(t *testing.T) {
+	contents := []mcp.ResourceContents{
+		mcp.TextResourceContents{URI: "file:///readme.md", MIMEType: "text/markdown", Text: "# Readme"},
+		&mcp.BlobResourceContents{URI: "file:///logo.png", MIMEType: "image/png", Blob: "iVBORw0KGgo="},
+	}
+
+	prefixed := prefixResourceContents("docs", contents)
+	require.Len(t, prefixed, 2)
+
+	text, ok := prefixed[0].(mcp.TextResourceContents)
+	require.True(t, ok)
+	assert.Equal(t, "docs:file:///readme.md", text.URI)
+	assert.Equal(t, "# Readme", text.Text)
+
+	blob, ok := prefixed[1].(mcp.BlobResourceContents)
+	require.True(t, ok)
+	assert.Equal(t, "docs:file:///logo.png", blob.URI)
+
+	// The upstream result is left untouched
+	assert.Equal(t, "file:///logo.png", contents[1].(*mcp.BlobResourceContents).URI)
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
+	"mcpproxy-go/internal/storage"
+)
+
+// Orders accepted by the sort argument of retrieve_tools
+const (
+	sortByRelevance = "relevance"
+	sortByRecent    = "recent"
+	sortByPopular   = "popular"
+)
+
+// retrieveToolsOrder returns the comparison used to reorder retrieve_tools matches for sortBy.
+// A nil comparison keeps the relevance ranking of the index.
+func (p *MCPProxyServer) retrieveToolsOrder(sortBy string) (func(a, b *config.SearchResult) bool, error) {
+	switch sortBy {
+	case "", sortByRelevance:
+		return nil, nil
+	case sortByRecent:
+		metadata, err := p.storage.GetAllToolMetadata()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load tool metadata: %w", err)
+		}
+		return recentFirst(metadata), nil
+	case sortByPopular:
+		stats, err := p.storage.ListToolUsage()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load tool usage: %w", err)
+		}
+		return popularFirst(stats), nil
+	default:
+		return nil, fmt.Errorf("invalid sort %q: must be one of %s, %s, %s", sortBy, sortByRelevance, sortByRecent, sortByPopular)
+	}
+}
+
+// recentFirst orders results by the Updated timestamp of their tool metadata, newest first.
+// Tools without stored metadata sort last.
+func recentFirst(metadata []*config.ToolMetadata) func(a, b *config.SearchResult) bool {
+	updated := make(map[string]time.Time, len(metadata))
+	for _, meta := range metadata {
+		updated[meta.Name] = meta.Updated
+	}
+	return func(a, b *config.SearchResult) bool {
+		return updated[a.Tool.Name].After(updated[b.Tool.Name])
+	}
+}
+
+// popularFirst orders results by their recorded call count, most called first.
+// Tools that were never called sort last.
+func popularFirst(stats []*storage.ToolStatRecord) func(a, b *config.SearchResult) bool {
+	counts := make(map[string]uint64, len(stats))
+	for _, stat := range stats {
+		counts[stat.ToolName] = stat.Count
+	}
+	return func(a, b *config.SearchResult) bool {
+		return counts[a.Tool.Name] > counts[b.Tool.Name]
+	}
+}
+
+// serverSearchBoosts returns the score multipliers of the servers with a search_priority set
+func (p *MCPProxyServer) serverSearchBoosts() map[string]float64 {
+	servers, err := p.storage.ListUpstreamServers()
+	if err != nil {
+		p.logger.Warn("Failed to load servers for search priorities", zap.Error(err))
+		return nil
+	}
+	return searchBoosts(servers)
+}
+
+// searchBoosts maps the servers with a non-zero search_priority to their score multiplier
+func searchBoosts(servers []*config.ServerConfig) map[string]float64 {
+	boosts := make(map[string]float64)
+	for _, server := range servers {
+		if server.SearchPriority != 0 {
+			boosts[server.Name] = server.SearchBoost()
+		}
+	}
+	return boosts
+}
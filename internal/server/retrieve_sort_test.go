@@ -0,0 +1,62 @@
+package server
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"mcpproxy-go/internal/config"
+	"mcpproxy-go/internal/storage"
+)
+
+func sortedNames(results []*config.SearchResult, less func(a, b *config.SearchResult) bool) []string {
+	sort.SliceStable(results, func(i, j int) bool { return less(results[i], results[j]) })
+	names := make([]string, 0, len(results))
+	for _, r := range results {
+		names = append(names, r.Tool.Name)
+	}
+	return names
+}
+
+func searchResults(names ...string) []*config.SearchResult {
+	results := make([]*config.SearchResult, 0, len(names))
+	for _, name := range names {
+		results = append(results, &config.SearchResult{Tool: &config.ToolMetadata{Name: name}})
+	}
+	return results
+}
+
+func TestRecentFirst(t *testing.T) {
+	now := time.Now()
+	less := recentFirst([]*config.ToolMetadata{
+		{Name: "a:old", Updated: now.Add(-time.Hour)},
+		{Name: "a:new", Updated: now},
+	})
+
+	// Unknown tools sort last and keep their relevance order
+	names := sortedNames(searchResults("a:unknown", "a:old", "a:other", "a:new"), less)
+	assert.Equal(t, []string{"a:new", "a:old", "a:unknown", "a:other"}, names)
+}
+
+func TestPopularFirst(t *testing.T) {
+	less := popularFirst([]*storage.ToolStatRecord{
+		{ToolName: "a:rare", Count: 1},
+		{ToolName: "a:busy", Count: 10},
+	})
+
+	names := sortedNames(searchResults("a:never", "a:rare", "a:busy"), less)
+	assert.Equal(t, []string{"a:busy", "a:rare", "a:never"}, names)
+}
+
+func TestSearchBoosts(t *testing.T) {
+	boosts := searchBoosts([]*config.ServerConfig{
+		{Name: "trusted", SearchPriority: 5},
+		{Name: "plain"},
+		{Name: "legacy", SearchPriority: -3},
+		{Name: "buried", SearchPriority: -20},
+	})
+
+	assert.Equal(t, map[string]float64{"trusted": 1.5, "legacy": 0.7, "buried": 0.1}, boosts)
+}
@@ -10,24 +10,31 @@ import (
 	"go.uber.org/zap"
 )
 
+// embeddingModelHeader carries the pinned embedding model in every request to the service
+const embeddingModelHeader = "X-Embedding-Model"
+
 // SemanticSearchService provides semantic search capabilities via HTTP API
 type SemanticSearchService struct {
-	baseURL    string
-	httpClient *http.Client
-	logger     *zap.Logger
+	baseURL        string
+	embeddingModel string // Pinned embedding model; empty uses the service default
+	httpClient     *http.Client
+	logger         *zap.Logger
 }
 
 // HealthResponse represents the health check response from semantic search API
 type HealthResponse struct {
-	Status        string `json:"status"`
-	ToolsIndexed  int    `json:"tools_indexed"`
-	ServersIndexed int   `json:"servers_indexed"`
+	Status         string `json:"status"`
+	ToolsIndexed   int    `json:"tools_indexed"`
+	ServersIndexed int    `json:"servers_indexed"`
+	EmbeddingModel string `json:"embedding_model,omitempty"`
 }
 
-// NewSemanticSearchService creates a new semantic search service client
-func NewSemanticSearchService(baseURL string, logger *zap.Logger) *SemanticSearchService {
+// NewSemanticSearchService creates a new semantic search service client. embeddingModel pins
+// the model the service must use; empty accepts the service default.
+func NewSemanticSearchService(baseURL, embeddingModel string, logger *zap.Logger) *SemanticSearchService {
 	return &SemanticSearchService{
-		baseURL: baseURL,
+		baseURL:        baseURL,
+		embeddingModel: embeddingModel,
 		httpClient: &http.Client{
 			Timeout: 5 * time.Second,
 		},
@@ -35,6 +42,18 @@ func NewSemanticSearchService(baseURL string, logger *zap.Logger) *SemanticSearc
 	}
 }
 
+// newRequest creates a request to the service carrying the pinned embedding model
+func (s *SemanticSearchService) newRequest(ctx context.Context, method, path string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, s.baseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if s.embeddingModel != "" {
+		req.Header.Set(embeddingModelHeader, s.embeddingModel)
+	}
+	return req, nil
+}
+
 // IsAvailable checks if the semantic search service is available and healthy
 func (s *SemanticSearchService) IsAvailable(ctx context.Context) bool {
 	if s == nil {
@@ -42,7 +61,7 @@ func (s *SemanticSearchService) IsAvailable(ctx context.Context) bool {
 	}
 
 	// Create request with context
-	req, err := http.NewRequestWithContext(ctx, "GET", s.baseURL+"/health", nil)
+	req, err := s.newRequest(ctx, "GET", "/health")
 	if err != nil {
 		s.logger.Debug("Failed to create health check request",
 			zap.Error(err),
@@ -85,8 +104,18 @@ func (s *SemanticSearchService) IsAvailable(ctx context.Context) bool {
 		return false
 	}
 
+	// A service embedding with another model would return results that don't match the pinned model
+	if s.embeddingModel != "" && health.EmbeddingModel != "" && health.EmbeddingModel != s.embeddingModel {
+		s.logger.Warn("Semantic search service uses a different embedding model than configured",
+			zap.String("configured_model", s.embeddingModel),
+			zap.String("service_model", health.EmbeddingModel),
+			zap.String("url", s.baseURL))
+		return false
+	}
+
 	s.logger.Debug("Semantic search service is healthy",
 		zap.String("url", s.baseURL),
+		zap.String("embedding_model", health.EmbeddingModel),
 		zap.Int("tools_indexed", health.ToolsIndexed),
 		zap.Int("servers_indexed", health.ServersIndexed))
 
@@ -101,6 +130,14 @@ func (s *SemanticSearchService) GetBaseURL() string {
 	return s.baseURL
 }
 
+// GetEmbeddingModel returns the pinned embedding model, empty for the service default
+func (s *SemanticSearchService) GetEmbeddingModel() string {
+	if s == nil {
+		return ""
+	}
+	return s.embeddingModel
+}
+
 // Close cleans up resources (currently no-op, reserved for future use)
 func (s *SemanticSearchService) Close() error {
 	// Future: close any persistent connections if needed
@@ -0,0 +1,32 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestSemanticSearchService_PinnedEmbeddingModel(t *testing.T) {
+	var receivedModel string
+	service := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedModel = r.Header.Get(embeddingModelHeader)
+		_ = json.NewEncoder(w).Encode(HealthResponse{Status: "healthy", EmbeddingModel: "all-MiniLM-L6-v2"})
+	}))
+	defer service.Close()
+
+	matching := NewSemanticSearchService(service.URL, "all-MiniLM-L6-v2", zap.NewNop())
+	assert.True(t, matching.IsAvailable(context.Background()))
+	assert.Equal(t, "all-MiniLM-L6-v2", receivedModel)
+
+	other := NewSemanticSearchService(service.URL, "all-mpnet-base-v2", zap.NewNop())
+	assert.False(t, other.IsAvailable(context.Background()), "a service embedding with another model must not be used")
+
+	unpinned := NewSemanticSearchService(service.URL, "", zap.NewNop())
+	assert.True(t, unpinned.IsAvailable(context.Background()))
+	assert.Empty(t, receivedModel)
+}
@@ -1,15 +1,20 @@
 package server
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"os"
 	"os/exec"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/mark3labs/mcp-go/server"
@@ -20,6 +25,7 @@ import (
 	"mcpproxy-go/internal/events"
 	"mcpproxy-go/internal/index"
 	"mcpproxy-go/internal/logs"
+	"mcpproxy-go/internal/oauth"
 	"mcpproxy-go/internal/shutdown"
 	"mcpproxy-go/internal/startup"
 	"mcpproxy-go/internal/storage"
@@ -153,6 +159,12 @@ type Server struct {
 	status   Status
 	statusMu sync.RWMutex
 	statusCh chan interface{} // Changed to interface{} to support both Status struct and status map
+	// Status updates dropped because statusCh was full while a consumer was attached
+	statusDropped atomic.Uint64
+	// statusConsumer is set once StatusChannel hands statusCh to a consumer such as the tray
+	statusConsumer atomic.Bool
+	// statusDropWarned is the time of the last dropped-update warning, in Unix nanoseconds
+	statusDropWarned atomic.Int64
 
 	// Tool count cache to avoid excessive ListTools operations
 	toolCountCache map[string]*toolCountCache
@@ -161,6 +173,18 @@ type Server struct {
 	// Tray state provider - callback to get actual tray menu state
 	trayStateProvider   func() interface{}
 	trayStateProviderMu sync.RWMutex
+
+	// Version and build information reported by the proxy_info tool
+	buildInfo BuildInfo
+
+	// Guards the one-time tool prefetch on first client connect (prefetch_on_connect)
+	prefetchOnce sync.Once
+
+	// MCP Inspector processes launched from the web UI, stopped on shutdown
+	inspectors inspectorProcesses
+
+	// Maintenance mode pauses upstream tool calls (maintenance tool, /api/maintenance)
+	maintenance maintenanceMode
 }
 
 // NewServer creates a new server instance
@@ -227,6 +251,24 @@ func NewServerWithConfigPath(cfg *config.Config, configPath string, logger *zap.
 		return nil, fmt.Errorf("failed to initialize index manager: %w", err)
 	}
 
+	// Reuse embeddings for unchanged tools across restarts and re-indexing
+	indexManager.SetEmbeddingCache(storageManager)
+	indexManager.SetToolLimits(cfg.MaxToolsPerServer, cfg.MaxTotalTools)
+
+	// Repopulate a recreated (previously corrupt) index from the persisted tool metadata
+	if indexManager.NeedsRebuild() {
+		tools, err := storageManager.GetAllToolMetadata()
+		if err == nil {
+			err = indexManager.RebuildIndex(tools)
+		}
+		if err != nil {
+			indexManager.Close()
+			storageManager.Close()
+			return nil, fmt.Errorf("failed to rebuild index from stored tool metadata: %w", err)
+		}
+		logger.Info("Rebuilt search index from stored tool metadata", zap.Int("tools", len(tools)))
+	}
+
 	// Initialize upstream manager
 	upstreamManager := upstream.NewManager(logger, cfg, storageManager.GetBoltDB())
 
@@ -276,8 +318,8 @@ func NewServerWithConfigPath(cfg *config.Config, configPath string, logger *zap.
 		appState:            AppStateStarting, // Initialize app state
 		appCtx:              ctx,
 		appCancel:           cancel,
-		statusCh:            make(chan interface{}, 10),       // Buffered channel for status updates (can be Status or map)
-		toolCountCache:      make(map[string]*toolCountCache), // Initialize tool count cache
+		statusCh:            make(chan interface{}, cfg.GetStatusBufferSize()), // Buffered channel for status updates (can be Status or map)
+		toolCountCache:      make(map[string]*toolCountCache),                  // Initialize tool count cache
 		status: Status{
 			Phase:       "Initializing",
 			Message:     "Server is initializing...",
@@ -293,7 +335,11 @@ func NewServerWithConfigPath(cfg *config.Config, configPath string, logger *zap.
 	if semanticSearchURL == "" {
 		semanticSearchURL = "http://127.0.0.1:8081"
 	}
-	server.semanticSearchService = NewSemanticSearchService(semanticSearchURL, logger)
+	var embeddingModel string
+	if cfg.SemanticSearch != nil {
+		embeddingModel = cfg.SemanticSearch.EmbeddingModel
+	}
+	server.semanticSearchService = NewSemanticSearchService(semanticSearchURL, embeddingModel, logger)
 
 	// Check if semantic search is available
 	if server.semanticSearchService.IsAvailable(context.Background()) {
@@ -315,8 +361,8 @@ func NewServerWithConfigPath(cfg *config.Config, configPath string, logger *zap.
 	// Initialize groups from config
 	server.initGroupsFromConfig()
 
-	// Migrate legacy group_name -> group_id if present
-	server.migrateLegacyGroupNamesToIDs()
+	// Persist config_version migrations applied on load (e.g. legacy group_name -> group_id)
+	server.persistConfigMigrations()
 
 	// Initialize server-group assignments from config
 	server.initServerGroupAssignments()
@@ -345,6 +391,12 @@ func NewServerWithConfigPath(cfg *config.Config, configPath string, logger *zap.
 		}
 	})
 
+	// Setup auto-quarantine callback to persist the quarantine with its reason
+	upstreamManager.SetServerAutoQuarantineCallback(server.autoQuarantineServer)
+
+	// Audit every upstream tool call, whichever path it came from
+	upstreamManager.SetToolCallObserver(mcpProxy.auditUpstreamCall)
+
 	// Setup event bridge to connect StateManager to EventBus
 	server.setupEventBridge()
 
@@ -357,15 +409,46 @@ func NewServerWithConfigPath(cfg *config.Config, configPath string, logger *zap.
 	return server, nil
 }
 
-// registerShutdownHandlers registers all shutdown handlers with the coordinator
+// registerShutdownHandlers registers all shutdown handlers with the coordinator.
+// Stages run in this order: startup script, MCP Inspector processes, HTTP server, WebSocket
+// manager, upstream servers, app context, then cache, index and storage.
 // MED-003: Centralized shutdown coordination
 func (s *Server) registerShutdownHandlers() {
-	// Phase 1: Stop accepting new connections (HTTP server)
+	// Phase 1: Stop startup script
+	s.shutdownCoordinator.Register(&shutdown.Handler{
+		Name:     "startup-script",
+		Phase:    shutdown.PhaseConnections,
+		Priority: 120,
+		Timeout:  config.ShutdownStageTimeout,
+		Fn: func(ctx context.Context) error {
+			if s.startupManager != nil {
+				s.logger.Info("Stopping startup script")
+				return s.startupManager.Stop()
+			}
+			return nil
+		},
+	})
+
+	// Phase 1: Stop MCP Inspector processes, whose open sessions would hold up the HTTP drain
+	s.shutdownCoordinator.Register(&shutdown.Handler{
+		Name:     "inspector",
+		Phase:    shutdown.PhaseConnections,
+		Priority: 110,
+		Timeout:  config.ShutdownStageTimeout,
+		Fn: func(ctx context.Context) error {
+			if n := s.inspectors.count(); n > 0 {
+				s.logger.Info("Stopping MCP Inspector processes", zap.Int("count", n))
+			}
+			return s.inspectors.stopAll(ctx)
+		},
+	})
+
+	// Phase 1: Stop accepting new connections and drain in-flight requests (HTTP server)
 	s.shutdownCoordinator.Register(&shutdown.Handler{
 		Name:     "http-server",
 		Phase:    shutdown.PhaseConnections,
 		Priority: 100,
-		Timeout:  30 * time.Second,
+		Timeout:  httpDrainTimeout(s.config.GetShutdownTimeout()),
 		Fn: func(ctx context.Context) error {
 			s.mu.Lock()
 			httpServer := s.httpServer
@@ -384,27 +467,12 @@ func (s *Server) registerShutdownHandlers() {
 		},
 	})
 
-	// Phase 1: Stop startup script
-	s.shutdownCoordinator.Register(&shutdown.Handler{
-		Name:     "startup-script",
-		Phase:    shutdown.PhaseConnections,
-		Priority: 90,
-		Timeout:  5 * time.Second,
-		Fn: func(ctx context.Context) error {
-			if s.startupManager != nil {
-				s.logger.Info("Stopping startup script")
-				return s.startupManager.Stop()
-			}
-			return nil
-		},
-	})
-
 	// Phase 2: Stop WebSocket manager
 	s.shutdownCoordinator.Register(&shutdown.Handler{
 		Name:     "websocket-manager",
 		Phase:    shutdown.PhaseWebSockets,
 		Priority: 100,
-		Timeout:  5 * time.Second,
+		Timeout:  config.ShutdownStageTimeout,
 		Fn: func(ctx context.Context) error {
 			if s.wsManager != nil {
 				s.logger.Info("Stopping WebSocket manager")
@@ -422,7 +490,7 @@ func (s *Server) registerShutdownHandlers() {
 		Timeout:  config.ServerDisconnectTimeout,
 		Fn: func(ctx context.Context) error {
 			s.logger.Info("Disconnecting upstream servers")
-			return s.upstreamManager.DisconnectAll()
+			return s.upstreamManager.ShutdownAll()
 		},
 	})
 
@@ -446,7 +514,7 @@ func (s *Server) registerShutdownHandlers() {
 		Name:     "cache-manager",
 		Phase:    shutdown.PhaseStorage,
 		Priority: 90,
-		Timeout:  5 * time.Second,
+		Timeout:  config.ShutdownStageTimeout,
 		Fn: func(ctx context.Context) error {
 			if s.cacheManager != nil {
 				s.logger.Info("Closing cache manager")
@@ -461,7 +529,7 @@ func (s *Server) registerShutdownHandlers() {
 		Name:     "index-manager",
 		Phase:    shutdown.PhaseStorage,
 		Priority: 80,
-		Timeout:  5 * time.Second,
+		Timeout:  config.ShutdownStageTimeout,
 		Fn: func(ctx context.Context) error {
 			s.logger.Info("Closing index manager")
 			return s.indexManager.Close()
@@ -473,7 +541,7 @@ func (s *Server) registerShutdownHandlers() {
 		Name:     "storage-manager",
 		Phase:    shutdown.PhaseStorage,
 		Priority: 10,
-		Timeout:  5 * time.Second,
+		Timeout:  config.ShutdownStageTimeout,
 		Fn: func(ctx context.Context) error {
 			s.logger.Info("Closing storage manager")
 			return s.storageManager.Close()
@@ -484,6 +552,23 @@ func (s *Server) registerShutdownHandlers() {
 		zap.Int("count", s.shutdownCoordinator.GetHandlerCount()))
 }
 
+// httpDrainTimeout returns how long in-flight HTTP requests may drain during shutdown: half of
+// the shutdown grace period, so upstream servers and storage are always left time to close.
+// With the default grace period this is 5s; the fixed 30s drain it replaced never applied in
+// full because the whole shutdown was already capped at 10s.
+func httpDrainTimeout(shutdownTimeout time.Duration) time.Duration {
+	return shutdownTimeout / 2
+}
+
+// applyShutdownTimeouts applies the current shutdown_timeout to the coordinator, so a value
+// changed by a config reload takes effect
+func (s *Server) applyShutdownTimeouts() time.Duration {
+	timeout := s.config.GetShutdownTimeout()
+	s.shutdownCoordinator.SetTotalTimeout(timeout)
+	s.shutdownCoordinator.SetHandlerTimeout("http-server", httpDrainTimeout(timeout))
+	return timeout
+}
+
 // GetStatus returns the current server status
 func (s *Server) GetStatus() interface{} {
 	s.statusMu.RLock()
@@ -520,10 +605,14 @@ func (s *Server) TriggerOAuthLogin(serverName string) error {
 	return nil
 }
 
+// statusDropWarnInterval rate-limits the warning logged when status updates are dropped
+const statusDropWarnInterval = time.Minute
+
 // StatusChannel returns a channel that receives status updates
 func (s *Server) StatusChannel() <-chan interface{} {
 	// Create a new channel that converts Status to interface{}
-	ch := make(chan interface{}, 10)
+	ch := make(chan interface{}, cap(s.statusCh))
+	s.statusConsumer.Store(true)
 	go func() {
 		defer close(ch)
 		for status := range s.statusCh {
@@ -550,7 +639,30 @@ func (s *Server) updateStatus(phase, message string) {
 	select {
 	case s.statusCh <- statusMap:
 	default:
-		// If channel is full, skip this update
+		if !s.statusConsumer.Load() {
+			// Nobody reads the channel when running headless: replace the oldest update so a
+			// consumer attached later starts from recent status
+			select {
+			case <-s.statusCh:
+			default:
+			}
+			select {
+			case s.statusCh <- statusMap:
+			default:
+			}
+			break
+		}
+
+		// If channel is full, skip this update: the tray may show a stale status until the next one
+		dropped := s.statusDropped.Add(1)
+		now := time.Now()
+		last := s.statusDropWarned.Load()
+		if now.Sub(time.Unix(0, last)) >= statusDropWarnInterval && s.statusDropWarned.CompareAndSwap(last, now.UnixNano()) {
+			s.logger.Warn("Status update dropped, status channel is full",
+				zap.String("phase", phase),
+				zap.Uint64("dropped_total", dropped),
+				zap.Int("status_buffer_size", cap(s.statusCh)))
+		}
 	}
 
 	s.logger.Info("Status updated", zap.String("phase", phase), zap.String("message", message))
@@ -591,6 +703,12 @@ func (s *Server) backgroundInitialization() {
 	s.mu.RUnlock()
 	go s.backgroundConnections(appCtx)
 
+	// Replay calls to durable servers interrupted by the last shutdown
+	go s.mcpProxy.resumeDurableCalls(appCtx)
+
+	// Keep the audit log within audit_log_retention
+	go s.mcpProxy.pruneAuditLog(appCtx)
+
 	// Start background tool discovery and indexing using application context
 	s.mu.RLock()
 	appCtx = s.appCtx // Use application context, not server context
@@ -734,7 +852,7 @@ func (s *Server) checkAndTransitionToRunning() {
 
 		// Get listen address for status message
 		s.mu.RLock()
-		listen := s.config.Listen
+		listen := s.config.ListenAddress()
 		s.mu.RUnlock()
 
 		s.updateStatus("Running", fmt.Sprintf("Server is running on %s (all servers initialized)", listen))
@@ -774,6 +892,15 @@ func (s *Server) backgroundToolIndexing(ctx context.Context) {
 		}
 	}
 
+	// Resources and prompts are listed only from servers that are connected at this point;
+	// those stored by earlier runs stay published
+	if err := s.discoverAndIndexResources(ctx); err != nil {
+		s.logger.Error("Failed to discover resources", zap.Error(err))
+	}
+	if err := s.discoverAndIndexPrompts(ctx); err != nil {
+		s.logger.Error("Failed to discover prompts", zap.Error(err))
+	}
+
 	// NOTE: Removed periodic re-indexing ticker
 	// Tools should only be reloaded via:
 	// 1. Manual reload from tray UI
@@ -835,18 +962,7 @@ func (s *Server) loadToolsForStartOnBootServers(ctx context.Context) error {
 		}
 
 		// Prefix tools with server name for indexing
-		for _, tool := range tools {
-			prefixedTool := &config.ToolMetadata{
-				Name:        fmt.Sprintf("%s:%s", serverName, tool.Name),
-				ServerName:  serverName,
-				Description: tool.Description,
-				ParamsJSON:  tool.ParamsJSON,
-				Hash:        tool.Hash,
-				Created:     tool.Created,
-				Updated:     tool.Updated,
-			}
-			toolsToIndex = append(toolsToIndex, prefixedTool)
-		}
+		toolsToIndex = append(toolsToIndex, prefixServerTools(serverName, tools)...)
 
 		startOnBootCount++
 		s.logger.Info("Loaded and saved tools from StartOnBoot server",
@@ -859,6 +975,7 @@ func (s *Server) loadToolsForStartOnBootServers(ctx context.Context) error {
 		if err := s.indexManager.BatchIndexTools(toolsToIndex); err != nil {
 			return fmt.Errorf("failed to index StartOnBoot tools: %w", err)
 		}
+		s.mcpProxy.publishFeaturedTools()
 		s.logger.Info("Successfully indexed StartOnBoot tools",
 			zap.Int("server_count", startOnBootCount),
 			zap.Int("total_tools", len(toolsToIndex)))
@@ -924,6 +1041,14 @@ func (s *Server) loadConfiguredServers() error {
 	var mu sync.Mutex
 	errorCount := 0
 
+	// Closed once a server has been added (and connected, if active) so dependents can start
+	started := make([]chan struct{}, len(serversCopy))
+	startedByName := make(map[string]chan struct{}, len(serversCopy))
+	for i, serverCfg := range serversCopy {
+		started[i] = make(chan struct{})
+		startedByName[serverCfg.Name] = started[i]
+	}
+
 	for i := range serversCopy {
 		serverCfg := serversCopy[i] // Use the stable copy to avoid race conditions
 
@@ -946,14 +1071,19 @@ func (s *Server) loadConfiguredServers() error {
 		// Always sync config to storage (ensures consistency) - sequential for DB writes
 		if err := s.storageManager.SaveUpstreamServer(serverCfg); err != nil {
 			s.logger.Error("Failed to save/update server in storage", zap.Error(err), zap.String("server", serverCfg.Name))
+			close(started[i])
 			continue
 		}
 
 		// Sync to upstream manager - always add servers to track state, even if disabled
 		// The upstream manager will handle disabled servers internally by not connecting to them
 		wg.Add(1)
-		go func(cfg *config.ServerConfig) {
+		go func(cfg *config.ServerConfig, done chan struct{}) {
 			defer wg.Done()
+			defer close(done)
+
+			// Wait for dependencies before taking a slot so waiting dependents can't starve them
+			s.waitForDependencies(cfg, configuredServers, startedByName)
 
 			// Acquire semaphore
 			semaphore <- struct{}{}
@@ -981,7 +1111,7 @@ func (s *Server) loadConfiguredServers() error {
 					zap.String("server", cfg.Name),
 					zap.String("startup_mode", cfg.StartupMode))
 			}
-		}(serverCfg)
+		}(serverCfg, started[i])
 	}
 
 	// Wait for all parallel operations to complete
@@ -1051,6 +1181,67 @@ func (s *Server) loadConfiguredServers() error {
 	return nil
 }
 
+// waitForDependencies blocks until every server in cfg.DependsOn has been started and is
+// connected, or config.DependencyWaitTimeout has passed. Dependencies that are not configured
+// or not connected at startup (disabled, quarantined, lazy) are not waited for.
+func (s *Server) waitForDependencies(cfg *config.ServerConfig, configured map[string]*config.ServerConfig, started map[string]chan struct{}) {
+	if len(cfg.DependsOn) == 0 || !cfg.ShouldConnectOnStartup() {
+		return
+	}
+
+	deadline := time.NewTimer(config.DependencyWaitTimeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	logTimeout := func(dep string) {
+		s.logger.Warn("Timed out waiting for dependency, starting server anyway",
+			zap.String("server", cfg.Name),
+			zap.String("depends_on", dep),
+			zap.Duration("timeout", config.DependencyWaitTimeout))
+	}
+
+	for _, dep := range cfg.DependsOn {
+		depCfg, ok := configured[dep]
+		if !ok {
+			s.logger.Warn("Ignoring dependency on unknown server",
+				zap.String("server", cfg.Name),
+				zap.String("depends_on", dep))
+			continue
+		}
+		if !depCfg.ShouldConnectOnStartup() {
+			s.logger.Info("Dependency is not connected at startup, not waiting for it",
+				zap.String("server", cfg.Name),
+				zap.String("depends_on", dep),
+				zap.String("startup_mode", depCfg.StartupMode))
+			continue
+		}
+
+		s.logger.Debug("Waiting for dependency to connect",
+			zap.String("server", cfg.Name),
+			zap.String("depends_on", dep))
+
+		select {
+		case <-started[dep]:
+		case <-deadline.C:
+			logTimeout(dep)
+			return
+		}
+
+		// The first connect attempt may have failed; give background retries until the deadline
+		for {
+			if client, ok := s.upstreamManager.GetClient(dep); ok && client.IsConnected() {
+				break
+			}
+			select {
+			case <-ticker.C:
+			case <-deadline.C:
+				logTimeout(dep)
+				return
+			}
+		}
+	}
+}
+
 // Start starts the MCP proxy server
 func (s *Server) Start(ctx context.Context) error {
 	s.logger.Info("Starting MCP proxy server - HTTP server will start immediately")
@@ -1090,6 +1281,9 @@ func (s *Server) Start(ctx context.Context) error {
 		// Clean up any orphaned Docker containers from previous runs
 		s.cleanupOrphanedDockerContainers(ctx)
 
+		// Keep looking for containers orphaned while running
+		go s.reconcileDockerContainers(ctx)
+
 		// Start startup script (best-effort) in background
 		if s.startupManager != nil {
 			if err := s.startupManager.Start(ctx); err != nil {
@@ -1183,6 +1377,7 @@ func (s *Server) discoverAndIndexTools(ctx context.Context) error {
 	if err := s.indexManager.BatchIndexTools(tools); err != nil {
 		return fmt.Errorf("failed to index tools: %w", err)
 	}
+	s.mcpProxy.publishFeaturedTools()
 
 	s.logger.Info("Successfully discovered, saved, and indexed tools",
 		zap.Int("total_tools", len(tools)),
@@ -1202,7 +1397,9 @@ func (s *Server) Shutdown() error {
 	s.shutdown = true
 	s.mu.Unlock()
 
-	s.logger.Info("Shutting down MCP proxy server using coordinated shutdown...")
+	timeout := s.applyShutdownTimeouts()
+	s.logger.Info("Shutting down MCP proxy server using coordinated shutdown...",
+		zap.Duration("shutdown_timeout", timeout))
 
 	// Use shutdown coordinator for ordered shutdown with timeouts
 	ctx := context.Background()
@@ -1229,7 +1426,9 @@ func (s *Server) ShutdownWithContext(ctx context.Context) error {
 	s.shutdown = true
 	s.mu.Unlock()
 
-	s.logger.Info("Shutting down MCP proxy server using coordinated shutdown...")
+	timeout := s.applyShutdownTimeouts()
+	s.logger.Info("Shutting down MCP proxy server using coordinated shutdown...",
+		zap.Duration("shutdown_timeout", timeout))
 
 	return s.shutdownCoordinator.Shutdown(ctx)
 }
@@ -1401,6 +1600,12 @@ func (s *Server) GetAllServers() ([]map[string]interface{}, error) {
 		clientsByName = s.upstreamManager.GetAllClients()
 	}
 
+	// Servers whose tools were cut short by max_tools_per_server / max_total_tools
+	var truncatedTools map[string]int
+	if s.indexManager != nil {
+		truncatedTools = s.indexManager.TruncatedServers()
+	}
+
 	// Debug: Log server count discrepancy
 	configServerCount := len(s.config.Servers)
 	dbServerCount := len(servers)
@@ -1499,8 +1704,13 @@ func (s *Server) GetAllServers() ([]map[string]interface{}, error) {
 
 		// Enrich with group assignment from config (if present)
 		var groupID int
+		tags := server.Tags
 		if cfg, ok := configByName[server.Name]; ok && cfg != nil {
 			groupID = cfg.GroupID
+			tags = cfg.Tags
+		}
+		if tags == nil {
+			tags = []string{}
 		}
 
 		// Get description, start_on_boot, health_check from config
@@ -1509,6 +1719,10 @@ func (s *Server) GetAllServers() ([]map[string]interface{}, error) {
 		var startOnBoot bool
 		var healthCheck bool
 		var userStopped bool
+		var sleeping bool
+		var snoozedUntil time.Time
+		var lastSuccessfulCall time.Time
+		var lastHealthCheck upstreamtypes.HealthCheckResult
 		if cfg, ok := configByName[server.Name]; ok && cfg != nil {
 			description = cfg.Description
 			startOnBoot = cfg.StartupMode == "active"
@@ -1518,12 +1732,16 @@ func (s *Server) GetAllServers() ([]map[string]interface{}, error) {
 		// Use pre-fetched clients map
 		if client, exists := clientsByName[server.Name]; exists {
 			userStopped = client.StateManager.IsUserStopped()
+			sleeping = client.StateManager.IsSleeping()
+			snoozedUntil = client.StateManager.SnoozedUntil()
+			lastSuccessfulCall = client.StateManager.LastSuccessfulCall()
+			lastHealthCheck = client.StateManager.LastHealthCheck()
 		}
 
 		// Determine connected status using connection_state as single source of truth
 		isConnected := connectionState == "Ready"
 
-		result = append(result, map[string]interface{}{
+		entry := map[string]interface{}{
 			"name":                server.Name,
 			"description":         description,
 			"url":                 server.URL,
@@ -1531,6 +1749,8 @@ func (s *Server) GetAllServers() ([]map[string]interface{}, error) {
 			"args":                server.Args,
 			"working_dir":         server.WorkingDir,
 			"env":                 server.Env,
+			"env_file":            server.EnvFile,
+			"shell":               server.Shell,
 			"protocol":            server.Protocol,
 			"repository_url":      server.RepositoryURL,
 			"startup_mode":        server.StartupMode,
@@ -1546,9 +1766,36 @@ func (s *Server) GetAllServers() ([]map[string]interface{}, error) {
 			"auto_disabled":       autoDisabled,
 			"auto_disable_reason": autoDisableReason,
 			"group_id":            groupID,
+			"tags":                tags,
 			"start_on_boot":       startOnBoot,
 			"health_check":        healthCheck,
-		})
+			"snoozed":             !snoozedUntil.IsZero(), // Runtime-only state (NOT persisted)
+		}
+		if !snoozedUntil.IsZero() {
+			entry["snoozed_until"] = snoozedUntil
+		}
+		if sleeping {
+			entry["sleeping"] = true // Runtime-only state (NOT persisted)
+		}
+		if !lastSuccessfulCall.IsZero() {
+			entry["last_successful_call"] = lastSuccessfulCall
+		}
+		if healthCheck && !lastHealthCheck.CheckedAt.IsZero() {
+			entry["last_health_check"] = lastHealthCheck // Runtime-only state (NOT persisted)
+		}
+		if skipped := truncatedTools[server.Name]; skipped > 0 {
+			entry["tools_truncated"] = true
+			entry["tools_skipped"] = skipped
+		}
+
+		// Surface OAuth token expiry for URL-based servers with a stored token
+		if server.URL != "" && server.Command == "" && s.storageManager != nil {
+			if record, err := oauth.GetStoredTokenRecord(server.Name, server.URL, s.storageManager.GetBoltDB()); err == nil && !record.ExpiresAt.IsZero() {
+				entry["oauth_token_expires_at"] = record.ExpiresAt
+			}
+		}
+
+		result = append(result, entry)
 	}
 
 	return result, nil
@@ -1580,6 +1827,7 @@ func (s *Server) GetServerCategories() (*types.ServerStatusCategories, error) {
 		name, _ := server["name"].(string)
 		startupMode, _ := server["startup_mode"].(string)
 		connectionState, _ := server["connection_state"].(string)
+		sleeping, _ := server["sleeping"].(bool)
 
 		// Use connection_state == "Ready" as the single source of truth for connected status
 		isConnected := connectionState == "Ready"
@@ -1592,7 +1840,7 @@ func (s *Server) GetServerCategories() (*types.ServerStatusCategories, error) {
 			categories.AutoDisabled = append(categories.AutoDisabled, name)
 		case startupMode == "disabled":
 			categories.Disabled = append(categories.Disabled, name)
-		case startupMode == "lazy_loading" && !isConnected:
+		case (startupMode == "lazy_loading" || sleeping) && !isConnected:
 			categories.Sleeping = append(categories.Sleeping, name)
 		case startupMode != "disabled" && startupMode != "quarantined" && startupMode != "auto_disabled":
 			if isConnected {
@@ -1678,6 +1926,9 @@ func (s *Server) GetQuarantinedServers() ([]map[string]interface{}, error) {
 			"quarantined":  (server.StartupMode == "quarantined"),
 			"created":      server.Created,
 		}
+		if server.QuarantineReason != "" {
+			serverMap["quarantine_reason"] = server.QuarantineReason
+		}
 		result = append(result, serverMap)
 
 		s.logger.Debug("Added quarantined server to result",
@@ -1696,6 +1947,50 @@ func (s *Server) UnquarantineServer(serverName string) error {
 	return s.QuarantineServer(serverName, false)
 }
 
+// addDisabledServer persists a new server in the disabled state without connecting it.
+// It fails if a server with the same name already exists.
+func (s *Server) addDisabledServer(serverConfig *config.ServerConfig) error {
+	serverConfig.StartupMode = "disabled"
+	return s.addNewServer(serverConfig)
+}
+
+// addQuarantinedServer persists a new server in quarantine without connecting it, so it can
+// only be used after a review in the tray or config file. It fails if a server with the same
+// name already exists.
+func (s *Server) addQuarantinedServer(serverConfig *config.ServerConfig) error {
+	serverConfig.StartupMode = "quarantined"
+	return s.addNewServer(serverConfig)
+}
+
+// addNewServer persists a new server with its startup mode as set
+func (s *Server) addNewServer(serverConfig *config.ServerConfig) error {
+	s.mu.RLock()
+	for _, existing := range s.config.Servers {
+		if existing.Name == serverConfig.Name {
+			s.mu.RUnlock()
+			return fmt.Errorf("server '%s' already exists", serverConfig.Name)
+		}
+	}
+	s.mu.RUnlock()
+
+	if err := s.storageManager.SaveUpstreamServer(serverConfig); err != nil {
+		return fmt.Errorf("failed to save server '%s': %w", serverConfig.Name, err)
+	}
+
+	s.mu.Lock()
+	s.config.Servers = append(s.config.Servers, serverConfig)
+	s.mu.Unlock()
+
+	if err := s.SaveConfiguration(); err != nil {
+		s.logger.Error("Failed to save configuration after adding server",
+			zap.String("server", serverConfig.Name),
+			zap.Error(err))
+	}
+	s.OnUpstreamServerChange()
+
+	return nil
+}
+
 // EnableServer enables/disables a server and ensures all state is synchronized.
 // It acts as the entry point for changes originating from the UI or API.
 func (s *Server) EnableServer(serverName string, enabled bool) error {
@@ -1886,6 +2181,14 @@ func (s *Server) QuarantineServer(serverName string, quarantined bool) error {
 		return fmt.Errorf("failed to update quarantine state for server '%s' in storage: %w", serverName, err)
 	}
 
+	// A manual unquarantine gives an auto-quarantined server a fresh failure count
+	if !quarantined {
+		s.setQuarantineReason(serverName, "")
+		if client, exists := s.upstreamManager.GetClient(serverName); exists {
+			client.StateManager.ResetAutoQuarantine()
+		}
+	}
+
 	if err := s.SaveConfiguration(); err != nil {
 		s.logger.Error("Failed to save configuration after quarantine state change", zap.Error(err))
 	}
@@ -2213,8 +2516,8 @@ func (s *Server) StopServer() error {
 	s.logger.Info("STOPSERVER - Shutting down HTTP server")
 	_ = s.logger.Sync()
 	if s.httpServer != nil {
-		// Give the server 5 seconds to shutdown gracefully
-		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		// Give in-flight requests the same drain time as a full shutdown
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), httpDrainTimeout(s.config.GetShutdownTimeout()))
 		defer cancel()
 
 		if err := s.httpServer.Shutdown(shutdownCtx); err != nil {
@@ -2270,6 +2573,7 @@ func (s *Server) startCustomHTTPServer(streamableServer *server.StreamableHTTPSe
 
 			// Log incoming request with connection details
 			s.logger.Debug("MCP client request received",
+				logs.CorrelationField(r.Context()),
 				zap.String("method", r.Method),
 				zap.String("path", r.URL.Path),
 				zap.String("remote_addr", r.RemoteAddr),
@@ -2290,6 +2594,7 @@ func (s *Server) startCustomHTTPServer(streamableServer *server.StreamableHTTPSe
 			// Log response with timing and status
 			if wrappedWriter.statusCode >= 400 {
 				s.logger.Warn("MCP client request completed with error",
+					logs.CorrelationField(r.Context()),
 					zap.String("method", r.Method),
 					zap.String("path", r.URL.Path),
 					zap.String("remote_addr", r.RemoteAddr),
@@ -2298,6 +2603,7 @@ func (s *Server) startCustomHTTPServer(streamableServer *server.StreamableHTTPSe
 				)
 			} else {
 				s.logger.Debug("MCP client request completed successfully",
+					logs.CorrelationField(r.Context()),
 					zap.String("method", r.Method),
 					zap.String("path", r.URL.Path),
 					zap.String("remote_addr", r.RemoteAddr),
@@ -2308,13 +2614,19 @@ func (s *Server) startCustomHTTPServer(streamableServer *server.StreamableHTTPSe
 		})
 	}
 
+	var streamableHandler http.Handler = streamableServer
+	if !s.config.DisableCompression {
+		streamableHandler = compressResponses(streamableHandler, s.config.GetCompressionMinBytes())
+	}
+	mcpHandler := loggingHandler(s.limitRequestBody(streamableHandler, s.config.MaxRequestBodyBytes))
+
 	// Standard MCP endpoint according to the specification
-	mux.Handle("/mcp", loggingHandler(streamableServer))
-	mux.Handle("/mcp/", loggingHandler(streamableServer)) // Handle trailing slash
+	mux.Handle("/mcp", mcpHandler)
+	mux.Handle("/mcp/", mcpHandler) // Handle trailing slash
 
 	// Legacy endpoints for backward compatibility
-	mux.Handle("/v1/tool_code", loggingHandler(streamableServer))
-	mux.Handle("/v1/tool-code", loggingHandler(streamableServer)) // Alias for python client
+	mux.Handle("/v1/tool_code", mcpHandler)
+	mux.Handle("/v1/tool-code", mcpHandler) // Alias for python client
 
 	// Root dashboard handler
 	mux.HandleFunc("/", s.handleDashboard)
@@ -2325,6 +2637,8 @@ func (s *Server) startCustomHTTPServer(streamableServer *server.StreamableHTTPSe
 	// Metrics web interface
 	mux.HandleFunc("/metrics", s.handleMetricsWeb)
 	mux.HandleFunc("/api/metrics/current", s.handleMetricsAPI)
+	mux.HandleFunc("/metrics/prometheus", s.handlePrometheusMetrics)
+	mux.HandleFunc("/api/tools/stats", s.handleToolStatsAPI)
 
 	// Comprehensive resources web interface
 	mux.HandleFunc("/resources", s.handleResourcesWeb)
@@ -2335,28 +2649,41 @@ func (s *Server) startCustomHTTPServer(streamableServer *server.StreamableHTTPSe
 	mux.HandleFunc("/servers", s.handleServersWeb)
 	mux.HandleFunc("/failed-servers", s.handleFailedServers)
 	mux.HandleFunc("/api/servers/status", s.handleServersStatusAPI)
+	mux.HandleFunc("/api/servers/summary", s.handleServersSummaryAPI)
+	mux.HandleFunc("/api/servers/health", s.handleServersHealthAPI)
+	mux.HandleFunc("/api/audit", s.handleAuditAPI)
+	mux.HandleFunc("/api/docker/orphans", s.handleDockerOrphansAPI)
+	mux.HandleFunc("/api/maintenance", s.handleMaintenanceAPI)
 	mux.HandleFunc("/api/tray/status", s.handleTrayStatusAPI)     // Tray menu categories API (computed)
 	mux.HandleFunc("/api/tray/internal", s.handleTrayInternalAPI) // Actual tray internal state
 	mux.HandleFunc("/api/servers", s.handleServersAPI)
 	mux.HandleFunc("/api/servers/", s.handleServerConfigOrToolsAPI)
 
-	// Server diagnostic chat interface
-	mux.HandleFunc("/server/chat", s.handleServerChat)
-	mux.HandleFunc("/api/chat/sessions", s.handleChatSession)
-	mux.HandleFunc("/api/chat/sessions/", s.handleChatMessage)
-
-	// Chat tool endpoints for OpenAI Function Calling
-	mux.HandleFunc("/chat/read-config", s.handleChatReadConfig)
-	mux.HandleFunc("/chat/write-config", s.handleChatWriteConfig)
-	mux.HandleFunc("/chat/read-log", s.handleChatReadLog)
-	mux.HandleFunc("/chat/read-github", s.handleChatReadGitHub)
-	mux.HandleFunc("/chat/restart-server", s.handleChatRestartServer)
-	mux.HandleFunc("/chat/call-tool", s.handleChatCallTool)
-	mux.HandleFunc("/chat/get-server-status", s.handleChatGetServerStatus)
-	mux.HandleFunc("/chat/test-server-tools", s.handleChatTestServerTools)
-	mux.HandleFunc("/chat/list-all-servers", s.handleChatListAllServers)
-	mux.HandleFunc("/chat/list-all-tools", s.handleChatListAllTools)
-	mux.HandleFunc("/chat/context", s.handleChatContext)
+	// Global settings API
+	mux.HandleFunc("/api/config", s.handleConfigAPI)
+	mux.HandleFunc("/api/config/effective", s.handleEffectiveConfigAPI)
+
+	// Server diagnostic chat interface. When disabled the routes are not registered and 404.
+	if s.config.DiagnosticChatEnabled {
+		mux.HandleFunc("/server/chat", s.handleServerChat)
+		mux.HandleFunc("/api/chat/sessions", s.handleChatSession)
+		mux.HandleFunc("/api/chat/sessions/", s.handleChatSessionPath)
+
+		// Chat tool endpoints for OpenAI Function Calling
+		mux.HandleFunc("/chat/read-config", s.handleChatReadConfig)
+		mux.HandleFunc("/chat/write-config", s.handleChatWriteConfig)
+		mux.HandleFunc("/chat/read-log", s.handleChatReadLog)
+		mux.HandleFunc("/chat/read-github", s.handleChatReadGitHub)
+		mux.HandleFunc("/chat/restart-server", s.handleChatRestartServer)
+		mux.HandleFunc("/chat/call-tool", s.handleChatCallTool)
+		mux.HandleFunc("/chat/get-server-status", s.handleChatGetServerStatus)
+		mux.HandleFunc("/chat/test-server-tools", s.handleChatTestServerTools)
+		mux.HandleFunc("/chat/list-all-servers", s.handleChatListAllServers)
+		mux.HandleFunc("/chat/list-all-tools", s.handleChatListAllTools)
+		mux.HandleFunc("/chat/context", s.handleChatContext)
+	} else {
+		s.logger.Info("Diagnostic chat disabled, /server/chat and /chat/ endpoints are not served")
+	}
 
 	// Group management web interface
 	mux.HandleFunc("/groups", s.handleGroupsWeb)
@@ -2417,6 +2744,7 @@ func (s *Server) startCustomHTTPServer(streamableServer *server.StreamableHTTPSe
 		}
 	})
 	mux.HandleFunc("/api/v1/agent/logs/main", s.handleAgentMainLogs)
+	mux.HandleFunc("/api/startup/logs", s.handleStartupLogsAPI)
 	mux.HandleFunc("/api/v1/agent/registries/search", s.handleAgentSearchRegistries)
 	mux.HandleFunc("/api/v1/agent/install", s.handleAgentInstallServer)
 
@@ -2428,16 +2756,38 @@ func (s *Server) startCustomHTTPServer(streamableServer *server.StreamableHTTPSe
 		serverFilter := r.URL.Query().Get("server")
 		s.wsManager.HandleWebSocket(w, r, serverFilter)
 	})
+	mux.HandleFunc("/api/events", s.handleEventsAPI)
+
+	listenAddr := s.config.ListenAddress()
+	s.warnIfExposedWithoutAuth(listenAddr)
+
+	// HTTPS when a certificate is configured; the reloader lets renewed certs apply on SIGHUP
+	var certs *certReloader
+	var tlsConfig *tls.Config
+	if s.config.TLSEnabled() {
+		var err error
+		certs, err = newCertReloader(s.config.TLSCertFile, s.config.TLSKeyFile)
+		if err != nil {
+			s.logger.Error("Failed to load TLS certificate", zap.Error(err))
+			s.mu.Lock()
+			s.running = false
+			s.mu.Unlock()
+			s.updateStatus("Error", fmt.Sprintf("Server failed: %v", err))
+			return err
+		}
+		tlsConfig = certs.tlsConfig()
+	}
 
 	s.mu.Lock()
 	s.httpServer = &http.Server{
-		Addr:              s.config.Listen,
-		Handler:           mux,
+		Addr:              listenAddr,
+		Handler:           withCorrelationID(s.apiAuthMiddleware(mux)),
 		ReadHeaderTimeout: 60 * time.Second,  // Increased for better client compatibility
 		ReadTimeout:       120 * time.Second, // Full request read timeout
 		WriteTimeout:      120 * time.Second, // Response write timeout
 		IdleTimeout:       180 * time.Second, // Keep-alive timeout for persistent connections
-		MaxHeaderBytes:    1 << 20,           // 1MB max header size
+		MaxHeaderBytes:    s.config.GetMaxHeaderBytes(),
+		TLSConfig:         tlsConfig,
 		// Enable connection state tracking for better debugging
 		ConnState: s.logConnectionState,
 	}
@@ -2445,14 +2795,45 @@ func (s *Server) startCustomHTTPServer(streamableServer *server.StreamableHTTPSe
 	s.mu.Unlock()
 
 	s.logger.Info("Starting MCP HTTP server with enhanced client stability",
-		zap.String("address", s.config.Listen),
+		zap.String("address", listenAddr),
 		zap.Strings("endpoints", []string{"/mcp", "/mcp/", "/v1/tool_code", "/v1/tool-code"}),
 		zap.Duration("read_timeout", 120*time.Second),
 		zap.Duration("write_timeout", 120*time.Second),
 		zap.Duration("idle_timeout", 180*time.Second),
 		zap.String("features", "connection_tracking,graceful_shutdown,enhanced_logging"),
+		zap.Bool("tls", certs != nil),
 	)
-	if err := s.httpServer.ListenAndServe(); err != http.ErrServerClosed {
+	listener, err := s.listen(listenAddr)
+	if err != nil {
+		s.logger.Error("Failed to listen", zap.String("address", listenAddr), zap.Error(err))
+		s.mu.Lock()
+		s.running = false
+		s.mu.Unlock()
+		s.updateStatus("Error", fmt.Sprintf("Server failed: %v", err))
+		return err
+	}
+
+	serve := s.httpServer.Serve
+	if certs != nil {
+		stopReload := make(chan struct{})
+		defer close(stopReload)
+		go s.reloadCertsOnSIGHUP(certs, stopReload)
+
+		if s.config.TLSRedirectListen != "" {
+			redirectServer, err := s.startTLSRedirect(s.config.TLSRedirectListen, listenAddr)
+			if err != nil {
+				// HTTPS still works without the redirect, so don't fail startup over it
+				s.logger.Error("Failed to start HTTP to HTTPS redirect", zap.Error(err))
+			} else {
+				defer redirectServer.Close()
+			}
+		}
+
+		// Certificates come from TLSConfig.GetCertificate
+		serve = func(l net.Listener) error { return s.httpServer.ServeTLS(l, "", "") }
+	}
+
+	if err := serve(listener); err != http.ErrServerClosed {
 		s.logger.Error("HTTP server error", zap.Error(err))
 		s.mu.Lock()
 		s.running = false
@@ -2465,6 +2846,82 @@ func (s *Server) startCustomHTTPServer(streamableServer *server.StreamableHTTPSe
 	return nil
 }
 
+// listen opens the HTTP listener: a TCP address, or a Unix domain socket for unix:// addresses.
+// The socket file is chmod'ed to listen_socket_mode so filesystem permissions control access.
+func (s *Server) listen(listenAddr string) (net.Listener, error) {
+	socketPath, ok := config.UnixSocketPath(listenAddr)
+	if !ok {
+		return net.Listen("tcp", listenAddr)
+	}
+
+	mode, err := s.config.GetListenSocketMode()
+	if err != nil {
+		return nil, err
+	}
+
+	// Remove a socket left behind by a previous run; refuse to clobber anything else
+	if info, err := os.Lstat(socketPath); err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("%s exists and is not a socket", socketPath)
+		}
+		if err := os.Remove(socketPath); err != nil {
+			return nil, fmt.Errorf("failed to remove stale socket %s: %w", socketPath, err)
+		}
+	}
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(socketPath, mode); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to set permissions on socket %s: %w", socketPath, err)
+	}
+
+	s.logger.Info("Listening on Unix domain socket",
+		zap.String("path", socketPath),
+		zap.String("mode", fmt.Sprintf("%#o", mode)))
+	return listener, nil
+}
+
+// limitRequestBody rejects requests whose body exceeds maxBytes with 413 Request Entity Too Large.
+// The body is read up front so oversize chunked requests are rejected before reaching the handler.
+// A maxBytes of 0 disables the limit.
+func (s *Server) limitRequestBody(handler http.Handler, maxBytes int64) http.Handler {
+	if maxBytes <= 0 {
+		return handler
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength > maxBytes {
+			s.rejectOversizeRequest(w, r, maxBytes)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBytes))
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				s.rejectOversizeRequest(w, r, maxBytes)
+				return
+			}
+			http.Error(w, "Failed to read request body", http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		handler.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) rejectOversizeRequest(w http.ResponseWriter, r *http.Request, maxBytes int64) {
+	s.logger.Warn("Rejected MCP request with oversize body",
+		zap.String("path", r.URL.Path),
+		zap.String("remote_addr", r.RemoteAddr),
+		zap.Int64("content_length", r.ContentLength),
+		zap.Int64("max_request_body_bytes", maxBytes))
+	http.Error(w, fmt.Sprintf("Request body exceeds %d bytes", maxBytes), http.StatusRequestEntityTooLarge)
+}
+
 // responseWriter wraps http.ResponseWriter to capture the status code
 type responseWriter struct {
 	http.ResponseWriter
@@ -2480,6 +2937,20 @@ func (rw *responseWriter) WriteHeader(code int) {
 	}
 }
 
+// warnIfExposedWithoutAuth logs a prominent warning when the admin API and dashboard
+// are reachable from other hosts without any authentication in front of them
+func (s *Server) warnIfExposedWithoutAuth(listenAddr string) {
+	if !s.config.IsListenExternal() || s.config.APIToken != "" {
+		return
+	}
+
+	s.logger.Warn("SECURITY WARNING: HTTP server is reachable from other hosts without authentication. "+
+		"Anyone who can reach this address can manage upstream servers and edit configuration. "+
+		"Set \"api_token\", set \"bind_loopback_only\": true, or listen on 127.0.0.1 to restrict access.",
+		zap.String("address", listenAddr),
+		zap.Strings("exposed_endpoints", []string{"/api/", "/chat/", "/ws/events", "/mcp"}))
+}
+
 // logConnectionState logs HTTP connection state changes for debugging client issues
 func (s *Server) logConnectionState(conn net.Conn, state http.ConnState) {
 	switch state {
@@ -2554,6 +3025,10 @@ func (s *Server) SaveConfiguration() error {
 
 	mergedGroups := make([]map[string]interface{}, 0, len(s.config.Groups))
 	for _, g := range s.config.Groups {
+		// Groups defined in mcp_config.d/ are written back to their own file below
+		if s.config.GroupSourceFile(g.Name) != "" {
+			continue
+		}
 		mg := map[string]interface{}{
 			"id":      g.ID,
 			"name":    g.Name,
@@ -2607,6 +3082,15 @@ func (s *Server) SaveConfiguration() error {
 		mergedGroups = append(mergedGroups, mg)
 	}
 	existing["groups"] = mergedGroups
+	existing["config_version"] = s.config.ConfigVersion
+	// Top-level settings that can be changed at runtime via PATCH /api/config
+	config.MergeRuntimeSettings(s.config, existing)
+	// featured_tools entries are rewritten when a server is renamed
+	if len(s.config.FeaturedTools) > 0 {
+		existing["featured_tools"] = s.config.FeaturedTools
+	} else {
+		delete(existing, "featured_tools")
+	}
 
 	// --- Merge Servers ---
 	// Create lookup of existing servers by name
@@ -2624,8 +3108,12 @@ func (s *Server) SaveConfiguration() error {
 	}
 
 	// Lookups for latest server configs by name
+	// Servers loaded from mcp_config.d/ are written back to their own file below
 	latestByName := map[string]*config.ServerConfig{}
 	for _, sc := range s.config.Servers {
+		if s.config.ServerSourceFile(sc.Name) != "" {
+			continue
+		}
 		latestByName[sc.Name] = sc
 	}
 
@@ -2684,7 +3172,22 @@ func (s *Server) SaveConfiguration() error {
 			m["args"] = sc.Args
 			m["working_dir"] = sc.WorkingDir
 			m["env"] = sc.Env
+			if sc.EnvFile != "" {
+				m["env_file"] = sc.EnvFile
+			} else {
+				delete(m, "env_file")
+			}
+			if sc.Shell != "" {
+				m["shell"] = sc.Shell
+			} else {
+				delete(m, "shell")
+			}
 			m["headers"] = sc.Headers
+			if sc.HTTPProxy != "" {
+				m["http_proxy"] = sc.HTTPProxy
+			} else {
+				delete(m, "http_proxy")
+			}
 			m["oauth"] = sc.OAuth
 			m["repository_url"] = sc.RepositoryURL
 			m["startup_mode"] = sc.StartupMode // Use startup_mode instead of deprecated boolean fields
@@ -2692,11 +3195,121 @@ func (s *Server) SaveConfiguration() error {
 			m["updated"] = sc.Updated
 			m["isolation"] = sc.Isolation
 			m["auto_disable_reason"] = sc.AutoDisableReason
+			if sc.QuarantineReason != "" {
+				m["quarantine_reason"] = sc.QuarantineReason
+			} else {
+				delete(m, "quarantine_reason")
+			}
 			m["health_check"] = sc.HealthCheck
 			m["ever_connected"] = sc.EverConnected
 			m["last_successful_connection"] = sc.LastSuccessfulConnection
 			m["tool_count"] = sc.ToolCount
 			m["auto_disable_threshold"] = sc.AutoDisableThreshold
+			if sc.RestartOnExit {
+				m["restart_on_exit"] = true
+			} else {
+				delete(m, "restart_on_exit")
+			}
+			if sc.MaxRestarts > 0 {
+				m["max_restarts"] = sc.MaxRestarts
+			} else {
+				delete(m, "max_restarts")
+			}
+			if sc.MaxResponseBytes > 0 {
+				m["max_response_bytes"] = sc.MaxResponseBytes
+			} else {
+				delete(m, "max_response_bytes")
+			}
+			if sc.MaxConcurrentCalls > 0 {
+				m["max_concurrent_calls"] = sc.MaxConcurrentCalls
+			} else {
+				delete(m, "max_concurrent_calls")
+			}
+			if sc.MaxCallDuration > 0 {
+				m["max_call_duration"] = sc.MaxCallDuration
+			} else {
+				delete(m, "max_call_duration")
+			}
+			if sc.IdleDisconnectAfter > 0 {
+				m["idle_disconnect_after"] = sc.IdleDisconnectAfter
+			} else {
+				delete(m, "idle_disconnect_after")
+			}
+			if len(sc.DefaultArgs) > 0 {
+				m["default_args"] = sc.DefaultArgs
+			} else {
+				delete(m, "default_args")
+			}
+			if len(sc.CacheableTools) > 0 {
+				m["cacheable_tools"] = sc.CacheableTools
+			} else {
+				delete(m, "cacheable_tools")
+			}
+			if sc.CacheTTL > 0 {
+				m["cache_ttl"] = sc.CacheTTL
+			} else {
+				delete(m, "cache_ttl")
+			}
+			if len(sc.SensitiveTools) > 0 {
+				m["sensitive_tools"] = sc.SensitiveTools
+			} else {
+				delete(m, "sensitive_tools")
+			}
+			if sc.ReadOnly {
+				m["read_only"] = true
+			} else {
+				delete(m, "read_only")
+			}
+			if sc.Experimental {
+				m["experimental"] = true
+			} else {
+				delete(m, "experimental")
+			}
+			if sc.SearchPriority != 0 {
+				m["search_priority"] = sc.SearchPriority
+			} else {
+				delete(m, "search_priority")
+			}
+			if sc.DisableCallRetry {
+				m["disable_call_retry"] = true
+			} else {
+				delete(m, "disable_call_retry")
+			}
+			if sc.Durable {
+				m["durable"] = true
+			} else {
+				delete(m, "durable")
+			}
+			if len(sc.ReadTools) > 0 {
+				m["read_tools"] = sc.ReadTools
+			} else {
+				delete(m, "read_tools")
+			}
+			if len(sc.WriteTools) > 0 {
+				m["write_tools"] = sc.WriteTools
+			} else {
+				delete(m, "write_tools")
+			}
+			if len(sc.DependsOn) > 0 {
+				m["depends_on"] = sc.DependsOn
+			} else {
+				delete(m, "depends_on")
+			}
+			if sc.ProtocolVersion != "" {
+				m["protocol_version"] = sc.ProtocolVersion
+			} else {
+				delete(m, "protocol_version")
+			}
+			if len(sc.ToolMaxResponseBytes) > 0 {
+				m["tool_max_response_bytes"] = sc.ToolMaxResponseBytes
+			} else {
+				delete(m, "tool_max_response_bytes")
+			}
+			if len(sc.Tags) > 0 {
+				m["tags"] = sc.Tags
+			} else {
+				delete(m, "tags")
+			}
 		}
 		// Remove deprecated fields that should not be written to config
 		delete(m, "enabled")
@@ -2752,6 +3365,81 @@ func (s *Server) SaveConfiguration() error {
 			"tool_count":                   sc.ToolCount,
 			"auto_disable_threshold":       sc.AutoDisableThreshold,
 		}
+		if sc.RestartOnExit {
+			m["restart_on_exit"] = true
+		}
+		if sc.MaxRestarts > 0 {
+			m["max_restarts"] = sc.MaxRestarts
+		}
+		if sc.MaxResponseBytes > 0 {
+			m["max_response_bytes"] = sc.MaxResponseBytes
+		}
+		if sc.MaxConcurrentCalls > 0 {
+			m["max_concurrent_calls"] = sc.MaxConcurrentCalls
+		}
+		if sc.MaxCallDuration > 0 {
+			m["max_call_duration"] = sc.MaxCallDuration
+		}
+		if sc.IdleDisconnectAfter > 0 {
+			m["idle_disconnect_after"] = sc.IdleDisconnectAfter
+		}
+		if sc.HTTPProxy != "" {
+			m["http_proxy"] = sc.HTTPProxy
+		}
+		if sc.EnvFile != "" {
+			m["env_file"] = sc.EnvFile
+		}
+		if sc.Shell != "" {
+			m["shell"] = sc.Shell
+		}
+		if len(sc.DefaultArgs) > 0 {
+			m["default_args"] = sc.DefaultArgs
+		}
+		if len(sc.CacheableTools) > 0 {
+			m["cacheable_tools"] = sc.CacheableTools
+		}
+		if sc.CacheTTL > 0 {
+			m["cache_ttl"] = sc.CacheTTL
+		}
+		if len(sc.SensitiveTools) > 0 {
+			m["sensitive_tools"] = sc.SensitiveTools
+		}
+		if sc.ReadOnly {
+			m["read_only"] = true
+		}
+		if sc.Experimental {
+			m["experimental"] = true
+		}
+		if sc.SearchPriority != 0 {
+			m["search_priority"] = sc.SearchPriority
+		}
+		if sc.DisableCallRetry {
+			m["disable_call_retry"] = true
+		}
+		if sc.Durable {
+			m["durable"] = true
+		}
+		if sc.QuarantineReason != "" {
+			m["quarantine_reason"] = sc.QuarantineReason
+		}
+		if len(sc.ReadTools) > 0 {
+			m["read_tools"] = sc.ReadTools
+		}
+		if len(sc.WriteTools) > 0 {
+			m["write_tools"] = sc.WriteTools
+		}
+		if len(sc.DependsOn) > 0 {
+			m["depends_on"] = sc.DependsOn
+		}
+		if sc.ProtocolVersion != "" {
+			m["protocol_version"] = sc.ProtocolVersion
+		}
+		if len(sc.ToolMaxResponseBytes) > 0 {
+			m["tool_max_response_bytes"] = sc.ToolMaxResponseBytes
+		}
+		if len(sc.Tags) > 0 {
+			m["tags"] = sc.Tags
+		}
 		// Group fields for new server: compute from assignment (if any) else 0/""
 		finalID, _ := computeGroupFields(name, sc.GroupID, "")
 		m["group_id"] = finalID
@@ -2787,6 +3475,10 @@ func (s *Server) SaveConfiguration() error {
 		s.logger.Error("Failed to write merged configuration", zap.Error(err))
 		return err
 	}
+	if err := config.SaveConfigFragments(s.config); err != nil {
+		s.logger.Error("Failed to write config fragments", zap.Error(err))
+		return err
+	}
 
 	s.logger.Info("Configuration saved with merge strategy", zap.Int("servers", len(mergedServers)), zap.Int("groups", len(mergedGroups)))
 	return nil
@@ -2962,32 +3654,17 @@ func (s *Server) initServerGroupAssignments() {
 		zap.Bool("id_mode", idMode))
 }
 
-// migrateLegacyGroupNamesToIDs converts any server with only group_name set into group_id in-memory and saves the config
-func (s *Server) migrateLegacyGroupNamesToIDs() {
-	changed := false
-	// Build lookup of group name -> id
-	groupsMutex.RLock()
-	nameToID := make(map[string]int)
-	for name, g := range groups {
-		if g != nil && g.ID > 0 {
-			nameToID[name] = g.ID
-		}
-	}
-	groupsMutex.RUnlock()
-
-	for _, srv := range s.config.Servers {
-		if srv.GroupID == 0 && srv.GroupName != "" {
-			if id, ok := nameToID[srv.GroupName]; ok {
-				srv.GroupID = id
-				srv.GroupName = ""
-				changed = true
-			}
-		}
+// persistConfigMigrations writes the config back when it was upgraded to a newer
+// config_version on load, so the migrations don't have to run again
+func (s *Server) persistConfigMigrations() {
+	if !s.config.WasMigrated() {
+		return
 	}
-	if changed {
-		_ = s.SaveConfiguration()
-		s.logger.Info("Migrated legacy group_name to group_id in config")
+	if err := s.SaveConfiguration(); err != nil {
+		s.logger.Warn("Failed to save migrated config", zap.Error(err))
+		return
 	}
+	s.logger.Info("Migrated config to current version", zap.Int("config_version", s.config.ConfigVersion))
 }
 
 // getGroups returns a copy of all groups (thread-safe)
@@ -3016,11 +3693,47 @@ func (s *Server) deleteGroup(name string) {
 	delete(groups, name)
 }
 
+// publishConfigDiff logs a config reload diff and publishes it on the event bus
+func (s *Server) publishConfigDiff(diff *config.ConfigDiff) {
+	if diff.IsEmpty() {
+		s.logger.Info("Configuration reloaded with no changes")
+		return
+	}
+
+	s.logger.Info("Configuration changes detected on reload",
+		zap.Strings("added_servers", diff.AddedServers),
+		zap.Strings("removed_servers", diff.RemovedServers),
+		zap.Int("modified_servers", len(diff.ModifiedServers)),
+		zap.Strings("changes", diff.Summary()))
+
+	if s.eventBus != nil {
+		s.eventBus.Publish(events.Event{
+			Type: events.ConfigDiff,
+			Data: diff,
+		})
+	}
+}
+
 // ReloadConfiguration reloads the configuration from disk
 func (s *Server) ReloadConfiguration() error {
 	s.logger.Info("Reloading configuration from disk - full restart of all servers")
 
-	// Step 1: Disconnect all upstream servers cleanly before reloading
+	// Step 1: Load fresh configuration from file. A missing or broken file (e.g. mid
+	// rename-on-save) fails the reload before any server is touched.
+	s.mu.RLock()
+	dataDir := s.config.DataDir
+	s.mu.RUnlock()
+
+	configPath := config.GetConfigPath(dataDir)
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		return fmt.Errorf("config file %s not found, keeping the current configuration", configPath)
+	}
+	newConfig, err := config.LoadFromFile(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to reload config: %w", err)
+	}
+
+	// Step 2: Disconnect all upstream servers cleanly before applying it
 	s.logger.Info("Disconnecting all upstream servers before config reload")
 	if err := s.upstreamManager.DisconnectAll(); err != nil {
 		s.logger.Warn("Some servers failed to disconnect cleanly before reload", zap.Error(err))
@@ -3037,24 +3750,18 @@ func (s *Server) ReloadConfiguration() error {
 	}
 	assignmentsMutex.RUnlock()
 
-	// Step 2: Load fresh configuration from file
-	s.mu.RLock()
-	dataDir := s.config.DataDir
-	s.mu.RUnlock()
-
-	configPath := config.GetConfigPath(dataDir)
-	newConfig, err := config.LoadFromFile(configPath)
-	if err != nil {
-		return fmt.Errorf("failed to reload config: %w", err)
-	}
-
 	// Update internal config with write lock to prevent race conditions
 	s.mu.Lock()
+	oldConfig := s.config
 	s.config = newConfig
 	s.mu.Unlock()
+	s.indexManager.SetToolLimits(newConfig.MaxToolsPerServer, newConfig.MaxTotalTools)
 
-	// Migrate legacy names to IDs after reload
-	s.migrateLegacyGroupNamesToIDs()
+	// Report exactly what changed between the old and new config
+	s.publishConfigDiff(config.DiffConfigs(oldConfig, newConfig))
+
+	// Persist config_version migrations applied while reloading
+	s.persistConfigMigrations()
 
 	// NOTE: Do not restore preserved assignments here. We want the file to be authoritative
 	// on reload (including clearing assignments where group_id == 0). The assignments map
@@ -3206,6 +3913,35 @@ func (s *Server) GetLLMConfig() *config.LLMConfig {
 	return s.config.LLM
 }
 
+// GetAPIToken returns the bearer token required by the HTTP API (empty when auth is disabled)
+func (s *Server) GetAPIToken() string {
+	if s.config == nil {
+		return ""
+	}
+	return s.config.APIToken
+}
+
+// GetTLSCertFile returns the certificate served by the HTTP API (empty when TLS is disabled)
+func (s *Server) GetTLSCertFile() string {
+	if s.config == nil || !s.config.TLSEnabled() {
+		return ""
+	}
+	return s.config.TLSCertFile
+}
+
+// GetUpdateWindow returns the local time window in which the tray may apply updates (empty means any time)
+func (s *Server) GetUpdateWindow() string {
+	if s.config == nil {
+		return ""
+	}
+	return s.config.UpdateWindow
+}
+
+// GetShutdownTimeout returns the configured grace period for a graceful shutdown
+func (s *Server) GetShutdownTimeout() time.Duration {
+	return s.config.GetShutdownTimeout()
+}
+
 // --- Startup Script Management (exposed for tray/MCP) ---
 
 // StartStartupScript starts the configured startup script if enabled
@@ -3240,6 +3976,14 @@ func (s *Server) GetStartupScriptStatus() map[string]interface{} {
 	return s.startupManager.Status()
 }
 
+// GetStartupScriptLogs returns the most recent captured output lines of the startup script
+func (s *Server) GetStartupScriptLogs(lines int) []startup.LogLine {
+	if s.startupManager == nil {
+		return []startup.LogLine{}
+	}
+	return s.startupManager.Logs(lines)
+}
+
 // UpdateStartupScript updates startup script configuration and persists it
 func (s *Server) UpdateStartupScript(cfg *config.StartupScriptConfig) error {
 	if cfg == nil {
@@ -3306,20 +4050,12 @@ func (s *Server) cleanupOrphanedDockerContainers(ctx context.Context) {
 			zap.String("container_name", containerName),
 			zap.String("server", serverName))
 
-		// Try graceful stop first
-		stopCmd := exec.CommandContext(ctx, "docker", "stop", containerID)
-		if err := stopCmd.Run(); err != nil {
-			// Force kill if stop fails
-			s.logger.Debug("Graceful stop failed, force killing container",
+		// Try graceful stop first, force kill if stop fails
+		if err := s.removeDockerContainer(ctx, containerID); err != nil {
+			s.logger.Warn("Failed to kill orphaned container",
 				zap.String("container_id", containerID),
 				zap.Error(err))
-			killCmd := exec.CommandContext(ctx, "docker", "kill", containerID)
-			if err := killCmd.Run(); err != nil {
-				s.logger.Warn("Failed to kill orphaned container",
-					zap.String("container_id", containerID),
-					zap.Error(err))
-				continue
-			}
+			continue
 		}
 
 		cleanedCount++
@@ -3358,7 +4094,8 @@ func (s *Server) handleServersAPI(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// handleServerConfigOrToolsAPI handles both GET /api/servers/{name}/tools and PUT /api/servers/{name}/config
+// handleServerConfigOrToolsAPI handles GET /api/servers/{name}/tools, GET /api/servers/{name}/capabilities,
+// GET /api/servers/{name}/logs, PUT /api/servers/{name}/config and the /api/servers/{name}/oauth/* endpoints
 func (s *Server) handleServerConfigOrToolsAPI(w http.ResponseWriter, r *http.Request) {
 	// Extract server name from URL path
 	path := r.URL.Path
@@ -3384,8 +4121,14 @@ func (s *Server) handleServerConfigOrToolsAPI(w http.ResponseWriter, r *http.Req
 	}
 
 	// Route based on method and endpoint
-	if endpoint == "tools" && r.Method == http.MethodGet {
+	if endpoint == "oauth" && len(parts) == 3 {
+		s.handleServerOAuthAPI(w, r, serverName, parts[2])
+	} else if endpoint == "tools" && r.Method == http.MethodGet {
 		s.handleGetServerTools(w, r, serverName)
+	} else if endpoint == "capabilities" && r.Method == http.MethodGet {
+		s.handleGetServerCapabilities(w, r, serverName)
+	} else if endpoint == "logs" && r.Method == http.MethodGet {
+		s.handleGetServerLogs(w, r, serverName)
 	} else if endpoint == "config" && r.Method == http.MethodPut {
 		s.handleUpdateServerConfig(w, r, serverName)
 	} else {
@@ -0,0 +1,167 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+)
+
+// errServerNotFound is returned for server names that are neither configured nor running
+var errServerNotFound = errors.New("server not found")
+
+// capabilityFeatures summarizes the optional MCP features an upstream advertised
+type capabilityFeatures struct {
+	Tools                bool     `json:"tools"`
+	ToolsListChanged     bool     `json:"tools_list_changed"`
+	Resources            bool     `json:"resources"`
+	ResourcesSubscribe   bool     `json:"resources_subscribe"`
+	ResourcesListChanged bool     `json:"resources_list_changed"`
+	Prompts              bool     `json:"prompts"`
+	PromptsListChanged   bool     `json:"prompts_list_changed"`
+	Logging              bool     `json:"logging"`
+	Sampling             bool     `json:"sampling"`
+	Experimental         []string `json:"experimental,omitempty"`
+}
+
+// serverCapabilities is the initialize result of an upstream server as returned by the
+// server_capabilities tool and /api/servers/{name}/capabilities
+type serverCapabilities struct {
+	Server    string `json:"server"`
+	State     string `json:"state"`
+	Connected bool   `json:"connected"`
+	// Initialized is false until the server completed its first initialize handshake. The
+	// result of the last handshake is kept while the server is disconnected.
+	Initialized     bool                    `json:"initialized"`
+	InitializedAt   *time.Time              `json:"initialized_at,omitempty"`
+	ProtocolVersion string                  `json:"protocol_version,omitempty"`
+	ServerInfo      *mcp.Implementation     `json:"server_info,omitempty"`
+	Instructions    string                  `json:"instructions,omitempty"`
+	Features        *capabilityFeatures     `json:"features,omitempty"`
+	Capabilities    *mcp.ServerCapabilities `json:"capabilities,omitempty"` // As advertised by the server
+}
+
+// newCapabilityFeatures flattens the advertised capabilities into feature flags
+func newCapabilityFeatures(caps mcp.ServerCapabilities) capabilityFeatures {
+	features := capabilityFeatures{
+		Tools:     caps.Tools != nil,
+		Resources: caps.Resources != nil,
+		Prompts:   caps.Prompts != nil,
+		Logging:   caps.Logging != nil,
+		Sampling:  caps.Sampling != nil,
+	}
+	if caps.Tools != nil {
+		features.ToolsListChanged = caps.Tools.ListChanged
+	}
+	if caps.Resources != nil {
+		features.ResourcesSubscribe = caps.Resources.Subscribe
+		features.ResourcesListChanged = caps.Resources.ListChanged
+	}
+	if caps.Prompts != nil {
+		features.PromptsListChanged = caps.Prompts.ListChanged
+	}
+	for name := range caps.Experimental {
+		features.Experimental = append(features.Experimental, name)
+	}
+	sort.Strings(features.Experimental)
+	return features
+}
+
+// newServerCapabilities builds the capabilities report from a client's last initialize result,
+// which is nil if the server never completed a handshake
+func newServerCapabilities(name, state string, connected bool, result *mcp.InitializeResult, initializedAt time.Time) serverCapabilities {
+	report := serverCapabilities{
+		Server:    name,
+		State:     state,
+		Connected: connected,
+	}
+	if result == nil {
+		return report
+	}
+
+	features := newCapabilityFeatures(result.Capabilities)
+	serverInfo := result.ServerInfo
+	capabilities := result.Capabilities
+
+	report.Initialized = true
+	report.InitializedAt = &initializedAt
+	report.ProtocolVersion = result.ProtocolVersion
+	report.ServerInfo = &serverInfo
+	report.Instructions = result.Instructions
+	report.Features = &features
+	report.Capabilities = &capabilities
+	return report
+}
+
+// GetServerCapabilities returns what the named upstream server advertised in its last
+// initialize handshake
+func (s *Server) GetServerCapabilities(serverName string) (*serverCapabilities, error) {
+	if s.upstreamManager != nil {
+		if client, exists := s.upstreamManager.GetClient(serverName); exists {
+			result, initializedAt := client.LastInitializeResult()
+			report := newServerCapabilities(serverName, client.GetState().String(), client.IsConnected(), result, initializedAt)
+			return &report, nil
+		}
+	}
+
+	// Configured servers without a client (e.g. disabled) have never been initialized
+	for _, server := range s.config.Servers {
+		if server.Name == serverName {
+			state := "Not Started"
+			if server.IsDisabled() {
+				state = "Disabled"
+			}
+			report := newServerCapabilities(serverName, state, false, nil, time.Time{})
+			return &report, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", errServerNotFound, serverName)
+}
+
+// handleGetServerCapabilities handles GET /api/servers/{name}/capabilities
+func (s *Server) handleGetServerCapabilities(w http.ResponseWriter, _ *http.Request, serverName string) {
+	report, err := s.GetServerCapabilities(serverName)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, errServerNotFound) {
+			status = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		s.logger.Error("Failed to encode capabilities JSON", zap.Error(err))
+	}
+}
+
+// handleServerCapabilities implements the server_capabilities MCP tool
+func (p *MCPProxyServer) handleServerCapabilities(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, err := request.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError("Missing required parameter 'name'"), nil
+	}
+	if p.mainServer == nil {
+		return mcp.NewToolResultError("Server capabilities are not available"), nil
+	}
+
+	report, err := p.mainServer.GetServerCapabilities(name)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	jsonResult, err := json.Marshal(report)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to serialize result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonResult)), nil
+}
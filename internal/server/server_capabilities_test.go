@@ -0,0 +1,59 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewServerCapabilities(t *testing.T) {
+	result := &mcp.InitializeResult{
+		ProtocolVersion: "2025-03-26",
+		ServerInfo:      mcp.Implementation{Name: "docs-server", Version: "1.2.0"},
+		Instructions:    "Search the docs first",
+	}
+	result.Capabilities.Resources = &struct {
+		Subscribe   bool `json:"subscribe,omitempty"`
+		ListChanged bool `json:"listChanged,omitempty"`
+	}{Subscribe: true}
+	result.Capabilities.Tools = &struct {
+		ListChanged bool `json:"listChanged,omitempty"`
+	}{ListChanged: true}
+	result.Capabilities.Experimental = map[string]any{"streaming": true, "batching": true}
+
+	initializedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	report := newServerCapabilities("docs", "Ready", true, result, initializedAt)
+
+	assert.True(t, report.Initialized)
+	assert.Equal(t, "2025-03-26", report.ProtocolVersion)
+	assert.Equal(t, "docs-server", report.ServerInfo.Name)
+	assert.Equal(t, initializedAt, *report.InitializedAt)
+	assert.Equal(t, capabilityFeatures{
+		Tools:              true,
+		ToolsListChanged:   true,
+		Resources:          true,
+		ResourcesSubscribe: true,
+		Experimental:       []string{"batching", "streaming"},
+	}, *report.Features)
+
+	// The advertised capabilities are passed through as-is
+	data, err := json.Marshal(report)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"capabilities":{"experimental"`)
+	assert.Contains(t, string(data), `"resources":{"subscribe":true}`)
+}
+
+func TestNewServerCapabilities_NeverInitialized(t *testing.T) {
+	report := newServerCapabilities("slow", "Connecting", false, nil, time.Time{})
+
+	assert.False(t, report.Initialized)
+	assert.Equal(t, "Connecting", report.State)
+
+	data, err := json.Marshal(report)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"server":"slow","state":"Connecting","connected":false,"initialized":false}`, string(data))
+}
@@ -347,6 +347,7 @@ func (s *Server) handleChatCallTool(w http.ResponseWriter, r *http.Request) {
 	// MED-002: Use centralized timeout to prevent hanging API requests
 	ctx, cancel := context.WithTimeout(r.Context(), config.ToolCallTimeout)
 	defer cancel()
+	ctx, _ = withAuditClient(ctx, auditClientChat, "")
 
 	var result interface{}
 	var err error
@@ -568,6 +569,7 @@ func (s *Server) handleChatTestServerTools(w http.ResponseWriter, r *http.Reques
 			// Call the tool with test arguments
 			// MED-002: Use centralized timeout for each tool call
 			callCtx, callCancel := context.WithTimeout(r.Context(), config.ToolCallTimeout)
+			callCtx, _ = withAuditClient(callCtx, auditClientChat, "")
 			fullToolName := fmt.Sprintf("%s:%s", request.ServerName, toolName)
 			result, err := s.upstreamManager.CallTool(callCtx, fullToolName, testCase.Arguments)
 			callCancel()
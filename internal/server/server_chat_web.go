@@ -22,6 +22,7 @@ type chatSession struct {
 	ServerName string
 	Messages   []chatMessage
 	CreatedAt  time.Time
+	LastActive time.Time
 }
 
 // chatMessage represents a single message in the conversation
@@ -46,14 +47,20 @@ func (sm *sessionManager) getOrCreateSession(sessionID, serverName string) *chat
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 
+	now := time.Now()
 	if session, exists := sm.sessions[sessionID]; exists {
+		session.LastActive = now
 		return session
 	}
 
+	// Drop idle sessions before adding a new one so the map doesn't grow without bound
+	sm.evictIdleLocked(now, chatSessionTTL)
+
 	session := &chatSession{
 		ServerName: serverName,
 		Messages:   make([]chatMessage, 0),
-		CreatedAt:  time.Now(),
+		CreatedAt:  now,
+		LastActive: now,
 	}
 	sm.sessions[sessionID] = session
 	return session
@@ -69,6 +76,7 @@ func (sm *sessionManager) addMessage(sessionID string, role, content string) {
 			Role:    role,
 			Content: content,
 		})
+		session.LastActive = time.Now()
 	}
 }
 
@@ -1076,22 +1084,29 @@ func (s *Server) handleServerChat(w http.ResponseWriter, r *http.Request) {
                     sessionId = sessionData.session_id;
                 }
 
-                // Send message
+                // Send message, asking for the answer to be streamed as it is generated
                 const response = await fetch('/api/chat/sessions/' + sessionId + '/messages', {
                     method: 'POST',
-                    headers: { 'Content-Type': 'application/json' },
+                    headers: {
+                        'Content-Type': 'application/json',
+                        'Accept': 'text/event-stream'
+                    },
                     body: JSON.stringify({
                         message: message,
                         agent_type: 'llm'
                     })
                 });
 
-                const data = await response.json();
-
-                if (data.error) {
-                    addMessage('agent', 'Error: ' + data.error, true);
+                let data;
+                if ((response.headers.get('Content-Type') || '').startsWith('text/event-stream')) {
+                    data = await readChatStream(response);
                 } else {
-                    addMessage('agent', data.response);
+                    data = await response.json();
+                    if (data.error) {
+                        addMessage('agent', 'Error: ' + data.error, true);
+                    } else {
+                        addMessage('agent', data.response);
+                    }
                 }
 
                 // Display MCP communications if available
@@ -1108,6 +1123,59 @@ func (s *Server) handleServerChat(w http.ResponseWriter, r *http.Request) {
             }
         }
 
+        // Read a streamed answer, showing tokens as they arrive. Returns the final event payload.
+        async function readChatStream(response) {
+            const reader = response.body.getReader();
+            const decoder = new TextDecoder();
+            let buffer = '';
+            let streamed = '';
+            let contentDiv = null;
+            let result = {};
+
+            while (true) {
+                const { done, value } = await reader.read();
+                if (done) break;
+                buffer += decoder.decode(value, { stream: true });
+
+                let end;
+                while ((end = buffer.indexOf('\n\n')) !== -1) {
+                    const raw = buffer.slice(0, end);
+                    buffer = buffer.slice(end + 2);
+
+                    let event = 'message';
+                    let payload = '';
+                    raw.split('\n').forEach(line => {
+                        if (line.startsWith('event: ')) event = line.slice(7);
+                        else if (line.startsWith('data: ')) payload += line.slice(6);
+                    });
+                    if (!payload) continue;
+                    const data = JSON.parse(payload);
+
+                    if (event === 'token') {
+                        if (!contentDiv) {
+                            document.getElementById('loading').classList.remove('active');
+                            contentDiv = addMessage('agent', '');
+                        }
+                        streamed += data.content;
+                        contentDiv.innerHTML = formatMessageContent(streamed);
+                        const messagesDiv = document.getElementById('chat-messages');
+                        messagesDiv.scrollTop = messagesDiv.scrollHeight;
+                    } else if (event === 'done') {
+                        result = data;
+                        if (contentDiv) {
+                            contentDiv.innerHTML = formatMessageContent(data.response);
+                        } else {
+                            addMessage('agent', data.response);
+                        }
+                    } else if (event === 'error') {
+                        result = data;
+                        addMessage('agent', 'Error: ' + data.error, true);
+                    }
+                }
+            }
+            return result;
+        }
+
         // Display MCP communications in sidebar
         function displayMCPCommunications(communications) {
             const mcpDiv = document.getElementById('mcpCommunications');
@@ -1168,14 +1236,7 @@ func (s *Server) handleServerChat(w http.ResponseWriter, r *http.Request) {
             const contentDiv = document.createElement('div');
             contentDiv.className = 'message-content content-' + role;
 
-            // Format content (convert markdown-style code blocks)
-            let formattedContent = content;
-            // Use triple-tilde instead of triple-backtick to avoid string delimiter conflicts
-            formattedContent = formattedContent.replace(/~~~(\w+)?\n([\s\S]*?)~~~/g, '<pre><code>$2</code></pre>');
-            formattedContent = formattedContent.replace(/~([^~]+)~/g, '<code>$1</code>');
-            formattedContent = formattedContent.replace(/\n/g, '<br>');
-
-            contentDiv.innerHTML = formattedContent;
+            contentDiv.innerHTML = formatMessageContent(content);
 
             if (role === 'user') {
                 messageDiv.appendChild(contentDiv);
@@ -1187,6 +1248,17 @@ func (s *Server) handleServerChat(w http.ResponseWriter, r *http.Request) {
 
             messagesDiv.appendChild(messageDiv);
             messagesDiv.scrollTop = messagesDiv.scrollHeight;
+            return contentDiv;
+        }
+
+        // Format content (convert markdown-style code blocks)
+        function formatMessageContent(content) {
+            let formattedContent = content;
+            // Use triple-tilde instead of triple-backtick to avoid string delimiter conflicts
+            formattedContent = formattedContent.replace(/~~~(\w+)?\n([\s\S]*?)~~~/g, '<pre><code>$2</code></pre>');
+            formattedContent = formattedContent.replace(/~([^~]+)~/g, '<code>$1</code>');
+            formattedContent = formattedContent.replace(/\n/g, '<br>');
+            return formattedContent;
         }
 
         // Update context information
@@ -1366,8 +1438,12 @@ func (s *Server) handleServerChat(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprint(w, html)
 }
 
-// handleChatSession creates or retrieves a chat session
+// handleChatSession creates or retrieves a chat session (POST) or lists active sessions (GET)
 func (s *Server) handleChatSession(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		s.handleListChatSessions(w, r)
+		return
+	}
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
@@ -1437,7 +1513,8 @@ func (s *Server) handleChatMessage(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	if apiKey == "" {
+	// OpenAI-compatible servers configured via llm.base_url usually don't need a key
+	if apiKey == "" && s.config.LLM.ChatRequiresAPIKey() {
 		responseData := map[string]interface{}{
 			"error": "OpenAI API key not configured. Please set OPENAI_API_KEY in .env file or environment variable.",
 		}
@@ -1531,13 +1608,24 @@ If testing tools:
 	// and leave room for function definitions (~151 tokens) + response (~2000 tokens)
 	messages = pruneMessages(messages, 40000)
 
+	// Stream tokens as server-sent events when the client asks for it, otherwise buffer the answer
+	stream := newChatEventStream(w, r)
+	var onToken func(string)
+	if stream != nil {
+		onToken = stream.token
+	}
+
 	// Call OpenAI API with full conversation history and tools support
-	response, mcpCommunications, err := s.callOpenAIWithTools(apiKey, messages, serverName)
+	response, mcpCommunications, err := s.callOpenAIWithToolsStream(r.Context(), apiKey, messages, serverName, onToken)
 	if err != nil {
 		responseData := map[string]interface{}{
 			"error":               fmt.Sprintf("AI request failed: %v", err),
 			"mcp_communications":  mcpCommunications,
 		}
+		if stream != nil {
+			stream.send("error", responseData)
+			return
+		}
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(responseData)
 		return
@@ -1551,6 +1639,12 @@ If testing tools:
 		"mcp_communications": mcpCommunications,
 	}
 
+	if stream != nil {
+		// The final event carries the complete answer, including notes added after streaming
+		stream.send("done", responseData)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(responseData)
 }
@@ -1732,12 +1826,12 @@ func (s *Server) buildServerContext(serverName string) string {
 
 // callOpenAI makes a request to OpenAI API with conversation history
 func (s *Server) callOpenAI(apiKey string, messages []chatMessage) (string, error) {
-	// OpenAI API endpoint
-	apiURL := "https://api.openai.com/v1/chat/completions"
+	// OpenAI or OpenAI-compatible API endpoint
+	apiURL := s.config.LLM.ChatCompletionsURL()
 
 	// Prepare request payload
 	reqBody := openAIRequest{
-		Model:       "gpt-4o-mini", // Use gpt-4o-mini for cost efficiency, can be changed to "gpt-4" for better quality
+		Model:       s.config.LLM.ChatModel(),
 		Messages:    messages,
 		Temperature: 0.7,
 		MaxTokens:   2000,
@@ -1760,7 +1854,9 @@ func (s *Server) callOpenAI(apiKey string, messages []chatMessage) (string, erro
 
 	// Set headers
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", apiKey))
+	if apiKey != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", apiKey))
+	}
 
 	// Send request
 	client := &http.Client{
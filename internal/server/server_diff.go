@@ -0,0 +1,202 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpproxy-go/internal/config"
+)
+
+// similarToolThreshold is the description similarity from which two differently named tools
+// are reported as doing the same thing
+const similarToolThreshold = 0.5
+
+// commonTool is a tool offered by both servers under the same name
+type commonTool struct {
+	Name       string  `json:"name"`
+	Similarity float64 `json:"description_similarity"`
+}
+
+// similarToolPair is a pair of differently named tools with similar descriptions
+type similarToolPair struct {
+	ToolA      string  `json:"tool_a"`
+	ToolB      string  `json:"tool_b"`
+	Similarity float64 `json:"description_similarity"`
+}
+
+// serverToolsDiff is the comparison of the tool sets of two servers. Tool names are given
+// without the server prefix.
+type serverToolsDiff struct {
+	OnlyInA []string          `json:"only_in_a"`
+	OnlyInB []string          `json:"only_in_b"`
+	Common  []commonTool      `json:"common"`
+	Similar []similarToolPair `json:"similar"`
+}
+
+// descriptionWords returns the distinct lowercase words of a description, ignoring words
+// shorter than three characters
+func descriptionWords(description string) map[string]bool {
+	words := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(description), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len([]rune(word)) >= 3 {
+			words[word] = true
+		}
+	}
+	return words
+}
+
+// descriptionSimilarity is the Jaccard similarity of the words of two descriptions, from 0
+// (no word in common) to 1 (same words)
+func descriptionSimilarity(a, b string) float64 {
+	wordsA, wordsB := descriptionWords(a), descriptionWords(b)
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return 0
+	}
+	shared := 0
+	for word := range wordsA {
+		if wordsB[word] {
+			shared++
+		}
+	}
+	similarity := float64(shared) / float64(len(wordsA)+len(wordsB)-shared)
+	return math.Round(similarity*100) / 100
+}
+
+// diffServerTools compares two tool sets, first by name, then pairing the remaining tools
+// whose descriptions are at least similarToolThreshold similar, most similar first
+func diffServerTools(toolsA, toolsB []*config.ToolMetadata) serverToolsDiff {
+	bareName := func(tool *config.ToolMetadata) string {
+		if _, name, found := strings.Cut(tool.Name, ":"); found {
+			return name
+		}
+		return tool.Name
+	}
+
+	descriptionsB := make(map[string]string, len(toolsB))
+	for _, tool := range toolsB {
+		descriptionsB[bareName(tool)] = tool.Description
+	}
+
+	diff := serverToolsDiff{
+		OnlyInA: []string{},
+		OnlyInB: []string{},
+		Common:  []commonTool{},
+		Similar: []similarToolPair{},
+	}
+	matched := make(map[string]bool)
+	remainingA := make(map[string]string)
+	for _, tool := range toolsA {
+		name := bareName(tool)
+		if descriptionB, ok := descriptionsB[name]; ok {
+			diff.Common = append(diff.Common, commonTool{Name: name, Similarity: descriptionSimilarity(tool.Description, descriptionB)})
+			matched[name] = true
+			continue
+		}
+		remainingA[name] = tool.Description
+	}
+	remainingB := make(map[string]string)
+	for name, description := range descriptionsB {
+		if !matched[name] {
+			remainingB[name] = description
+		}
+	}
+
+	var candidates []similarToolPair
+	for nameA, descriptionA := range remainingA {
+		for nameB, descriptionB := range remainingB {
+			if similarity := descriptionSimilarity(descriptionA, descriptionB); similarity >= similarToolThreshold {
+				candidates = append(candidates, similarToolPair{ToolA: nameA, ToolB: nameB, Similarity: similarity})
+			}
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Similarity != candidates[j].Similarity {
+			return candidates[i].Similarity > candidates[j].Similarity
+		}
+		if candidates[i].ToolA != candidates[j].ToolA {
+			return candidates[i].ToolA < candidates[j].ToolA
+		}
+		return candidates[i].ToolB < candidates[j].ToolB
+	})
+	for _, pair := range candidates {
+		if _, ok := remainingA[pair.ToolA]; !ok {
+			continue
+		}
+		if _, ok := remainingB[pair.ToolB]; !ok {
+			continue
+		}
+		diff.Similar = append(diff.Similar, pair)
+		delete(remainingA, pair.ToolA)
+		delete(remainingB, pair.ToolB)
+	}
+
+	for name := range remainingA {
+		diff.OnlyInA = append(diff.OnlyInA, name)
+	}
+	for name := range remainingB {
+		diff.OnlyInB = append(diff.OnlyInB, name)
+	}
+	sort.Strings(diff.OnlyInA)
+	sort.Strings(diff.OnlyInB)
+	sort.Slice(diff.Common, func(i, j int) bool {
+		return diff.Common[i].Name < diff.Common[j].Name
+	})
+	return diff
+}
+
+// handleDiffServerTools implements the diff_server_tools MCP tool. It compares the stored tool
+// metadata, so disconnected servers can be compared too.
+func (p *MCPProxyServer) handleDiffServerTools(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	serverA, err := request.RequireString("server_a")
+	if err != nil {
+		return mcp.NewToolResultError("Missing required parameter 'server_a'"), nil
+	}
+	serverB, err := request.RequireString("server_b")
+	if err != nil {
+		return mcp.NewToolResultError("Missing required parameter 'server_b'"), nil
+	}
+	if serverA == serverB {
+		return mcp.NewToolResultError("server_a and server_b must be different servers"), nil
+	}
+
+	var toolSets [2][]*config.ToolMetadata
+	for i, serverName := range []string{serverA, serverB} {
+		serverConfig, err := p.storage.GetUpstreamServer(serverName)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Server '%s' not found", serverName)), nil
+		}
+		// Tools of quarantined servers are only shown through quarantine_security
+		if serverConfig.IsQuarantined() {
+			return mcp.NewToolResultError(fmt.Sprintf("Server '%s' is quarantined for security review. Use the 'quarantine_security' tool to inspect its tools.", serverName)), nil
+		}
+		if toolSets[i], err = p.storage.GetToolMetadata(serverName); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to load tools of server '%s': %v", serverName, err)), nil
+		}
+	}
+
+	diff := diffServerTools(toolSets[0], toolSets[1])
+	jsonResult, err := json.Marshal(map[string]interface{}{
+		"server_a":       serverA,
+		"server_b":       serverB,
+		"tool_count_a":   len(toolSets[0]),
+		"tool_count_b":   len(toolSets[1]),
+		"only_in_a":      diff.OnlyInA,
+		"only_in_b":      diff.OnlyInB,
+		"common":         diff.Common,
+		"similar":        diff.Similar,
+		"similar_cutoff": similarToolThreshold,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to serialize result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(jsonResult)), nil
+}
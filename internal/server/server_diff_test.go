@@ -0,0 +1,48 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"mcpproxy-go/internal/config"
+)
+
+func TestDescriptionSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, descriptionSimilarity("Create a new issue", "create NEW issue!"))
+	assert.Equal(t, 0.0, descriptionSimilarity("Create a new issue", "Delete files"))
+	assert.Equal(t, 0.0, descriptionSimilarity("", "Delete files"))
+	assert.Equal(t, 0.5, descriptionSimilarity("search open issues", "search issues by label"))
+}
+
+func TestDiffServerTools(t *testing.T) {
+	github := []*config.ToolMetadata{
+		{Name: "github:create_issue", Description: "Create a new issue in a repository"},
+		{Name: "github:search_issues", Description: "Search issues and pull requests by query"},
+		{Name: "github:list_repos", Description: "List repositories of the user"},
+		{Name: "github:fork", Description: "Fork a repository"},
+	}
+	gitlab := []*config.ToolMetadata{
+		{Name: "gitlab:create_issue", Description: "Create an issue in a project"},
+		{Name: "gitlab:find_issues", Description: "Search issues and merge requests by query"},
+		{Name: "gitlab:list_pipelines", Description: "List CI pipelines of a project"},
+	}
+
+	diff := diffServerTools(github, gitlab)
+	assert.Equal(t, []string{"fork", "list_repos"}, diff.OnlyInA)
+	assert.Equal(t, []string{"list_pipelines"}, diff.OnlyInB)
+	assert.Equal(t, []commonTool{{Name: "create_issue", Similarity: 0.4}}, diff.Common)
+	assert.Equal(t, []similarToolPair{{ToolA: "search_issues", ToolB: "find_issues", Similarity: 0.71}}, diff.Similar)
+
+	// Each tool is paired at most once, with its most similar counterpart
+	diff = diffServerTools(
+		[]*config.ToolMetadata{{Name: "a:post", Description: "Send a chat message to a channel"}},
+		[]*config.ToolMetadata{
+			{Name: "b:send", Description: "Send a chat message to a channel"},
+			{Name: "b:send_dm", Description: "Send a chat message to a user"},
+		},
+	)
+	assert.Equal(t, []similarToolPair{{ToolA: "post", ToolB: "send", Similarity: 1}}, diff.Similar)
+	assert.Equal(t, []string{"send_dm"}, diff.OnlyInB)
+	assert.Empty(t, diff.OnlyInA)
+}
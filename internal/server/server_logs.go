@@ -0,0 +1,140 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
+	"mcpproxy-go/internal/logs"
+)
+
+const (
+	defaultServerLogLines = 50
+	maxServerLogLines     = 500 // Bound of logs.ReadUpstreamServerLogTail
+)
+
+// serverLogTail is the end of an upstream server's log file as returned by
+// GET /api/servers/{name}/logs and upstream_servers operation 'logs'
+type serverLogTail struct {
+	Server string `json:"server"`
+	Path   string `json:"path"`
+	// Exists is false until the server wrote its first log line, e.g. before its first connect
+	Exists         bool     `json:"exists"`
+	LinesRequested int      `json:"lines_requested"`
+	Lines          []string `json:"lines"`
+}
+
+// GetServerLogTail returns the last lines of the named server's log file in GetLogDir()
+func (s *Server) GetServerLogTail(serverName string, lines int) (*serverLogTail, error) {
+	if !s.serverExists(serverName) {
+		return nil, fmt.Errorf("%w: %s", errServerNotFound, serverName)
+	}
+
+	if lines <= 0 {
+		lines = defaultServerLogLines
+	}
+	if lines > maxServerLogLines {
+		lines = maxServerLogLines
+	}
+
+	logDir := s.GetLogDir()
+	path, err := logs.GetLogFilePathWithDir(logDir, fmt.Sprintf("server-%s.log", serverName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve log file of server %s: %w", serverName, err)
+	}
+
+	tail := &serverLogTail{
+		Server:         serverName,
+		Path:           path,
+		LinesRequested: lines,
+		Lines:          []string{},
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return tail, nil
+	}
+
+	logLines, err := logs.ReadUpstreamServerLogTail(&config.LogConfig{LogDir: logDir}, serverName, lines)
+	if err != nil {
+		return nil, err
+	}
+	tail.Exists = true
+	tail.Lines = logLines
+	return tail, nil
+}
+
+// serverExists reports whether serverName is configured or has an upstream client
+func (s *Server) serverExists(serverName string) bool {
+	if s.upstreamManager != nil {
+		if _, exists := s.upstreamManager.GetClient(serverName); exists {
+			return true
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, server := range s.config.Servers {
+		if server.Name == serverName {
+			return true
+		}
+	}
+	return false
+}
+
+// handleGetServerLogs handles GET /api/servers/{name}/logs?tail=N
+func (s *Server) handleGetServerLogs(w http.ResponseWriter, r *http.Request, serverName string) {
+	lines := defaultServerLogLines
+	if tail := r.URL.Query().Get("tail"); tail != "" {
+		parsed, err := strconv.Atoi(tail)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Invalid tail value: %s", tail), http.StatusBadRequest)
+			return
+		}
+		lines = parsed
+	}
+
+	tail, err := s.GetServerLogTail(serverName, lines)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, errServerNotFound) {
+			status = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(tail); err != nil {
+		s.logger.Error("Failed to encode server log JSON", zap.Error(err))
+	}
+}
+
+// handleServerLogs implements upstream_servers operation 'logs'
+func (p *MCPProxyServer) handleServerLogs(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, err := request.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError("Missing required parameter 'name'"), nil
+	}
+	if p.mainServer == nil {
+		return mcp.NewToolResultError("Server logs are not available"), nil
+	}
+
+	tail, err := p.mainServer.GetServerLogTail(name, int(request.GetFloat("lines", defaultServerLogLines)))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	jsonResult, err := json.Marshal(tail)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to serialize result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonResult)), nil
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
+)
+
+func newServerLogsTestServer(t *testing.T) (*Server, string) {
+	logDir := t.TempDir()
+	return &Server{
+		config: &config.Config{
+			Logging: &config.LogConfig{LogDir: logDir},
+			Servers: []*config.ServerConfig{{Name: "github"}},
+		},
+		logger: zap.NewNop(),
+	}, logDir
+}
+
+func TestGetServerLogTail(t *testing.T) {
+	s, logDir := newServerLogsTestServer(t)
+
+	tail, err := s.GetServerLogTail("github", 10)
+	require.NoError(t, err)
+	assert.False(t, tail.Exists, "no log file before the server logged anything")
+	assert.Empty(t, tail.Lines)
+	assert.Equal(t, filepath.Join(logDir, "server-github.log"), tail.Path)
+
+	require.NoError(t, os.WriteFile(tail.Path, []byte("one\ntwo\nthree\n"), 0600))
+
+	tail, err = s.GetServerLogTail("github", 2)
+	require.NoError(t, err)
+	assert.True(t, tail.Exists)
+	assert.Equal(t, []string{"two", "three"}, tail.Lines)
+
+	tail, err = s.GetServerLogTail("github", 10000)
+	require.NoError(t, err)
+	assert.Equal(t, maxServerLogLines, tail.LinesRequested)
+
+	_, err = s.GetServerLogTail("unknown", 10)
+	assert.ErrorIs(t, err, errServerNotFound)
+}
+
+func TestHandleGetServerLogs(t *testing.T) {
+	s, logDir := newServerLogsTestServer(t)
+	require.NoError(t, os.WriteFile(filepath.Join(logDir, "server-github.log"), []byte("one\ntwo\n"), 0600))
+
+	rec := httptest.NewRecorder()
+	s.handleGetServerLogs(rec, httptest.NewRequest(http.MethodGet, "/api/servers/github/logs?tail=1", nil), "github")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var tail serverLogTail
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &tail))
+	assert.Equal(t, []string{"two"}, tail.Lines)
+
+	rec = httptest.NewRecorder()
+	s.handleGetServerLogs(rec, httptest.NewRequest(http.MethodGet, "/api/servers/github/logs?tail=abc", nil), "github")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = httptest.NewRecorder()
+	s.handleGetServerLogs(rec, httptest.NewRequest(http.MethodGet, "/api/servers/unknown/logs", nil), "unknown")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
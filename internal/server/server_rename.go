@@ -0,0 +1,229 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
+	"mcpproxy-go/internal/events"
+	"mcpproxy-go/internal/storage"
+)
+
+// RenameServer renames a server: its storage record, stored tool metadata and stats, search
+// index entries, group assignment, depends_on and featured_tools references, config entry and
+// runtime stopped and snoozed state. It fails if newName is already taken or durable calls to
+// the server are still queued or running.
+func (s *Server) RenameServer(oldName, newName string) error {
+	newName = strings.TrimSpace(newName)
+	if newName == "" {
+		return fmt.Errorf("new server name cannot be empty")
+	}
+	if strings.Contains(newName, ":") {
+		return fmt.Errorf("server name '%s' cannot contain ':'", newName)
+	}
+	if newName == oldName {
+		return fmt.Errorf("server '%s' already has this name", oldName)
+	}
+
+	s.mu.RLock()
+	found := false
+	for _, existing := range s.config.Servers {
+		if existing.Name == newName {
+			s.mu.RUnlock()
+			return fmt.Errorf("server '%s' already exists", newName)
+		}
+		if existing.Name == oldName {
+			found = true
+		}
+	}
+	s.mu.RUnlock()
+	if !found {
+		return fmt.Errorf("%w: %s", errServerNotFound, oldName)
+	}
+
+	// Queued durable calls address the server by name and would fail after the rename
+	pending, err := s.pendingDurableCalls(oldName)
+	if err != nil {
+		return fmt.Errorf("failed to check durable calls of server '%s': %w", oldName, err)
+	}
+	if pending > 0 {
+		return fmt.Errorf("server '%s' has %d durable calls queued or running; rename it once they finished", oldName, pending)
+	}
+
+	// Storage is authoritative and renames everything keyed by the server name in one
+	// transaction, so a failure here leaves the server untouched
+	if err := s.storageManager.RenameUpstreamServer(oldName, newName); err != nil {
+		return fmt.Errorf("failed to rename server '%s' in storage: %w", oldName, err)
+	}
+
+	s.mu.Lock()
+	var serverConfig *config.ServerConfig
+	for _, sc := range s.config.Servers {
+		if sc.Name == oldName {
+			sc.Name = newName
+			sc.Updated = time.Now()
+			serverConfig = sc
+		}
+		for i, dep := range sc.DependsOn {
+			if dep == oldName {
+				sc.DependsOn[i] = newName
+			}
+		}
+	}
+	for i, name := range s.config.FeaturedTools {
+		if toolName, ok := strings.CutPrefix(strings.TrimSpace(name), oldName+":"); ok {
+			s.config.FeaturedTools[i] = newName + ":" + toolName
+		}
+	}
+	s.config.RenameServerSource(oldName, newName)
+	s.mu.Unlock()
+
+	assignmentsMutex.Lock()
+	if groupName, ok := serverGroupAssignments[oldName]; ok {
+		delete(serverGroupAssignments, oldName)
+		serverGroupAssignments[newName] = groupName
+	}
+	assignmentsMutex.Unlock()
+
+	s.reindexRenamedServer(oldName, newName)
+	if s.mcpProxy != nil {
+		s.mcpProxy.publishFeaturedTools()
+	}
+
+	// Runtime-only state lives on the upstream client and moves to the replacement
+	var userStopped bool
+	var snoozedUntil time.Time
+	if client, exists := s.upstreamManager.GetClient(oldName); exists {
+		userStopped = client.StateManager.IsUserStopped()
+		snoozedUntil = client.StateManager.SnoozedUntil()
+	}
+
+	// Replace the upstream client so tools are served under the new prefix
+	s.upstreamManager.RemoveServer(oldName)
+	if serverConfig.StartupMode == "active" || serverConfig.StartupMode == "lazy_loading" {
+		if err := s.upstreamManager.AddServerConfig(newName, serverConfig); err != nil {
+			s.logger.Warn("Failed to add renamed server to upstream manager",
+				zap.String("server", newName),
+				zap.Error(err))
+		} else if client, exists := s.upstreamManager.GetClient(newName); exists {
+			client.StateManager.SetUserStopped(userStopped)
+			client.StateManager.SetSnoozedUntil(snoozedUntil)
+
+			// A server the user stopped stays stopped under its new name
+			if !userStopped && serverConfig.ShouldConnectOnStartup() {
+				go func() {
+					connectCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+					defer cancel()
+					if err := client.Connect(connectCtx); err != nil {
+						s.logger.Warn("Background connection failed after rename",
+							zap.String("server", newName),
+							zap.Error(err))
+					}
+				}()
+			}
+		}
+	}
+
+	if err := s.SaveConfiguration(); err != nil {
+		s.logger.Error("Failed to save configuration after renaming server",
+			zap.String("server", newName),
+			zap.Error(err))
+	}
+	s.OnUpstreamServerChange()
+
+	s.eventBus.Publish(events.Event{
+		Type:       events.EventConfigChange,
+		ServerName: newName,
+		Data: events.ConfigChangeData{
+			Action: "renamed",
+		},
+	})
+
+	s.logger.Info("Renamed upstream server",
+		zap.String("old_name", oldName),
+		zap.String("new_name", newName))
+	return nil
+}
+
+// pendingDurableCalls counts the durable calls to serverName that are queued or running
+func (s *Server) pendingDurableCalls(serverName string) (int, error) {
+	records, err := s.storageManager.ListDurableCalls()
+	if err != nil {
+		return 0, err
+	}
+
+	pending := 0
+	for _, record := range records {
+		if record.Status != storage.DurableCallPending && record.Status != storage.DurableCallRunning {
+			continue
+		}
+		if recordServer, _, _ := strings.Cut(record.Tool, ":"); recordServer == serverName {
+			pending++
+		}
+	}
+	return pending, nil
+}
+
+// reindexRenamedServer moves a renamed server's tools in the search index from the old
+// prefix to the new one, using the tool metadata already renamed in storage
+func (s *Server) reindexRenamedServer(oldName, newName string) {
+	if err := s.indexManager.DeleteServerTools(oldName); err != nil {
+		s.logger.Warn("Failed to remove renamed server tools from index",
+			zap.String("server", oldName),
+			zap.Error(err))
+	}
+
+	tools, err := s.storageManager.GetToolMetadata(newName)
+	if err != nil {
+		s.logger.Warn("Failed to load tool metadata of renamed server",
+			zap.String("server", newName),
+			zap.Error(err))
+		return
+	}
+	if len(tools) == 0 {
+		return
+	}
+	if err := s.indexManager.BatchIndexTools(tools); err != nil {
+		s.logger.Warn("Failed to index tools of renamed server",
+			zap.String("server", newName),
+			zap.Error(err))
+	}
+}
+
+// handleRenameUpstream implements the upstream_servers rename operation
+func (p *MCPProxyServer) handleRenameUpstream(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	oldName, err := request.RequireString("old_name")
+	if err != nil {
+		return mcp.NewToolResultError("Missing required parameter 'old_name'"), nil
+	}
+	newName, err := request.RequireString("new_name")
+	if err != nil {
+		return mcp.NewToolResultError("Missing required parameter 'new_name'"), nil
+	}
+	newName = strings.TrimSpace(newName)
+	if p.mainServer == nil {
+		return mcp.NewToolResultError("Renaming servers is not available"), nil
+	}
+
+	if err := p.mainServer.RenameServer(oldName, newName); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to rename server: %v", err)), nil
+	}
+
+	jsonResult, err := json.Marshal(map[string]interface{}{
+		"old_name": oldName,
+		"name":     newName,
+		"renamed":  true,
+		"message":  fmt.Sprintf("Server '%s' is now '%s'. Its tools are called as '%s:<tool>'.", oldName, newName, newName),
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to serialize result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonResult)), nil
+}
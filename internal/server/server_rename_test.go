@@ -0,0 +1,128 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
+	"mcpproxy-go/internal/events"
+	"mcpproxy-go/internal/index"
+	"mcpproxy-go/internal/storage"
+	"mcpproxy-go/internal/upstream"
+)
+
+func setupRenameTestServer(t *testing.T, servers ...*config.ServerConfig) *Server {
+	t.Helper()
+	tempDir := t.TempDir()
+	logger := zap.NewNop()
+
+	storageManager, err := storage.NewManager(tempDir, logger.Sugar())
+	require.NoError(t, err)
+	t.Cleanup(func() { storageManager.Close() })
+
+	indexManager, err := index.NewManager(tempDir, logger, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { indexManager.Close() })
+
+	eventBus := events.NewEventBus()
+	t.Cleanup(eventBus.Close)
+
+	cfg := &config.Config{DataDir: tempDir, Servers: servers}
+	for _, sc := range servers {
+		require.NoError(t, storageManager.SaveUpstreamServer(sc))
+	}
+
+	upstreamManager := upstream.NewManager(logger, cfg, storageManager.GetBoltDB())
+
+	assignmentsMutex.Lock()
+	serverGroupAssignments = make(map[string]string)
+	assignmentsMutex.Unlock()
+
+	return &Server{
+		config:          cfg,
+		logger:          logger,
+		storageManager:  storageManager,
+		indexManager:    indexManager,
+		upstreamManager: upstreamManager,
+		eventBus:        eventBus,
+	}
+}
+
+func TestRenameServer(t *testing.T) {
+	s := setupRenameTestServer(t,
+		&config.ServerConfig{Name: "github", URL: "http://localhost:1/mcp", Protocol: "http", StartupMode: "lazy_loading"},
+		&config.ServerConfig{Name: "gitlab", URL: "http://localhost:2/mcp", Protocol: "http", StartupMode: "disabled", DependsOn: []string{"github"}},
+	)
+	s.config.FeaturedTools = []string{"github:create_issue", "gitlab:create_issue"}
+	require.NoError(t, s.upstreamManager.AddServerConfig("github", s.config.Servers[0]))
+	client, _ := s.upstreamManager.GetClient("github")
+	client.StateManager.SetUserStopped(true)
+	snoozedUntil := time.Now().Add(time.Hour)
+	client.StateManager.SetSnoozedUntil(snoozedUntil)
+
+	tools := []*config.ToolMetadata{{Name: "create_issue", ServerName: "github", Description: "Create a GitHub issue"}}
+	require.NoError(t, s.storageManager.SaveToolMetadata("github", tools))
+	require.NoError(t, s.indexManager.BatchIndexTools([]*config.ToolMetadata{
+		{Name: "github:create_issue", ServerName: "github", Description: "Create a GitHub issue"},
+	}))
+	assignmentsMutex.Lock()
+	serverGroupAssignments["github"] = "dev"
+	assignmentsMutex.Unlock()
+
+	require.NoError(t, s.RenameServer("github", "gh"))
+
+	assert.Equal(t, "gh", s.config.Servers[0].Name)
+	assert.Equal(t, []string{"gh"}, s.config.Servers[1].DependsOn)
+	assert.Equal(t, []string{"gh:create_issue", "gitlab:create_issue"}, s.config.FeaturedTools)
+
+	_, err := s.storageManager.GetUpstreamServer("github")
+	assert.Error(t, err)
+	stored, err := s.storageManager.GetUpstreamServer("gh")
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:1/mcp", stored.URL)
+
+	assignmentsMutex.RLock()
+	assert.Equal(t, "dev", serverGroupAssignments["gh"])
+	_, stale := serverGroupAssignments["github"]
+	assignmentsMutex.RUnlock()
+	assert.False(t, stale)
+
+	results, err := s.indexManager.SearchTools("issue", 10)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "gh:create_issue", results[0].Tool.Name)
+	assert.Equal(t, "gh", results[0].Tool.ServerName)
+
+	_, exists := s.upstreamManager.GetClient("github")
+	assert.False(t, exists)
+	client, exists = s.upstreamManager.GetClient("gh")
+	require.True(t, exists)
+	assert.True(t, client.StateManager.IsUserStopped(), "a stopped server stays stopped")
+	assert.WithinDuration(t, snoozedUntil, client.StateManager.SnoozedUntil(), 0)
+}
+
+func TestRenameServer_Rejected(t *testing.T) {
+	s := setupRenameTestServer(t,
+		&config.ServerConfig{Name: "github", URL: "http://localhost:1/mcp", StartupMode: "disabled"},
+		&config.ServerConfig{Name: "gitlab", URL: "http://localhost:2/mcp", StartupMode: "disabled"},
+	)
+
+	assert.ErrorContains(t, s.RenameServer("github", "gitlab"), "already exists")
+	assert.ErrorIs(t, s.RenameServer("missing", "other"), errServerNotFound)
+	assert.Error(t, s.RenameServer("github", ""))
+	assert.Error(t, s.RenameServer("github", "git:hub"))
+
+	now := time.Now()
+	require.NoError(t, s.storageManager.SaveDurableCall(&storage.DurableCallRecord{
+		ID: "queued", Tool: "github:create_issue", Status: storage.DurableCallPending, Created: now, Updated: now,
+	}))
+	assert.ErrorContains(t, s.RenameServer("github", "gh"), "durable calls")
+
+	_, err := s.storageManager.GetUpstreamServer("github")
+	assert.NoError(t, err)
+	assert.Equal(t, "github", s.config.Servers[0].Name)
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"mcpproxy-go/internal/config"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// filterServersByTags returns the servers carrying any (or, with matchAll, all)
+// of the given tags, sorted by name
+func filterServersByTags(servers []*config.ServerConfig, tags []string, matchAll bool) []*config.ServerConfig {
+	var matched []*config.ServerConfig
+	for _, server := range servers {
+		if server != nil && server.HasTags(tags, matchAll) {
+			matched = append(matched, server)
+		}
+	}
+
+	sort.Slice(matched, func(i, j int) bool { return matched[i].Name < matched[j].Name })
+	return matched
+}
+
+// handleListServersByTag implements the list_servers_by_tag MCP tool
+func (p *MCPProxyServer) handleListServersByTag(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var tags []string
+	for _, tag := range request.GetStringSlice("tags", nil) {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	if len(tags) == 0 {
+		return mcp.NewToolResultError("Missing required parameter 'tags': provide at least one tag"), nil
+	}
+
+	match := request.GetString("match", "any")
+	if match != "any" && match != "all" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid match: %s (must be 'any' or 'all')", match)), nil
+	}
+
+	matched := filterServersByTags(p.config.Servers, tags, match == "all")
+
+	servers := make([]map[string]interface{}, 0, len(matched))
+	for _, server := range matched {
+		servers = append(servers, map[string]interface{}{
+			"name":         server.Name,
+			"description":  server.Description,
+			"tags":         server.Tags,
+			"startup_mode": server.StartupMode,
+			"group_id":     server.GroupID,
+		})
+	}
+
+	jsonResult, err := json.Marshal(map[string]interface{}{
+		"servers": servers,
+		"tags":    tags,
+		"match":   match,
+		"total":   len(servers),
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to serialize result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonResult)), nil
+}
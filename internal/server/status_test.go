@@ -0,0 +1,36 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
+	"mcpproxy-go/internal/upstream"
+)
+
+func TestUpdateStatusDroppedUpdates(t *testing.T) {
+	s := &Server{
+		config:          &config.Config{Listen: "127.0.0.1:8080"},
+		logger:          zap.NewNop(),
+		upstreamManager: upstream.NewManager(zap.NewNop(), nil, nil),
+		statusCh:        make(chan interface{}, 1),
+	}
+
+	// Without a consumer the newest update replaces the queued one and nothing counts as dropped
+	s.updateStatus("Starting", "first")
+	s.updateStatus("Ready", "second")
+	assert.Equal(t, uint64(0), s.statusDropped.Load())
+	status := (<-s.statusCh).(map[string]interface{})
+	assert.Equal(t, "second", status["message"])
+
+	// With a consumer attached an update that does not fit is dropped and counted
+	s.statusConsumer.Store(true)
+	s.updateStatus("Ready", "third")
+	s.updateStatus("Ready", "fourth")
+	s.updateStatus("Ready", "fifth")
+	assert.Equal(t, uint64(2), s.statusDropped.Load())
+	status = (<-s.statusCh).(map[string]interface{})
+	assert.Equal(t, "third", status["message"])
+}
@@ -0,0 +1,126 @@
+package server
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// certReloader serves the TLS certificate of the HTTP listener and swaps it in place when
+// reloaded, so renewed certificates are picked up without restarting mcpproxy
+type certReloader struct {
+	certFile string
+	keyFile  string
+
+	mu   sync.RWMutex
+	cert *tls.Certificate
+}
+
+func newCertReloader(certFile, keyFile string) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload reads the certificate and key files again. On error the current certificate is kept.
+func (r *certReloader) Reload() error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS certificate %s: %w", r.certFile, err)
+	}
+
+	r.mu.Lock()
+	r.cert = &cert
+	r.mu.Unlock()
+	return nil
+}
+
+// GetCertificate implements tls.Config.GetCertificate
+func (r *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// tlsConfig returns the TLS configuration of the HTTP listener, backed by the reloader
+func (r *certReloader) tlsConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: r.GetCertificate,
+	}
+}
+
+// reloadCertsOnSIGHUP reloads the TLS certificate whenever SIGHUP is received, until stop is closed
+func (s *Server) reloadCertsOnSIGHUP(reloader *certReloader, stop <-chan struct{}) {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	defer signal.Stop(sighup)
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-sighup:
+			if err := reloader.Reload(); err != nil {
+				s.logger.Error("Failed to reload TLS certificate, keeping the current one", zap.Error(err))
+				continue
+			}
+			s.logger.Info("Reloaded TLS certificate", zap.String("cert_file", reloader.certFile))
+		}
+	}
+}
+
+// startTLSRedirect serves a plain HTTP listener on redirectAddr that redirects every request to
+// the HTTPS listener on listenAddr. The returned server must be closed by the caller.
+func (s *Server) startTLSRedirect(redirectAddr, listenAddr string) (*http.Server, error) {
+	_, httpsPort, err := net.SplitHostPort(listenAddr)
+	if err != nil {
+		return nil, fmt.Errorf("cannot redirect to %s: %w", listenAddr, err)
+	}
+
+	listener, err := net.Listen("tcp", redirectAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	redirectServer := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, httpsRedirectURL(r, httpsPort), http.StatusMovedPermanently)
+		}),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		if err := redirectServer.Serve(listener); err != nil && err != http.ErrServerClosed {
+			s.logger.Error("HTTP to HTTPS redirect server error", zap.Error(err))
+		}
+	}()
+
+	s.logger.Info("Redirecting HTTP to HTTPS",
+		zap.String("address", redirectAddr),
+		zap.String("https_port", httpsPort))
+	return redirectServer, nil
+}
+
+// httpsRedirectURL returns the HTTPS URL of r on the given port, keeping the requested host
+func httpsRedirectURL(r *http.Request, httpsPort string) string {
+	host := r.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.Trim(host, "[]") // Bare IPv6 host, re-bracketed by JoinHostPort
+	if host == "" {
+		host = "localhost"
+	}
+	return "https://" + net.JoinHostPort(host, httpsPort) + r.URL.RequestURI()
+}
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestCert writes a self-signed certificate and key for commonName into dir
+func writeTestCert(t *testing.T, dir, commonName string) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certFile, keyFile
+}
+
+func TestCertReloader(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeTestCert(t, dir, "first")
+
+	reloader, err := newCertReloader(certFile, keyFile)
+	require.NoError(t, err)
+	cert, err := reloader.GetCertificate(nil)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+	assert.Equal(t, "first", leaf.Subject.CommonName)
+
+	// A renewed certificate is served after Reload
+	writeTestCert(t, dir, "renewed")
+	require.NoError(t, reloader.Reload())
+	cert, err = reloader.GetCertificate(nil)
+	require.NoError(t, err)
+	leaf, err = x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+	assert.Equal(t, "renewed", leaf.Subject.CommonName)
+
+	// A broken file keeps the current certificate
+	require.NoError(t, os.WriteFile(certFile, []byte("not a certificate"), 0600))
+	assert.Error(t, reloader.Reload())
+	current, err := reloader.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.Same(t, cert, current)
+
+	_, err = newCertReloader(filepath.Join(dir, "missing.pem"), keyFile)
+	assert.Error(t, err)
+}
+
+func TestHTTPSRedirectURL(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{"mcpproxy.lan:8081", "https://mcpproxy.lan:8443/api/servers?x=1"},
+		{"mcpproxy.lan", "https://mcpproxy.lan:8443/api/servers?x=1"},
+		{"[::1]:8081", "https://[::1]:8443/api/servers?x=1"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", "/api/servers?x=1", nil)
+		req.Host = tt.host
+		assert.Equal(t, tt.want, httpsRedirectURL(req, "8443"), tt.host)
+	}
+}
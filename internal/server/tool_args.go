@@ -0,0 +1,231 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
+)
+
+// argViolation is one way the arguments of a tool call don't match the tool's input schema
+type argViolation struct {
+	Path    string `json:"path"` // Argument path, e.g. "repo", "options.limit" or "labels[0]"
+	Problem string `json:"problem"`
+}
+
+// validateToolArgs checks call arguments against a tool's JSON input schema. It covers what
+// agents most often get wrong: missing required fields, wrong types, values outside an enum and
+// unknown fields where additionalProperties is false, in nested objects and array items too.
+// Other schema keywords are ignored, so a loose schema never rejects a call.
+func validateToolArgs(schema, args map[string]interface{}) []argViolation {
+	if args == nil {
+		args = map[string]interface{}{}
+	}
+
+	var violations []argViolation
+	validateValue(schema, args, "", &violations)
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].Path < violations[j].Path
+	})
+	return violations
+}
+
+func validateValue(schema map[string]interface{}, value interface{}, path string, violations *[]argViolation) {
+	if types := schemaTypes(schema); len(types) > 0 {
+		actual := jsonType(value)
+		if !typeAllowed(types, actual, value) {
+			*violations = append(*violations, argViolation{
+				Path:    displayPath(path),
+				Problem: fmt.Sprintf("expected %s, got %s", strings.Join(types, " or "), actual),
+			})
+			return
+		}
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok && len(enum) > 0 && !enumContains(enum, value) {
+		*violations = append(*violations, argViolation{
+			Path:    displayPath(path),
+			Problem: fmt.Sprintf("must be one of %s", formatEnum(enum)),
+		})
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		validateObject(schema, v, path, violations)
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				validateValue(items, item, fmt.Sprintf("%s[%d]", path, i), violations)
+			}
+		}
+	}
+}
+
+func validateObject(schema, object map[string]interface{}, path string, violations *[]argViolation) {
+	if required, ok := schema["required"].([]interface{}); ok {
+		for _, field := range required {
+			name, ok := field.(string)
+			if !ok {
+				continue
+			}
+			if _, present := object[name]; !present {
+				*violations = append(*violations, argViolation{Path: joinPath(path, name), Problem: "missing required field"})
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]interface{})
+	strict := schema["additionalProperties"] == false
+	for name, fieldValue := range object {
+		fieldSchema, known := properties[name].(map[string]interface{})
+		if !known {
+			if strict {
+				*violations = append(*violations, argViolation{Path: joinPath(path, name), Problem: "unknown field"})
+			}
+			continue
+		}
+		validateValue(fieldSchema, fieldValue, joinPath(path, name), violations)
+	}
+}
+
+// schemaTypes returns the allowed types of a schema, given as a string or an array of strings
+func schemaTypes(schema map[string]interface{}) []string {
+	switch t := schema["type"].(type) {
+	case string:
+		return []string{t}
+	case []interface{}:
+		var types []string
+		for _, entry := range t {
+			if name, ok := entry.(string); ok {
+				types = append(types, name)
+			}
+		}
+		return types
+	}
+	return nil
+}
+
+// jsonType returns the JSON Schema type name of a decoded JSON value
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64, float32, int, int64, int32, json.Number:
+		return "number"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func typeAllowed(types []string, actual string, value interface{}) bool {
+	for _, allowed := range types {
+		switch {
+		case allowed == actual:
+			return true
+		case allowed == "integer" && actual == "number" && isInteger(value):
+			return true
+		}
+	}
+	return false
+}
+
+func isInteger(value interface{}) bool {
+	switch v := value.(type) {
+	case int, int64, int32:
+		return true
+	case float64:
+		return v == math.Trunc(v) && !math.IsInf(v, 0)
+	case float32:
+		return float64(v) == math.Trunc(float64(v))
+	case json.Number:
+		_, err := v.Int64()
+		return err == nil
+	}
+	return false
+}
+
+func enumContains(enum []interface{}, value interface{}) bool {
+	for _, allowed := range enum {
+		if reflect.DeepEqual(allowed, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func formatEnum(enum []interface{}) string {
+	values := make([]string, 0, len(enum))
+	for _, allowed := range enum {
+		encoded, _ := json.Marshal(allowed)
+		values = append(values, string(encoded))
+	}
+	return "[" + strings.Join(values, ", ") + "]"
+}
+
+func joinPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "(arguments)"
+	}
+	return path
+}
+
+// checkToolArgs validates the arguments of an upstream call against the tool's stored input
+// schema, with the server's default_args merged in as they will be sent. It returns the error
+// result to send back, or nil if the call may be forwarded. Tools without a stored schema are
+// never rejected.
+func (p *MCPProxyServer) checkToolArgs(toolName, serverName string, args map[string]interface{}, serverConfig *config.ServerConfig) *mcp.CallToolResult {
+	tools, err := p.storage.GetToolMetadata(serverName)
+	if err != nil {
+		return nil
+	}
+
+	for _, tool := range tools {
+		if tool.Name != toolName || tool.ParamsJSON == "" {
+			continue
+		}
+
+		var schema map[string]interface{}
+		if err := json.Unmarshal([]byte(tool.ParamsJSON), &schema); err != nil {
+			return nil
+		}
+
+		violations := validateToolArgs(schema, serverConfig.MergeDefaultArgs(args, tool.ParamsJSON))
+		if len(violations) == 0 {
+			return nil
+		}
+
+		p.logger.Info("Rejected tool call with invalid arguments",
+			zap.String("tool_name", toolName),
+			zap.Int("violations", len(violations)))
+
+		errorDetails := map[string]interface{}{
+			"error_type": "invalid_arguments",
+			"violations": violations,
+			"hint":       "Check the tool's inputSchema with get_tool and retry with corrected arguments",
+		}
+		return callErrorResult(callErrorValidation, fmt.Sprintf("Arguments of '%s' don't match the tool's input schema; the call was not sent to the server", toolName), errorDetails)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const createIssueSchema = `{
+	"type": "object",
+	"properties": {
+		"repo": {"type": "string"},
+		"title": {"type": "string"},
+		"priority": {"type": "integer"},
+		"state": {"type": "string", "enum": ["open", "closed"]},
+		"labels": {"type": "array", "items": {"type": "string"}},
+		"assignee": {"type": ["string", "null"]},
+		"options": {
+			"type": "object",
+			"properties": {"notify": {"type": "boolean"}},
+			"required": ["notify"],
+			"additionalProperties": false
+		}
+	},
+	"required": ["repo", "title"]
+}`
+
+func parseArgs(t *testing.T, raw string) map[string]interface{} {
+	t.Helper()
+	var args map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(raw), &args))
+	return args
+}
+
+func TestValidateToolArgs(t *testing.T) {
+	schema := parseArgs(t, createIssueSchema)
+
+	tests := []struct {
+		name     string
+		args     string
+		expected []argViolation
+	}{
+		{
+			name: "valid",
+			args: `{"repo": "acme/api", "title": "Bug", "priority": 2, "state": "open", "labels": ["bug"], "assignee": null, "options": {"notify": true}}`,
+		},
+		{
+			name: "unknown top-level fields are allowed",
+			args: `{"repo": "acme/api", "title": "Bug", "milestone": 3}`,
+		},
+		{
+			name:     "missing required fields",
+			args:     `{}`,
+			expected: []argViolation{{Path: "repo", Problem: "missing required field"}, {Path: "title", Problem: "missing required field"}},
+		},
+		{
+			name:     "wrong type",
+			args:     `{"repo": "acme/api", "title": 42}`,
+			expected: []argViolation{{Path: "title", Problem: "expected string, got number"}},
+		},
+		{
+			name:     "fraction for integer",
+			args:     `{"repo": "acme/api", "title": "Bug", "priority": 1.5}`,
+			expected: []argViolation{{Path: "priority", Problem: "expected integer, got number"}},
+		},
+		{
+			name:     "value outside enum",
+			args:     `{"repo": "acme/api", "title": "Bug", "state": "pending"}`,
+			expected: []argViolation{{Path: "state", Problem: `must be one of ["open", "closed"]`}},
+		},
+		{
+			name:     "array item type",
+			args:     `{"repo": "acme/api", "title": "Bug", "labels": ["bug", 7]}`,
+			expected: []argViolation{{Path: "labels[1]", Problem: "expected string, got number"}},
+		},
+		{
+			name: "nested object",
+			args: `{"repo": "acme/api", "title": "Bug", "options": {"silent": true}}`,
+			expected: []argViolation{
+				{Path: "options.notify", Problem: "missing required field"},
+				{Path: "options.silent", Problem: "unknown field"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, validateToolArgs(schema, parseArgs(t, tt.args)))
+		})
+	}
+}
+
+func TestValidateToolArgs_LooseSchema(t *testing.T) {
+	// Schemas without types or required fields accept anything
+	schema := parseArgs(t, `{"type": "object", "properties": {"query": {"description": "Search text"}}}`)
+	assert.Empty(t, validateToolArgs(schema, parseArgs(t, `{"query": 42, "extra": true}`)))
+	assert.Empty(t, validateToolArgs(schema, nil))
+}
@@ -0,0 +1,200 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"mcpproxy-go/internal/config"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Reasons call_tool refuses a tool, as reported by why_blocked
+const (
+	toolBlockNone            = "not blocked"
+	toolBlockInvalidName     = "invalid_name"
+	toolBlockBuiltinDisabled = "builtin_tool_disabled"
+	toolBlockQuarantined     = "quarantined"
+	toolBlockReadOnly        = "read_only"
+	toolBlockUnknownServer   = "unknown_server"
+	toolBlockServerDisabled  = "server_disabled"
+	toolBlockConnecting      = "connecting"
+	toolBlockSnoozed         = "snoozed"
+	toolBlockNotConnected    = "not_connected"
+	toolBlockMaintenance     = "maintenance"
+)
+
+// proxyToolNames are the built-in tools call_tool routes to directly
+var proxyToolNames = map[string]bool{
+	operationUpstreamServers: true,
+	operationQuarantineSec:   true,
+	operationRetrieveTools:   true,
+	operationCallTool:        true,
+	"read_cache":             true,
+	"list_registries":        true,
+	"search_servers":         true,
+	"groups":                 true,
+	"list_available_groups":  true,
+	operationMaintenance:     true,
+	operationToolStatistics:  true,
+	operationListByTag:       true,
+	operationReadMainLog:     true,
+	operationSearchRegistry:  true,
+	operationInstallServer:   true,
+	operationHealthSummary:   true,
+	operationProxyStatus:     true,
+	operationProxyInfo:       true,
+	operationProxyPaths:      true,
+	operationWhyBlocked:      true,
+	operationServerCaps:      true,
+	operationRetrieveRes:     true,
+	operationListPrompts:     true,
+	operationGetPrompt:       true,
+	operationBatchCall:       true,
+	operationGetTool:         true,
+	operationHealthFailures:  true,
+	operationFindByParam:     true,
+	operationGetCallResult:   true,
+	operationActiveCalls:     true,
+	operationCancelCall:      true,
+	operationDiffTools:       true,
+}
+
+// toolBlock is the decision whether call_tool would refuse a tool, and why
+type toolBlock struct {
+	Tool    string `json:"tool"`
+	Server  string `json:"server,omitempty"`
+	Blocked bool   `json:"blocked"`
+	Reason  string `json:"reason"`
+	Message string `json:"message,omitempty"`
+}
+
+// upstreamCallState is the runtime state of an upstream server that affects tool calls
+type upstreamCallState struct {
+	Exists       bool
+	Connected    bool
+	Connecting   bool
+	Sleeping     bool // Disconnected for being idle; the call reconnects it
+	State        string
+	SnoozedUntil time.Time
+
+	// MCP annotations of the called tool as stored at discovery (nil when unknown or unset)
+	ReadOnlyHint    *bool
+	DestructiveHint *bool
+}
+
+// decideToolBlock decides whether call_tool refuses toolName. serverConfig is the stored
+// config of the tool's server, or nil if there is none. Checks run in the order call_tool
+// applies them, so the first reason that applies is reported.
+func decideToolBlock(cfg *config.Config, toolName string, serverConfig *config.ServerConfig, upstream upstreamCallState, now time.Time) toolBlock {
+	serverName, actualToolName, isUpstream := strings.Cut(toolName, ":")
+	if !isUpstream {
+		block := toolBlock{Tool: toolName, Reason: toolBlockNone}
+		switch {
+		case !proxyToolNames[toolName]:
+			return blocked(block, toolBlockInvalidName, fmt.Sprintf("Invalid tool name format: %s (expected server:tool for upstream tools, or use proxy tool names like 'upstream_servers')", toolName))
+		case cfg.IsBuiltinToolDisabled(toolName):
+			return blocked(block, toolBlockBuiltinDisabled, fmt.Sprintf("Built-in tool '%s' is disabled by disabled_builtin_tools", toolName))
+		}
+		return block
+	}
+
+	block := toolBlock{Tool: toolName, Server: serverName, Reason: toolBlockNone}
+	switch {
+	case serverConfig != nil && serverConfig.IsQuarantined():
+		return blocked(block, toolBlockQuarantined, fmt.Sprintf("Server '%s' is quarantined for security review. Use the 'quarantine_security' tool to inspect it; it can only be unquarantined from the tray UI or the config file.", serverName))
+	case serverConfig != nil && serverConfig.IsToolWriteBlocked(actualToolName, upstream.ReadOnlyHint, upstream.DestructiveHint):
+		return blocked(block, toolBlockReadOnly, fmt.Sprintf("Tool '%s' is blocked: server '%s' is read-only and the tool is not annotated as read-only by the server. List it in the server's 'read_tools' to allow it, or unset 'read_only' to allow all calls.", actualToolName, serverName))
+	case !upstream.Exists:
+		return blocked(block, toolBlockUnknownServer, fmt.Sprintf("No client found for server: %s", serverName))
+	case serverConfig != nil && serverConfig.IsDisabled():
+		message := fmt.Sprintf("Server '%s' is disabled (startup_mode: %s)", serverName, serverConfig.StartupMode)
+		if serverConfig.AutoDisableReason != "" {
+			message += ": " + serverConfig.AutoDisableReason
+		}
+		return blocked(block, toolBlockServerDisabled, message)
+	case upstream.Connected || upstream.Sleeping:
+		return block
+	case upstream.Connecting:
+		return blocked(block, toolBlockConnecting, fmt.Sprintf("Server '%s' is currently connecting - please wait for connection to complete (state: %s)", serverName, upstream.State))
+	case upstream.SnoozedUntil.After(now):
+		return blocked(block, toolBlockSnoozed, fmt.Sprintf("Server '%s' is not connected and reconnect attempts are snoozed until %s. Use 'upstream_servers' snooze with duration '0' to resume them.", serverName, upstream.SnoozedUntil.Format(time.RFC3339)))
+	}
+	return blocked(block, toolBlockNotConnected, fmt.Sprintf("Server '%s' is not connected (state: %s) - use 'upstream_servers' tool to check server configuration", serverName, upstream.State))
+}
+
+func blocked(block toolBlock, reason, message string) toolBlock {
+	block.Blocked = true
+	block.Reason = reason
+	block.Message = message
+	return block
+}
+
+// checkToolBlock decides whether call_tool refuses toolName given the current config and
+// server states. It also returns the stored config of the tool's server, if any.
+func (p *MCPProxyServer) checkToolBlock(toolName string) (toolBlock, *config.ServerConfig) {
+	var serverConfig *config.ServerConfig
+	var upstream upstreamCallState
+
+	if serverName, _, isUpstream := strings.Cut(toolName, ":"); isUpstream {
+		if stored, err := p.storage.GetUpstreamServer(serverName); err == nil {
+			serverConfig = stored
+		}
+		// Maintenance mode pauses every upstream tool, whatever the state of its server
+		if p.mainServer != nil {
+			if maintenance := p.mainServer.MaintenanceMode(); maintenance.Enabled {
+				block := toolBlock{Tool: toolName, Server: serverName}
+				return blocked(block, toolBlockMaintenance, maintenance.message()), serverConfig
+			}
+		}
+		if client, exists := p.upstreamManager.GetClient(serverName); exists {
+			upstream = upstreamCallState{
+				Exists:       true,
+				Connected:    client.IsConnected(),
+				Connecting:   client.IsConnecting(),
+				Sleeping:     client.StateManager.IsSleeping(),
+				State:        client.GetState().String(),
+				SnoozedUntil: client.StateManager.SnoozedUntil(),
+			}
+		}
+		if serverConfig != nil && serverConfig.ReadOnly {
+			upstream.ReadOnlyHint, upstream.DestructiveHint = p.storedToolHints(serverName, toolName)
+		}
+	}
+
+	return decideToolBlock(p.config, toolName, serverConfig, upstream, time.Now()), serverConfig
+}
+
+// storedToolHints returns the MCP annotations relevant to read-only servers of a discovered tool
+func (p *MCPProxyServer) storedToolHints(serverName, toolName string) (readOnlyHint, destructiveHint *bool) {
+	tools, err := p.storage.GetToolMetadata(serverName)
+	if err != nil {
+		return nil, nil
+	}
+	for _, tool := range tools {
+		if tool.Name == toolName {
+			return tool.ReadOnlyHint, tool.DestructiveHint
+		}
+	}
+	return nil, nil
+}
+
+// handleWhyBlocked implements the why_blocked MCP tool
+func (p *MCPProxyServer) handleWhyBlocked(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	toolName, err := request.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError("Missing required parameter 'name'"), nil
+	}
+
+	block, _ := p.checkToolBlock(toolName)
+
+	jsonResult, err := json.Marshal(block)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to serialize result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonResult)), nil
+}
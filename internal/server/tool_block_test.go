@@ -0,0 +1,163 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"mcpproxy-go/internal/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecideToolBlock_BuiltinTools(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.DisabledBuiltinTools = []string{"upstream_servers"}
+	now := time.Now()
+
+	block := decideToolBlock(cfg, "retrieve_tools", nil, upstreamCallState{}, now)
+	assert.False(t, block.Blocked)
+	assert.Equal(t, toolBlockNone, block.Reason)
+
+	block = decideToolBlock(cfg, "upstream_servers", nil, upstreamCallState{}, now)
+	assert.True(t, block.Blocked)
+	assert.Equal(t, toolBlockBuiltinDisabled, block.Reason)
+	assert.Contains(t, block.Message, "disabled_builtin_tools")
+
+	block = decideToolBlock(cfg, "no_such_tool", nil, upstreamCallState{}, now)
+	assert.True(t, block.Blocked)
+	assert.Equal(t, toolBlockInvalidName, block.Reason)
+}
+
+func TestDecideToolBlock_UpstreamTools(t *testing.T) {
+	cfg := config.DefaultConfig()
+	now := time.Now()
+	connected := upstreamCallState{Exists: true, Connected: true, State: "Ready"}
+	readOnly, destructive := true, true
+	connectedReadTool := upstreamCallState{Exists: true, Connected: true, State: "Ready", ReadOnlyHint: &readOnly}
+	disconnected := upstreamCallState{Exists: true, State: "Disconnected"}
+
+	tests := []struct {
+		name     string
+		tool     string
+		server   *config.ServerConfig
+		upstream upstreamCallState
+		reason   string
+	}{
+		{
+			name:     "connected server",
+			tool:     "github:list_issues",
+			server:   &config.ServerConfig{Name: "github", StartupMode: "active"},
+			upstream: connected,
+			reason:   toolBlockNone,
+		},
+		{
+			name:     "quarantined server",
+			tool:     "github:list_issues",
+			server:   &config.ServerConfig{Name: "github", StartupMode: "quarantined"},
+			upstream: connected,
+			reason:   toolBlockQuarantined,
+		},
+		{
+			name:     "read-only server blocks unannotated tools",
+			tool:     "github:list_issues",
+			server:   &config.ServerConfig{Name: "github", StartupMode: "active", ReadOnly: true},
+			upstream: connected,
+			reason:   toolBlockReadOnly,
+		},
+		{
+			name:     "read-only server blocks destructive tools",
+			tool:     "github:delete_repo",
+			server:   &config.ServerConfig{Name: "github", StartupMode: "active", ReadOnly: true},
+			upstream: upstreamCallState{Exists: true, Connected: true, State: "Ready", ReadOnlyHint: &readOnly, DestructiveHint: &destructive},
+			reason:   toolBlockReadOnly,
+		},
+		{
+			name:     "read-only server allows tools annotated read-only",
+			tool:     "github:list_issues",
+			server:   &config.ServerConfig{Name: "github", StartupMode: "active", ReadOnly: true},
+			upstream: connectedReadTool,
+			reason:   toolBlockNone,
+		},
+		{
+			name:     "read-only server allows listed read tools",
+			tool:     "github:list_issues",
+			server:   &config.ServerConfig{Name: "github", StartupMode: "active", ReadOnly: true, ReadTools: []string{"list_issues"}},
+			upstream: connected,
+			reason:   toolBlockNone,
+		},
+		{
+			name:     "experimental server is not blocked",
+			tool:     "github:create_issue",
+			server:   &config.ServerConfig{Name: "github", StartupMode: "active", Experimental: true},
+			upstream: connected,
+			reason:   toolBlockNone,
+		},
+		{
+			name:     "unknown server",
+			tool:     "missing:tool",
+			upstream: upstreamCallState{},
+			reason:   toolBlockUnknownServer,
+		},
+		{
+			name:     "auto-disabled server",
+			tool:     "github:list_issues",
+			server:   &config.ServerConfig{Name: "github", StartupMode: "auto_disabled", AutoDisableReason: "3 consecutive failures"},
+			upstream: disconnected,
+			reason:   toolBlockServerDisabled,
+		},
+		{
+			name:     "connecting server",
+			tool:     "github:list_issues",
+			server:   &config.ServerConfig{Name: "github", StartupMode: "active"},
+			upstream: upstreamCallState{Exists: true, Connecting: true, State: "Connecting"},
+			reason:   toolBlockConnecting,
+		},
+		{
+			name:     "snoozed server",
+			tool:     "github:list_issues",
+			server:   &config.ServerConfig{Name: "github", StartupMode: "active"},
+			upstream: upstreamCallState{Exists: true, State: "Error", SnoozedUntil: now.Add(time.Hour)},
+			reason:   toolBlockSnoozed,
+		},
+		{
+			name:     "expired snooze",
+			tool:     "github:list_issues",
+			server:   &config.ServerConfig{Name: "github", StartupMode: "active"},
+			upstream: upstreamCallState{Exists: true, State: "Error", SnoozedUntil: now.Add(-time.Minute)},
+			reason:   toolBlockNotConnected,
+		},
+		{
+			name:     "sleeping server",
+			tool:     "github:list_issues",
+			server:   &config.ServerConfig{Name: "github", StartupMode: "active"},
+			upstream: upstreamCallState{Exists: true, Sleeping: true, State: "Disconnected"},
+			reason:   toolBlockNone,
+		},
+		{
+			name:     "disconnected server",
+			tool:     "github:list_issues",
+			server:   &config.ServerConfig{Name: "github", StartupMode: "active"},
+			upstream: disconnected,
+			reason:   toolBlockNotConnected,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			block := decideToolBlock(cfg, tt.tool, tt.server, tt.upstream, now)
+			assert.Equal(t, tt.reason, block.Reason)
+			assert.Equal(t, tt.reason != toolBlockNone, block.Blocked)
+			assert.Equal(t, tt.tool, block.Tool)
+			if block.Blocked {
+				assert.NotEmpty(t, block.Message)
+			}
+		})
+	}
+}
+
+func TestDecideToolBlock_DisabledMessageIncludesReason(t *testing.T) {
+	server := &config.ServerConfig{Name: "github", StartupMode: "auto_disabled", AutoDisableReason: "3 consecutive failures"}
+	block := decideToolBlock(config.DefaultConfig(), "github:list_issues", server, upstreamCallState{Exists: true}, time.Now())
+	assert.Equal(t, "github", block.Server)
+	assert.Contains(t, block.Message, "3 consecutive failures")
+}
@@ -0,0 +1,123 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"mcpproxy-go/internal/storage"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// buildToolStatistics converts stored tool stats into response entries.
+// sortBy is one of "count" (default), "last_used" or "avg_duration"; an empty
+// server includes all servers and a limit <= 0 returns every entry.
+func buildToolStatistics(records []*storage.ToolStatRecord, sortBy, server string, limit int) ([]map[string]interface{}, error) {
+	filtered := make([]*storage.ToolStatRecord, 0, len(records))
+	for _, record := range records {
+		if server != "" && !strings.HasPrefix(record.ToolName, server+":") {
+			continue
+		}
+		filtered = append(filtered, record)
+	}
+
+	switch sortBy {
+	case "", "count":
+		sort.SliceStable(filtered, func(i, j int) bool { return filtered[i].Count > filtered[j].Count })
+	case "last_used":
+		sort.SliceStable(filtered, func(i, j int) bool { return filtered[i].LastUsed.After(filtered[j].LastUsed) })
+	case "avg_duration":
+		sort.SliceStable(filtered, func(i, j int) bool {
+			return filtered[i].AverageDuration() > filtered[j].AverageDuration()
+		})
+	default:
+		return nil, fmt.Errorf("invalid sort_by: %s (must be one of: count, last_used, avg_duration)", sortBy)
+	}
+
+	if limit > 0 && len(filtered) > limit {
+		filtered = filtered[:limit]
+	}
+
+	entries := make([]map[string]interface{}, 0, len(filtered))
+	for _, record := range filtered {
+		entries = append(entries, map[string]interface{}{
+			"tool_name":       record.ToolName,
+			"count":           record.Count,
+			"last_used":       record.LastUsed,
+			"avg_duration_ms": record.AverageDuration().Milliseconds(),
+		})
+	}
+	return entries, nil
+}
+
+// handleToolStatistics implements the tool_statistics MCP tool
+func (p *MCPProxyServer) handleToolStatistics(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	sortBy := request.GetString("sort_by", "count")
+	server := request.GetString("server", "")
+	limit := int(request.GetFloat("limit", 0))
+
+	records, err := p.storage.ListToolUsage()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load tool statistics: %v", err)), nil
+	}
+
+	entries, err := buildToolStatistics(records, sortBy, server, limit)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	jsonResult, err := json.Marshal(map[string]interface{}{
+		"tools":       entries,
+		"total_tools": len(records),
+		"sort_by":     sortBy,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to serialize result: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(string(jsonResult)), nil
+}
+
+// handleToolStatsAPI serves GET /api/tools/stats?sort_by=&server=&limit=
+func (s *Server) handleToolStatsAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	sortBy := query.Get("sort_by")
+	limit := 0
+	if limitStr := query.Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil {
+			http.Error(w, "limit must be an integer", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	records, err := s.storageManager.ListToolUsage()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to load tool statistics: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	entries, err := buildToolStatistics(records, sortBy, query.Get("server"), limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":     true,
+		"tools":       entries,
+		"total_tools": len(records),
+	})
+}
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"mcpproxy-go/internal/config"
+	"mcpproxy-go/internal/upstream/core"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+)
+
+// defaultProbeTimeout bounds the initialize + ListTools handshake of a connectivity test
+const defaultProbeTimeout = 30 * time.Second
+
+// handleTestUpstream connects a temporary client built from add-style arguments,
+// lists its tools and tears it down again without persisting anything
+func (p *MCPProxyServer) handleTestUpstream(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name := request.GetString("name", "connectivity-test")
+
+	serverConfig, err := serverConfigFromArgs(name, request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if err := config.CheckSecretReferenceChange(nil, serverConfig); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	timeout := defaultProbeTimeout
+	if seconds := request.GetFloat("timeout_seconds", 0); seconds > 0 {
+		timeout = time.Duration(seconds * float64(time.Second))
+	}
+
+	result := map[string]interface{}{
+		"name":     serverConfig.Name,
+		"protocol": serverConfig.Protocol,
+		"success":  false,
+	}
+
+	client, err := core.NewClient("test-"+serverConfig.Name, serverConfig, p.logger, nil, p.config, p.storage.GetBoltDB())
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to create test client: %v", err)), nil
+	}
+	defer func() {
+		if err := client.Disconnect(); err != nil {
+			p.logger.Debug("Failed to disconnect test client", zap.String("server", serverConfig.Name), zap.Error(err))
+		}
+	}()
+
+	testCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	if err := client.Connect(testCtx); err != nil {
+		result["stage"] = "initialize"
+		result["error"] = err.Error()
+		return p.probeResult(result, start)
+	}
+
+	tools, err := client.ListTools(testCtx)
+	if err != nil {
+		result["stage"] = "list_tools"
+		result["error"] = err.Error()
+		return p.probeResult(result, start)
+	}
+
+	toolNames := make([]string, 0, len(tools))
+	for _, tool := range tools {
+		toolNames = append(toolNames, tool.Name)
+	}
+	result["success"] = true
+	result["tool_count"] = len(tools)
+	result["tools"] = toolNames
+
+	return p.probeResult(result, start)
+}
+
+// probeResult serializes a connectivity test result including its duration
+func (p *MCPProxyServer) probeResult(result map[string]interface{}, start time.Time) (*mcp.CallToolResult, error) {
+	result["duration_ms"] = time.Since(start).Milliseconds()
+
+	jsonResult, err := json.Marshal(result)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to serialize result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(jsonResult)), nil
+}
@@ -2,8 +2,10 @@ package server
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"reflect"
+	"strings"
 	"sync"
 	"time"
 
@@ -21,6 +23,25 @@ const (
 	maxMessageSize = 512 * 1024 // 512 KB
 )
 
+// streamedEventTypes are the event bus types forwarded to WebSocket clients by default
+var streamedEventTypes = []events.EventType{
+	events.ServerStateChanged,
+	events.ServerConfigChanged,
+	events.ServerAutoDisabled,
+	events.ServerGroupUpdated,
+	events.ServerRestarted,
+	events.ServerAutoQuarantined,
+	events.EventStateChange,
+	events.EventConfigChange,
+	events.AppStateChanged,
+	events.EventAppStateChange,
+	events.ConfigDiff,
+	events.ToolsUpdated,
+	events.ToolCalled,
+	events.ConnectionEstablished,
+	events.ConnectionLost,
+}
+
 var upgrader = websocket.Upgrader{
 	ReadBufferSize:  1024,
 	WriteBufferSize: 1024,
@@ -47,6 +68,7 @@ type wsClient struct {
 	send         chan []byte
 	manager      *WebSocketManager
 	eventChannels []<-chan events.Event // Multiple channels for different event types
+	eventTypes    []events.EventType    // Event type of each channel, for unsubscribing
 	filterServer string // If set, only send events for this server
 	stopChan     chan struct{}
 }
@@ -109,25 +131,24 @@ func (m *WebSocketManager) Stop() {
 
 // HandleWebSocket handles WebSocket connection upgrades
 func (m *WebSocketManager) HandleWebSocket(w http.ResponseWriter, r *http.Request, filterServer string) {
+	m.HandleWebSocketWithTypes(w, r, filterServer, nil)
+}
+
+// HandleWebSocketWithTypes handles a WebSocket connection that only receives the given
+// event types (all streamed types if empty)
+func (m *WebSocketManager) HandleWebSocketWithTypes(w http.ResponseWriter, r *http.Request, filterServer string, eventTypes []events.EventType) {
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		m.logger.Error("Failed to upgrade WebSocket connection", zap.Error(err))
 		return
 	}
 
-	// Subscribe to all relevant event types
-	eventChannels := []<-chan events.Event{
-		m.eventBus.Subscribe(events.ServerStateChanged),
-		m.eventBus.Subscribe(events.ServerConfigChanged),
-		m.eventBus.Subscribe(events.ServerAutoDisabled),
-		m.eventBus.Subscribe(events.ServerGroupUpdated),
-		m.eventBus.Subscribe(events.EventStateChange),
-		m.eventBus.Subscribe(events.EventConfigChange),
-		m.eventBus.Subscribe(events.AppStateChanged),
-		m.eventBus.Subscribe(events.ToolsUpdated),
-		m.eventBus.Subscribe(events.ToolCalled),
-		m.eventBus.Subscribe(events.ConnectionEstablished),
-		m.eventBus.Subscribe(events.ConnectionLost),
+	if len(eventTypes) == 0 {
+		eventTypes = streamedEventTypes
+	}
+	eventChannels := make([]<-chan events.Event, len(eventTypes))
+	for i, eventType := range eventTypes {
+		eventChannels[i] = m.eventBus.Subscribe(eventType)
 	}
 
 	client := &wsClient{
@@ -135,6 +156,7 @@ func (m *WebSocketManager) HandleWebSocket(w http.ResponseWriter, r *http.Reques
 		send:          make(chan []byte, 256),
 		manager:       m,
 		eventChannels: eventChannels,
+		eventTypes:    eventTypes,
 		filterServer:  filterServer,
 		stopChan:      make(chan struct{}),
 	}
@@ -159,6 +181,9 @@ func (m *WebSocketManager) GetActiveConnections() int {
 func (c *wsClient) readPump() {
 	defer func() {
 		close(c.stopChan) // Signal event pump to stop
+		for i, eventType := range c.eventTypes {
+			c.manager.eventBus.Unsubscribe(eventType, c.eventChannels[i])
+		}
 		c.manager.unregister <- c
 		c.conn.Close()
 	}()
@@ -273,3 +298,45 @@ func (c *wsClient) eventPump() {
 		}
 	}
 }
+
+// parseEventTypes parses the "type" query parameter (repeated or comma-separated) of the
+// events endpoint. Unknown types are rejected so typos don't silently stream nothing.
+func parseEventTypes(values []string) ([]events.EventType, error) {
+	known := make(map[events.EventType]bool, len(streamedEventTypes))
+	for _, eventType := range streamedEventTypes {
+		known[eventType] = true
+	}
+
+	var eventTypes []events.EventType
+	for _, value := range values {
+		for _, name := range strings.Split(value, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			eventType := events.EventType(name)
+			if !known[eventType] {
+				return nil, fmt.Errorf("unknown event type %q", name)
+			}
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+	return eventTypes, nil
+}
+
+// handleEventsAPI streams event bus events as JSON over a WebSocket
+// GET /api/events?type=server_state_changed,config_change&server=github
+func (s *Server) handleEventsAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	eventTypes, err := parseEventTypes(r.URL.Query()["type"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.wsManager.HandleWebSocketWithTypes(w, r, r.URL.Query().Get("server"), eventTypes)
+}
@@ -302,3 +302,58 @@ func TestWebSocketManagerStop(t *testing.T) {
 	// All connections should be closed
 	assert.Equal(t, 0, manager.GetActiveConnections())
 }
+
+func TestWebSocketEventTypeFilter(t *testing.T) {
+	eventBus := events.NewEventBus()
+	defer eventBus.Close()
+
+	logger := zap.NewNop().Sugar()
+	manager := NewWebSocketManager(eventBus, logger)
+	defer manager.Stop()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		manager.HandleWebSocketWithTypes(w, r, "", []events.EventType{events.ConnectionLost})
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	require.NoError(t, err)
+
+	// Wait for connection to be registered
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, 1, eventBus.SubscriberCount(events.ConnectionLost))
+	assert.Equal(t, 0, eventBus.SubscriberCount(events.EventStateChange))
+
+	// Events of other types are not forwarded
+	eventBus.Publish(events.Event{Type: events.EventStateChange, ServerName: "server-a"})
+	eventBus.Publish(events.Event{Type: events.ConnectionLost, ServerName: "server-b"})
+
+	conn.SetReadDeadline(time.Now().Add(1 * time.Second))
+	_, message, err := conn.ReadMessage()
+	require.NoError(t, err)
+
+	var receivedEvent events.Event
+	require.NoError(t, json.Unmarshal(message, &receivedEvent))
+	assert.Equal(t, events.ConnectionLost, receivedEvent.Type)
+	assert.Equal(t, "server-b", receivedEvent.ServerName)
+
+	// Disconnecting releases the subscription
+	conn.Close()
+	assert.Eventually(t, func() bool {
+		return eventBus.SubscriberCount(events.ConnectionLost) == 0
+	}, 2*time.Second, 50*time.Millisecond)
+}
+
+func TestParseEventTypes(t *testing.T) {
+	eventTypes, err := parseEventTypes([]string{"connection_lost, config_change", "tools_updated"})
+	require.NoError(t, err)
+	assert.Equal(t, []events.EventType{events.ConnectionLost, events.EventConfigChange, events.ToolsUpdated}, eventTypes)
+
+	eventTypes, err = parseEventTypes(nil)
+	require.NoError(t, err)
+	assert.Empty(t, eventTypes)
+
+	_, err = parseEventTypes([]string{"no_such_event"})
+	assert.Error(t, err)
+}
@@ -268,13 +268,22 @@ func (c *Coordinator) executePhase(ctx context.Context, phase Phase) error {
 func (c *Coordinator) executeHandler(ctx context.Context, h *Handler) error {
 	startTime := time.Now()
 
+	// The handler timeout is cut short when less of the total shutdown timeout remains
+	timeout := h.Timeout
+	if deadline, ok := ctx.Deadline(); ok {
+		if remaining := time.Until(deadline); remaining < timeout {
+			timeout = remaining
+		}
+	}
+
 	// Create handler-specific timeout
-	handlerCtx, cancel := context.WithTimeout(ctx, h.Timeout)
+	handlerCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	c.logger.Debug("Executing shutdown handler",
+	c.logger.Info("Executing shutdown handler",
 		zap.String("name", h.Name),
-		zap.String("phase", h.Phase.String()))
+		zap.String("phase", h.Phase.String()),
+		zap.Duration("timeout", timeout))
 
 	// Execute handler
 	errCh := make(chan error, 1)
@@ -287,7 +296,7 @@ func (c *Coordinator) executeHandler(ctx context.Context, h *Handler) error {
 	case err = <-errCh:
 		// Handler completed
 	case <-handlerCtx.Done():
-		err = fmt.Errorf("handler timeout after %v", h.Timeout)
+		err = fmt.Errorf("handler timeout after %v", timeout.Round(time.Millisecond))
 	}
 
 	duration := time.Since(startTime)
@@ -309,13 +318,15 @@ func (c *Coordinator) executeHandler(ctx context.Context, h *Handler) error {
 		c.logger.Warn("Shutdown handler failed",
 			zap.String("name", h.Name),
 			zap.Duration("duration", duration),
+			zap.Duration("timeout", timeout),
 			zap.Error(err))
 		return err
 	}
 
-	c.logger.Debug("Shutdown handler completed",
+	c.logger.Info("Shutdown handler completed",
 		zap.String("name", h.Name),
-		zap.Duration("duration", duration))
+		zap.Duration("duration", duration),
+		zap.Duration("timeout", timeout))
 	return nil
 }
 
@@ -333,6 +344,26 @@ func (c *Coordinator) SetDefaultTimeout(d time.Duration) {
 	c.defaultTimeout = d
 }
 
+// SetHandlerTimeout changes the timeout of a registered handler by name.
+// It returns false if no handler with that name is registered.
+func (c *Coordinator) SetHandlerTimeout(name string, d time.Duration) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if d == 0 {
+		d = c.defaultTimeout
+	}
+	for _, handlers := range c.handlers {
+		for _, h := range handlers {
+			if h.Name == name {
+				h.Timeout = d
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // GetHandlerCount returns the number of registered handlers
 func (c *Coordinator) GetHandlerCount() int {
 	c.mu.RLock()
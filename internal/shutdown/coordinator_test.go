@@ -225,6 +225,39 @@ func TestShutdownTimeout(t *testing.T) {
 	}
 }
 
+func TestSetHandlerTimeout(t *testing.T) {
+	logger := zap.NewNop()
+	c := NewCoordinator(logger)
+
+	c.Register(&Handler{
+		Name:    "slow-handler",
+		Phase:   PhaseConnections,
+		Timeout: 5 * time.Second,
+		Fn: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+
+	if c.SetHandlerTimeout("missing", time.Second) {
+		t.Error("Expected SetHandlerTimeout to report an unknown handler")
+	}
+	if !c.SetHandlerTimeout("slow-handler", 50*time.Millisecond) {
+		t.Fatal("Expected SetHandlerTimeout to find the handler")
+	}
+
+	start := time.Now()
+	err := c.Shutdown(context.Background())
+	duration := time.Since(start)
+
+	if err == nil {
+		t.Error("Expected timeout error")
+	}
+	if duration > 500*time.Millisecond {
+		t.Errorf("Handler ran past its new timeout: %v", duration)
+	}
+}
+
 func TestShutdownOnlyOnce(t *testing.T) {
 	logger := zap.NewNop()
 	c := NewCoordinator(logger)
@@ -0,0 +1,105 @@
+package startup
+
+import (
+	"bytes"
+	"sync"
+	"time"
+)
+
+// defaultLogBufferLines is the number of output lines kept for the startup script
+const defaultLogBufferLines = 1000
+
+// LogLine is a single captured line of startup script output
+type LogLine struct {
+	Time   time.Time `json:"time"`
+	Stream string    `json:"stream"` // stdout, stderr or system
+	Text   string    `json:"text"`
+}
+
+// logBuffer is a fixed-size ring buffer of output lines
+type logBuffer struct {
+	mu    sync.Mutex
+	lines []LogLine
+	next  int
+	full  bool
+}
+
+func newLogBuffer(size int) *logBuffer {
+	return &logBuffer{lines: make([]LogLine, size)}
+}
+
+// add appends a line, overwriting the oldest one when the buffer is full
+func (b *logBuffer) add(stream, text string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lines[b.next] = LogLine{Time: time.Now(), Stream: stream, Text: text}
+	b.next = (b.next + 1) % len(b.lines)
+	if b.next == 0 {
+		b.full = true
+	}
+}
+
+// tail returns up to n of the most recent lines, oldest first (n <= 0 returns all)
+func (b *logBuffer) tail(n int) []LogLine {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	count := b.next
+	if b.full {
+		count = len(b.lines)
+	}
+	if n <= 0 || n > count {
+		n = count
+	}
+
+	result := make([]LogLine, 0, n)
+	for i := count - n; i < count; i++ {
+		idx := i
+		if b.full {
+			idx = (b.next + i) % len(b.lines)
+		}
+		result = append(result, b.lines[idx])
+	}
+	return result
+}
+
+// writer returns an io.Writer that splits output into lines for the given stream
+func (b *logBuffer) writer(stream string) *lineWriter {
+	return &lineWriter{buf: b, stream: stream}
+}
+
+// lineWriter buffers partial writes until a full line is available
+type lineWriter struct {
+	mu      sync.Mutex
+	buf     *logBuffer
+	stream  string
+	pending []byte
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.pending = append(w.pending, p...)
+	for {
+		idx := bytes.IndexByte(w.pending, '\n')
+		if idx < 0 {
+			break
+		}
+		w.buf.add(w.stream, string(bytes.TrimRight(w.pending[:idx], "\r")))
+		w.pending = w.pending[idx+1:]
+	}
+	return len(p), nil
+}
+
+// Flush records any trailing output that didn't end with a newline
+func (w *lineWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.pending) > 0 {
+		w.buf.add(w.stream, string(w.pending))
+		w.pending = nil
+	}
+}
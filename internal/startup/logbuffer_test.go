@@ -0,0 +1,50 @@
+package startup
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func texts(lines []LogLine) []string {
+	result := make([]string, 0, len(lines))
+	for _, line := range lines {
+		result = append(result, line.Text)
+	}
+	return result
+}
+
+func TestLogBufferTail(t *testing.T) {
+	buf := newLogBuffer(3)
+	assert.Empty(t, buf.tail(10))
+
+	buf.add("stdout", "one")
+	buf.add("stdout", "two")
+	assert.Equal(t, []string{"one", "two"}, texts(buf.tail(0)))
+	assert.Equal(t, []string{"two"}, texts(buf.tail(1)))
+
+	// Overflow drops the oldest lines
+	for i := 3; i <= 5; i++ {
+		buf.add("stderr", fmt.Sprintf("line %d", i))
+	}
+	assert.Equal(t, []string{"line 3", "line 4", "line 5"}, texts(buf.tail(10)))
+	assert.Equal(t, []string{"line 4", "line 5"}, texts(buf.tail(2)))
+}
+
+func TestLineWriterSplitsLines(t *testing.T) {
+	buf := newLogBuffer(10)
+	w := buf.writer("stderr")
+
+	_, err := w.Write([]byte("first\r\nsec"))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("ond\npartial"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "second"}, texts(buf.tail(0)))
+
+	w.Flush()
+	lines := buf.tail(0)
+	assert.Equal(t, []string{"first", "second", "partial"}, texts(lines))
+	assert.Equal(t, "stderr", lines[2].Stream)
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"runtime"
 	"strings"
 	"sync"
 	"time"
@@ -21,10 +23,11 @@ type Manager struct {
 	cfg    *config.StartupScriptConfig
 	log    *zap.SugaredLogger
 	start  time.Time
+	output *logBuffer // Captured stdout/stderr of the script
 }
 
 func NewManager(cfg *config.StartupScriptConfig, logger *zap.SugaredLogger) *Manager {
-	return &Manager{cfg: cfg, log: logger}
+	return &Manager{cfg: cfg, log: logger, output: newLogBuffer(defaultLogBufferLines)}
 }
 
 // UpdateConfig updates the runtime configuration. Caller persists to disk.
@@ -45,8 +48,9 @@ func (m *Manager) Start(ctx context.Context) error {
 	if m.cmd != nil {
 		return fmt.Errorf("startup script already running")
 	}
-	if m.cfg.Path == "" {
-		return fmt.Errorf("startup script path/command not configured")
+	command := Command(m.cfg, runtime.GOOS)
+	if command == "" {
+		return fmt.Errorf("startup script path/command not configured for %s", runtime.GOOS)
 	}
 
 	// Build command: shell -c "<Path>" plus optional args
@@ -55,14 +59,16 @@ func (m *Manager) Start(ctx context.Context) error {
 		shell = "/bin/bash"
 	}
 
-	args := []string{"-c", m.cfg.Path}
+	args := []string{"-c", command}
 	if len(m.cfg.Args) > 0 {
 		args = append(args, m.cfg.Args...)
 	}
 
 	cmd := exec.CommandContext(ctx, shell, args...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	stdout := m.output.writer("stdout")
+	stderr := m.output.writer("stderr")
+	cmd.Stdout = io.MultiWriter(os.Stdout, stdout)
+	cmd.Stderr = io.MultiWriter(os.Stderr, stderr)
 	cmd.Env = os.Environ()
 	for k, v := range m.cfg.Env {
 		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", k, v))
@@ -75,8 +81,10 @@ func (m *Manager) Start(ctx context.Context) error {
 	setupProcessGroup(cmd)
 
 	if err := cmd.Start(); err != nil {
+		m.output.add("system", fmt.Sprintf("failed to start: %v", err))
 		return err
 	}
+	m.output.add("system", fmt.Sprintf("started: %s -c %s", shell, command))
 
 	// Handle optional timeout
 	if m.cfg.Timeout.Duration() > 0 {
@@ -95,12 +103,19 @@ func (m *Manager) Start(ctx context.Context) error {
 	m.start = time.Now()
 	m.log.Info("Startup script started",
 		zap.String("shell", shell),
-		zap.String("command", m.cfg.Path),
+		zap.String("command", command),
 		zap.String("dir", cmd.Dir))
 
 	// Reap when finished
 	go func(c *exec.Cmd) {
-		_ = c.Wait()
+		err := c.Wait()
+		stdout.Flush()
+		stderr.Flush()
+		if err != nil {
+			m.output.add("system", fmt.Sprintf("exited: %v", err))
+		} else {
+			m.output.add("system", "exited: status 0")
+		}
 		m.mu.Lock()
 		defer m.mu.Unlock()
 		m.cmd = nil
@@ -143,11 +158,18 @@ func (m *Manager) Status() map[string]interface{} {
 		"running":  running,
 		"pid":      pid,
 		"path":     func() string { if m.cfg!=nil {return m.cfg.Path}; return "" }(),
+		"command":  Command(m.cfg, runtime.GOOS),
 		"shell":    func() string { if m.cfg!=nil {return m.cfg.Shell}; return "" }(),
 		"since":    m.start,
 	}
 }
 
+// Logs returns up to the given number of most recent output lines of the
+// startup script, oldest first (lines <= 0 returns everything buffered)
+func (m *Manager) Logs(lines int) []LogLine {
+	return m.output.tail(lines)
+}
+
 // killProcessTree attempts to kill the whole process tree for the command
 func (m *Manager) killProcessTree(c *exec.Cmd) error {
 	// Use platform-specific implementation
@@ -159,10 +181,33 @@ func ValidateConfig(cfg *config.StartupScriptConfig) error {
 	if cfg == nil {
 		return errors.New("config is nil")
 	}
-	if cfg.Enabled && strings.TrimSpace(cfg.Path) == "" {
-		return fmt.Errorf("startup script path is required when enabled")
+	if cfg.Enabled && strings.TrimSpace(cfg.Path) == "" && strings.TrimSpace(cfg.CommandDarwin) == "" &&
+		strings.TrimSpace(cfg.CommandLinux) == "" && strings.TrimSpace(cfg.CommandWindows) == "" {
+		return fmt.Errorf("startup script path or an OS-specific command is required when enabled")
 	}
 	return nil
 }
 
+// Command returns the command the startup script runs on goos: the OS-specific command
+// (command_darwin, command_linux, command_windows) if set, otherwise the generic path
+func Command(cfg *config.StartupScriptConfig, goos string) string {
+	if cfg == nil {
+		return ""
+	}
+
+	var override string
+	switch goos {
+	case "darwin":
+		override = cfg.CommandDarwin
+	case "linux":
+		override = cfg.CommandLinux
+	case "windows":
+		override = cfg.CommandWindows
+	}
+	if strings.TrimSpace(override) != "" {
+		return override
+	}
+	return strings.TrimSpace(cfg.Path)
+}
+
 
@@ -0,0 +1,34 @@
+package startup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"mcpproxy-go/internal/config"
+)
+
+func TestCommand(t *testing.T) {
+	cfg := &config.StartupScriptConfig{
+		Path:          "/opt/start.sh",
+		CommandDarwin: "open -a Docker",
+		CommandLinux:  "systemctl --user start docker",
+	}
+
+	assert.Equal(t, "open -a Docker", Command(cfg, "darwin"))
+	assert.Equal(t, "systemctl --user start docker", Command(cfg, "linux"))
+	// Without an override the generic path is used
+	assert.Equal(t, "/opt/start.sh", Command(cfg, "windows"))
+	assert.Equal(t, "/opt/start.sh", Command(cfg, "freebsd"))
+
+	assert.Empty(t, Command(&config.StartupScriptConfig{CommandLinux: "start.sh"}, "darwin"))
+	assert.Empty(t, Command(nil, "linux"))
+}
+
+func TestValidateConfig(t *testing.T) {
+	assert.Error(t, ValidateConfig(nil))
+	assert.NoError(t, ValidateConfig(&config.StartupScriptConfig{}))
+	assert.Error(t, ValidateConfig(&config.StartupScriptConfig{Enabled: true, Path: "  "}))
+	assert.NoError(t, ValidateConfig(&config.StartupScriptConfig{Enabled: true, Path: "/opt/start.sh"}))
+	assert.NoError(t, ValidateConfig(&config.StartupScriptConfig{Enabled: true, CommandWindows: "start.cmd"}))
+}
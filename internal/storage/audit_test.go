@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestManager_AuditLog(t *testing.T) {
+	manager, err := NewManager(t.TempDir(), zap.NewNop().Sugar())
+	require.NoError(t, err)
+	defer manager.Close()
+
+	start := time.Now().Add(-time.Hour)
+	calls := []*AuditRecord{
+		{Timestamp: start, Client: "claude-desktop/1.0", Tool: "github:list_repos", Status: "success"},
+		{Timestamp: start.Add(time.Minute), Client: "cursor/0.42", Tool: "github:create_issue", Status: "error", Error: "forbidden"},
+		{Timestamp: start.Add(2 * time.Minute), Client: "claude-desktop/1.0", Tool: "vault:read_secret", Redacted: true, Status: "success"},
+	}
+	for _, call := range calls {
+		require.NoError(t, manager.AppendAuditRecord(call))
+	}
+	assert.Equal(t, []uint64{1, 2, 3}, []uint64{calls[0].ID, calls[1].ID, calls[2].ID})
+
+	all, err := manager.ListAuditRecords(AuditFilter{})
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, "vault:read_secret", all[0].Tool, "newest record first")
+
+	byServer, err := manager.ListAuditRecords(AuditFilter{Tool: "github"})
+	require.NoError(t, err)
+	assert.Len(t, byServer, 2)
+
+	byClient, err := manager.ListAuditRecords(AuditFilter{Client: "CLAUDE"})
+	require.NoError(t, err)
+	assert.Len(t, byClient, 2)
+
+	errorsOnly, err := manager.ListAuditRecords(AuditFilter{Status: "error"})
+	require.NoError(t, err)
+	require.Len(t, errorsOnly, 1)
+	assert.Equal(t, "forbidden", errorsOnly[0].Error)
+
+	recent, err := manager.ListAuditRecords(AuditFilter{Since: start.Add(30 * time.Second)})
+	require.NoError(t, err)
+	assert.Len(t, recent, 2)
+
+	limited, err := manager.ListAuditRecords(AuditFilter{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, limited, 1)
+	assert.Equal(t, uint64(3), limited[0].ID)
+
+	pruned, err := manager.PruneAuditRecords(start.Add(90 * time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, 2, pruned)
+
+	remaining, err := manager.ListAuditRecords(AuditFilter{})
+	require.NoError(t, err)
+	require.Len(t, remaining, 1)
+	assert.Equal(t, "vault:read_secret", remaining[0].Tool)
+
+	next := &AuditRecord{Timestamp: time.Now(), Tool: "github:list_repos", Status: "success"}
+	require.NoError(t, manager.AppendAuditRecord(next))
+	assert.Equal(t, uint64(4), next.ID, "sequence numbers are not reused after pruning")
+}
@@ -95,8 +95,13 @@ func (b *BoltDB) initBuckets() error {
 			ToolStatsBucket,
 			ToolHashBucket,
 			ToolMetadataBucket,
+			ResourceMetadataBucket,
+			PromptMetadataBucket,
 			OAuthTokenBucket,
 			MetaBucket,
+			EmbeddingsBucket,
+			AuditLogBucket,
+			DurableCallsBucket,
 		}
 
 		for _, bucket := range buckets {
@@ -200,6 +205,24 @@ func (b *BoltDB) DeleteUpstream(id string) error {
 
 // IncrementToolStats increments the usage count for a tool
 func (b *BoltDB) IncrementToolStats(toolName string) error {
+	return b.updateToolStats(toolName, func(record *ToolStatRecord) {
+		record.Count++
+		record.LastUsed = time.Now()
+	})
+}
+
+// RecordToolCall increments the usage count for a tool and adds the call duration
+func (b *BoltDB) RecordToolCall(toolName string, duration time.Duration) error {
+	return b.updateToolStats(toolName, func(record *ToolStatRecord) {
+		record.Count++
+		record.LastUsed = time.Now()
+		record.TotalDuration += duration
+		record.TimedCalls++
+	})
+}
+
+// updateToolStats applies update to the stored stats record for a tool
+func (b *BoltDB) updateToolStats(toolName string, update func(record *ToolStatRecord)) error {
 	return b.db.Update(func(tx *bbolt.Tx) error {
 		bucket := tx.Bucket([]byte(ToolStatsBucket))
 
@@ -214,9 +237,7 @@ func (b *BoltDB) IncrementToolStats(toolName string) error {
 			record.ToolName = toolName
 		}
 
-		// Increment count and update timestamp
-		record.Count++
-		record.LastUsed = time.Now()
+		update(&record)
 
 		// Save back
 		newData, err := record.MarshalBinary()
@@ -0,0 +1,122 @@
+package storage
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+// AppendAuditRecord appends a record to the audit log. Records are keyed by an increasing
+// sequence number and never updated, so the log is append-only.
+func (b *BoltDB) AppendAuditRecord(record *AuditRecord) error {
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists([]byte(AuditLogBucket))
+		if err != nil {
+			return fmt.Errorf("failed to create audit log bucket: %w", err)
+		}
+
+		id, err := bucket.NextSequence()
+		if err != nil {
+			return fmt.Errorf("failed to allocate audit record id: %w", err)
+		}
+		record.ID = id
+
+		data, err := record.MarshalBinary()
+		if err != nil {
+			return fmt.Errorf("failed to marshal audit record: %w", err)
+		}
+
+		key := make([]byte, 8)
+		binary.BigEndian.PutUint64(key, id)
+		return bucket.Put(key, data)
+	})
+}
+
+// ListAuditRecords returns audit records matching filter, newest first
+func (b *BoltDB) ListAuditRecords(filter AuditFilter) ([]*AuditRecord, error) {
+	records := []*AuditRecord{}
+
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte(AuditLogBucket))
+		if bucket == nil {
+			return nil // No calls audited yet
+		}
+
+		cursor := bucket.Cursor()
+		for k, v := cursor.Last(); k != nil; k, v = cursor.Prev() {
+			record := &AuditRecord{}
+			if err := record.UnmarshalBinary(v); err != nil {
+				return fmt.Errorf("failed to unmarshal audit record: %w", err)
+			}
+
+			// Keys are in time order, so nothing older can match
+			if !filter.Since.IsZero() && record.Timestamp.Before(filter.Since) {
+				break
+			}
+			if !filter.matches(record) {
+				continue
+			}
+
+			records = append(records, record)
+			if filter.Limit > 0 && len(records) >= filter.Limit {
+				break
+			}
+		}
+		return nil
+	})
+
+	return records, err
+}
+
+// PruneAuditRecords deletes audit records older than cutoff and returns how many were deleted
+func (b *BoltDB) PruneAuditRecords(cutoff time.Time) (int, error) {
+	pruned := 0
+
+	err := b.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte(AuditLogBucket))
+		if bucket == nil {
+			return nil
+		}
+
+		// Keys are in time order, so the expired records are a prefix of the bucket
+		var expired [][]byte
+		cursor := bucket.Cursor()
+		for k, v := cursor.First(); k != nil; k, v = cursor.Next() {
+			record := &AuditRecord{}
+			if err := record.UnmarshalBinary(v); err != nil {
+				return fmt.Errorf("failed to unmarshal audit record: %w", err)
+			}
+			if !record.Timestamp.Before(cutoff) {
+				break
+			}
+			expired = append(expired, append([]byte(nil), k...))
+		}
+
+		for _, key := range expired {
+			if err := bucket.Delete(key); err != nil {
+				return fmt.Errorf("failed to delete audit record: %w", err)
+			}
+		}
+		pruned = len(expired)
+		return nil
+	})
+
+	return pruned, err
+}
+
+// matches reports whether record passes the tool, client and status filters
+func (f *AuditFilter) matches(record *AuditRecord) bool {
+	if f.Tool != "" && record.Tool != f.Tool && !strings.HasPrefix(record.Tool, f.Tool+":") {
+		return false
+	}
+	if f.Client != "" && !strings.Contains(strings.ToLower(record.Client), strings.ToLower(f.Client)) {
+		return false
+	}
+	if f.Status != "" && record.Status != f.Status {
+		return false
+	}
+	return true
+}
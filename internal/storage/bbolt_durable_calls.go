@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+// SaveDurableCall creates or replaces a durable call record
+func (b *BoltDB) SaveDurableCall(record *DurableCallRecord) error {
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists([]byte(DurableCallsBucket))
+		if err != nil {
+			return fmt.Errorf("failed to create durable calls bucket: %w", err)
+		}
+
+		data, err := record.MarshalBinary()
+		if err != nil {
+			return fmt.Errorf("failed to marshal durable call: %w", err)
+		}
+		return bucket.Put([]byte(record.ID), data)
+	})
+}
+
+// GetDurableCall returns the durable call with the given ID
+func (b *BoltDB) GetDurableCall(id string) (*DurableCallRecord, error) {
+	var record *DurableCallRecord
+
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte(DurableCallsBucket))
+		if bucket == nil {
+			return bbolt.ErrBucketNotFound
+		}
+
+		data := bucket.Get([]byte(id))
+		if data == nil {
+			return fmt.Errorf("durable call %s not found", id)
+		}
+
+		record = &DurableCallRecord{}
+		return record.UnmarshalBinary(data)
+	})
+
+	return record, err
+}
+
+// ListDurableCalls returns all durable call records
+func (b *BoltDB) ListDurableCalls() ([]*DurableCallRecord, error) {
+	var records []*DurableCallRecord
+
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte(DurableCallsBucket))
+		if bucket == nil {
+			return nil // No durable calls yet
+		}
+
+		return bucket.ForEach(func(_, v []byte) error {
+			record := &DurableCallRecord{}
+			if err := record.UnmarshalBinary(v); err != nil {
+				return fmt.Errorf("failed to unmarshal durable call: %w", err)
+			}
+			records = append(records, record)
+			return nil
+		})
+	})
+
+	return records, err
+}
+
+// PruneDurableCalls deletes finished durable calls last updated before cutoff and returns
+// how many were deleted. Pending and running calls are kept.
+func (b *BoltDB) PruneDurableCalls(cutoff time.Time) (int, error) {
+	pruned := 0
+
+	err := b.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte(DurableCallsBucket))
+		if bucket == nil {
+			return nil
+		}
+
+		var expired [][]byte
+		if err := bucket.ForEach(func(k, v []byte) error {
+			record := &DurableCallRecord{}
+			if err := record.UnmarshalBinary(v); err != nil {
+				return fmt.Errorf("failed to unmarshal durable call: %w", err)
+			}
+			if record.Finished() && record.Updated.Before(cutoff) {
+				expired = append(expired, append([]byte(nil), k...))
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		for _, key := range expired {
+			if err := bucket.Delete(key); err != nil {
+				return fmt.Errorf("failed to delete durable call: %w", err)
+			}
+		}
+		pruned = len(expired)
+		return nil
+	})
+
+	return pruned, err
+}
@@ -0,0 +1,175 @@
+package storage
+
+import (
+	"bytes"
+	"encoding"
+	"fmt"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+// RenameUpstream renames an upstream server in a single transaction: its record and the
+// tool, resource and prompt metadata, tool statistics and tool hashes stored under its name.
+// Other upstreams depending on oldName are pointed at newName. It fails without changes if
+// oldName does not exist or newName is taken.
+func (b *BoltDB) RenameUpstream(oldName, newName string) error {
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		upstreams := tx.Bucket([]byte(UpstreamsBucket))
+		data := upstreams.Get([]byte(oldName))
+		if data == nil {
+			return fmt.Errorf("upstream %s not found", oldName)
+		}
+		if upstreams.Get([]byte(newName)) != nil {
+			return fmt.Errorf("upstream %s already exists", newName)
+		}
+
+		record := &UpstreamRecord{}
+		if err := record.UnmarshalBinary(data); err != nil {
+			return fmt.Errorf("failed to unmarshal upstream: %w", err)
+		}
+		record.ID = newName
+		record.Name = newName
+		record.Updated = time.Now()
+		if err := putRecord(upstreams, newName, record); err != nil {
+			return err
+		}
+		if err := upstreams.Delete([]byte(oldName)); err != nil {
+			return fmt.Errorf("failed to delete upstream %s: %w", oldName, err)
+		}
+		if err := renameDependencies(upstreams, oldName, newName); err != nil {
+			return err
+		}
+
+		oldPrefix, newPrefix := oldName+":", newName+":"
+
+		if err := renameKeys(tx, ToolMetadataBucket, oldPrefix, newPrefix, func(data []byte) (encoding.BinaryMarshaler, error) {
+			record := &ToolMetadataRecord{}
+			if err := record.UnmarshalBinary(data); err != nil {
+				return nil, err
+			}
+			record.ServerID = newName
+			record.PrefixedName = newPrefix + record.ToolName
+			return record, nil
+		}); err != nil {
+			return err
+		}
+
+		if err := renameKeys(tx, ResourceMetadataBucket, oldPrefix, newPrefix, func(data []byte) (encoding.BinaryMarshaler, error) {
+			record := &ResourceMetadataRecord{}
+			if err := record.UnmarshalBinary(data); err != nil {
+				return nil, err
+			}
+			record.ServerID = newName
+			return record, nil
+		}); err != nil {
+			return err
+		}
+
+		if err := renameKeys(tx, PromptMetadataBucket, oldPrefix, newPrefix, func(data []byte) (encoding.BinaryMarshaler, error) {
+			record := &PromptMetadataRecord{}
+			if err := record.UnmarshalBinary(data); err != nil {
+				return nil, err
+			}
+			record.ServerID = newName
+			return record, nil
+		}); err != nil {
+			return err
+		}
+
+		if err := renameKeys(tx, ToolStatsBucket, oldPrefix, newPrefix, func(data []byte) (encoding.BinaryMarshaler, error) {
+			record := &ToolStatRecord{}
+			if err := record.UnmarshalBinary(data); err != nil {
+				return nil, err
+			}
+			record.ToolName = newPrefix + record.ToolName[len(oldPrefix):]
+			return record, nil
+		}); err != nil {
+			return err
+		}
+
+		return renameKeys(tx, ToolHashBucket, oldPrefix, newPrefix, func(data []byte) (encoding.BinaryMarshaler, error) {
+			record := &ToolHashRecord{}
+			if err := record.UnmarshalBinary(data); err != nil {
+				return nil, err
+			}
+			record.ToolName = newPrefix + record.ToolName[len(oldPrefix):]
+			return record, nil
+		})
+	})
+}
+
+// renameDependencies points the depends_on entries of other upstreams naming oldName to newName
+func renameDependencies(upstreams *bbolt.Bucket, oldName, newName string) error {
+	var dependents []*UpstreamRecord
+	if err := upstreams.ForEach(func(_, v []byte) error {
+		record := &UpstreamRecord{}
+		if err := record.UnmarshalBinary(v); err != nil {
+			return fmt.Errorf("failed to unmarshal upstream: %w", err)
+		}
+		for i, dep := range record.DependsOn {
+			if dep == oldName {
+				record.DependsOn[i] = newName
+				dependents = append(dependents, record)
+				break
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, record := range dependents {
+		if err := putRecord(upstreams, record.ID, record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renameKeys moves the entries of a bucket whose keys start with oldPrefix to keys starting
+// with newPrefix, rewriting each value with rewrite
+func renameKeys(tx *bbolt.Tx, bucketName, oldPrefix, newPrefix string, rewrite func(data []byte) (encoding.BinaryMarshaler, error)) error {
+	bucket := tx.Bucket([]byte(bucketName))
+	if bucket == nil {
+		return nil
+	}
+
+	// Collect first: bbolt does not allow modifying a bucket while iterating it
+	type entry struct {
+		key   []byte
+		value encoding.BinaryMarshaler
+	}
+	var entries []entry
+
+	prefix := []byte(oldPrefix)
+	cursor := bucket.Cursor()
+	for k, v := cursor.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = cursor.Next() {
+		value, err := rewrite(v)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal %s entry %s: %w", bucketName, string(k), err)
+		}
+		entries = append(entries, entry{key: append([]byte(nil), k...), value: value})
+	}
+
+	for _, e := range entries {
+		if err := bucket.Delete(e.key); err != nil {
+			return fmt.Errorf("failed to delete %s entry %s: %w", bucketName, string(e.key), err)
+		}
+		if err := putRecord(bucket, newPrefix+string(e.key[len(prefix):]), e.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func putRecord(bucket *bbolt.Bucket, key string, record encoding.BinaryMarshaler) error {
+	data, err := record.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", key, err)
+	}
+	if err := bucket.Put([]byte(key), data); err != nil {
+		return fmt.Errorf("failed to save %s: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestManager_DurableCalls(t *testing.T) {
+	manager, err := NewManager(t.TempDir(), zap.NewNop().Sugar())
+	require.NoError(t, err)
+	defer manager.Close()
+
+	old := time.Now().Add(-48 * time.Hour)
+	calls := []*DurableCallRecord{
+		{ID: "a1", Tool: "builder:build", Args: map[string]interface{}{"target": "all"}, Status: DurableCallPending, Created: old, Updated: old},
+		{ID: "b2", Tool: "builder:build", Status: DurableCallCompleted, Result: `{"content":[]}`, Created: old, Updated: old},
+		{ID: "c3", Tool: "builder:test", Status: DurableCallFailed, Error: "boom", Created: time.Now(), Updated: time.Now()},
+	}
+	for _, call := range calls {
+		require.NoError(t, manager.SaveDurableCall(call))
+	}
+
+	call, err := manager.GetDurableCall("a1")
+	require.NoError(t, err)
+	assert.Equal(t, "all", call.Args["target"])
+	assert.False(t, call.Finished())
+
+	_, err = manager.GetDurableCall("missing")
+	assert.Error(t, err)
+
+	call.Status = DurableCallRunning
+	call.Attempts = 1
+	require.NoError(t, manager.SaveDurableCall(call))
+
+	all, err := manager.ListDurableCalls()
+	require.NoError(t, err)
+	assert.Len(t, all, 3)
+
+	// Only finished calls past the cutoff are pruned
+	pruned, err := manager.PruneDurableCalls(time.Now().Add(-24 * time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, pruned)
+
+	_, err = manager.GetDurableCall("b2")
+	assert.Error(t, err)
+	running, err := manager.GetDurableCall("a1")
+	require.NoError(t, err)
+	assert.Equal(t, DurableCallRunning, running.Status)
+	assert.Equal(t, 1, running.Attempts)
+}
@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestManager_EmbeddingCache(t *testing.T) {
+	manager, err := NewManager(t.TempDir(), zap.NewNop().Sugar())
+	require.NoError(t, err)
+	defer manager.Close()
+
+	// Cache miss returns nil without error
+	embedding, err := manager.GetEmbedding("missing")
+	require.NoError(t, err)
+	assert.Nil(t, embedding)
+
+	require.NoError(t, manager.SaveEmbedding("hash-a", []float32{0.1, 0.2, 0.3}))
+	require.NoError(t, manager.SaveEmbedding("hash-b", []float32{0.4}))
+
+	embedding, err = manager.GetEmbedding("hash-a")
+	require.NoError(t, err)
+	assert.Equal(t, []float32{0.1, 0.2, 0.3}, embedding)
+
+	require.NoError(t, manager.DeleteEmbedding("hash-a"))
+	embedding, err = manager.GetEmbedding("hash-a")
+	require.NoError(t, err)
+	assert.Nil(t, embedding)
+
+	cleared, err := manager.ClearEmbeddingCache()
+	require.NoError(t, err)
+	assert.Equal(t, 1, cleared)
+
+	embedding, err = manager.GetEmbedding("hash-b")
+	require.NoError(t, err)
+	assert.Nil(t, embedding)
+
+	// Cache remains usable after clearing
+	require.NoError(t, manager.SaveEmbedding("hash-c", []float32{1}))
+	embedding, err = manager.GetEmbedding("hash-c")
+	require.NoError(t, err)
+	assert.Equal(t, []float32{1}, embedding)
+}
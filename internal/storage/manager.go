@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"bytes"
 	"fmt"
 	"sort"
 	"sync"
@@ -181,7 +182,10 @@ func (m *Manager) SaveUpstreamServer(serverConfig *config.ServerConfig) error {
 		Args:                     serverConfig.Args,
 		WorkingDir:               serverConfig.WorkingDir,
 		Env:                      serverConfig.Env,
+		EnvFile:                  serverConfig.EnvFile,
+		Shell:                    serverConfig.Shell,
 		Headers:                  serverConfig.Headers,
+		HTTPProxy:                serverConfig.HTTPProxy,
 		OAuth:                    serverConfig.OAuth,
 		RepositoryURL:            serverConfig.RepositoryURL,
 		Created:                  serverConfig.Created,
@@ -189,13 +193,35 @@ func (m *Manager) SaveUpstreamServer(serverConfig *config.ServerConfig) error {
 		Isolation:                serverConfig.Isolation,
 		GroupID:                  serverConfig.GroupID,
 		GroupName:                serverConfig.GroupName,
+		Tags:                     serverConfig.Tags,
 		EverConnected:            serverConfig.EverConnected,
 		LastSuccessfulConnection: serverConfig.LastSuccessfulConnection,
 		ToolCount:                serverConfig.ToolCount,
 		HealthCheck:              serverConfig.HealthCheck,
 		AutoDisableThreshold:     serverConfig.AutoDisableThreshold,
+		RestartOnExit:            serverConfig.RestartOnExit,
+		MaxRestarts:              serverConfig.MaxRestarts,
+		MaxResponseBytes:         serverConfig.MaxResponseBytes,
+		ToolMaxResponseBytes:     serverConfig.ToolMaxResponseBytes,
+		MaxConcurrentCalls:       serverConfig.MaxConcurrentCalls,
+		MaxCallDuration:          serverConfig.MaxCallDuration,
+		IdleDisconnectAfter:      serverConfig.IdleDisconnectAfter,
+		DefaultArgs:              serverConfig.DefaultArgs,
+		CacheableTools:           serverConfig.CacheableTools,
+		CacheTTL:                 serverConfig.CacheTTL,
+		SensitiveTools:           serverConfig.SensitiveTools,
+		ReadOnly:                 serverConfig.ReadOnly,
+		Experimental:             serverConfig.Experimental,
+		SearchPriority:           serverConfig.SearchPriority,
+		DisableCallRetry:         serverConfig.DisableCallRetry,
+		Durable:                  serverConfig.Durable,
+		ReadTools:                serverConfig.ReadTools,
+		WriteTools:               serverConfig.WriteTools,
+		DependsOn:                serverConfig.DependsOn,
+		ProtocolVersion:          serverConfig.ProtocolVersion,
 		ServerState:              serverConfig.StartupMode,       // Map config.StartupMode → storage.ServerState
 		AutoDisableReason:        serverConfig.AutoDisableReason, // Save auto-disable reason
+		QuarantineReason:         serverConfig.QuarantineReason,
 	}
 
 	return m.db.SaveUpstream(record)
@@ -256,7 +282,10 @@ func (m *Manager) GetUpstreamServer(name string) (*config.ServerConfig, error) {
 		Args:                     record.Args,
 		WorkingDir:               record.WorkingDir,
 		Env:                      record.Env,
+		EnvFile:                  record.EnvFile,
+		Shell:                    record.Shell,
 		Headers:                  record.Headers,
+		HTTPProxy:                record.HTTPProxy,
 		OAuth:                    record.OAuth,
 		RepositoryURL:            record.RepositoryURL,
 		Created:                  record.Created,
@@ -264,13 +293,35 @@ func (m *Manager) GetUpstreamServer(name string) (*config.ServerConfig, error) {
 		Isolation:                record.Isolation,
 		GroupID:                  record.GroupID,
 		GroupName:                record.GroupName,
+		Tags:                     record.Tags,
 		EverConnected:            record.EverConnected,
 		LastSuccessfulConnection: record.LastSuccessfulConnection,
 		ToolCount:                record.ToolCount,
 		HealthCheck:              record.HealthCheck,
 		AutoDisableThreshold:     record.AutoDisableThreshold,
+		RestartOnExit:            record.RestartOnExit,
+		MaxRestarts:              record.MaxRestarts,
+		MaxResponseBytes:         record.MaxResponseBytes,
+		ToolMaxResponseBytes:     record.ToolMaxResponseBytes,
+		MaxConcurrentCalls:       record.MaxConcurrentCalls,
+		MaxCallDuration:          record.MaxCallDuration,
+		IdleDisconnectAfter:      record.IdleDisconnectAfter,
+		DefaultArgs:              record.DefaultArgs,
+		CacheableTools:           record.CacheableTools,
+		CacheTTL:                 record.CacheTTL,
+		SensitiveTools:           record.SensitiveTools,
+		ReadOnly:                 record.ReadOnly,
+		Experimental:             record.Experimental,
+		SearchPriority:           record.SearchPriority,
+		DisableCallRetry:         record.DisableCallRetry,
+		Durable:                  record.Durable,
+		ReadTools:                record.ReadTools,
+		WriteTools:               record.WriteTools,
+		DependsOn:                record.DependsOn,
+		ProtocolVersion:          record.ProtocolVersion,
 		StartupMode:              startupMode,              // Use config-prioritized startup mode
 		AutoDisableReason:        record.AutoDisableReason, // Include auto-disable reason
+		QuarantineReason:         record.QuarantineReason,
 	}, nil
 }
 
@@ -345,7 +396,10 @@ func (m *Manager) ListUpstreamServers() ([]*config.ServerConfig, error) {
 			Args:                     record.Args,
 			WorkingDir:               record.WorkingDir,
 			Env:                      record.Env,
+			EnvFile:                  record.EnvFile,
+			Shell:                    record.Shell,
 			Headers:                  record.Headers,
+			HTTPProxy:                record.HTTPProxy,
 			OAuth:                    record.OAuth,
 			RepositoryURL:            record.RepositoryURL,
 			Created:                  record.Created,
@@ -353,13 +407,35 @@ func (m *Manager) ListUpstreamServers() ([]*config.ServerConfig, error) {
 			Isolation:                record.Isolation,
 			GroupID:                  record.GroupID,
 			GroupName:                record.GroupName,
+			Tags:                     record.Tags,
 			EverConnected:            record.EverConnected,
 			LastSuccessfulConnection: record.LastSuccessfulConnection,
 			ToolCount:                record.ToolCount,
 			HealthCheck:              record.HealthCheck,
 			AutoDisableThreshold:     record.AutoDisableThreshold,
+			RestartOnExit:            record.RestartOnExit,
+			MaxRestarts:              record.MaxRestarts,
+			MaxResponseBytes:         record.MaxResponseBytes,
+			ToolMaxResponseBytes:     record.ToolMaxResponseBytes,
+			MaxConcurrentCalls:       record.MaxConcurrentCalls,
+			MaxCallDuration:          record.MaxCallDuration,
+			IdleDisconnectAfter:      record.IdleDisconnectAfter,
+			DefaultArgs:              record.DefaultArgs,
+			CacheableTools:           record.CacheableTools,
+			CacheTTL:                 record.CacheTTL,
+			SensitiveTools:           record.SensitiveTools,
+			ReadOnly:                 record.ReadOnly,
+			Experimental:             record.Experimental,
+			SearchPriority:           record.SearchPriority,
+			DisableCallRetry:         record.DisableCallRetry,
+			Durable:                  record.Durable,
+			ReadTools:                record.ReadTools,
+			WriteTools:               record.WriteTools,
+			DependsOn:                record.DependsOn,
+			ProtocolVersion:          record.ProtocolVersion,
 			StartupMode:              startupMode, // Use fallback value if database was empty
 			AutoDisableReason:        record.AutoDisableReason,
+			QuarantineReason:         record.QuarantineReason,
 		})
 	}
 
@@ -399,7 +475,10 @@ func (m *Manager) ListQuarantinedUpstreamServers() ([]*config.ServerConfig, erro
 				Args:                     record.Args,
 				WorkingDir:               record.WorkingDir,
 				Env:                      record.Env,
+				EnvFile:                  record.EnvFile,
+				Shell:                    record.Shell,
 				Headers:                  record.Headers,
+				HTTPProxy:                record.HTTPProxy,
 				OAuth:                    record.OAuth,
 				RepositoryURL:            record.RepositoryURL,
 				Created:                  record.Created,
@@ -413,6 +492,7 @@ func (m *Manager) ListQuarantinedUpstreamServers() ([]*config.ServerConfig, erro
 				HealthCheck:              record.HealthCheck,
 				StartupMode:              record.ServerState,
 				AutoDisableReason:        record.AutoDisableReason,
+				QuarantineReason:         record.QuarantineReason,
 			})
 
 			m.logger.Debugw("Added server to quarantined list",
@@ -469,6 +549,15 @@ func (m *Manager) DeleteUpstreamServer(name string) error {
 	return m.db.DeleteUpstream(name)
 }
 
+// RenameUpstreamServer renames an upstream server together with the metadata, statistics and
+// hashes stored under its name
+func (m *Manager) RenameUpstreamServer(oldName, newName string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.db.RenameUpstream(oldName, newName)
+}
+
 // EnableUpstreamServer enables/disables an upstream server using server_state
 // When enabling, sets server_state to "active", when disabling sets to "disabled"
 func (m *Manager) EnableUpstreamServer(name string, enabled bool) error {
@@ -544,7 +633,20 @@ func (m *Manager) EnableUpstreamServer(name string, enabled bool) error {
 
 // QuarantineUpstreamServer sets the quarantine status of an upstream server using server_state
 func (m *Manager) QuarantineUpstreamServer(name string, quarantined bool) error {
+	return m.setQuarantine(name, quarantined, "")
+}
+
+// AutoQuarantineUpstreamServer quarantines an upstream server that failed too often, recording
+// why in quarantine_reason
+func (m *Manager) AutoQuarantineUpstreamServer(name, reason string) error {
+	return m.setQuarantine(name, true, reason)
+}
+
+// setQuarantine updates the quarantine status in the database and the config file. The reason
+// is stored when quarantining and cleared when unquarantining.
+func (m *Manager) setQuarantine(name string, quarantined bool, reason string) error {
 	var oldServerState string
+	var oldQuarantineReason string
 	var newServerState string
 
 	m.logger.Debugw("QuarantineUpstreamServer called",
@@ -562,8 +664,9 @@ func (m *Manager) QuarantineUpstreamServer(name string, quarantined bool) error
 		return err
 	}
 
-	// Store old value for rollback
+	// Store old values for rollback
 	oldServerState = record.ServerState
+	oldQuarantineReason = record.QuarantineReason
 
 	m.logger.Debugw("Retrieved upstream record for quarantine",
 		"server", name,
@@ -573,9 +676,11 @@ func (m *Manager) QuarantineUpstreamServer(name string, quarantined bool) error
 	// Set appropriate server_state
 	if quarantined {
 		newServerState = "quarantined"
+		record.QuarantineReason = reason
 	} else {
 		// When un-quarantining, set to active
 		newServerState = "active"
+		record.QuarantineReason = "" // Clear the automatic quarantine reason
 	}
 	record.ServerState = newServerState
 	record.Updated = time.Now()
@@ -597,8 +702,10 @@ func (m *Manager) QuarantineUpstreamServer(name string, quarantined bool) error
 				if server.Name == name {
 					if quarantined {
 						cfg.Servers[i].StartupMode = "quarantined"
+						cfg.Servers[i].QuarantineReason = reason
 					} else {
 						cfg.Servers[i].StartupMode = "active"
+						cfg.Servers[i].QuarantineReason = ""
 					}
 					break
 				}
@@ -608,6 +715,7 @@ func (m *Manager) QuarantineUpstreamServer(name string, quarantined bool) error
 			// Rollback database changes (needs lock)
 			m.mu.Lock()
 			record.ServerState = oldServerState
+			record.QuarantineReason = oldQuarantineReason
 			if rollbackErr := m.db.SaveUpstream(record); rollbackErr != nil {
 				m.logger.Errorw("Failed to rollback database changes",
 					"server", name,
@@ -846,6 +954,78 @@ func (m *Manager) IncrementToolUsage(toolName string) error {
 	return m.db.IncrementToolStats(toolName)
 }
 
+// RecordToolCall records a completed tool call with its duration
+func (m *Manager) RecordToolCall(toolName string, duration time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.db.RecordToolCall(toolName, duration)
+}
+
+// AppendAuditRecord appends a tool call to the audit log
+func (m *Manager) AppendAuditRecord(record *AuditRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.db.AppendAuditRecord(record)
+}
+
+// ListAuditRecords returns audit log entries matching filter, newest first
+func (m *Manager) ListAuditRecords(filter AuditFilter) ([]*AuditRecord, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.db.ListAuditRecords(filter)
+}
+
+// PruneAuditRecords deletes audit log entries older than cutoff
+func (m *Manager) PruneAuditRecords(cutoff time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.db.PruneAuditRecords(cutoff)
+}
+
+// SaveDurableCall creates or replaces a queued call to a durable server
+func (m *Manager) SaveDurableCall(record *DurableCallRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.db.SaveDurableCall(record)
+}
+
+// GetDurableCall returns a queued call to a durable server by its call ID
+func (m *Manager) GetDurableCall(id string) (*DurableCallRecord, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.db.GetDurableCall(id)
+}
+
+// ListDurableCalls returns all queued calls to durable servers
+func (m *Manager) ListDurableCalls() ([]*DurableCallRecord, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.db.ListDurableCalls()
+}
+
+// PruneDurableCalls deletes finished durable calls last updated before cutoff
+func (m *Manager) PruneDurableCalls(cutoff time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.db.PruneDurableCalls(cutoff)
+}
+
+// ListToolUsage returns the usage statistics of every tool that has been called
+func (m *Manager) ListToolUsage() ([]*ToolStatRecord, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.db.ListToolStats()
+}
+
 // GetToolUsage retrieves usage statistics for a tool
 func (m *Manager) GetToolUsage(toolName string) (*ToolStatRecord, error) {
 	m.mu.RLock()
@@ -1027,6 +1207,9 @@ func (m *Manager) SaveToolMetadata(serverID string, tools []*config.ToolMetadata
 				InputSchema:  map[string]interface{}{}, // Store as empty map for now
 				Created:      now,
 				Updated:      now,
+
+				ReadOnlyHint:    tool.ReadOnlyHint,
+				DestructiveHint: tool.DestructiveHint,
 			}
 
 			// If tool has ParamsJSON, store it in InputSchema as a marker
@@ -1090,6 +1273,9 @@ func (m *Manager) GetToolMetadata(serverID string) ([]*config.ToolMetadata, erro
 				Hash:        "",
 				Created:     record.Created,
 				Updated:     record.Updated,
+
+				ReadOnlyHint:    record.ReadOnlyHint,
+				DestructiveHint: record.DestructiveHint,
 			})
 		}
 
@@ -1138,6 +1324,9 @@ func (m *Manager) GetAllToolMetadata() ([]*config.ToolMetadata, error) {
 				Hash:        "",
 				Created:     record.Created,
 				Updated:     record.Updated,
+
+				ReadOnlyHint:    record.ReadOnlyHint,
+				DestructiveHint: record.DestructiveHint,
 			})
 			return nil
 		})
@@ -1185,3 +1374,363 @@ func (m *Manager) DeleteServerToolMetadata(serverID string) error {
 		return nil
 	})
 }
+
+// DeleteOrphanedToolMetadata deletes the tool metadata of servers not listed in serverIDs,
+// e.g. records left behind by a crash during server removal. It returns the number of
+// records deleted per server.
+func (m *Manager) DeleteOrphanedToolMetadata(serverIDs []string) (map[string]int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	known := make(map[string]bool, len(serverIDs))
+	for _, id := range serverIDs {
+		known[id] = true
+	}
+
+	pruned := make(map[string]int)
+	err := m.db.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte(ToolMetadataBucket))
+		if bucket == nil {
+			return fmt.Errorf("tool metadata bucket not found")
+		}
+
+		keysToDelete := [][]byte{}
+		if err := bucket.ForEach(func(k, v []byte) error {
+			var record ToolMetadataRecord
+			if err := record.UnmarshalBinary(v); err != nil {
+				m.logger.Warnf("Failed to unmarshal tool metadata for key %s: %v", string(k), err)
+				return nil // Continue to next record
+			}
+			if known[record.ServerID] {
+				return nil
+			}
+
+			// Copy the key since it is only valid during the iteration
+			keyCopy := make([]byte, len(k))
+			copy(keyCopy, k)
+			keysToDelete = append(keysToDelete, keyCopy)
+			pruned[record.ServerID]++
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		for _, key := range keysToDelete {
+			if err := bucket.Delete(key); err != nil {
+				return fmt.Errorf("failed to delete tool metadata key %s: %w", string(key), err)
+			}
+		}
+
+		m.logger.Infof("Deleted %d orphaned tool metadata records from %d servers", len(keysToDelete), len(pruned))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pruned, nil
+}
+
+// SaveResourceMetadata replaces the stored resources of a server with the given list.
+// Resources are stored with key: {serverID}:{uri}
+func (m *Manager) SaveResourceMetadata(serverID string, resources []*config.ResourceMetadata) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.db.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte(ResourceMetadataBucket))
+		if bucket == nil {
+			return fmt.Errorf("resource metadata bucket not found")
+		}
+
+		// Collect the existing records so resources that disappeared are dropped and
+		// unchanged ones keep their creation time
+		prefix := []byte(serverID + ":")
+		existing := make(map[string]time.Time)
+		cursor := bucket.Cursor()
+		for k, v := cursor.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = cursor.Next() {
+			var record ResourceMetadataRecord
+			if err := record.UnmarshalBinary(v); err == nil {
+				existing[string(k)] = record.Created
+			} else {
+				existing[string(k)] = time.Time{}
+			}
+		}
+
+		now := time.Now()
+		for _, resource := range resources {
+			key := serverID + ":" + resource.URI
+			created, ok := existing[key]
+			if !ok || created.IsZero() {
+				created = now
+			}
+			delete(existing, key)
+
+			record := &ResourceMetadataRecord{
+				ServerID:    serverID,
+				URI:         resource.URI,
+				Name:        resource.Name,
+				Description: resource.Description,
+				MIMEType:    resource.MIMEType,
+				Created:     created,
+				Updated:     now,
+			}
+			data, err := record.MarshalBinary()
+			if err != nil {
+				return fmt.Errorf("failed to marshal resource metadata: %w", err)
+			}
+			if err := bucket.Put([]byte(key), data); err != nil {
+				return fmt.Errorf("failed to save resource metadata: %w", err)
+			}
+		}
+
+		for key := range existing {
+			if err := bucket.Delete([]byte(key)); err != nil {
+				return fmt.Errorf("failed to delete resource metadata key %s: %w", key, err)
+			}
+		}
+
+		m.logger.Debugf("Saved %d resource metadata records for server %s (%d removed)", len(resources), serverID, len(existing))
+		return nil
+	})
+}
+
+// GetAllResourceMetadata retrieves the resources of all servers from the database
+func (m *Manager) GetAllResourceMetadata() ([]*config.ResourceMetadata, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var resources []*config.ResourceMetadata
+	err := m.db.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte(ResourceMetadataBucket))
+		if bucket == nil {
+			return fmt.Errorf("resource metadata bucket not found")
+		}
+
+		return bucket.ForEach(func(k, v []byte) error {
+			var record ResourceMetadataRecord
+			if err := record.UnmarshalBinary(v); err != nil {
+				m.logger.Warnf("Failed to unmarshal resource metadata for key %s: %v", string(k), err)
+				return nil // Continue to next record
+			}
+
+			resources = append(resources, &config.ResourceMetadata{
+				URI:         record.URI,
+				ServerName:  record.ServerID,
+				Name:        record.Name,
+				Description: record.Description,
+				MIMEType:    record.MIMEType,
+				Created:     record.Created,
+				Updated:     record.Updated,
+			})
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// DeleteServerResourceMetadata deletes all resource metadata for a specific server
+func (m *Manager) DeleteServerResourceMetadata(serverID string) error {
+	return m.SaveResourceMetadata(serverID, nil)
+}
+
+// SavePromptMetadata replaces the stored prompts of a server with the given list.
+// Prompts are stored with key: {serverID}:{name}
+func (m *Manager) SavePromptMetadata(serverID string, prompts []*config.PromptMetadata) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.db.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte(PromptMetadataBucket))
+		if bucket == nil {
+			return fmt.Errorf("prompt metadata bucket not found")
+		}
+
+		// Collect the existing records so prompts that disappeared are dropped and
+		// unchanged ones keep their creation time
+		prefix := []byte(serverID + ":")
+		existing := make(map[string]time.Time)
+		cursor := bucket.Cursor()
+		for k, v := cursor.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = cursor.Next() {
+			var record PromptMetadataRecord
+			if err := record.UnmarshalBinary(v); err == nil {
+				existing[string(k)] = record.Created
+			} else {
+				existing[string(k)] = time.Time{}
+			}
+		}
+
+		now := time.Now()
+		for _, prompt := range prompts {
+			key := serverID + ":" + prompt.Name
+			created, ok := existing[key]
+			if !ok || created.IsZero() {
+				created = now
+			}
+			delete(existing, key)
+
+			record := &PromptMetadataRecord{
+				ServerID:    serverID,
+				Name:        prompt.Name,
+				Description: prompt.Description,
+				Arguments:   prompt.Arguments,
+				Created:     created,
+				Updated:     now,
+			}
+			data, err := record.MarshalBinary()
+			if err != nil {
+				return fmt.Errorf("failed to marshal prompt metadata: %w", err)
+			}
+			if err := bucket.Put([]byte(key), data); err != nil {
+				return fmt.Errorf("failed to save prompt metadata: %w", err)
+			}
+		}
+
+		for key := range existing {
+			if err := bucket.Delete([]byte(key)); err != nil {
+				return fmt.Errorf("failed to delete prompt metadata key %s: %w", key, err)
+			}
+		}
+
+		m.logger.Debugf("Saved %d prompt metadata records for server %s (%d removed)", len(prompts), serverID, len(existing))
+		return nil
+	})
+}
+
+// GetAllPromptMetadata retrieves the prompts of all servers from the database
+func (m *Manager) GetAllPromptMetadata() ([]*config.PromptMetadata, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var prompts []*config.PromptMetadata
+	err := m.db.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte(PromptMetadataBucket))
+		if bucket == nil {
+			return fmt.Errorf("prompt metadata bucket not found")
+		}
+
+		return bucket.ForEach(func(k, v []byte) error {
+			var record PromptMetadataRecord
+			if err := record.UnmarshalBinary(v); err != nil {
+				m.logger.Warnf("Failed to unmarshal prompt metadata for key %s: %v", string(k), err)
+				return nil // Continue to next record
+			}
+
+			prompts = append(prompts, &config.PromptMetadata{
+				Name:        record.Name,
+				ServerName:  record.ServerID,
+				Description: record.Description,
+				Arguments:   record.Arguments,
+				Created:     record.Created,
+				Updated:     record.Updated,
+			})
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return prompts, nil
+}
+
+// DeleteServerPromptMetadata deletes all prompt metadata for a specific server
+func (m *Manager) DeleteServerPromptMetadata(serverID string) error {
+	return m.SavePromptMetadata(serverID, nil)
+}
+
+// GetEmbedding returns the cached embedding for a text hash.
+// A cache miss returns a nil embedding and a nil error.
+func (m *Manager) GetEmbedding(hash string) ([]float32, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var embedding []float32
+	err := m.db.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte(EmbeddingsBucket))
+		if bucket == nil {
+			return nil
+		}
+
+		data := bucket.Get([]byte(hash))
+		if data == nil {
+			return nil
+		}
+
+		var record EmbeddingRecord
+		if err := record.UnmarshalBinary(data); err != nil {
+			return fmt.Errorf("failed to unmarshal embedding: %w", err)
+		}
+		embedding = record.Embedding
+		return nil
+	})
+
+	return embedding, err
+}
+
+// SaveEmbedding stores an embedding keyed by the hash of the embedded text
+func (m *Manager) SaveEmbedding(hash string, embedding []float32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	record := &EmbeddingRecord{
+		Hash:      hash,
+		Embedding: embedding,
+		Created:   time.Now(),
+	}
+
+	return m.db.db.Update(func(tx *bbolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists([]byte(EmbeddingsBucket))
+		if err != nil {
+			return fmt.Errorf("failed to create embeddings bucket: %w", err)
+		}
+
+		data, err := record.MarshalBinary()
+		if err != nil {
+			return fmt.Errorf("failed to marshal embedding: %w", err)
+		}
+		return bucket.Put([]byte(hash), data)
+	})
+}
+
+// DeleteEmbedding removes the cached embedding for a text hash
+func (m *Manager) DeleteEmbedding(hash string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.db.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte(EmbeddingsBucket))
+		if bucket == nil {
+			return nil
+		}
+		return bucket.Delete([]byte(hash))
+	})
+}
+
+// ClearEmbeddingCache removes all cached embeddings and returns how many were removed
+func (m *Manager) ClearEmbeddingCache() (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	count := 0
+	err := m.db.db.Update(func(tx *bbolt.Tx) error {
+		if bucket := tx.Bucket([]byte(EmbeddingsBucket)); bucket != nil {
+			count = bucket.Stats().KeyN
+			if err := tx.DeleteBucket([]byte(EmbeddingsBucket)); err != nil {
+				return fmt.Errorf("failed to delete embeddings bucket: %w", err)
+			}
+		}
+		_, err := tx.CreateBucketIfNotExists([]byte(EmbeddingsBucket))
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	m.logger.Infof("Cleared %d cached embeddings", count)
+	return count, nil
+}
@@ -8,15 +8,20 @@ import (
 
 // Bucket names for bbolt database
 const (
-	UpstreamsBucket       = "upstreams"
-	ToolStatsBucket       = "toolstats"
-	ToolHashBucket        = "toolhash"
-	ToolMetadataBucket    = "tool_metadata"    // Store complete tool metadata for lazy loading
-	OAuthTokenBucket      = "oauth_tokens" //nolint:gosec // bucket name, not a credential
-	OAuthCompletionBucket = "oauth_completion"
-	MetaBucket            = "meta"
-	CacheBucket           = "cache"
-	CacheStatsBucket      = "cache_stats"
+	UpstreamsBucket        = "upstreams"
+	ToolStatsBucket        = "toolstats"
+	ToolHashBucket         = "toolhash"
+	ToolMetadataBucket     = "tool_metadata"     // Store complete tool metadata for lazy loading
+	ResourceMetadataBucket = "resource_metadata" // Resources advertised by upstream servers
+	PromptMetadataBucket   = "prompt_metadata"   // Prompts advertised by upstream servers
+	OAuthTokenBucket       = "oauth_tokens"      //nolint:gosec // bucket name, not a credential
+	OAuthCompletionBucket  = "oauth_completion"
+	MetaBucket             = "meta"
+	CacheBucket            = "cache"
+	CacheStatsBucket       = "cache_stats"
+	EmbeddingsBucket       = "embeddings"    // Semantic search embeddings keyed by tool hash
+	AuditLogBucket         = "audit_log"     // Append-only tool call audit log keyed by sequence number
+	DurableCallsBucket     = "durable_calls" // Queued calls to durable servers keyed by call ID
 )
 
 // Meta keys
@@ -38,7 +43,10 @@ type UpstreamRecord struct {
 	Args          []string                `json:"args,omitempty"`
 	WorkingDir    string                  `json:"working_dir,omitempty"` // Working directory for stdio servers
 	Env           map[string]string       `json:"env,omitempty"`
+	EnvFile       string                  `json:"env_file,omitempty"`
+	Shell         string                  `json:"shell,omitempty"`
 	Headers       map[string]string       `json:"headers,omitempty"` // For HTTP authentication
+	HTTPProxy     string                  `json:"http_proxy,omitempty"`
 	OAuth         *config.OAuthConfig     `json:"oauth,omitempty"`   // OAuth configuration
 	RepositoryURL string                  `json:"repository_url,omitempty"` // GitHub/Repository URL for the MCP server
 	Created       time.Time               `json:"created"`
@@ -46,6 +54,7 @@ type UpstreamRecord struct {
 	Isolation     *config.IsolationConfig `json:"isolation,omitempty"` // Per-server isolation settings
 	GroupID       int                     `json:"group_id,omitempty"`
 	GroupName     string                  `json:"group_name,omitempty"`
+	Tags          []string                `json:"tags,omitempty"`
 
 	// Connection history for prioritization
 	EverConnected            bool      `json:"ever_connected,omitempty"`
@@ -58,6 +67,56 @@ type UpstreamRecord struct {
 	// Auto-disable threshold (number of failures before auto-disabling)
 	AutoDisableThreshold int    `json:"auto_disable_threshold,omitempty"`
 
+	// Crash supervision for stdio servers
+	RestartOnExit bool `json:"restart_on_exit,omitempty"`
+	MaxRestarts   int  `json:"max_restarts,omitempty"`
+
+	// Response size limits (0 = use global tool_response_limit)
+	MaxResponseBytes     int            `json:"max_response_bytes,omitempty"`
+	ToolMaxResponseBytes map[string]int `json:"tool_max_response_bytes,omitempty"`
+
+	// Pinned MCP protocol version (empty = latest)
+	ProtocolVersion string `json:"protocol_version,omitempty"`
+
+	// Max in-flight tool calls (0 = unlimited)
+	MaxConcurrentCalls int `json:"max_concurrent_calls,omitempty"`
+
+	// Bound for tool calls from clients without a deadline
+	MaxCallDuration config.Duration `json:"max_call_duration,omitempty"`
+
+	// Idle time before the connection is closed until the next call
+	IdleDisconnectAfter config.Duration `json:"idle_disconnect_after,omitempty"`
+
+	// Arguments merged into every tool call
+	DefaultArgs map[string]interface{} `json:"default_args,omitempty"`
+
+	// Result caching for idempotent tools
+	CacheableTools []string        `json:"cacheable_tools,omitempty"`
+	CacheTTL       config.Duration `json:"cache_ttl,omitempty"`
+
+	// Tools whose arguments are redacted in the audit log
+	SensitiveTools []string `json:"sensitive_tools,omitempty"`
+
+	// Read-only mode and the tools it allows and blocks
+	ReadOnly   bool     `json:"read_only,omitempty"`
+	ReadTools  []string `json:"read_tools,omitempty"`
+	WriteTools []string `json:"write_tools,omitempty"`
+
+	// Experimental servers are flagged to agents in tool search and call results
+	Experimental bool `json:"experimental,omitempty"`
+
+	// Boost of the server's tools in retrieve_tools ranking
+	SearchPriority int `json:"search_priority,omitempty"`
+
+	// Calls that lose their connection are not replayed after reconnecting
+	DisableCallRetry bool `json:"disable_call_retry,omitempty"`
+
+	// Calls are queued durably and polled with get_call_result
+	Durable bool `json:"durable,omitempty"`
+
+	// Servers that must be connected before this one starts
+	DependsOn []string `json:"depends_on,omitempty"`
+
 	// Server state (persisted runtime state, NOT the config-level startup_mode)
 	// IMPORTANT: This is the DATABASE representation of server state
 	// Config layer uses "startup_mode", but database uses "server_state" for clarity
@@ -65,13 +124,24 @@ type UpstreamRecord struct {
 	// NOTE: "stopped" is database-only (for lazy_loading servers with cached tools), never persisted to config startup_mode
 	ServerState       string `json:"server_state,omitempty"`
 	AutoDisableReason string `json:"auto_disable_reason,omitempty"` // Reason for auto-disable
+	QuarantineReason  string `json:"quarantine_reason,omitempty"`   // Reason for automatic quarantine
 }
 
 // ToolStatRecord represents tool usage statistics
 type ToolStatRecord struct {
-	ToolName string    `json:"tool_name"`
-	Count    uint64    `json:"count"`
-	LastUsed time.Time `json:"last_used"`
+	ToolName      string        `json:"tool_name"`
+	Count         uint64        `json:"count"`
+	LastUsed      time.Time     `json:"last_used"`
+	TotalDuration time.Duration `json:"total_duration,omitempty"` // Sum of durations of timed calls
+	TimedCalls    uint64        `json:"timed_calls,omitempty"`    // Number of calls with a recorded duration
+}
+
+// AverageDuration returns the mean duration of timed calls
+func (t *ToolStatRecord) AverageDuration() time.Duration {
+	if t.TimedCalls == 0 {
+		return 0
+	}
+	return t.TotalDuration / time.Duration(t.TimedCalls)
 }
 
 // ToolHashRecord represents a tool hash for change detection
@@ -91,6 +161,40 @@ type ToolMetadataRecord struct {
 	InputSchema map[string]interface{} `json:"input_schema,omitempty"`
 	Created     time.Time              `json:"created"`
 	Updated     time.Time              `json:"updated"`
+
+	// MCP annotations checked for read-only servers
+	ReadOnlyHint    *bool `json:"read_only_hint,omitempty"`
+	DestructiveHint *bool `json:"destructive_hint,omitempty"`
+}
+
+// ResourceMetadataRecord represents a resource advertised by an upstream server,
+// stored with key: {serverID}:{uri}
+type ResourceMetadataRecord struct {
+	ServerID    string    `json:"server_id"`
+	URI         string    `json:"uri"` // Unprefixed resource URI
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	MIMEType    string    `json:"mime_type,omitempty"`
+	Created     time.Time `json:"created"`
+	Updated     time.Time `json:"updated"`
+}
+
+// PromptMetadataRecord represents a prompt advertised by an upstream server,
+// stored with key: {serverID}:{name}
+type PromptMetadataRecord struct {
+	ServerID    string                  `json:"server_id"`
+	Name        string                  `json:"name"` // Unprefixed prompt name
+	Description string                  `json:"description,omitempty"`
+	Arguments   []config.PromptArgument `json:"arguments,omitempty"`
+	Created     time.Time               `json:"created"`
+	Updated     time.Time               `json:"updated"`
+}
+
+// EmbeddingRecord represents a cached semantic search embedding for a tool
+type EmbeddingRecord struct {
+	Hash      string    `json:"hash"`
+	Embedding []float32 `json:"embedding"`
+	Created   time.Time `json:"created"`
 }
 
 // OAuthTokenRecord represents stored OAuth tokens for a server
@@ -112,6 +216,59 @@ type OAuthCompletionEvent struct {
 	ProcessedAt *time.Time `json:"processed_at,omitempty"` // Nil if not yet processed by server
 }
 
+// AuditRecord is an audit log entry for a single tool call
+type AuditRecord struct {
+	ID         uint64                 `json:"id"`
+	Timestamp  time.Time              `json:"timestamp"`
+	Client     string                 `json:"client"`               // MCP client name/version, or "unknown"
+	SessionID  string                 `json:"session_id,omitempty"` // MCP session the call was made in
+	Tool       string                 `json:"tool"`                 // server:tool, or the built-in tool name
+	Args       map[string]interface{} `json:"args,omitempty"`
+	Redacted   bool                   `json:"redacted,omitempty"` // Args omitted because the tool is flagged sensitive
+	Status     string                 `json:"status"`             // "success" or "error"
+	Error      string                 `json:"error,omitempty"`
+	DurationMs int64                  `json:"duration_ms"`
+}
+
+// Statuses of a DurableCallRecord
+const (
+	DurableCallPending   = "pending"   // Queued, not started yet
+	DurableCallRunning   = "running"   // Sent to the upstream server
+	DurableCallCompleted = "completed" // Result available
+	DurableCallFailed    = "failed"    // Error available
+)
+
+// DurableCallRecord is a tool call to a durable server, kept until its result was collected.
+// Calls still pending or running when the proxy stops are replayed on the next start.
+type DurableCallRecord struct {
+	ID        string                 `json:"id"`
+	Tool      string                 `json:"tool"` // server:tool
+	Args      map[string]interface{} `json:"args,omitempty"`
+	Status    string                 `json:"status"`
+	Result    string                 `json:"result,omitempty"`     // Serialized upstream result of a completed call
+	ErrorCode string                 `json:"error_code,omitempty"` // call_tool error code of a failed call
+	Error     string                 `json:"error,omitempty"`
+	Attempts  int                    `json:"attempts"`         // Times the call was sent, counting replays
+	Client    string                 `json:"client,omitempty"` // MCP client that queued the call, for the audit log
+	SessionID string                 `json:"session_id,omitempty"`
+	Created   time.Time              `json:"created"`
+	Updated   time.Time              `json:"updated"`
+}
+
+// Finished reports whether the call completed or failed
+func (d *DurableCallRecord) Finished() bool {
+	return d.Status == DurableCallCompleted || d.Status == DurableCallFailed
+}
+
+// AuditFilter selects audit records; zero fields match everything
+type AuditFilter struct {
+	Tool   string    // Exact tool name, or a server name to match all of its tools
+	Client string    // Case-insensitive substring of the client
+	Status string    // "success" or "error"
+	Since  time.Time // Only records at or after this time
+	Limit  int       // Maximum number of records (newest first); 0 = no limit
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler
 func (u *UpstreamRecord) MarshalBinary() ([]byte, error) {
 	return json.Marshal(u)
@@ -171,3 +328,53 @@ func (t *ToolMetadataRecord) MarshalBinary() ([]byte, error) {
 func (t *ToolMetadataRecord) UnmarshalBinary(data []byte) error {
 	return json.Unmarshal(data, t)
 }
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (r *ResourceMetadataRecord) MarshalBinary() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (r *ResourceMetadataRecord) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, r)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (r *PromptMetadataRecord) MarshalBinary() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (r *PromptMetadataRecord) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, r)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (e *EmbeddingRecord) MarshalBinary() ([]byte, error) {
+	return json.Marshal(e)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (e *EmbeddingRecord) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, e)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (a *AuditRecord) MarshalBinary() ([]byte, error) {
+	return json.Marshal(a)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (a *AuditRecord) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, a)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (d *DurableCallRecord) MarshalBinary() ([]byte, error) {
+	return json.Marshal(d)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (d *DurableCallRecord) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, d)
+}
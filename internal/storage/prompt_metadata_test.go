@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
+)
+
+func TestManager_SavePromptMetadata(t *testing.T) {
+	manager, err := NewManager(t.TempDir(), zap.NewNop().Sugar())
+	require.NoError(t, err)
+	defer manager.Close()
+
+	require.NoError(t, manager.SavePromptMetadata("github", []*config.PromptMetadata{
+		{
+			Name:        "review_pr",
+			Description: "Review a pull request",
+			Arguments:   []config.PromptArgument{{Name: "number", Description: "PR number", Required: true}},
+		},
+		{Name: "summarize_issues"},
+	}))
+
+	prompts, err := manager.GetAllPromptMetadata()
+	require.NoError(t, err)
+	require.Len(t, prompts, 2)
+	for _, prompt := range prompts {
+		assert.Equal(t, "github", prompt.ServerName)
+		if prompt.Name == "review_pr" {
+			assert.Equal(t, []config.PromptArgument{{Name: "number", Description: "PR number", Required: true}}, prompt.Arguments)
+		}
+	}
+
+	// A later discovery drops prompts the server no longer advertises
+	require.NoError(t, manager.SavePromptMetadata("github", []*config.PromptMetadata{{Name: "review_pr"}}))
+	prompts, err = manager.GetAllPromptMetadata()
+	require.NoError(t, err)
+	require.Len(t, prompts, 1)
+	assert.Equal(t, "review_pr", prompts[0].Name)
+
+	require.NoError(t, manager.DeleteServerPromptMetadata("github"))
+	prompts, err = manager.GetAllPromptMetadata()
+	require.NoError(t, err)
+	assert.Empty(t, prompts)
+}
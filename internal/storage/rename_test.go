@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
+)
+
+func TestManager_RenameUpstreamServer(t *testing.T) {
+	manager, err := NewManager(t.TempDir(), zap.NewNop().Sugar())
+	require.NoError(t, err)
+	defer manager.Close()
+
+	require.NoError(t, manager.SaveUpstreamServer(&config.ServerConfig{Name: "github", URL: "https://api.github.com/mcp"}))
+	require.NoError(t, manager.SaveUpstreamServer(&config.ServerConfig{Name: "gitlab", URL: "https://gitlab.com/mcp", DependsOn: []string{"github"}}))
+	require.NoError(t, manager.SaveToolMetadata("github", []*config.ToolMetadata{{Name: "create_issue", Description: "Create an issue"}}))
+	require.NoError(t, manager.SaveToolMetadata("gitlab", []*config.ToolMetadata{{Name: "create_issue"}}))
+	require.NoError(t, manager.SaveResourceMetadata("github", []*config.ResourceMetadata{{URI: "repo://readme", Name: "README"}}))
+	require.NoError(t, manager.SavePromptMetadata("github", []*config.PromptMetadata{{Name: "review"}}))
+	require.NoError(t, manager.RecordToolCall("github:create_issue", time.Second))
+	require.NoError(t, manager.SaveToolHash("github:create_issue", "abc"))
+
+	require.NoError(t, manager.RenameUpstreamServer("github", "gh"))
+
+	_, err = manager.GetUpstreamServer("github")
+	assert.Error(t, err)
+	renamed, err := manager.GetUpstreamServer("gh")
+	require.NoError(t, err)
+	assert.Equal(t, "gh", renamed.Name)
+	assert.Equal(t, "https://api.github.com/mcp", renamed.URL)
+
+	tools, err := manager.GetToolMetadata("gh")
+	require.NoError(t, err)
+	require.Len(t, tools, 1)
+	assert.Equal(t, "gh:create_issue", tools[0].Name)
+	assert.Equal(t, "gh", tools[0].ServerName)
+	assert.Equal(t, "Create an issue", tools[0].Description)
+	tools, err = manager.GetToolMetadata("github")
+	require.NoError(t, err)
+	assert.Empty(t, tools)
+
+	resources, err := manager.GetAllResourceMetadata()
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, "gh", resources[0].ServerName)
+
+	prompts, err := manager.GetAllPromptMetadata()
+	require.NoError(t, err)
+	require.Len(t, prompts, 1)
+	assert.Equal(t, "gh", prompts[0].ServerName)
+
+	stats, err := manager.GetToolUsage("gh:create_issue")
+	require.NoError(t, err)
+	assert.Equal(t, "gh:create_issue", stats.ToolName)
+	assert.Equal(t, uint64(1), stats.Count)
+	hash, err := manager.GetToolHash("gh:create_issue")
+	require.NoError(t, err)
+	assert.Equal(t, "abc", hash)
+
+	dependent, err := manager.GetUpstreamServer("gitlab")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"gh"}, dependent.DependsOn)
+
+	// Other servers' data is untouched
+	tools, err = manager.GetToolMetadata("gitlab")
+	require.NoError(t, err)
+	require.Len(t, tools, 1)
+	assert.Equal(t, "gitlab:create_issue", tools[0].Name)
+}
+
+func TestManager_RenameUpstreamServer_Rejected(t *testing.T) {
+	manager, err := NewManager(t.TempDir(), zap.NewNop().Sugar())
+	require.NoError(t, err)
+	defer manager.Close()
+
+	require.NoError(t, manager.SaveUpstreamServer(&config.ServerConfig{Name: "github"}))
+	require.NoError(t, manager.SaveUpstreamServer(&config.ServerConfig{Name: "gitlab"}))
+	require.NoError(t, manager.SaveToolMetadata("github", []*config.ToolMetadata{{Name: "create_issue"}}))
+
+	assert.Error(t, manager.RenameUpstreamServer("missing", "other"))
+	assert.Error(t, manager.RenameUpstreamServer("github", "gitlab"))
+
+	// A rejected rename leaves everything in place
+	_, err = manager.GetUpstreamServer("github")
+	require.NoError(t, err)
+	tools, err := manager.GetToolMetadata("github")
+	require.NoError(t, err)
+	assert.Len(t, tools, 1)
+}
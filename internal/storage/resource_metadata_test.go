@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
+)
+
+func TestManager_SaveResourceMetadata_ReplacesServerResources(t *testing.T) {
+	manager, err := NewManager(t.TempDir(), zap.NewNop().Sugar())
+	require.NoError(t, err)
+	defer manager.Close()
+
+	require.NoError(t, manager.SaveResourceMetadata("docs", []*config.ResourceMetadata{
+		{URI: "file:///readme.md", Name: "README", MIMEType: "text/markdown"},
+		{URI: "file:///old.md", Name: "Old"},
+	}))
+	require.NoError(t, manager.SaveResourceMetadata("db", []*config.ResourceMetadata{
+		{URI: "postgres://main/users", Name: "users"},
+	}))
+
+	all, err := manager.GetAllResourceMetadata()
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	for _, r := range all {
+		assert.False(t, r.Created.IsZero())
+	}
+
+	// A later discovery drops resources the server no longer advertises
+	require.NoError(t, manager.SaveResourceMetadata("docs", []*config.ResourceMetadata{
+		{URI: "file:///readme.md", Name: "README", Description: "Project readme"},
+	}))
+
+	all, err = manager.GetAllResourceMetadata()
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	for _, r := range all {
+		if r.ServerName == "docs" {
+			assert.Equal(t, "file:///readme.md", r.URI)
+			assert.Equal(t, "Project readme", r.Description)
+			assert.False(t, r.Updated.Before(r.Created))
+		}
+	}
+
+	require.NoError(t, manager.DeleteServerResourceMetadata("docs"))
+	all, err = manager.GetAllResourceMetadata()
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.Equal(t, "db", all[0].ServerName)
+}
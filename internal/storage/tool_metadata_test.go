@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
+)
+
+func TestManager_DeleteOrphanedToolMetadata(t *testing.T) {
+	manager, err := NewManager(t.TempDir(), zap.NewNop().Sugar())
+	require.NoError(t, err)
+	defer manager.Close()
+
+	require.NoError(t, manager.SaveToolMetadata("github", []*config.ToolMetadata{
+		{Name: "create_issue"}, {Name: "list_repos"},
+	}))
+	require.NoError(t, manager.SaveToolMetadata("removed", []*config.ToolMetadata{
+		{Name: "a"}, {Name: "b"}, {Name: "c"},
+	}))
+	require.NoError(t, manager.SaveToolMetadata("crashed", []*config.ToolMetadata{
+		{Name: "x"},
+	}))
+
+	pruned, err := manager.DeleteOrphanedToolMetadata([]string{"github"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"removed": 3, "crashed": 1}, pruned)
+
+	tools, err := manager.GetAllToolMetadata()
+	require.NoError(t, err)
+	require.Len(t, tools, 2)
+	for _, tool := range tools {
+		assert.Equal(t, "github", tool.ServerName)
+	}
+
+	// Nothing left to prune
+	pruned, err = manager.DeleteOrphanedToolMetadata([]string{"github"})
+	require.NoError(t, err)
+	assert.Empty(t, pruned)
+}
@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestManager_RecordToolCall(t *testing.T) {
+	manager, err := NewManager(t.TempDir(), zap.NewNop().Sugar())
+	require.NoError(t, err)
+	defer manager.Close()
+
+	require.NoError(t, manager.RecordToolCall("github:create_issue", 100*time.Millisecond))
+	require.NoError(t, manager.RecordToolCall("github:create_issue", 300*time.Millisecond))
+	// Untimed increments count calls without skewing the average
+	require.NoError(t, manager.IncrementToolUsage("github:create_issue"))
+
+	record, err := manager.GetToolUsage("github:create_issue")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), record.Count)
+	assert.Equal(t, uint64(2), record.TimedCalls)
+	assert.Equal(t, 200*time.Millisecond, record.AverageDuration())
+	assert.WithinDuration(t, time.Now(), record.LastUsed, 5*time.Second)
+
+	require.NoError(t, manager.RecordToolCall("weather:forecast", time.Second))
+
+	records, err := manager.ListToolUsage()
+	require.NoError(t, err)
+	assert.Len(t, records, 2)
+}
@@ -0,0 +1,76 @@
+package transport
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// headerTemplate matches ${env:VAR} and ${file:path} references in header values
+var headerTemplate = regexp.MustCompile(`\$\{(env|file):([^}]+)\}`)
+
+// ExpandHeaders resolves ${env:VAR} and ${file:path} references in header values.
+// It is called every time a transport is created, so a token file rewritten by an
+// external process is picked up on the next reconnect. File contents are trimmed of
+// surrounding whitespace; a leading ~/ in a path refers to the home directory.
+func ExpandHeaders(headers map[string]string) (map[string]string, error) {
+	if len(headers) == 0 {
+		return headers, nil
+	}
+
+	expanded := make(map[string]string, len(headers))
+	for name, value := range headers {
+		resolved, err := expandReferences(value)
+		if err != nil {
+			return nil, fmt.Errorf("header %s: %w", name, err)
+		}
+		expanded[name] = resolved
+	}
+	return expanded, nil
+}
+
+// expandReferences resolves the ${env:VAR} and ${file:path} references in one value
+func expandReferences(value string) (string, error) {
+	var expandErr error
+	expanded := headerTemplate.ReplaceAllStringFunc(value, func(ref string) string {
+		match := headerTemplate.FindStringSubmatch(ref)
+		resolved, err := resolveHeaderReference(match[1], match[2])
+		if err != nil && expandErr == nil {
+			expandErr = err
+		}
+		return resolved
+	})
+	if expandErr != nil {
+		return "", expandErr
+	}
+	return expanded, nil
+}
+
+func resolveHeaderReference(kind, ref string) (string, error) {
+	switch kind {
+	case "env":
+		value, ok := os.LookupEnv(ref)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", ref)
+		}
+		return value, nil
+	case "file":
+		path := ref
+		if strings.HasPrefix(path, "~/") {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return "", fmt.Errorf("failed to resolve home directory for %s: %w", ref, err)
+			}
+			path = filepath.Join(home, path[2:])
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", ref, err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	default:
+		return "", fmt.Errorf("unsupported reference ${%s:%s}", kind, ref)
+	}
+}
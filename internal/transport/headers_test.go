@@ -0,0 +1,40 @@
+package transport
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandHeaders(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("file-token\n"), 0600))
+	t.Setenv("MCPPROXY_TEST_TOKEN", "env-token")
+
+	headers, err := ExpandHeaders(map[string]string{
+		"Authorization": "Bearer ${file:" + tokenFile + "}",
+		"X-Api-Key":     "${env:MCPPROXY_TEST_TOKEN}",
+		"X-Static":      "plain ${value}",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer file-token", headers["Authorization"])
+	assert.Equal(t, "env-token", headers["X-Api-Key"])
+	assert.Equal(t, "plain ${value}", headers["X-Static"])
+
+	// The file is re-read on every call so rotated tokens are picked up
+	require.NoError(t, os.WriteFile(tokenFile, []byte("rotated-token"), 0600))
+	headers, err = ExpandHeaders(map[string]string{"Authorization": "Bearer ${file:" + tokenFile + "}"})
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer rotated-token", headers["Authorization"])
+}
+
+func TestExpandHeadersErrors(t *testing.T) {
+	_, err := ExpandHeaders(map[string]string{"X-Api-Key": "${env:MCPPROXY_TEST_UNSET_VARIABLE}"})
+	assert.ErrorContains(t, err, "MCPPROXY_TEST_UNSET_VARIABLE")
+
+	_, err = ExpandHeaders(map[string]string{"Authorization": "${file:/nonexistent/mcpproxy/token}"})
+	assert.ErrorContains(t, err, "Authorization")
+}
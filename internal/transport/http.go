@@ -87,6 +87,7 @@ type HTTPTransportConfig struct {
 	Headers     map[string]string
 	OAuthConfig *client.OAuthConfig
 	UseOAuth    bool
+	ProxyURL    string // Proxy for this server's requests (empty = default transport behavior)
 }
 
 // CreateHTTPClient creates a new MCP client using HTTP transport
@@ -137,7 +138,16 @@ func CreateHTTPClient(cfg *HTTPTransportConfig) (*client.Client, error) {
 			zap.String("client_id", cfg.OAuthConfig.ClientID),
 			zap.Bool("has_token_store", cfg.OAuthConfig.TokenStore != nil))
 
-		client, err := client.NewOAuthStreamableHttpClient(cfg.URL, *cfg.OAuthConfig)
+		var options []transport.StreamableHTTPCOption
+		if cfg.ProxyURL != "" {
+			proxyClient, err := newProxyHTTPClient(cfg.ProxyURL)
+			if err != nil {
+				return nil, err
+			}
+			options = append(options, transport.WithHTTPBasicClient(proxyClient))
+		}
+
+		client, err := client.NewOAuthStreamableHttpClient(cfg.URL, *cfg.OAuthConfig, options...)
 		if err != nil {
 			logger.Error("Failed to create OAuth client", zap.Error(err))
 			return nil, fmt.Errorf("failed to create OAuth client: %w", err)
@@ -148,11 +158,25 @@ func CreateHTTPClient(cfg *HTTPTransportConfig) (*client.Client, error) {
 	}
 
 	logger.Debug("Creating regular HTTP client", zap.String("url", cfg.URL))
+	// Route this server's requests through its proxy; the client must be set before the timeout
+	var proxyOptions []transport.StreamableHTTPCOption
+	if cfg.ProxyURL != "" {
+		proxyClient, err := newProxyHTTPClient(cfg.ProxyURL)
+		if err != nil {
+			return nil, err
+		}
+		proxyOptions = append(proxyOptions, transport.WithHTTPBasicClient(proxyClient))
+	}
+
 	// Use regular HTTP client
 	if len(cfg.Headers) > 0 {
-		logger.Debug("Adding HTTP headers", zap.Int("header_count", len(cfg.Headers)))
+		headers, err := ExpandHeaders(cfg.Headers)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve HTTP headers: %w", err)
+		}
+		logger.Debug("Adding HTTP headers", zap.Int("header_count", len(headers)))
 		httpTransport, err := transport.NewStreamableHTTP(cfg.URL,
-			transport.WithHTTPHeaders(cfg.Headers))
+			append(proxyOptions, transport.WithHTTPHeaders(headers))...)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create HTTP transport: %w", err)
 		}
@@ -160,7 +184,7 @@ func CreateHTTPClient(cfg *HTTPTransportConfig) (*client.Client, error) {
 	}
 
 	httpTransport, err := transport.NewStreamableHTTP(cfg.URL,
-		transport.WithHTTPTimeout(config.HTTPConnectionTimeout)) // MED-002: Use centralized timeout
+		append(proxyOptions, transport.WithHTTPTimeout(config.HTTPConnectionTimeout))...) // MED-002: Use centralized timeout
 	if err != nil {
 		return nil, fmt.Errorf("failed to create HTTP transport: %w", err)
 	}
@@ -206,7 +230,16 @@ func CreateSSEClient(cfg *HTTPTransportConfig) (*client.Client, error) {
 			zap.String("client_id", cfg.OAuthConfig.ClientID),
 			zap.Bool("has_token_store", cfg.OAuthConfig.TokenStore != nil))
 
-		client, err := client.NewOAuthSSEClient(cfg.URL, *cfg.OAuthConfig)
+		var options []transport.ClientOption
+		if cfg.ProxyURL != "" {
+			proxyClient, err := newProxyHTTPClient(cfg.ProxyURL)
+			if err != nil {
+				return nil, err
+			}
+			options = append(options, transport.WithHTTPClient(proxyClient))
+		}
+
+		client, err := client.NewOAuthSSEClient(cfg.URL, *cfg.OAuthConfig, options...)
 		if err != nil {
 			logger.Error("Failed to create OAuth SSE client", zap.Error(err))
 			return nil, fmt.Errorf("failed to create OAuth SSE client: %w", err)
@@ -217,13 +250,23 @@ func CreateSSEClient(cfg *HTTPTransportConfig) (*client.Client, error) {
 	}
 
 	logger.Debug("Creating regular SSE client", zap.String("url", cfg.URL))
+	proxy, err := proxyFunc(cfg.ProxyURL)
+	if err != nil {
+		return nil, err
+	}
+
 	// Use regular SSE client
 	if len(cfg.Headers) > 0 {
-		logger.Debug("Adding SSE headers", zap.Int("header_count", len(cfg.Headers)))
+		headers, err := ExpandHeaders(cfg.Headers)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve SSE headers: %w", err)
+		}
+		logger.Debug("Adding SSE headers", zap.Int("header_count", len(headers)))
 		// MED-002: Create custom HTTP client with centralized timeout for SSE
 		httpClient := &http.Client{
 			Timeout: config.HTTPConnectionTimeout,
 			Transport: &http.Transport{
+				Proxy:               proxy,
 				MaxIdleConns:        10,
 				IdleConnTimeout:     config.HTTPIdleConnTimeout,
 				DisableCompression:  false,
@@ -239,7 +282,7 @@ func CreateSSEClient(cfg *HTTPTransportConfig) (*client.Client, error) {
 
 		sseClient, err := client.NewSSEMCPClient(cfg.URL,
 			client.WithHTTPClient(httpClient),
-			client.WithHeaders(cfg.Headers))
+			client.WithHeaders(headers))
 		if err != nil {
 			return nil, fmt.Errorf("failed to create SSE client: %w", err)
 		}
@@ -250,6 +293,7 @@ func CreateSSEClient(cfg *HTTPTransportConfig) (*client.Client, error) {
 	httpClient := &http.Client{
 		Timeout: config.HTTPConnectionTimeout,
 		Transport: &http.Transport{
+			Proxy:               proxy,
 			MaxIdleConns:        10,
 			IdleConnTimeout:     config.HTTPIdleConnTimeout,
 			DisableCompression:  false,
@@ -298,6 +342,7 @@ func CreateHTTPTransportConfig(serverConfig *config.ServerConfig, oauthConfig *c
 		Headers:     serverConfig.Headers,
 		OAuthConfig: oauthConfig,
 		UseOAuth:    oauthConfig != nil,
+		ProxyURL:    serverConfig.HTTPProxy,
 	}
 }
 
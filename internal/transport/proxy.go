@@ -0,0 +1,41 @@
+package transport
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"mcpproxy-go/internal/config"
+)
+
+// proxyFunc returns the Proxy function of a transport that sends all requests through
+// proxyURL, or nil for an empty proxyURL. ${env:VAR} and ${file:path} references in the URL
+// are resolved like header values, so credentials need not be stored in the config.
+func proxyFunc(proxyURL string) (func(*http.Request) (*url.URL, error), error) {
+	if proxyURL == "" {
+		return nil, nil
+	}
+
+	expanded, err := expandReferences(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("http_proxy: %w", err)
+	}
+	parsed, err := config.ParseProxyURL(expanded)
+	if err != nil {
+		return nil, fmt.Errorf("http_proxy: %w", err)
+	}
+	return http.ProxyURL(parsed), nil
+}
+
+// newProxyHTTPClient returns an HTTP client with the default transport settings whose requests
+// all go through proxyURL
+func newProxyHTTPClient(proxyURL string) (*http.Client, error) {
+	proxy, err := proxyFunc(proxyURL)
+	if err != nil {
+		return nil, err
+	}
+
+	httpTransport := http.DefaultTransport.(*http.Transport).Clone()
+	httpTransport.Proxy = proxy
+	return &http.Client{Transport: httpTransport}, nil
+}
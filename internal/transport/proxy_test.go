@@ -0,0 +1,48 @@
+package transport
+
+import (
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProxyHTTPClient_RoutesThroughProxy(t *testing.T) {
+	var requestedURL, proxyAuth string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedURL = r.URL.String()
+		proxyAuth = r.Header.Get("Proxy-Authorization")
+		_, _ = io.WriteString(w, "via proxy")
+	}))
+	defer proxy.Close()
+
+	t.Setenv("MCPPROXY_TEST_PROXY_PASSWORD", "s3cret")
+	client, err := newProxyHTTPClient("http://alice:${env:MCPPROXY_TEST_PROXY_PASSWORD}@" + proxy.Listener.Addr().String())
+	require.NoError(t, err)
+
+	resp, err := client.Get("http://upstream.internal/mcp")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, "via proxy", string(body))
+	assert.Equal(t, "http://upstream.internal/mcp", requestedURL)
+	assert.Equal(t, "Basic "+base64.StdEncoding.EncodeToString([]byte("alice:s3cret")), proxyAuth)
+}
+
+func TestProxyFunc(t *testing.T) {
+	proxy, err := proxyFunc("")
+	require.NoError(t, err)
+	assert.Nil(t, proxy, "an empty http_proxy keeps the transport's default")
+
+	_, err = proxyFunc("ftp://proxy.corp:21")
+	assert.Error(t, err)
+
+	_, err = proxyFunc("http://${env:MCPPROXY_TEST_UNSET_PROXY}")
+	assert.Error(t, err)
+}
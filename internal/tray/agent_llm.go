@@ -28,6 +28,9 @@ type LLMAgent struct {
 		GetLogDir() string
 		GetGitHubURL() string
 	}
+
+	// Sends tool calls to the main server's /chat/ endpoints
+	apiRequest apiRequestFunc
 }
 
 // NewLLMAgent creates a new LLM-powered agent with config-based client
@@ -39,7 +42,7 @@ func NewLLMAgent(logger *zap.Logger, llmConfig *config.LLMConfig, serverManager
 	GetConfigPath() string
 	GetLogDir() string
 	GetGitHubURL() string
-}) *LLMAgent {
+}, apiRequest apiRequestFunc) *LLMAgent {
 	// Create LLM client based on configuration
 	llmClient := NewLLMClientFromConfig(llmConfig)
 
@@ -61,6 +64,7 @@ func NewLLMAgent(logger *zap.Logger, llmConfig *config.LLMConfig, serverManager
 		logger:        logger,
 		llmClient:     llmClient,
 		serverManager: serverManager,
+		apiRequest:    apiRequest,
 	}
 }
 
@@ -932,11 +936,11 @@ func (a *LLMAgent) createToolExecutor(serverName string) ToolExecutor {
 
 		switch toolName {
 		case "read_config":
-			endpoint = "http://localhost:8080/chat/read-config"
+			endpoint = "/chat/read-config"
 			requestBody = map[string]interface{}{}
 
 		case "write_config":
-			endpoint = "http://localhost:8080/chat/write-config"
+			endpoint = "/chat/write-config"
 			content, ok := arguments["content"].(string)
 			if !ok {
 				return "", fmt.Errorf("write_config requires 'content' parameter")
@@ -946,11 +950,11 @@ func (a *LLMAgent) createToolExecutor(serverName string) ToolExecutor {
 			}
 
 		case "read_log":
-			endpoint = "http://localhost:8080/chat/read-log"
+			endpoint = "/chat/read-log"
 			requestBody = map[string]interface{}{}
 
 		case "read_github":
-			endpoint = "http://localhost:8080/chat/read-github"
+			endpoint = "/chat/read-github"
 			url, ok := arguments["url"].(string)
 			if !ok {
 				return "", fmt.Errorf("read_github requires 'url' parameter")
@@ -960,7 +964,7 @@ func (a *LLMAgent) createToolExecutor(serverName string) ToolExecutor {
 			}
 
 		case "restart_server":
-			endpoint = "http://localhost:8080/chat/restart-server"
+			endpoint = "/chat/restart-server"
 			serverNameParam, ok := arguments["server_name"].(string)
 			if !ok {
 				return "", fmt.Errorf("restart_server requires 'server_name' parameter")
@@ -970,7 +974,7 @@ func (a *LLMAgent) createToolExecutor(serverName string) ToolExecutor {
 			}
 
 		case "call_tool":
-			endpoint = "http://localhost:8080/chat/call-tool"
+			endpoint = "/chat/call-tool"
 			serverNameParam, ok1 := arguments["server_name"].(string)
 			toolNameParam, ok2 := arguments["tool_name"].(string)
 			toolArguments, ok3 := arguments["arguments"].(map[string]interface{})
@@ -984,7 +988,7 @@ func (a *LLMAgent) createToolExecutor(serverName string) ToolExecutor {
 			}
 
 		case "get_server_status":
-			endpoint = "http://localhost:8080/chat/get-server-status"
+			endpoint = "/chat/get-server-status"
 			serverNameParam, ok := arguments["server_name"].(string)
 			if !ok {
 				return "", fmt.Errorf("get_server_status requires 'server_name' parameter")
@@ -994,7 +998,7 @@ func (a *LLMAgent) createToolExecutor(serverName string) ToolExecutor {
 			}
 
 		case "test_server_tools":
-			endpoint = "http://localhost:8080/chat/test-server-tools"
+			endpoint = "/chat/test-server-tools"
 			serverNameParam, ok := arguments["server_name"].(string)
 			if !ok {
 				return "", fmt.Errorf("test_server_tools requires 'server_name' parameter")
@@ -1012,11 +1016,11 @@ func (a *LLMAgent) createToolExecutor(serverName string) ToolExecutor {
 			}
 
 		case "list_all_servers":
-			endpoint = "http://localhost:8080/chat/list-all-servers"
+			endpoint = "/chat/list-all-servers"
 			requestBody = map[string]interface{}{}
 
 		case "list_all_tools":
-			endpoint = "http://localhost:8080/chat/list-all-tools"
+			endpoint = "/chat/list-all-tools"
 			requestBody = map[string]interface{}{}
 
 		default:
@@ -1029,7 +1033,10 @@ func (a *LLMAgent) createToolExecutor(serverName string) ToolExecutor {
 			return "", fmt.Errorf("failed to marshal request: %w", err)
 		}
 
-		resp, err := http.Post(endpoint, "application/json", bytes.NewBuffer(jsonData))
+		if a.apiRequest == nil {
+			return "", fmt.Errorf("server API not available")
+		}
+		resp, err := a.apiRequest(http.MethodPost, endpoint, bytes.NewBuffer(jsonData))
 		if err != nil {
 			return "", fmt.Errorf("failed to call endpoint: %w", err)
 		}
@@ -57,6 +57,9 @@ type ChatSystem struct {
 		GetLogDir() string
 		GetGitHubURL() string
 	}
+
+	// Sends the agent's tool calls to the main server's /chat/ endpoints
+	apiRequest apiRequestFunc
 }
 
 // DiagnosticAgentInterface defines the interface for all diagnostic agents
@@ -85,13 +88,14 @@ func NewChatSystem(logger *zap.Logger, storage ChatStorage, llmConfig *config.LL
 	GetConfigPath() string
 	GetLogDir() string
 	GetGitHubURL() string
-}) *ChatSystem {
+}, apiRequest apiRequestFunc) *ChatSystem {
 	cs := &ChatSystem{
 		logger:        logger,
 		storage:       storage,
 		agents:        make(map[AgentType]DiagnosticAgentInterface),
 		llmConfig:     llmConfig,
 		serverManager: serverManager,
+		apiRequest:    apiRequest,
 	}
 
 	// Initialize agents
@@ -102,7 +106,7 @@ func NewChatSystem(logger *zap.Logger, storage ChatStorage, llmConfig *config.LL
 
 // initializeAgents creates and registers the LLM-powered agent
 func (cs *ChatSystem) initializeAgents() {
-	cs.agents[AgentTypeLLM] = NewLLMAgent(cs.logger, cs.llmConfig, cs.serverManager)
+	cs.agents[AgentTypeLLM] = NewLLMAgent(cs.logger, cs.llmConfig, cs.serverManager, cs.apiRequest)
 }
 
 // CreateSession creates a new chat session for a server
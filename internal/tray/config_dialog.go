@@ -47,9 +47,11 @@ type ServerConfigDialog struct {
 		GetConfigPath() string
 		GetLogDir() string
 		GetGitHubURL() string
-		GetListenAddress() string
 		ReloadConfiguration() error
 	}
+
+	// Sends requests to the main server's HTTP API with the tray's token and TLS settings
+	apiRequest apiRequestFunc
 }
 
 // ConfigDialogData contains data passed to the HTML template
@@ -895,7 +897,7 @@ const configDialogTemplate = `<!DOCTYPE html>
 
         function openPath(path) {
             // Open file/folder path in system default application
-            fetch('http://localhost:8080/api/open-path', {
+            fetch('/open-path', {
                 method: 'POST',
                 headers: {
                     'Content-Type': 'application/json',
@@ -1333,7 +1335,7 @@ const configDialogTemplate = `<!DOCTYPE html>
             inspectorBtn.textContent = '🔄 Starting...';
             inspectorBtn.disabled = true;
 
-            fetch('http://localhost:8080/api/inspector/start', {
+            fetch('/chat/inspector/start', {
                 method: 'POST'
             })
             .then(response => response.json())
@@ -1361,7 +1363,7 @@ const configDialogTemplate = `<!DOCTYPE html>
         }
 
         function checkInspectorStatus() {
-            fetch('http://localhost:8080/api/inspector/status')
+            fetch('/chat/inspector/status')
             .then(response => response.json())
             .then(data => {
                 const inspectorBtn = document.getElementById('inspectorBtn');
@@ -1424,6 +1426,7 @@ func (d *ServerConfigDialog) Show(ctx context.Context, onSave func(*config.Serve
 	mux.HandleFunc("/chat/inspector/start", d.handleChatInspectorStart)
 	mux.HandleFunc("/chat/inspector/stop", d.handleChatInspectorStop)
 	mux.HandleFunc("/chat/inspector/status", d.handleChatInspectorStatus)
+	mux.HandleFunc("/open-path", d.handleOpenPath)
 
 	d.httpServer = &http.Server{
 		Handler:      mux,
@@ -2070,53 +2073,7 @@ func (d *ServerConfigDialog) handleChatInspectorStart(w http.ResponseWriter, r *
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
-
-	if d.serverManager == nil {
-		http.Error(w, "Server manager not available", http.StatusServiceUnavailable)
-		return
-	}
-
-	// Get server address
-	listenAddr := d.serverManager.GetListenAddress()
-	if listenAddr == "" {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Server listen address not available",
-		})
-		return
-	}
-
-	// Make request to main server's Inspector start endpoint
-	url := fmt.Sprintf("http://%s/api/inspector/start", listenAddr)
-	resp, err := http.Post(url, "application/json", nil)
-	if err != nil {
-		d.logger.Error("Failed to start Inspector",
-			zap.String("url", url),
-			zap.Error(err))
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   fmt.Sprintf("Failed to start Inspector: %v", err),
-		})
-		return
-	}
-	defer resp.Body.Close()
-
-	// Read response
-	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		d.logger.Error("Failed to decode Inspector start response", zap.Error(err))
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   fmt.Sprintf("Failed to decode response: %v", err),
-		})
-		return
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(result)
+	d.forwardToAPI(w, http.MethodPost, "/api/inspector/start", nil, "start Inspector")
 }
 
 // handleChatInspectorStop stops the MCP Inspector
@@ -2125,53 +2082,7 @@ func (d *ServerConfigDialog) handleChatInspectorStop(w http.ResponseWriter, r *h
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
-
-	if d.serverManager == nil {
-		http.Error(w, "Server manager not available", http.StatusServiceUnavailable)
-		return
-	}
-
-	// Get server address
-	listenAddr := d.serverManager.GetListenAddress()
-	if listenAddr == "" {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Server listen address not available",
-		})
-		return
-	}
-
-	// Make request to main server's Inspector stop endpoint
-	url := fmt.Sprintf("http://%s/api/inspector/stop", listenAddr)
-	resp, err := http.Post(url, "application/json", nil)
-	if err != nil {
-		d.logger.Error("Failed to stop Inspector",
-			zap.String("url", url),
-			zap.Error(err))
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   fmt.Sprintf("Failed to stop Inspector: %v", err),
-		})
-		return
-	}
-	defer resp.Body.Close()
-
-	// Read response
-	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		d.logger.Error("Failed to decode Inspector stop response", zap.Error(err))
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   fmt.Sprintf("Failed to decode response: %v", err),
-		})
-		return
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(result)
+	d.forwardToAPI(w, http.MethodPost, "/api/inspector/stop", nil, "stop Inspector")
 }
 
 // handleChatInspectorStatus gets the MCP Inspector status
@@ -2180,35 +2091,40 @@ func (d *ServerConfigDialog) handleChatInspectorStatus(w http.ResponseWriter, r
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
+	d.forwardToAPI(w, http.MethodGet, "/api/inspector/status", nil, "get Inspector status")
+}
 
-	if d.serverManager == nil {
-		http.Error(w, "Server manager not available", http.StatusServiceUnavailable)
+// handleOpenPath opens a file or folder through the main server
+func (d *ServerConfigDialog) handleOpenPath(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
+	d.forwardToAPI(w, http.MethodPost, "/api/open-path", r.Body, "open path")
+}
 
-	// Get server address
-	listenAddr := d.serverManager.GetListenAddress()
-	if listenAddr == "" {
+// forwardToAPI sends a request to the main server's HTTP API and relays its JSON response.
+// The dialog page can't call the API itself, since it has no API token.
+func (d *ServerConfigDialog) forwardToAPI(w http.ResponseWriter, method, path string, body io.Reader, action string) {
+	writeError := func(message string) {
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]interface{}{
 			"success": false,
-			"error":   "Server listen address not available",
+			"error":   message,
 		})
+	}
+
+	if d.apiRequest == nil {
+		http.Error(w, "Server API not available", http.StatusServiceUnavailable)
 		return
 	}
 
-	// Make request to main server's Inspector status endpoint
-	url := fmt.Sprintf("http://%s/api/inspector/status", listenAddr)
-	resp, err := http.Get(url)
+	resp, err := d.apiRequest(method, path, body)
 	if err != nil {
-		d.logger.Error("Failed to get Inspector status",
-			zap.String("url", url),
+		d.logger.Error("Failed to "+action,
+			zap.String("path", path),
 			zap.Error(err))
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   fmt.Sprintf("Failed to get Inspector status: %v", err),
-		})
+		writeError(fmt.Sprintf("Failed to %s: %v", action, err))
 		return
 	}
 	defer resp.Body.Close()
@@ -2216,15 +2132,14 @@ func (d *ServerConfigDialog) handleChatInspectorStatus(w http.ResponseWriter, r
 	// Read response
 	var result map[string]interface{}
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		d.logger.Error("Failed to decode Inspector status response", zap.Error(err))
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   fmt.Sprintf("Failed to decode response: %v", err),
-		})
+		d.logger.Error("Failed to decode API response",
+			zap.String("path", path),
+			zap.Int("status", resp.StatusCode),
+			zap.Error(err))
+		writeError(fmt.Sprintf("Failed to decode response (status %d): %v", resp.StatusCode, err))
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(result)
-}
\ No newline at end of file
+}
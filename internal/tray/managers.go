@@ -432,12 +432,13 @@ func (m *MenuManager) UpdateStatusBasedMenus(servers []map[string]interface{}) {
 		startupMode, _ := server["startup_mode"].(string)
 		connectionState, _ := server["connection_state"].(string)
 		serverConnected, _ := server["connected"].(bool)
+		serverSleeping, _ := server["sleeping"].(bool)
 
 		// Categorization priority (in order):
 		// 1. Quarantined (startup_mode == "quarantined")
 		// 2. Auto-Disabled (startup_mode == "auto_disabled")
 		// 3. Disabled (startup_mode == "disabled")
-		// 4. Sleeping (startup_mode == "lazy_loading" AND NOT connected)
+		// 4. Sleeping ((startup_mode == "lazy_loading" OR idle-disconnected) AND NOT connected)
 		// 5. Connected (NOT disabled/quarantined/auto_disabled AND connection_state == "Ready")
 		// 6. Disconnected (NOT disabled/quarantined/auto_disabled AND connection_state != "Ready")
 		// Note: Using blacklist approach to handle servers with empty startup_mode
@@ -453,8 +454,8 @@ func (m *MenuManager) UpdateStatusBasedMenus(servers []map[string]interface{}) {
 			// Manually disabled servers
 			disabled = append(disabled, server)
 			disabledCount++
-		} else if startupMode == "lazy_loading" && !serverConnected {
-			// Sleeping servers: lazy_loading + not connected
+		} else if (startupMode == "lazy_loading" || serverSleeping) && !serverConnected {
+			// Sleeping servers: lazy_loading or idle-disconnected + not connected
 			sleeping = append(sleeping, server)
 			sleepingCount++
 		} else if startupMode != "disabled" && startupMode != "quarantined" && startupMode != "auto_disabled" {
@@ -8,11 +8,15 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	_ "embed"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"io"
 	"math"
+	"net"
 	"net/http"
 	"os"
 	"os/exec"
@@ -116,6 +120,10 @@ type ServerInterface interface {
 	GetLogDir() string
 	GetGitHubURL() string
 	GetLLMConfig() *config.LLMConfig
+	GetAPIToken() string
+	GetTLSCertFile() string // Empty when the HTTP API is served without TLS
+	GetUpdateWindow() string
+	GetShutdownTimeout() time.Duration // Grace period the tray waits for before forcing quit
 
 	// OAuth control
 	TriggerOAuthLogin(serverName string) error
@@ -172,6 +180,10 @@ type App struct {
 	configWatcher *fsnotify.Watcher
 	configPath    string
 
+	// Deferred update check for when the configured update window opens
+	updateWindowTimer *time.Timer
+	updateWindowMu    sync.Mutex
+
 	// Context for background operations
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -367,8 +379,9 @@ func (a *App) initConfigWatcher() error {
 
 	a.configWatcher = watcher
 
-	// Watch the config file
-	if err := a.configWatcher.Add(configPath); err != nil {
+	// Watch the config directory: a watch on the file itself ends when an editor saves by
+	// renaming or deleting and recreating it
+	if err := a.configWatcher.Add(filepath.Dir(configPath)); err != nil {
 		a.configWatcher.Close()
 		return fmt.Errorf("failed to watch config file %s: %w", configPath, err)
 	}
@@ -387,6 +400,15 @@ func (a *App) watchConfigFile() {
 			if !ok {
 				return
 			}
+			if filepath.Base(event.Name) != filepath.Base(a.configPath) {
+				continue // Another file in the config directory
+			}
+
+			if event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename) {
+				// Often transient (rename-on-save); the file is reloaded once it is recreated
+				a.logger.Warn("Config file was removed or renamed, keeping the current configuration", zap.String("event", event.String()))
+				continue
+			}
 
 			if event.Has(fsnotify.Write) || event.Has(fsnotify.Create) {
 				// Add a small delay to ensure file write is complete
@@ -515,7 +537,7 @@ func (a *App) onReady() {
 
 	// Get LLM configuration from server
 	llmConfig := a.server.GetLLMConfig()
-	a.chatSystem = NewChatSystem(a.logger.Desugar(), storage, llmConfig, a.server)
+	a.chatSystem = NewChatSystem(a.logger.Desugar(), storage, llmConfig, a.server, a.apiRequest)
 
 	// --- Set Action Callback ---
 	// Centralized action handler for all menu-driven server actions
@@ -664,10 +686,14 @@ func (a *App) onReady() {
 
 				// Force quit with timeout to prevent hanging
 				go func() {
-					// Set a maximum time for graceful shutdown
+					// Set a maximum time for graceful shutdown, never shorter than shutdown_timeout
 					// MED-002: Using centralized timeout constants
-					timeout := time.After(config.TrayQuitTimeout)
-					killTimeout := time.After(config.TrayKillTimeout)
+					quitTimeout := config.TrayQuitTimeout
+					if shutdownTimeout := a.server.GetShutdownTimeout(); shutdownTimeout > quitTimeout {
+						quitTimeout = shutdownTimeout
+					}
+					timeout := time.After(quitTimeout)
+					killTimeout := time.After(quitTimeout + config.TrayKillTimeout - config.TrayQuitTimeout)
 					done := make(chan bool, 1)
 
 					go func() {
@@ -682,7 +708,7 @@ func (a *App) onReady() {
 						a.logger.Info("Graceful shutdown completed")
 						os.Exit(0)
 					case <-timeout:
-						a.logger.Warn("Graceful shutdown timed out after 10s, forcing exit with os.Exit(0)")
+						a.logger.Warnf("Graceful shutdown timed out after %v, forcing exit with os.Exit(0)", quitTimeout)
 						os.Exit(0) // Force exit if graceful shutdown hangs
 
 						// If os.Exit(0) doesn't work (should never happen), wait for kill timeout
@@ -1287,6 +1313,23 @@ func (a *App) checkForUpdates() {
 		return
 	}
 
+	// Only apply updates inside the configured update window; re-check when it opens
+	if window := a.server.GetUpdateWindow(); window != "" {
+		open, nextStart, err := config.UpdateWindowStatus(window, time.Now())
+		if err != nil {
+			a.logger.Warn("Ignoring invalid update window", zap.String("update_window", window), zap.Error(err))
+		} else if !open {
+			a.logger.Info("Update available - deferred until update window",
+				zap.String("current", a.version),
+				zap.String("latest", latestVersion),
+				zap.String("update_window", window),
+				zap.Time("next_window_start", nextStart))
+			a.statusItem.SetTitle(fmt.Sprintf("Update available: %s", latestVersion))
+			a.scheduleUpdateCheck(nextStart)
+			return
+		}
+	}
+
 	downloadURL, err := a.findAssetURL(release)
 	if err != nil {
 		a.logger.Error("Failed to find asset for your system", zap.Error(err))
@@ -1298,6 +1341,17 @@ func (a *App) checkForUpdates() {
 	}
 }
 
+// scheduleUpdateCheck runs checkForUpdates again at the given time, replacing any earlier schedule
+func (a *App) scheduleUpdateCheck(at time.Time) {
+	a.updateWindowMu.Lock()
+	defer a.updateWindowMu.Unlock()
+
+	if a.updateWindowTimer != nil {
+		a.updateWindowTimer.Stop()
+	}
+	a.updateWindowTimer = time.AfterFunc(time.Until(at), a.checkForUpdates)
+}
+
 // getLatestRelease fetches the latest release information from GitHub
 func (a *App) getLatestRelease() (*GitHubRelease, error) {
 	url := fmt.Sprintf("https://api.github.com/repos/%s/releases/latest", repo)
@@ -1660,14 +1714,29 @@ func (a *App) openFile(path, fileType string) {
 
 // openGroupManagementWeb opens the web interface for group management
 func (a *App) openGroupManagementWeb() {
-	url := "http://localhost:8080/groups"
-	a.openFile(url, "group management web interface")
+	a.openWebPage("/groups", "group management web interface")
 }
 
 // openResourceMonitor opens the web interface for resource monitoring
 func (a *App) openResourceMonitor() {
-	url := "http://localhost:8080/"
-	a.openFile(url, "dashboard web interface")
+	a.openWebPage("/", "dashboard web interface")
+}
+
+// openWebPage opens a page of the web interface in the browser, at the address and scheme
+// the server actually listens on
+func (a *App) openWebPage(path, description string) {
+	if socketPath, isSocket := config.UnixSocketPath(a.server.GetListenAddress()); isSocket {
+		a.logger.Warn("The web interface can't be opened in a browser while listening on a Unix socket",
+			zap.String("socket", socketPath),
+			zap.String("page", description))
+		return
+	}
+	baseURL, err := a.apiBaseURL()
+	if err != nil {
+		a.logger.Error("Failed to open web interface", zap.String("page", description), zap.Error(err))
+		return
+	}
+	a.openFile(baseURL+path, description)
 }
 
 // refreshMenusDelayed refreshes menus after a delay using the synchronization manager
@@ -2121,8 +2190,7 @@ func (a *App) handleCreateGroup() {
 	a.logger.Info("Opening group creation interface")
 	
 	// Open the web interface for group management
-	url := "http://localhost:8080/groups"
-	a.openFile(url, "group management web interface")
+	a.openWebPage("/groups", "group management web interface")
 }
 
 // handleManageGroups handles managing existing groups
@@ -2651,6 +2719,7 @@ func (a *App) handleServerConfiguration(serverName string) error {
 	dialog.chatSystem = a.chatSystem
 	if a.server != nil {
 		dialog.serverManager = a.server
+		dialog.apiRequest = a.apiRequest
 	}
 
 	// Define save callback
@@ -2765,20 +2834,107 @@ func (a *App) handleServerRestart(serverName string) error {
 	a.logger.Info("Server restart completed successfully", zap.String("server", serverName))
 	return nil
 }
-// fetchGroupsFromAPI fetches groups from the web interface API
-func (a *App) fetchGroupsFromAPI() ([]map[string]interface{}, error) {
+// doAPIRequest sends a request to the local HTTP API, attaching the configured API token
+func (a *App) doAPIRequest(method, url string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token := a.server.GetAPIToken(); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return a.apiHTTPClient().Do(req)
+}
+
+// apiRequestFunc sends a request to a path of the local HTTP API, e.g. "/api/groups"
+type apiRequestFunc func(method, path string, body io.Reader) (*http.Response, error)
+
+// apiRequest sends a request to a path of the local HTTP API with the configured API token,
+// over TLS or the Unix socket when the server uses them
+func (a *App) apiRequest(method, path string, body io.Reader) (*http.Response, error) {
+	baseURL, err := a.apiBaseURL()
+	if err != nil {
+		return nil, err
+	}
+	return a.doAPIRequest(method, baseURL+path, body)
+}
+
+// apiHTTPClient returns a client for the local HTTP API. When the server listens on a
+// Unix socket every request is dialed to the socket regardless of the URL's host. With TLS
+// the server's certificate is pinned, since a LAN certificate won't be issued for localhost.
+func (a *App) apiHTTPClient() *http.Client {
+	socketPath, isSocket := config.UnixSocketPath(a.server.GetListenAddress())
+	certFile := a.server.GetTLSCertFile()
+	if !isSocket && certFile == "" {
+		return http.DefaultClient
+	}
+
+	transport := &http.Transport{DisableKeepAlives: true}
+	if isSocket {
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "unix", socketPath)
+		}
+	}
+	if certFile != "" {
+		transport.TLSClientConfig = pinnedCertTLSConfig(certFile)
+	}
+	return &http.Client{Transport: transport}
+}
+
+// pinnedCertTLSConfig accepts only the certificate currently in certFile. The file is read
+// on each handshake so the tray keeps working after the server reloads a renewed certificate.
+func pinnedCertTLSConfig(certFile string) *tls.Config {
+	return &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: true, // Hostname/CA checks replaced by pinning below
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			pemData, err := os.ReadFile(certFile)
+			if err != nil {
+				return fmt.Errorf("failed to read TLS certificate %s: %w", certFile, err)
+			}
+			block, _ := pem.Decode(pemData)
+			if block == nil || len(rawCerts) == 0 || !bytes.Equal(block.Bytes, rawCerts[0]) {
+				return fmt.Errorf("server certificate does not match %s", certFile)
+			}
+			return nil
+		},
+	}
+}
+
+// apiBaseURL returns the base URL of the local HTTP API
+func (a *App) apiBaseURL() (string, error) {
 	listenAddr := a.server.GetListenAddress()
 	if listenAddr == "" {
-		return nil, fmt.Errorf("server listen address not available")
+		return "", fmt.Errorf("server listen address not available")
 	}
-	
-	// Ensure we have a proper URL
-	if !strings.HasPrefix(listenAddr, "http") {
-		listenAddr = "http://localhost" + listenAddr
+
+	scheme := "http"
+	if a.server.GetTLSCertFile() != "" {
+		scheme = "https"
 	}
-	
-	url := listenAddr + "/api/groups"
-	resp, err := http.Get(url)
+	// Socket requests are routed by apiHTTPClient, the host is only a placeholder
+	if _, ok := config.UnixSocketPath(listenAddr); ok {
+		return scheme + "://unix", nil
+	}
+	if strings.HasPrefix(listenAddr, "http") {
+		return listenAddr, nil
+	}
+	return scheme + "://localhost" + listenAddr, nil
+}
+
+// fetchGroupsFromAPI fetches groups from the web interface API
+func (a *App) fetchGroupsFromAPI() ([]map[string]interface{}, error) {
+	baseURL, err := a.apiBaseURL()
+	if err != nil {
+		return nil, err
+	}
+
+	url := baseURL + "/api/groups"
+	resp, err := a.doAPIRequest(http.MethodGet, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch groups from %s: %w", url, err)
 	}
@@ -3064,8 +3220,11 @@ func (a *App) syncWithAPIGroups() {
 
 // fetchServerAssignments fetches server-to-group assignments
 func (a *App) fetchServerAssignments() (map[string]string, error) {
-	baseURL := "http://localhost:8080"
-	resp, err := http.Get(baseURL + "/api/assignments")
+	baseURL, err := a.apiBaseURL()
+	if err != nil {
+		return make(map[string]string), err
+	}
+	resp, err := a.doAPIRequest(http.MethodGet, baseURL+"/api/assignments", nil)
 	if err != nil {
 		a.logger.Error("Failed to fetch server assignments from API", zap.Error(err))
 		return make(map[string]string), err
@@ -3127,8 +3286,12 @@ func (a *App) assignServerToGroup(serverName, groupName string) {
 	}
 
 	// Send assignment request to API
-	baseURL := "http://localhost:8080"
-	resp, err := http.Post(baseURL+"/api/assign-server", "application/json", bytes.NewBuffer(jsonData))
+	baseURL, err := a.apiBaseURL()
+	if err != nil {
+		a.logger.Error("Failed to send server assignment request", zap.Error(err))
+		return
+	}
+	resp, err := a.doAPIRequest(http.MethodPost, baseURL+"/api/assign-server", bytes.NewBuffer(jsonData))
 	if err != nil {
 		a.logger.Error("Failed to send server assignment request", zap.Error(err))
 		return
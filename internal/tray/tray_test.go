@@ -5,6 +5,7 @@ package tray
 import (
 	"context"
 	"testing"
+	"time"
 
 	"mcpproxy-go/internal/config"
 	"mcpproxy-go/internal/events"
@@ -175,6 +176,22 @@ func (m *MockServerInterface) GetLLMConfig() *config.LLMConfig {
 	return nil
 }
 
+func (m *MockServerInterface) GetAPIToken() string {
+	return ""
+}
+
+func (m *MockServerInterface) GetTLSCertFile() string {
+	return ""
+}
+
+func (m *MockServerInterface) GetUpdateWindow() string {
+	return ""
+}
+
+func (m *MockServerInterface) GetShutdownTimeout() time.Duration {
+	return config.DefaultShutdownTimeout
+}
+
 func (m *MockServerInterface) StartStartupScript(ctx context.Context) error {
 	_ = ctx
 	return nil
@@ -0,0 +1,243 @@
+package truncate
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// MCP content item types that carry binary payloads
+const (
+	contentTypeText     = "text"
+	contentTypeImage    = "image"
+	contentTypeAudio    = "audio"
+	contentTypeResource = "resource"
+)
+
+// truncationMarker ends plain text that was cut short
+const truncationMarker = "\n... [truncated by mcpproxy]"
+
+// maxShrinkPasses bounds the shrinking loops so pathological inputs cannot spin forever
+const maxShrinkPasses = 64
+
+// parseToolResult decodes content as a serialized MCP tool result (an object with a
+// "content" array of typed items). ok is false for anything else, which keeps the
+// generic truncation path for plain text and arbitrary JSON.
+func parseToolResult(content string) (result map[string]interface{}, items []interface{}, ok bool) {
+	if err := json.Unmarshal([]byte(content), &result); err != nil {
+		return nil, nil, false
+	}
+	items, ok = result["content"].([]interface{})
+	if !ok || len(items) == 0 {
+		return nil, nil, false
+	}
+	for _, item := range items {
+		m, isMap := item.(map[string]interface{})
+		if !isMap {
+			return nil, nil, false
+		}
+		if _, hasType := m["type"].(string); !hasType {
+			return nil, nil, false
+		}
+	}
+	return result, items, true
+}
+
+// truncateToolResult shrinks a tool result according to the type of each content item:
+// images, audio and binary resources are replaced by a note, JSON text is cut at a
+// structural boundary and plain text is cut with a marker. The notice is appended as a
+// final text item so that the output is always a valid JSON tool result.
+func (t *Truncator) truncateToolResult(result map[string]interface{}, items []interface{}, notice string) string {
+	for i, item := range items {
+		items[i] = t.dropBinary(item.(map[string]interface{}))
+	}
+	items = append(items, textItem(notice))
+	result["content"] = items
+
+	out := marshalString(result)
+	exhausted := make(map[int]bool)
+	for pass := 0; len(out) > t.limit && pass < maxShrinkPasses; pass++ {
+		idx := largestTextItem(items[:len(items)-1], exhausted)
+		if idx < 0 {
+			break
+		}
+
+		item := items[idx].(map[string]interface{})
+		text := item["text"].(string)
+		target := len(text) - (len(out) - t.limit)
+		if target < 0 {
+			target = 0
+		}
+
+		shrunk := shrinkText(text, target)
+		if len(shrunk) >= len(text) {
+			exhausted[idx] = true
+			continue
+		}
+		item["text"] = shrunk
+		out = marshalString(result)
+	}
+
+	if len(out) > t.limit {
+		// Metadata or the notice alone exceed the limit; keep only the notice
+		out = marshalString(map[string]interface{}{
+			"content": []interface{}{textItem(notice)},
+			"isError": result["isError"],
+		})
+	}
+	return out
+}
+
+// dropBinary replaces an item carrying a binary payload with a text note
+func (t *Truncator) dropBinary(item map[string]interface{}) interface{} {
+	itemType, _ := item["type"].(string)
+	switch itemType {
+	case contentTypeImage, contentTypeAudio:
+		data, ok := item["data"].(string)
+		if !ok {
+			return item
+		}
+		mimeType, _ := item["mimeType"].(string)
+		return textItem(t.binaryNote(itemType, mimeType, len(data)))
+	case contentTypeResource:
+		resource, ok := item["resource"].(map[string]interface{})
+		if !ok {
+			return item
+		}
+		blob, ok := resource["blob"].(string)
+		if !ok {
+			return item
+		}
+		mimeType, _ := resource["mimeType"].(string)
+		note := t.binaryNote(itemType, mimeType, len(blob))
+		if uri, _ := resource["uri"].(string); uri != "" {
+			note += fmt.Sprintf(" (uri: %s)", uri)
+		}
+		return textItem(note)
+	}
+	return item
+}
+
+func (t *Truncator) binaryNote(itemType, mimeType string, size int) string {
+	if mimeType == "" {
+		mimeType = "unknown type"
+	}
+	return fmt.Sprintf("[%s content (%s, %d bytes) removed by mcpproxy: response exceeded the %d character limit]",
+		itemType, mimeType, size, t.limit)
+}
+
+// largestTextItem returns the index of the longest text item not yet exhausted, or -1
+func largestTextItem(items []interface{}, exhausted map[int]bool) int {
+	best, bestLen := -1, 0
+	for i, item := range items {
+		m := item.(map[string]interface{})
+		if exhausted[i] || m["type"] != contentTypeText {
+			continue
+		}
+		text, _ := m["text"].(string)
+		if len(text) > bestLen {
+			best, bestLen = i, len(text)
+		}
+	}
+	return best
+}
+
+// shrinkText cuts text to about target bytes. JSON text stays valid JSON.
+func shrinkText(text string, target int) string {
+	trimmed := strings.TrimSpace(text)
+	if (strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")) && json.Valid([]byte(trimmed)) {
+		return shrinkJSON(trimmed, target)
+	}
+
+	if target <= len(truncationMarker) {
+		return strings.TrimPrefix(truncationMarker, "\n")
+	}
+	cut := target - len(truncationMarker)
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return text[:cut] + truncationMarker
+}
+
+// shrinkJSON repeatedly halves the largest array or string in a JSON document until it
+// fits in target bytes. If that is not enough, a small placeholder object is returned.
+func shrinkJSON(text string, target int) string {
+	var value interface{}
+	if err := json.Unmarshal([]byte(text), &value); err != nil {
+		return jsonPlaceholder(len(text))
+	}
+
+	for pass := 0; pass < maxShrinkPasses; pass++ {
+		out := marshalString(value)
+		if len(out) <= target {
+			return out
+		}
+		reduced, ok := reduceLargest(value)
+		if !ok {
+			break
+		}
+		value = reduced
+	}
+	return jsonPlaceholder(len(text))
+}
+
+// reduceLargest halves the largest array (by element count) or long string in value
+func reduceLargest(value interface{}) (interface{}, bool) {
+	var bestSize int
+	var apply func()
+	var root = value
+
+	var walk func(v interface{}, set func(interface{}))
+	walk = func(v interface{}, set func(interface{})) {
+		switch node := v.(type) {
+		case []interface{}:
+			if len(node) > 1 {
+				if size := len(marshalString(node)); size > bestSize {
+					bestSize = size
+					apply = func() { set(node[:len(node)/2]) }
+				}
+			}
+			for i := range node {
+				i := i
+				walk(node[i], func(nv interface{}) { node[i] = nv })
+			}
+		case map[string]interface{}:
+			for k := range node {
+				k := k
+				walk(node[k], func(nv interface{}) { node[k] = nv })
+			}
+		case string:
+			if len(node) > 64 && len(node) > bestSize {
+				bestSize = len(node)
+				apply = func() { set(shrinkText(node, len(node)/2)) }
+			}
+		}
+	}
+	walk(value, func(nv interface{}) { root = nv })
+
+	if apply == nil {
+		return value, false
+	}
+	apply()
+	return root, true
+}
+
+func jsonPlaceholder(originalSize int) string {
+	return marshalString(map[string]interface{}{
+		"truncated_by_mcpproxy": true,
+		"original_size":         originalSize,
+	})
+}
+
+func textItem(text string) map[string]interface{} {
+	return map[string]interface{}{"type": contentTypeText, "text": text}
+}
+
+func marshalString(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
@@ -16,6 +16,7 @@ type TruncationResult struct {
 	RecordPath       string `json:"record_path,omitempty"`
 	TotalRecords     int    `json:"total_records,omitempty"`
 	TotalSize        int    `json:"total_size"`
+	DroppedBytes     int    `json:"dropped_bytes"`
 	CacheAvailable   bool   `json:"cache_available"`
 }
 
@@ -29,6 +30,20 @@ func NewTruncator(limit int) *Truncator {
 	return &Truncator{limit: limit}
 }
 
+// WithLimit returns a truncator using the given limit, or the receiver itself
+// when the limit is unchanged
+func (t *Truncator) WithLimit(limit int) *Truncator {
+	if limit == t.limit {
+		return t
+	}
+	return NewTruncator(limit)
+}
+
+// Limit returns the character limit of this truncator (0 = disabled)
+func (t *Truncator) Limit() int {
+	return t.limit
+}
+
 // Truncate analyzes and truncates a tool response if it exceeds the limit
 func (t *Truncator) Truncate(content, toolName string, args map[string]interface{}) *TruncationResult {
 	result := &TruncationResult{
@@ -43,10 +58,27 @@ func (t *Truncator) Truncate(content, toolName string, args map[string]interface
 
 	// Try to analyze JSON structure for record splitting
 	recordPath, totalRecords, err := t.analyzeJSONStructure(content)
+
+	// Serialized MCP tool results are truncated per content type so the output stays valid JSON
+	if toolResult, items, ok := parseToolResult(content); ok {
+		notice := t.simpleNotice(len(content))
+		if err == nil {
+			result.CacheKey = cache.GenerateKey(toolName, args, time.Now())
+			result.RecordPath = recordPath
+			result.TotalRecords = totalRecords
+			result.CacheAvailable = true
+			notice = t.cacheInstructions(result.CacheKey, totalRecords, len(content))
+		}
+		result.TruncatedContent = t.truncateToolResult(toolResult, items, notice)
+		result.DroppedBytes = droppedBytes(content, result.TruncatedContent)
+		return result
+	}
+
 	if err != nil {
 		// JSON analysis failed, do simple truncation
 		result.TruncatedContent = t.simpleTruncate(content)
 		result.CacheAvailable = false
+		result.DroppedBytes = droppedBytes(content, result.TruncatedContent)
 		return result
 	}
 
@@ -60,10 +92,20 @@ func (t *Truncator) Truncate(content, toolName string, args map[string]interface
 	result.RecordPath = recordPath
 	result.TotalRecords = totalRecords
 	result.CacheAvailable = true
+	result.DroppedBytes = droppedBytes(content, result.TruncatedContent)
 
 	return result
 }
 
+// droppedBytes reports how many bytes of the original content did not make it
+// into the truncated content
+func droppedBytes(original, truncated string) int {
+	if dropped := len(original) - len(truncated); dropped > 0 {
+		return dropped
+	}
+	return 0
+}
+
 // ArrayInfo holds information about found arrays
 type ArrayInfo struct {
 	Path  string
@@ -252,14 +294,7 @@ func (t *Truncator) simpleTruncate(content string) string {
 
 // createTruncatedWithCache creates a truncated response with cache instructions
 func (t *Truncator) createTruncatedWithCache(content, cacheKey string, totalRecords, totalSize int) string {
-	instructions := fmt.Sprintf(`
-
-... [truncated by mcpproxy]
-
-Response truncated (limit: %d chars, actual: %d chars, records: %d)
-Use read_cache tool: key="%s", offset=0, limit=50
-Returns: {"records": [...], "meta": {"total_records": %d, "total_size": %d}}`,
-		t.limit, totalSize, totalRecords, cacheKey, totalRecords, totalSize)
+	instructions := "\n\n" + t.cacheInstructions(cacheKey, totalRecords, totalSize)
 
 	// Calculate how much content we can show (ensure result fits within limit)
 	instructionsSize := len(instructions)
@@ -287,6 +322,22 @@ Returns: {"records": [...], "meta": {"total_records": %d, "total_size": %d}}`,
 	return truncated + instructions
 }
 
+// cacheInstructions tells the client how to page through the cached full response
+func (t *Truncator) cacheInstructions(cacheKey string, totalRecords, totalSize int) string {
+	return fmt.Sprintf(`... [truncated by mcpproxy]
+
+Response truncated (limit: %d chars, actual: %d chars, records: %d)
+Use read_cache tool: key="%s", offset=0, limit=50
+Returns: {"records": [...], "meta": {"total_records": %d, "total_size": %d}}`,
+		t.limit, totalSize, totalRecords, cacheKey, totalRecords, totalSize)
+}
+
+// simpleNotice explains a truncation for which no cached copy is available
+func (t *Truncator) simpleNotice(totalSize int) string {
+	return fmt.Sprintf("... [truncated by mcpproxy, cache not available]\n\nResponse truncated (limit: %d chars, actual: %d chars)",
+		t.limit, totalSize)
+}
+
 // ShouldTruncate returns true if content should be truncated
 func (t *Truncator) ShouldTruncate(content string) bool {
 	return t.limit > 0 && len(content) > t.limit
@@ -1,6 +1,8 @@
 package truncate
 
 import (
+	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
 )
@@ -547,3 +549,126 @@ func TestChooseBestArray(t *testing.T) {
 		})
 	}
 }
+
+func TestWithLimit(t *testing.T) {
+	base := NewTruncator(1000)
+
+	if base.WithLimit(1000) != base {
+		t.Error("WithLimit with unchanged limit should return the same truncator")
+	}
+
+	override := base.WithLimit(50)
+	if override.Limit() != 50 {
+		t.Errorf("Expected limit 50, got %d", override.Limit())
+	}
+	if base.Limit() != 1000 {
+		t.Errorf("Base truncator limit should be unchanged, got %d", base.Limit())
+	}
+}
+
+func TestTruncateReportsDroppedBytes(t *testing.T) {
+	truncator := NewTruncator(50)
+	content := strings.Repeat("x", 200)
+
+	result := truncator.Truncate(content, "test_tool", nil)
+
+	expected := len(content) - len(result.TruncatedContent)
+	if result.DroppedBytes != expected {
+		t.Errorf("Expected %d dropped bytes, got %d", expected, result.DroppedBytes)
+	}
+	if result.DroppedBytes == 0 {
+		t.Error("Dropped bytes should be reported for truncated content")
+	}
+
+	small := truncator.Truncate("short", "test_tool", nil)
+	if small.DroppedBytes != 0 {
+		t.Errorf("Expected no dropped bytes for content within limit, got %d", small.DroppedBytes)
+	}
+}
+
+func TestTruncateToolResultKeepsJSONValid(t *testing.T) {
+	var rows []string
+	for i := 0; i < 200; i++ {
+		rows = append(rows, fmt.Sprintf(`{"id": %d, "name": "item %d", "description": "a \"quoted\" description"}`, i, i))
+	}
+	inner := `{"items": [` + strings.Join(rows, ",") + `], "total": 200}`
+	toolResult, _ := json.Marshal(map[string]interface{}{
+		"content": []map[string]interface{}{{"type": "text", "text": inner}},
+	})
+
+	truncator := NewTruncator(2000)
+	result := truncator.Truncate(string(toolResult), "test_tool", map[string]interface{}{})
+
+	if len(result.TruncatedContent) > 2000 {
+		t.Errorf("Expected output within limit, got %d chars", len(result.TruncatedContent))
+	}
+	if !json.Valid([]byte(result.TruncatedContent)) {
+		t.Fatalf("Expected valid JSON, got: %s", result.TruncatedContent)
+	}
+
+	var parsed struct {
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+	}
+	if err := json.Unmarshal([]byte(result.TruncatedContent), &parsed); err != nil {
+		t.Fatalf("Failed to parse truncated result: %v", err)
+	}
+	if len(parsed.Content) != 2 {
+		t.Fatalf("Expected truncated text plus notice, got %d items", len(parsed.Content))
+	}
+	if !json.Valid([]byte(parsed.Content[0].Text)) {
+		t.Errorf("Expected truncated text item to still be valid JSON, got: %s", parsed.Content[0].Text)
+	}
+	if !strings.Contains(parsed.Content[1].Text, "truncated by mcpproxy") {
+		t.Errorf("Expected truncation notice, got: %s", parsed.Content[1].Text)
+	}
+	if result.DroppedBytes <= 0 {
+		t.Errorf("Expected positive DroppedBytes, got %d", result.DroppedBytes)
+	}
+}
+
+func TestTruncateToolResultDropsImages(t *testing.T) {
+	toolResult, _ := json.Marshal(map[string]interface{}{
+		"content": []map[string]interface{}{
+			{"type": "text", "text": "screenshot taken"},
+			{"type": "image", "mimeType": "image/png", "data": strings.Repeat("A", 5000)},
+		},
+	})
+
+	truncator := NewTruncator(1000)
+	result := truncator.Truncate(string(toolResult), "screenshot", map[string]interface{}{})
+
+	if !json.Valid([]byte(result.TruncatedContent)) {
+		t.Fatalf("Expected valid JSON, got: %s", result.TruncatedContent)
+	}
+	if strings.Contains(result.TruncatedContent, "AAAA") {
+		t.Error("Expected image data to be dropped")
+	}
+	if !strings.Contains(result.TruncatedContent, "screenshot taken") {
+		t.Error("Expected text item to be kept")
+	}
+	if !strings.Contains(result.TruncatedContent, "image content (image/png, 5000 bytes) removed by mcpproxy") {
+		t.Errorf("Expected note about the removed image, got: %s", result.TruncatedContent)
+	}
+}
+
+func TestTruncateToolResultPlainText(t *testing.T) {
+	toolResult, _ := json.Marshal(map[string]interface{}{
+		"content": []map[string]interface{}{{"type": "text", "text": strings.Repeat("log line\n", 500)}},
+	})
+
+	truncator := NewTruncator(1000)
+	result := truncator.Truncate(string(toolResult), "logs", map[string]interface{}{})
+
+	if len(result.TruncatedContent) > 1000 {
+		t.Errorf("Expected output within limit, got %d chars", len(result.TruncatedContent))
+	}
+	if !json.Valid([]byte(result.TruncatedContent)) {
+		t.Fatalf("Expected valid JSON, got: %s", result.TruncatedContent)
+	}
+	if !strings.Contains(result.TruncatedContent, "log line") {
+		t.Error("Expected leading text to be kept")
+	}
+}
@@ -11,7 +11,7 @@ import "time"
 // 1. Quarantined (startup_mode == "quarantined")
 // 2. Auto-Disabled (startup_mode == "auto_disabled")
 // 3. Disabled (startup_mode == "disabled")
-// 4. Sleeping (startup_mode == "lazy_loading" AND connection_state != "Ready")
+// 4. Sleeping ((startup_mode == "lazy_loading" OR disconnected by idle_disconnect_after) AND connection_state != "Ready")
 // 5. Connected (NOT disabled/quarantined/auto_disabled AND connection_state == "Ready")
 // 6. Disconnected (NOT disabled/quarantined/auto_disabled AND connection_state != "Ready")
 type ServerStatusCategories struct {
@@ -263,7 +263,11 @@ func (c *Client) CallTool(ctx context.Context, toolName string, args map[string]
 		zap.String("tool", toolName),
 		zap.Any("args", args))
 
-	result, err := c.coreClient.CallTool(ctx, toolName, args)
+	callArgs := args
+	if len(c.config.DefaultArgs) > 0 {
+		callArgs = c.config.MergeDefaultArgs(args, c.coreClient.ToolParamsJSON(ctx, toolName))
+	}
+	result, err := c.coreClient.CallTool(ctx, toolName, callArgs)
 	if err != nil {
 		c.logger.Error("❌ Tool call failed", zap.Error(err))
 		return nil, err
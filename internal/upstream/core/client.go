@@ -53,6 +53,10 @@ type Client struct {
 	oauthCompleted     bool
 	lastOAuthTimestamp time.Time
 
+	// OAuth token refresh (serialized so concurrent 401s trigger a single refresh)
+	oauthRefreshMu   sync.Mutex
+	lastOAuthRefresh time.Time
+
 	// Transport type and stderr access (for stdio)
 	transportType string
 	stderr        io.Reader
@@ -210,6 +214,11 @@ func (c *Client) ListTools(ctx context.Context) ([]*config.ToolMetadata, error)
 		return nil, fmt.Errorf("failed to list tools: %w", err)
 	}
 
+	// Keep the listed tools so calls can look up their input schema
+	c.mu.Lock()
+	c.cachedTools = toolsResult.Tools
+	c.mu.Unlock()
+
 	// Convert to our format
 	tools := []*config.ToolMetadata{}
 	for i := range toolsResult.Tools {
@@ -220,10 +229,12 @@ func (c *Client) ListTools(ctx context.Context) ([]*config.ToolMetadata, error)
 		}
 
 		toolMeta := &config.ToolMetadata{
-			ServerName:  c.config.Name,
-			Name:        tool.Name,
-			Description: tool.Description,
-			ParamsJSON:  paramsJSON,
+			ServerName:      c.config.Name,
+			Name:            tool.Name,
+			Description:     tool.Description,
+			ParamsJSON:      paramsJSON,
+			ReadOnlyHint:    tool.Annotations.ReadOnlyHint,
+			DestructiveHint: tool.Annotations.DestructiveHint,
 		}
 		tools = append(tools, toolMeta)
 	}
@@ -235,6 +246,229 @@ func (c *Client) ListTools(ctx context.Context) ([]*config.ToolMetadata, error)
 	return tools, nil
 }
 
+// ToolParamsJSON returns the input schema of toolName as listed by the server on the current
+// connection. It returns "" if the tool isn't known.
+func (c *Client) ToolParamsJSON(ctx context.Context, toolName string) string {
+	tool := c.listedTool(ctx, toolName)
+	if tool == nil {
+		return ""
+	}
+	schemaBytes, err := json.Marshal(tool.InputSchema)
+	if err != nil {
+		return ""
+	}
+	return string(schemaBytes)
+}
+
+// ToolAnnotations returns the MCP annotations of toolName as listed by the server on the current
+// connection, or empty annotations if the tool isn't known
+func (c *Client) ToolAnnotations(ctx context.Context, toolName string) mcp.ToolAnnotation {
+	tool := c.listedTool(ctx, toolName)
+	if tool == nil {
+		return mcp.ToolAnnotation{}
+	}
+	return tool.Annotations
+}
+
+// listedTool looks toolName up in the tools listed on the current connection, listing the tools
+// first if that hasn't happened yet
+func (c *Client) listedTool(ctx context.Context, toolName string) *mcp.Tool {
+	c.mu.RLock()
+	tools := c.cachedTools
+	c.mu.RUnlock()
+
+	if tools == nil {
+		if _, err := c.ListTools(ctx); err != nil {
+			return nil
+		}
+		c.mu.RLock()
+		tools = c.cachedTools
+		c.mu.RUnlock()
+	}
+
+	for i := range tools {
+		if tools[i].Name == toolName {
+			return &tools[i]
+		}
+	}
+	return nil
+}
+
+// ListResources retrieves available resources from the upstream server, following pagination
+func (c *Client) ListResources(ctx context.Context) ([]*config.ResourceMetadata, error) {
+	c.mu.RLock()
+	client := c.client
+	serverInfo := c.serverInfo
+	c.mu.RUnlock()
+
+	if !c.IsConnected() || client == nil {
+		return nil, fmt.Errorf("client not connected")
+	}
+
+	if serverInfo == nil {
+		return nil, fmt.Errorf("server info not available")
+	}
+
+	if serverInfo.Capabilities.Resources == nil {
+		c.logger.Debug("Server does not support resources")
+		return nil, nil
+	}
+
+	result, err := client.ListResources(ctx, mcp.ListResourcesRequest{})
+	if err != nil {
+		c.logger.Error("Failed to list resources from upstream server",
+			zap.String("server", c.config.Name),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to list resources: %w", err)
+	}
+
+	resources := make([]*config.ResourceMetadata, 0, len(result.Resources))
+	for i := range result.Resources {
+		resource := &result.Resources[i]
+		resources = append(resources, &config.ResourceMetadata{
+			URI:         resource.URI,
+			ServerName:  c.config.Name,
+			Name:        resource.Name,
+			Description: resource.Description,
+			MIMEType:    resource.MIMEType,
+		})
+	}
+
+	c.logger.Debug("Retrieved resources from upstream server",
+		zap.String("server", c.config.Name),
+		zap.Int("resource_count", len(resources)))
+
+	return resources, nil
+}
+
+// ReadResource reads a resource from the upstream server using its unprefixed URI
+func (c *Client) ReadResource(ctx context.Context, uri string) (*mcp.ReadResourceResult, error) {
+	c.mu.RLock()
+	client := c.client
+	c.mu.RUnlock()
+
+	if !c.IsConnected() || client == nil {
+		return nil, fmt.Errorf("client not connected")
+	}
+
+	// Reads share the tool call timeout
+	timeout := 2 * time.Minute
+	if c.globalConfig != nil && c.globalConfig.CallToolTimeout.Duration() > 0 {
+		timeout = c.globalConfig.CallToolTimeout.Duration()
+	}
+
+	readCtx := ctx
+	if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > timeout {
+		var cancel context.CancelFunc
+		readCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	request := mcp.ReadResourceRequest{}
+	request.Params.URI = uri
+
+	result, err := client.ReadResource(readCtx, request)
+	if err != nil {
+		if readCtx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("ReadResource '%s' timed out after %v", uri, timeout)
+		}
+		return nil, fmt.Errorf("ReadResource failed for '%s': %w", uri, err)
+	}
+
+	return result, nil
+}
+
+// ListPrompts retrieves available prompts from the upstream server, following pagination
+func (c *Client) ListPrompts(ctx context.Context) ([]*config.PromptMetadata, error) {
+	c.mu.RLock()
+	client := c.client
+	serverInfo := c.serverInfo
+	c.mu.RUnlock()
+
+	if !c.IsConnected() || client == nil {
+		return nil, fmt.Errorf("client not connected")
+	}
+
+	if serverInfo == nil {
+		return nil, fmt.Errorf("server info not available")
+	}
+
+	if serverInfo.Capabilities.Prompts == nil {
+		c.logger.Debug("Server does not support prompts")
+		return nil, nil
+	}
+
+	result, err := client.ListPrompts(ctx, mcp.ListPromptsRequest{})
+	if err != nil {
+		c.logger.Error("Failed to list prompts from upstream server",
+			zap.String("server", c.config.Name),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to list prompts: %w", err)
+	}
+
+	prompts := make([]*config.PromptMetadata, 0, len(result.Prompts))
+	for i := range result.Prompts {
+		prompt := &result.Prompts[i]
+		meta := &config.PromptMetadata{
+			Name:        prompt.Name,
+			ServerName:  c.config.Name,
+			Description: prompt.Description,
+		}
+		for _, arg := range prompt.Arguments {
+			meta.Arguments = append(meta.Arguments, config.PromptArgument{
+				Name:        arg.Name,
+				Description: arg.Description,
+				Required:    arg.Required,
+			})
+		}
+		prompts = append(prompts, meta)
+	}
+
+	c.logger.Debug("Retrieved prompts from upstream server",
+		zap.String("server", c.config.Name),
+		zap.Int("prompt_count", len(prompts)))
+
+	return prompts, nil
+}
+
+// GetPrompt renders a prompt on the upstream server using its unprefixed name
+func (c *Client) GetPrompt(ctx context.Context, name string, args map[string]string) (*mcp.GetPromptResult, error) {
+	c.mu.RLock()
+	client := c.client
+	c.mu.RUnlock()
+
+	if !c.IsConnected() || client == nil {
+		return nil, fmt.Errorf("client not connected")
+	}
+
+	// Prompt requests share the tool call timeout
+	timeout := 2 * time.Minute
+	if c.globalConfig != nil && c.globalConfig.CallToolTimeout.Duration() > 0 {
+		timeout = c.globalConfig.CallToolTimeout.Duration()
+	}
+
+	getCtx := ctx
+	if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > timeout {
+		var cancel context.CancelFunc
+		getCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	request := mcp.GetPromptRequest{}
+	request.Params.Name = name
+	request.Params.Arguments = args
+
+	result, err := client.GetPrompt(getCtx, request)
+	if err != nil {
+		if getCtx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("GetPrompt '%s' timed out after %v", name, timeout)
+		}
+		return nil, fmt.Errorf("GetPrompt failed for '%s': %w", name, err)
+	}
+
+	return result, nil
+}
+
 // CallTool executes a tool on the upstream server
 func (c *Client) CallTool(ctx context.Context, toolName string, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	c.mu.RLock()
@@ -252,6 +486,7 @@ func (c *Client) CallTool(ctx context.Context, toolName string, args map[string]
 	// Log to server-specific log
 	if c.upstreamLogger != nil {
 		c.upstreamLogger.Info("Starting CallTool operation",
+			logs.CorrelationField(ctx),
 			zap.String("tool_name", toolName))
 	}
 
@@ -259,6 +494,7 @@ func (c *Client) CallTool(ctx context.Context, toolName string, args map[string]
 	if c.upstreamLogger != nil {
 		if reqBytes, err := json.MarshalIndent(request, "", "  "); err == nil {
 			c.upstreamLogger.Debug("JSON-RPC CallTool Request",
+				logs.CorrelationField(ctx),
 				zap.String("method", "tools/call"),
 				zap.String("tool", toolName),
 				zap.String("formatted_json", string(reqBytes)))
@@ -284,14 +520,20 @@ func (c *Client) CallTool(ctx context.Context, toolName string, args map[string]
 
 	// Extra debug before sending request through transport
 	c.logger.Debug("Starting upstream CallTool",
+		logs.CorrelationField(ctx),
 		zap.String("server", c.config.Name),
 		zap.String("tool", toolName))
 
+	callStart := time.Now()
 	result, err := client.CallTool(callCtx, request)
+	if err != nil && c.retryWithRefreshedOAuthToken(callCtx, err, callStart) {
+		result, err = client.CallTool(callCtx, request)
+	}
 	if err != nil {
 		// Log CallTool failure to server-specific log
 		if c.upstreamLogger != nil {
 			c.upstreamLogger.Error("CallTool operation failed",
+				logs.CorrelationField(ctx),
 				zap.String("tool_name", toolName),
 				zap.Error(err))
 		}
@@ -305,6 +547,7 @@ func (c *Client) CallTool(ctx context.Context, toolName string, args map[string]
 		errStr := err.Error()
 		if strings.Contains(errStr, "broken pipe") || strings.Contains(errStr, "closed pipe") {
 			c.logger.Warn("CallTool write failed due to pipe closure",
+				logs.CorrelationField(ctx),
 				zap.String("server", c.config.Name),
 				zap.String("tool", toolName),
 				zap.String("transport", c.transportType))
@@ -316,6 +559,7 @@ func (c *Client) CallTool(ctx context.Context, toolName string, args map[string]
 	// Log successful CallTool to server-specific log
 	if c.upstreamLogger != nil {
 		c.upstreamLogger.Info("CallTool operation completed successfully",
+			logs.CorrelationField(ctx),
 			zap.String("tool_name", toolName))
 	}
 
@@ -323,6 +567,7 @@ func (c *Client) CallTool(ctx context.Context, toolName string, args map[string]
 	if c.upstreamLogger != nil {
 		if respBytes, err := json.MarshalIndent(result, "", "  "); err == nil {
 			c.upstreamLogger.Debug("JSON-RPC CallTool Response",
+				logs.CorrelationField(ctx),
 				zap.String("method", "tools/call"),
 				zap.String("tool", toolName),
 				zap.String("formatted_json", string(respBytes)))
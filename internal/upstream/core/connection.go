@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"mcpproxy-go/internal/config"
 	"mcpproxy-go/internal/oauth"
 	"mcpproxy-go/internal/transport"
 
@@ -34,9 +35,17 @@ const (
 type contextKey string
 
 const (
-	manualOAuthKey contextKey = "manual_oauth"
+	manualOAuthKey    contextKey = "manual_oauth"
+	authURLHandlerKey contextKey = "auth_url_handler"
 )
 
+// WithAuthURLHandler returns a context for ForceOAuthFlow that hands the authorization URL
+// to handler instead of opening a browser, for headless deployments where the user
+// completes the flow on another machine
+func WithAuthURLHandler(ctx context.Context, handler func(authURL string)) context.Context {
+	return context.WithValue(ctx, authURLHandlerKey, handler)
+}
+
 // Connect establishes connection to the upstream server
 func (c *Client) Connect(ctx context.Context) error {
 	c.mu.Lock()
@@ -247,13 +256,25 @@ func (c *Client) connectStdio(ctx context.Context) error {
 		return fmt.Errorf("invalid working directory for server %s: %w", c.config.Name, err)
 	}
 
+	// Server-specific variables: env_file, overridden by the inline env. The file is read on
+	// every connect so reconnects pick up edits.
+	serverEnv, err := c.serverEnv()
+	if err != nil {
+		if c.upstreamLogger != nil {
+			c.upstreamLogger.Error("Server startup failed due to unreadable env_file",
+				zap.String("env_file", c.config.EnvFile),
+				zap.Error(err))
+		}
+		return fmt.Errorf("invalid env_file for server %s: %w", c.config.Name, err)
+	}
+
 	// Build environment variables using secure environment manager
 	// This ensures PATH includes proper discovery even when launched via Launchd
 	envVars := c.envManager.BuildSecureEnvironment()
 
 	// Add server-specific environment variables (these are already included via envManager,
 	// but this ensures any additional runtime variables are included)
-	for k, v := range c.config.Env {
+	for k, v := range serverEnv {
 		found := false
 		for i, envVar := range envVars {
 			if strings.HasPrefix(envVar, k+"=") {
@@ -315,8 +336,19 @@ func (c *Client) connectStdio(ctx context.Context) error {
 			zap.String("server", c.config.Name),
 			zap.String("original_command", c.config.Command))
 
+		// Make sure the image is available according to image_pull_policy before docker run
+		runtimeType := c.isolationManager.DetectRuntimeType(c.config.Command)
+		if image, err := c.isolationManager.GetDockerImage(c.config, runtimeType); err == nil {
+			if err := c.ensureDockerImage(ctx, image); err != nil {
+				if c.upstreamLogger != nil {
+					c.upstreamLogger.Error("Docker image not available", zap.String("image", image), zap.Error(err))
+				}
+				return err
+			}
+		}
+
 		// Use Docker isolation (now shell-wrapped for PATH inheritance)
-		finalCommand, finalArgs = c.setupDockerIsolation(c.config.Command, args)
+		finalCommand, finalArgs = c.setupDockerIsolation(c.config.Command, args, serverEnv)
 		c.isDockerCommand = true
 
 		// Add cidfile to shell-wrapped Docker command if we have one
@@ -327,7 +359,8 @@ func (c *Client) connectStdio(ctx context.Context) error {
 		// Use shell wrapping for environment inheritance
 		// This fixes issues when mcpproxy is launched via Launchd and doesn't inherit
 		// user's shell environment (like PATH customizations from .bashrc, .zshrc, etc.)
-		finalCommand, finalArgs = c.wrapWithUserShell(c.config.Command, args)
+		// A per-server shell setting replaces the login shell
+		finalCommand, finalArgs = c.wrapCommand(c.config.Command, args)
 		c.isDockerCommand = false
 
 		// Handle explicit docker commands
@@ -534,7 +567,7 @@ func (c *Client) connectStdio(ctx context.Context) error {
 }
 
 // setupDockerIsolation sets up Docker isolation for a stdio command
-func (c *Client) setupDockerIsolation(command string, args []string) (dockerCommand string, dockerArgs []string) {
+func (c *Client) setupDockerIsolation(command string, args []string, serverEnv map[string]string) (dockerCommand string, dockerArgs []string) {
 	// Detect the runtime type from the command
 	runtimeType := c.isolationManager.DetectRuntimeType(command)
 	c.logger.Debug("Detected runtime type for Docker isolation",
@@ -543,7 +576,10 @@ func (c *Client) setupDockerIsolation(command string, args []string) (dockerComm
 		zap.String("runtime_type", runtimeType))
 
 	// Build Docker run arguments
-	dockerRunArgs, err := c.isolationManager.BuildDockerArgs(c.config, runtimeType)
+	// Pass the env_file variables into the container along with the inline env
+	isolationConfig := *c.config
+	isolationConfig.Env = serverEnv
+	dockerRunArgs, err := c.isolationManager.BuildDockerArgs(&isolationConfig, runtimeType)
 	if err != nil {
 		c.logger.Error("Failed to build Docker args, falling back to shell wrapping",
 			zap.String("server", c.config.Name),
@@ -588,7 +624,7 @@ func (c *Client) setupDockerIsolation(command string, args []string) (dockerComm
 func (c *Client) insertCidfileIntoShellDockerCommand(shellArgs []string, cidFile string) []string {
 	// Shell args typically look like: ["-l", "-c", "docker run -i --rm mcp/duckduckgo"]
 	// Fix: Check for correct shell format - args can be 2 or 3 elements
-	if len(shellArgs) < 2 || shellArgs[len(shellArgs)-2] != "-c" {
+	if len(shellArgs) < 2 || (shellArgs[len(shellArgs)-2] != "-c" && !strings.EqualFold(shellArgs[len(shellArgs)-2], "/c")) {
 		// If it's not the expected format, log error and fall back
 		c.logger.Error("Unexpected shell command format for Docker cidfile insertion - cannot track container ID",
 			zap.String("server", c.config.Name),
@@ -1404,7 +1440,7 @@ func (c *Client) isConfigError(err error) bool {
 // initialize performs MCP initialization handshake
 func (c *Client) initialize(ctx context.Context) error {
 	initRequest := mcp.InitializeRequest{}
-	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initRequest.Params.ProtocolVersion = c.config.GetProtocolVersion()
 	initRequest.Params.ClientInfo = mcp.Implementation{
 		Name:    "mcpproxy-go",
 		Version: "1.0.0",
@@ -1523,7 +1559,9 @@ func (c *Client) initialize(ctx context.Context) error {
 	c.serverInfo = serverInfo
 	c.logger.Info("MCP initialization successful",
 		zap.String("server_name", serverInfo.ServerInfo.Name),
-		zap.String("server_version", serverInfo.ServerInfo.Version))
+		zap.String("server_version", serverInfo.ServerInfo.Version),
+		zap.String("requested_protocol_version", initRequest.Params.ProtocolVersion),
+		zap.String("negotiated_protocol_version", serverInfo.ProtocolVersion))
 
 	// Log initialization success to server-specific log
 	if c.upstreamLogger != nil {
@@ -1798,6 +1836,17 @@ func (c *Client) handleOAuthAuthorization(ctx context.Context, authErr error, _
 	// Check if this is a manual OAuth flow using the proper context key
 	isManualFlow := c.isManualOAuthFlow(ctx)
 
+	// Headless flows report the URL to the caller instead of opening a browser
+	callbackTimeout := 30 * time.Second
+	authURLHandler, headless := ctx.Value(authURLHandlerKey).(func(string))
+	if headless {
+		c.logger.Info("🌐 Handing OAuth authorization URL to headless caller",
+			zap.String("server", c.config.Name),
+			zap.String("auth_url", authURL))
+		authURLHandler(authURL)
+		callbackTimeout = config.OAuthHeadlessCallbackTimeout
+	}
+
 	// Rate limit browser opening to prevent spam (CRITICAL FIX for Phase 1)
 	// Skip rate limiting for manual OAuth flows
 	browserRateLimit := 5 * time.Minute
@@ -1805,7 +1854,9 @@ func (c *Client) handleOAuthAuthorization(ctx context.Context, authErr error, _
 	timeSinceLastBrowser := time.Since(c.lastOAuthTimestamp)
 	c.oauthMu.RUnlock()
 
-	if !isManualFlow && timeSinceLastBrowser < browserRateLimit {
+	if headless {
+		// The user opens the URL themselves
+	} else if !isManualFlow && timeSinceLastBrowser < browserRateLimit {
 		c.logger.Warn("⏱️ Browser opening rate limited - OAuth attempt too soon after previous attempt",
 			zap.String("server", c.config.Name),
 			zap.Duration("time_since_last", timeSinceLastBrowser),
@@ -1895,10 +1946,11 @@ func (c *Client) handleOAuthAuthorization(ctx context.Context, authErr error, _
 
 		return nil
 
-	case <-time.After(30 * time.Second):
-		c.logger.Warn("⏱️ OAuth authorization timeout - user did not complete authorization within 30 seconds",
-			zap.String("server", c.config.Name))
-		return fmt.Errorf("OAuth authorization timeout - user did not complete authorization within 30 seconds")
+	case <-time.After(callbackTimeout):
+		c.logger.Warn("⏱️ OAuth authorization timeout - user did not complete authorization in time",
+			zap.String("server", c.config.Name),
+			zap.Duration("timeout", callbackTimeout))
+		return fmt.Errorf("OAuth authorization timeout - user did not complete authorization within %s", callbackTimeout)
 	case <-ctx.Done():
 		return ctx.Err()
 	}
@@ -11,6 +11,13 @@ import (
 	"go.uber.org/zap"
 )
 
+// GetContainerID returns the ID of the Docker container running this server, if known
+func (c *Client) GetContainerID() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.containerID
+}
+
 // readContainerIDWithContext reads the container ID from cidfile for tracking with context cancellation
 func (c *Client) readContainerIDWithContext(ctx context.Context, cidFile string) {
 	c.logger.Debug("Starting container ID tracking",
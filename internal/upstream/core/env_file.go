@@ -0,0 +1,31 @@
+package core
+
+import (
+	"fmt"
+	"os"
+
+	"mcpproxy-go/internal/config"
+)
+
+// serverEnv returns the server-specific environment variables: those of env_file, overridden
+// by the inline env on conflict
+func (c *Client) serverEnv() (map[string]string, error) {
+	if c.config.EnvFile == "" {
+		return c.config.Env, nil
+	}
+
+	// LoadDotEnv treats a missing file as empty; a configured env_file must exist
+	path := c.config.GetEnvFilePath()
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("failed to read env_file %s: %w", path, err)
+	}
+
+	env, err := config.LoadDotEnv(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read env_file %s: %w", path, err)
+	}
+	for k, v := range c.config.Env {
+		env[k] = v
+	}
+	return env, nil
+}
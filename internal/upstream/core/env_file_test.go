@@ -0,0 +1,38 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"mcpproxy-go/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerEnv_InlineEnvWinsOverEnvFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".env"), []byte("TOKEN=from-file\nREGION=eu\n"), 0600))
+
+	c := &Client{config: &config.ServerConfig{
+		Name:       "test",
+		WorkingDir: dir,
+		EnvFile:    ".env", // Relative to working_dir
+		Env:        map[string]string{"TOKEN": "inline"},
+	}}
+
+	env, err := c.serverEnv()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"TOKEN": "inline", "REGION": "eu"}, env)
+}
+
+func TestServerEnv_MissingEnvFile(t *testing.T) {
+	c := &Client{config: &config.ServerConfig{
+		Name:    "test",
+		EnvFile: filepath.Join(t.TempDir(), "missing.env"),
+	}}
+
+	_, err := c.serverEnv()
+	assert.Error(t, err)
+}
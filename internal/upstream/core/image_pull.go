@@ -0,0 +1,102 @@
+package core
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
+)
+
+// shouldPullImage decides whether to pull an image given the pull policy and whether the
+// image is already present locally. An error means the server cannot be started.
+func shouldPullImage(policy string, present bool) (bool, error) {
+	switch policy {
+	case config.ImagePullAlways:
+		return true, nil
+	case config.ImagePullNever:
+		if !present {
+			return false, fmt.Errorf("image is not present locally and image_pull_policy is %q", config.ImagePullNever)
+		}
+		return false, nil
+	default:
+		return !present, nil
+	}
+}
+
+// ensureDockerImage pulls the isolation image before launch according to the server's
+// image_pull_policy, so a missing image shows up as pull progress instead of a slow
+// docker run that looks like a connection timeout
+func (c *Client) ensureDockerImage(ctx context.Context, image string) error {
+	policy := c.config.Isolation.GetImagePullPolicy()
+
+	inspectCmd := exec.CommandContext(ctx, "docker", "image", "inspect", "--format", "{{.Id}}", image)
+	present := inspectCmd.Run() == nil
+
+	pull, err := shouldPullImage(policy, present)
+	if err != nil {
+		return fmt.Errorf("docker image %s: %w", image, err)
+	}
+	if !pull {
+		return nil
+	}
+
+	c.logger.Info("Pulling Docker image for isolated server",
+		zap.String("server", c.config.Name),
+		zap.String("image", image),
+		zap.String("pull_policy", policy),
+		zap.Bool("present", present))
+	if c.upstreamLogger != nil {
+		c.upstreamLogger.Info("Pulling Docker image", zap.String("image", image), zap.String("pull_policy", policy))
+	}
+
+	pullCmd := exec.CommandContext(ctx, "docker", "pull", image)
+	output, err := pullCmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to pull docker image %s: %w", image, err)
+	}
+	pullCmd.Stderr = pullCmd.Stdout
+	if err := pullCmd.Start(); err != nil {
+		return fmt.Errorf("failed to pull docker image %s: %w", image, err)
+	}
+
+	lastLine := c.streamPullProgress(output)
+	if err := pullCmd.Wait(); err != nil {
+		if lastLine != "" {
+			return fmt.Errorf("failed to pull docker image %s: %s: %w", image, lastLine, err)
+		}
+		return fmt.Errorf("failed to pull docker image %s: %w", image, err)
+	}
+
+	c.logger.Info("Docker image pulled",
+		zap.String("server", c.config.Name),
+		zap.String("image", image))
+	return nil
+}
+
+// streamPullProgress copies docker pull output line by line to the server log and
+// returns the last line, which holds the error message if the pull failed
+func (c *Client) streamPullProgress(output io.Reader) string {
+	var lastLine string
+	scanner := bufio.NewScanner(output)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		lastLine = line
+		if c.upstreamLogger != nil {
+			c.upstreamLogger.Info("docker pull", zap.String("progress", line))
+		} else {
+			c.logger.Debug("docker pull progress",
+				zap.String("server", c.config.Name),
+				zap.String("progress", line))
+		}
+	}
+	return lastLine
+}
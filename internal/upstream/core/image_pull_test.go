@@ -0,0 +1,31 @@
+package core
+
+import (
+	"testing"
+
+	"mcpproxy-go/internal/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldPullImage(t *testing.T) {
+	tests := []struct {
+		policy  string
+		present bool
+		pull    bool
+		wantErr bool
+	}{
+		{config.ImagePullAlways, true, true, false},
+		{config.ImagePullAlways, false, true, false},
+		{config.ImagePullIfNotPresent, true, false, false},
+		{config.ImagePullIfNotPresent, false, true, false},
+		{config.ImagePullNever, true, false, false},
+		{config.ImagePullNever, false, false, true},
+	}
+
+	for _, tt := range tests {
+		pull, err := shouldPullImage(tt.policy, tt.present)
+		assert.Equal(t, tt.pull, pull, "policy=%s present=%v", tt.policy, tt.present)
+		assert.Equal(t, tt.wantErr, err != nil, "policy=%s present=%v", tt.policy, tt.present)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"mcpproxy-go/internal/config"
@@ -229,12 +230,21 @@ func (im *IsolationManager) BuildDockerArgs(serverConfig *config.ServerConfig, r
 		args = append(args, "--network", networkMode)
 	}
 
-	// Add resource limits
-	if im.globalConfig.MemoryLimit != "" {
-		args = append(args, "--memory", im.globalConfig.MemoryLimit)
+	// Add resource limits, per-server limits take precedence over global ones
+	memoryLimit := im.globalConfig.MemoryLimit
+	if serverConfig.Isolation != nil && serverConfig.Isolation.MemoryLimitMB > 0 {
+		memoryLimit = fmt.Sprintf("%dm", serverConfig.Isolation.MemoryLimitMB)
 	}
-	if im.globalConfig.CPULimit != "" {
-		args = append(args, "--cpus", im.globalConfig.CPULimit)
+	if memoryLimit != "" {
+		args = append(args, "--memory", memoryLimit)
+	}
+
+	cpuLimit := im.globalConfig.CPULimit
+	if serverConfig.Isolation != nil && serverConfig.Isolation.CPUShares > 0 {
+		cpuLimit = strconv.FormatFloat(serverConfig.Isolation.CPUShares, 'f', -1, 64)
+	}
+	if cpuLimit != "" {
+		args = append(args, "--cpus", cpuLimit)
 	}
 
 	// Add working directory if specified
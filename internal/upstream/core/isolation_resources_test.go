@@ -0,0 +1,67 @@
+package core
+
+import (
+	"testing"
+
+	"mcpproxy-go/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flagValue returns the value following flag in args, or "" if the flag is absent
+func flagValue(args []string, flag string) string {
+	for i := 0; i < len(args)-1; i++ {
+		if args[i] == flag {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+func TestBuildDockerArgsResourceLimits(t *testing.T) {
+	t.Run("no limits when neither global nor per-server limits are set", func(t *testing.T) {
+		globalConfig := config.DefaultDockerIsolationConfig()
+		globalConfig.MemoryLimit = ""
+		globalConfig.CPULimit = ""
+		im := NewIsolationManager(globalConfig)
+
+		args, err := im.BuildDockerArgs(&config.ServerConfig{Name: "test-server", Command: "python"}, "python")
+		require.NoError(t, err)
+
+		assert.NotContains(t, args, "--memory")
+		assert.NotContains(t, args, "--cpus")
+	})
+
+	t.Run("per-server limits", func(t *testing.T) {
+		im := NewIsolationManager(config.DefaultDockerIsolationConfig())
+		serverConfig := &config.ServerConfig{
+			Name:      "test-server",
+			Command:   "python",
+			Isolation: &config.IsolationConfig{Enabled: true, MemoryLimitMB: 512, CPUShares: 1.5},
+		}
+
+		args, err := im.BuildDockerArgs(serverConfig, "python")
+		require.NoError(t, err)
+
+		assert.Equal(t, "512m", flagValue(args, "--memory"))
+		assert.Equal(t, "1.5", flagValue(args, "--cpus"))
+	})
+
+	t.Run("per-server limits override global ones", func(t *testing.T) {
+		globalConfig := config.DefaultDockerIsolationConfig()
+		globalConfig.MemoryLimit = "1g"
+		globalConfig.CPULimit = "2"
+		im := NewIsolationManager(globalConfig)
+
+		args, err := im.BuildDockerArgs(&config.ServerConfig{
+			Name:      "test-server",
+			Command:   "python",
+			Isolation: &config.IsolationConfig{Enabled: true, MemoryLimitMB: 256},
+		}, "python")
+		require.NoError(t, err)
+
+		assert.Equal(t, "256m", flagValue(args, "--memory"))
+		assert.Equal(t, "2", flagValue(args, "--cpus"))
+	})
+}
@@ -0,0 +1,96 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"mcpproxy-go/internal/oauth"
+
+	uptransport "github.com/mark3labs/mcp-go/client/transport"
+	"go.uber.org/zap"
+)
+
+// oauthHandler returns the OAuth handler of the active HTTP/SSE transport, if any
+func (c *Client) oauthHandler() *uptransport.OAuthHandler {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.client == nil {
+		return nil
+	}
+	t, ok := c.client.GetTransport().(interface {
+		GetOAuthHandler() *uptransport.OAuthHandler
+	})
+	if !ok {
+		return nil
+	}
+	return t.GetOAuthHandler()
+}
+
+// RefreshOAuthToken exchanges the stored refresh token for a new access token using the
+// live transport's OAuth handler. The handler persists the new token; the new expiry is returned.
+func (c *Client) RefreshOAuthToken(ctx context.Context) (time.Time, error) {
+	c.oauthRefreshMu.Lock()
+	defer c.oauthRefreshMu.Unlock()
+	return c.refreshOAuthToken(ctx)
+}
+
+// refreshOAuthToken implements RefreshOAuthToken. Must be called with oauthRefreshMu held.
+func (c *Client) refreshOAuthToken(ctx context.Context) (time.Time, error) {
+	handler := c.oauthHandler()
+	if handler == nil {
+		return time.Time{}, fmt.Errorf("server %s has no active OAuth transport", c.config.Name)
+	}
+
+	record, err := oauth.GetStoredTokenRecord(c.config.Name, c.config.URL, c.storage)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("no stored OAuth token: %w", err)
+	}
+	if record.RefreshToken == "" {
+		return time.Time{}, fmt.Errorf("stored OAuth token has no refresh token")
+	}
+
+	token, err := handler.RefreshToken(ctx, record.RefreshToken)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to refresh OAuth token: %w", err)
+	}
+
+	c.lastOAuthRefresh = time.Now()
+	c.logger.Debug("OAuth token refreshed",
+		zap.String("server", c.config.Name),
+		zap.Time("expires_at", token.ExpiresAt))
+
+	return token.ExpiresAt, nil
+}
+
+// retryWithRefreshedOAuthToken handles a request started at sentAt that the server rejected
+// with a 401, e.g. because it revoked the access token before its stored expiry. It refreshes
+// the token unless another request already did so after sentAt, and reports whether the
+// request should be retried. The transport reads the token store on every request, so the
+// retry carries the new token without reconnecting.
+func (c *Client) retryWithRefreshedOAuthToken(ctx context.Context, err error, sentAt time.Time) bool {
+	if !errors.Is(err, uptransport.ErrOAuthAuthorizationRequired) {
+		return false
+	}
+
+	c.oauthRefreshMu.Lock()
+	defer c.oauthRefreshMu.Unlock()
+	if c.lastOAuthRefresh.After(sentAt) {
+		return true
+	}
+
+	expiresAt, refreshErr := c.refreshOAuthToken(ctx)
+	if refreshErr != nil {
+		c.logger.Debug("OAuth token rejected and could not be refreshed",
+			zap.String("server", c.config.Name),
+			zap.Error(refreshErr))
+		return false
+	}
+
+	c.logger.Info("Server rejected OAuth token, retrying with refreshed token",
+		zap.String("server", c.config.Name),
+		zap.Time("expires_at", expiresAt))
+	return true
+}
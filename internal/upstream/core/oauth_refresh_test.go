@@ -0,0 +1,164 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"mcpproxy-go/internal/config"
+	"mcpproxy-go/internal/oauth"
+	"mcpproxy-go/internal/storage"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// oauthUpstream is an MCP server behind OAuth that accepts a single access token at a time
+type oauthUpstream struct {
+	*httptest.Server
+	validToken atomic.Value
+	refreshes  atomic.Int32
+}
+
+func newOAuthUpstream(t *testing.T) *oauthUpstream {
+	mcpServer := server.NewMCPServer("upstream", "1.0.0")
+	mcpServer.AddTool(mcp.NewTool("echo"), func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	})
+	mcpHandler := server.NewStreamableHTTPServer(mcpServer)
+
+	u := &oauthUpstream{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/oauth-authorization-server", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 u.URL,
+			"authorization_endpoint": u.URL + "/authorize",
+			"token_endpoint":         u.URL + "/token",
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "refresh_token", r.Form.Get("grant_type"))
+		u.refreshes.Add(1)
+		u.validToken.Store("fresh-token")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "fresh-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		})
+	})
+	mux.HandleFunc("/mcp", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+u.validToken.Load().(string) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mcpHandler.ServeHTTP(w, r)
+	})
+
+	u.validToken.Store("old-token")
+	u.Server = httptest.NewServer(mux)
+	t.Cleanup(u.Close)
+	return u
+}
+
+func TestCallTool_RetriesWithRefreshedOAuthToken(t *testing.T) {
+	upstream := newOAuthUpstream(t)
+	serverConfig := &config.ServerConfig{Name: "remote", URL: upstream.URL + "/mcp"}
+
+	db, err := storage.NewBoltDB(t.TempDir(), zap.NewNop().Sugar())
+	require.NoError(t, err)
+	defer db.Close()
+
+	// The stored token has not expired yet, so the transport sends it as is
+	tokenStore := oauth.NewPersistentTokenStore(serverConfig.Name, serverConfig.URL, db)
+	require.NoError(t, tokenStore.SaveToken(&client.Token{
+		AccessToken:  "old-token",
+		RefreshToken: "refresh-token",
+		TokenType:    "Bearer",
+		ExpiresAt:    time.Now().Add(time.Hour),
+	}))
+
+	mcpClient, err := client.NewOAuthStreamableHttpClient(serverConfig.URL, client.OAuthConfig{
+		TokenStore:            tokenStore,
+		AuthServerMetadataURL: upstream.URL + "/.well-known/oauth-authorization-server",
+	})
+	require.NoError(t, err)
+	defer mcpClient.Close()
+
+	ctx := context.Background()
+	require.NoError(t, mcpClient.Start(ctx))
+	_, err = mcpClient.Initialize(ctx, mcp.InitializeRequest{})
+	require.NoError(t, err)
+
+	c := &Client{
+		config:    serverConfig,
+		storage:   db,
+		logger:    zap.NewNop(),
+		client:    mcpClient,
+		connected: true,
+	}
+
+	// The server revokes the old token before its expiry, e.g. after a refresh elsewhere
+	upstream.validToken.Store("revoked")
+
+	result, err := c.CallTool(ctx, "echo", nil)
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	assert.Equal(t, "ok", result.Content[0].(mcp.TextContent).Text)
+	assert.Equal(t, int32(1), upstream.refreshes.Load())
+
+	// The refreshed token was persisted and is used by the next call without another refresh
+	record, err := oauth.GetStoredTokenRecord(serverConfig.Name, serverConfig.URL, db)
+	require.NoError(t, err)
+	assert.Equal(t, "fresh-token", record.AccessToken)
+	assert.Equal(t, "refresh-token", record.RefreshToken)
+
+	_, err = c.CallTool(ctx, "echo", nil)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), upstream.refreshes.Load())
+}
+
+func TestCallTool_UnauthorizedWithoutRefreshToken(t *testing.T) {
+	upstream := newOAuthUpstream(t)
+	serverConfig := &config.ServerConfig{Name: "remote", URL: upstream.URL + "/mcp"}
+
+	db, err := storage.NewBoltDB(t.TempDir(), zap.NewNop().Sugar())
+	require.NoError(t, err)
+	defer db.Close()
+
+	tokenStore := oauth.NewPersistentTokenStore(serverConfig.Name, serverConfig.URL, db)
+	require.NoError(t, tokenStore.SaveToken(&client.Token{
+		AccessToken: "old-token",
+		TokenType:   "Bearer",
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}))
+
+	mcpClient, err := client.NewOAuthStreamableHttpClient(serverConfig.URL, client.OAuthConfig{
+		TokenStore:            tokenStore,
+		AuthServerMetadataURL: upstream.URL + "/.well-known/oauth-authorization-server",
+	})
+	require.NoError(t, err)
+	defer mcpClient.Close()
+
+	ctx := context.Background()
+	require.NoError(t, mcpClient.Start(ctx))
+	_, err = mcpClient.Initialize(ctx, mcp.InitializeRequest{})
+	require.NoError(t, err)
+
+	c := &Client{config: serverConfig, storage: db, logger: zap.NewNop(), client: mcpClient, connected: true}
+	upstream.validToken.Store("revoked")
+
+	_, err = c.CallTool(ctx, "echo", nil)
+	require.Error(t, err)
+	assert.True(t, client.IsOAuthAuthorizationRequiredError(err))
+	assert.Equal(t, int32(0), upstream.refreshes.Load())
+}
@@ -5,6 +5,7 @@ package core
 import (
 	"context"
 	"os/exec"
+	"syscall"
 
 	"go.uber.org/zap"
 )
@@ -27,6 +28,13 @@ func createProcessGroupCommandFunc(workingDir string, logger *zap.Logger) func(c
 			cmd.Dir = workingDir
 		}
 
+		// Pass cmd /c command strings verbatim, os/exec would escape their quotes for the C runtime
+		if isCmdShell(command) {
+			if cmdLine := cmdCommandLine(command, args); cmdLine != "" {
+				cmd.SysProcAttr = &syscall.SysProcAttr{CmdLine: cmdLine}
+			}
+		}
+
 		// NOTE: Windows uses Job Objects instead of Unix process groups
 		// Feature Backlog: Implement proper Windows Job Object process management
 		// For now, use standard command creation
@@ -0,0 +1,89 @@
+package core
+
+import (
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// wrapCommand wraps a stdio command in the server's configured shell, or in the user's login
+// shell when none is configured
+func (c *Client) wrapCommand(command string, args []string) (shellCommand string, shellArgs []string) {
+	if strings.TrimSpace(c.config.Shell) == "" {
+		return c.wrapWithUserShell(command, args)
+	}
+	return c.wrapWithConfiguredShell(command, args)
+}
+
+// wrapWithConfiguredShell runs a command through the server's shell setting, e.g. "sh -c" or
+// "cmd /c". A shell given without flags gets "-c", or "/c" for cmd.
+func (c *Client) wrapWithConfiguredShell(command string, args []string) (shellCommand string, shellArgs []string) {
+	fields := strings.Fields(c.config.Shell)
+	shell := fields[0]
+	cmdShell := isCmdShell(shell)
+
+	shellArgs = append(shellArgs, fields[1:]...)
+	if len(shellArgs) == 0 {
+		if cmdShell {
+			shellArgs = append(shellArgs, "/c")
+		} else {
+			shellArgs = append(shellArgs, "-c")
+		}
+	}
+
+	quote := shellescape
+	if cmdShell {
+		quote = cmdQuote
+	}
+	commandParts := make([]string, 0, len(args)+1)
+	commandParts = append(commandParts, quote(command))
+	for _, arg := range args {
+		commandParts = append(commandParts, quote(arg))
+	}
+	commandString := strings.Join(commandParts, " ")
+
+	c.logger.Debug("Wrapping command with configured shell",
+		zap.String("server", c.config.Name),
+		zap.String("shell", c.config.Shell),
+		zap.String("wrapped_command", commandString))
+
+	return shell, append(shellArgs, commandString)
+}
+
+// isCmdShell reports whether shell is the Windows command interpreter
+func isCmdShell(shell string) bool {
+	name := strings.ToLower(filepath.Base(strings.ReplaceAll(shell, `\`, "/")))
+	return name == "cmd" || name == "cmd.exe"
+}
+
+// cmdCommandLine returns the raw command line for running cmd with args, or "" when args do not
+// end in a /c or /k command string. cmd does not parse its command line like other Windows
+// programs, so it must get the command string verbatim rather than escaped by os/exec. The
+// command string is wrapped in an extra pair of quotes, which cmd strips before running it.
+func cmdCommandLine(command string, args []string) string {
+	if len(args) < 2 {
+		return ""
+	}
+	flag := strings.ToLower(args[len(args)-2])
+	if flag != "/c" && flag != "/k" {
+		return ""
+	}
+
+	parts := make([]string, 0, len(args)+1)
+	parts = append(parts, cmdQuote(command))
+	parts = append(parts, args[:len(args)-1]...)
+	parts = append(parts, `"`+args[len(args)-1]+`"`)
+	return strings.Join(parts, " ")
+}
+
+// cmdQuote quotes an argument for a cmd /c command line
+func cmdQuote(s string) string {
+	if s == "" {
+		return `""`
+	}
+	if !strings.ContainsAny(s, " \t\"&|<>^()") {
+		return s
+	}
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
@@ -0,0 +1,90 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
+)
+
+func TestWrapCommand_ConfiguredShell(t *testing.T) {
+	tests := []struct {
+		name        string
+		shell       string
+		command     string
+		args        []string
+		wantCommand string
+		wantArgs    []string
+	}{
+		{
+			name:        "posix shell with flag",
+			shell:       "sh -c",
+			command:     "npx",
+			args:        []string{"-y", "@scope/server", "my dir"},
+			wantCommand: "sh",
+			wantArgs:    []string{"-c", "npx -y @scope/server 'my dir'"},
+		},
+		{
+			name:        "login shell flags",
+			shell:       "/bin/zsh -l -c",
+			command:     "uvx",
+			args:        []string{"mcp-server-fetch"},
+			wantCommand: "/bin/zsh",
+			wantArgs:    []string{"-l", "-c", "uvx mcp-server-fetch"},
+		},
+		{
+			name:        "shell without flags gets -c",
+			shell:       "bash",
+			command:     "node",
+			args:        []string{"server.js"},
+			wantCommand: "bash",
+			wantArgs:    []string{"-c", "node server.js"},
+		},
+		{
+			name:        "cmd quotes with double quotes",
+			shell:       "cmd /c",
+			command:     `C:\Program Files\nodejs\npx.cmd`,
+			args:        []string{"-y", "server"},
+			wantCommand: "cmd",
+			wantArgs:    []string{"/c", `"C:\Program Files\nodejs\npx.cmd" -y server`},
+		},
+		{
+			name:        "cmd without flags gets /c",
+			shell:       `C:\Windows\System32\cmd.exe`,
+			command:     "npx",
+			args:        []string{"server"},
+			wantCommand: `C:\Windows\System32\cmd.exe`,
+			wantArgs:    []string{"/c", "npx server"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{
+				config: &config.ServerConfig{Name: "test", Shell: tt.shell},
+				logger: zap.NewNop(),
+			}
+			command, args := c.wrapCommand(tt.command, tt.args)
+			assert.Equal(t, tt.wantCommand, command)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestCmdCommandLine(t *testing.T) {
+	assert.Equal(t,
+		`"C:\Program Files\cmd.exe" /c ""C:\Program Files\nodejs\npx.cmd" -y "my dir""`,
+		cmdCommandLine(`C:\Program Files\cmd.exe`, []string{"/c", `"C:\Program Files\nodejs\npx.cmd" -y "my dir"`}))
+	assert.Equal(t, `cmd /s /c "npx server"`, cmdCommandLine("cmd", []string{"/s", "/c", "npx server"}))
+	assert.Empty(t, cmdCommandLine("cmd", []string{"-l", "-c", "npx server"}), "only /c and /k command strings are passed verbatim")
+	assert.Empty(t, cmdCommandLine("cmd", []string{"/c"}))
+}
+
+func TestInsertCidfileIntoShellDockerCommand_CmdShell(t *testing.T) {
+	c := &Client{config: &config.ServerConfig{Name: "test"}, logger: zap.NewNop()}
+
+	args := c.insertCidfileIntoShellDockerCommand([]string{"/c", "docker run -i --rm mcp/fetch"}, "cid.txt")
+	assert.Equal(t, []string{"/c", "docker run --cidfile cid.txt -i --rm mcp/fetch"}, args)
+}
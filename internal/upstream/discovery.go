@@ -0,0 +1,91 @@
+package upstream
+
+import (
+	"context"
+	"sync"
+
+	"mcpproxy-go/internal/config"
+)
+
+// toolLister is the part of a client used for tool discovery
+type toolLister interface {
+	ListTools(ctx context.Context) ([]*config.ToolMetadata, error)
+}
+
+// resourceLister is the part of a client used for resource discovery
+type resourceLister interface {
+	ListResources(ctx context.Context) ([]*config.ResourceMetadata, error)
+}
+
+// promptLister is the part of a client used for prompt discovery
+type promptLister interface {
+	ListPrompts(ctx context.Context) ([]*config.PromptMetadata, error)
+}
+
+// listToolsConcurrently calls ListTools on every lister, running at most limit calls at a time,
+// and returns the combined tools. A failing lister is reported to onError and skipped.
+func listToolsConcurrently(ctx context.Context, listers map[string]toolLister, limit int, onError func(id string, err error)) []*config.ToolMetadata {
+	calls := make(map[string]func(context.Context) ([]*config.ToolMetadata, error), len(listers))
+	for id, lister := range listers {
+		calls[id] = lister.ListTools
+	}
+	return listConcurrently(ctx, calls, limit, onError)
+}
+
+// listResourcesConcurrently is the resource counterpart of listToolsConcurrently
+func listResourcesConcurrently(ctx context.Context, listers map[string]resourceLister, limit int, onError func(id string, err error)) []*config.ResourceMetadata {
+	calls := make(map[string]func(context.Context) ([]*config.ResourceMetadata, error), len(listers))
+	for id, lister := range listers {
+		calls[id] = lister.ListResources
+	}
+	return listConcurrently(ctx, calls, limit, onError)
+}
+
+// listPromptsConcurrently is the prompt counterpart of listToolsConcurrently
+func listPromptsConcurrently(ctx context.Context, listers map[string]promptLister, limit int, onError func(id string, err error)) []*config.PromptMetadata {
+	calls := make(map[string]func(context.Context) ([]*config.PromptMetadata, error), len(listers))
+	for id, lister := range listers {
+		calls[id] = lister.ListPrompts
+	}
+	return listConcurrently(ctx, calls, limit, onError)
+}
+
+// listConcurrently runs the list calls with at most limit in flight and combines their results
+func listConcurrently[T any](ctx context.Context, calls map[string]func(context.Context) ([]T, error), limit int, onError func(id string, err error)) []T {
+	if limit <= 0 {
+		limit = 1
+	}
+
+	semaphore := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var all []T
+
+	for id, list := range calls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case semaphore <- struct{}{}:
+				defer func() { <-semaphore }()
+			case <-ctx.Done():
+				onError(id, ctx.Err())
+				return
+			}
+
+			items, err := list(ctx)
+			if err != nil {
+				onError(id, err)
+				return
+			}
+			if items != nil {
+				mu.Lock()
+				all = append(all, items...)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return all
+}
@@ -0,0 +1,102 @@
+package upstream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"mcpproxy-go/internal/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingLister records how many ListTools calls run at the same time
+type countingLister struct {
+	name    string
+	active  *int32
+	maxSeen *int32
+	err     error
+}
+
+func (l *countingLister) ListTools(_ context.Context) ([]*config.ToolMetadata, error) {
+	current := atomic.AddInt32(l.active, 1)
+	defer atomic.AddInt32(l.active, -1)
+	for {
+		seen := atomic.LoadInt32(l.maxSeen)
+		if current <= seen || atomic.CompareAndSwapInt32(l.maxSeen, seen, current) {
+			break
+		}
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	if l.err != nil {
+		return nil, l.err
+	}
+	return []*config.ToolMetadata{{Name: l.name + ":tool", ServerName: l.name}}, nil
+}
+
+func (l *countingLister) ListResources(_ context.Context) ([]*config.ResourceMetadata, error) {
+	if l.err != nil {
+		return nil, l.err
+	}
+	return []*config.ResourceMetadata{{URI: "file:///" + l.name, ServerName: l.name}}, nil
+}
+
+func TestListToolsConcurrently_RespectsLimit(t *testing.T) {
+	var active, maxSeen int32
+	listers := make(map[string]toolLister)
+	for i := 0; i < 20; i++ {
+		name := fmt.Sprintf("server-%d", i)
+		listers[name] = &countingLister{name: name, active: &active, maxSeen: &maxSeen}
+	}
+
+	tools := listToolsConcurrently(context.Background(), listers, 3, func(id string, err error) {
+		t.Errorf("unexpected error from %s: %v", id, err)
+	})
+
+	assert.Len(t, tools, 20)
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxSeen), int32(3), "no more than the limit run at once")
+	assert.Greater(t, atomic.LoadInt32(&maxSeen), int32(1), "listers run concurrently")
+}
+
+func TestListToolsConcurrently_SkipsFailures(t *testing.T) {
+	var active, maxSeen int32
+	listers := map[string]toolLister{
+		"ok":     &countingLister{name: "ok", active: &active, maxSeen: &maxSeen},
+		"broken": &countingLister{name: "broken", active: &active, maxSeen: &maxSeen, err: errors.New("boom")},
+	}
+
+	var mu sync.Mutex
+	failed := map[string]error{}
+	tools := listToolsConcurrently(context.Background(), listers, 0, func(id string, err error) {
+		mu.Lock()
+		failed[id] = err
+		mu.Unlock()
+	})
+
+	assert.Len(t, tools, 1)
+	assert.Equal(t, "ok:tool", tools[0].Name)
+	assert.EqualError(t, failed["broken"], "boom")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&maxSeen), "a limit of 0 runs one at a time")
+}
+
+func TestListResourcesConcurrently(t *testing.T) {
+	var active, maxSeen int32
+	listers := map[string]resourceLister{
+		"a":      &countingLister{name: "a", active: &active, maxSeen: &maxSeen},
+		"b":      &countingLister{name: "b", active: &active, maxSeen: &maxSeen},
+		"broken": &countingLister{name: "broken", active: &active, maxSeen: &maxSeen, err: errors.New("boom")},
+	}
+
+	var failed []string
+	resources := listResourcesConcurrently(context.Background(), listers, 1, func(id string, _ error) {
+		failed = append(failed, id)
+	})
+
+	assert.Len(t, resources, 2)
+	assert.Equal(t, []string{"broken"}, failed)
+}
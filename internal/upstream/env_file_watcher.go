@@ -0,0 +1,177 @@
+package upstream
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
+	"mcpproxy-go/internal/upstream/managed"
+)
+
+// envFileReloadDebounce is how long an env_file must be quiet before its servers reconnect,
+// so a file written in several steps is loaded once it is complete
+const envFileReloadDebounce = 200 * time.Millisecond
+
+// watchEnvFile starts watching the env_file of a server so the server reconnects with the new
+// variables when the file changes
+func (m *Manager) watchEnvFile(serverConfig *config.ServerConfig) {
+	path := serverConfig.GetEnvFilePath()
+	if path == "" {
+		return
+	}
+
+	m.envWatchMu.Lock()
+	defer m.envWatchMu.Unlock()
+
+	if m.envWatcher == nil {
+		watcher, err := fsnotify.NewWatcher()
+		if err != nil {
+			m.logger.Warn("Failed to create env_file watcher, changes require a reconnect",
+				zap.String("server", serverConfig.Name),
+				zap.Error(err))
+			return
+		}
+		m.envWatcher = watcher
+		go m.envFileWatchLoop(watcher)
+	}
+
+	// Watch the directory rather than the file: editors that save by renaming or deleting and
+	// recreating the file would otherwise silently end a watch on the file itself
+	if err := m.envWatcher.Add(filepath.Dir(path)); err != nil {
+		m.logger.Warn("Failed to watch env_file, changes require a reconnect",
+			zap.String("server", serverConfig.Name),
+			zap.String("env_file", path),
+			zap.Error(err))
+	}
+}
+
+// unwatchEnvFile stops watching the directory of path once no server has an env_file in it,
+// and drops a pending reconnect for path when no server uses it anymore. The caller holds m.mu.
+func (m *Manager) unwatchEnvFile(path string) {
+	if path == "" {
+		return
+	}
+
+	dir := filepath.Dir(path)
+	fileUsed, dirUsed := false, false
+	for _, client := range m.clients {
+		if client.Config.EnvFile == "" {
+			continue
+		}
+		clientPath := client.Config.GetEnvFilePath()
+		if clientPath == path {
+			fileUsed = true
+		}
+		if filepath.Dir(clientPath) == dir {
+			dirUsed = true
+		}
+	}
+
+	m.envWatchMu.Lock()
+	defer m.envWatchMu.Unlock()
+
+	if !fileUsed {
+		if timer, exists := m.envReloads[path]; exists {
+			timer.Stop()
+			delete(m.envReloads, path)
+		}
+	}
+	if !dirUsed && m.envWatcher != nil {
+		if err := m.envWatcher.Remove(dir); err != nil {
+			m.logger.Debug("Failed to stop watching env_file directory",
+				zap.String("dir", dir),
+				zap.Error(err))
+		}
+	}
+}
+
+// stopEnvFileWatcher closes the env_file watcher and drops the pending reconnects
+func (m *Manager) stopEnvFileWatcher() {
+	m.envWatchMu.Lock()
+	defer m.envWatchMu.Unlock()
+
+	for path, timer := range m.envReloads {
+		timer.Stop()
+		delete(m.envReloads, path)
+	}
+	if m.envWatcher != nil {
+		if err := m.envWatcher.Close(); err != nil {
+			m.logger.Debug("Failed to close env_file watcher", zap.Error(err))
+		}
+		m.envWatcher = nil
+	}
+}
+
+// envFileWatchLoop schedules a reconnect for every written or recreated file in a watched directory
+func (m *Manager) envFileWatchLoop(watcher *fsnotify.Watcher) {
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Has(fsnotify.Write) || event.Has(fsnotify.Create) {
+				m.scheduleEnvFileReload(filepath.Clean(event.Name))
+			}
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			m.logger.Warn("env_file watcher error", zap.Error(err))
+		}
+	}
+}
+
+// scheduleEnvFileReload reconnects the servers using path once it has been quiet for envFileReloadDebounce
+func (m *Manager) scheduleEnvFileReload(path string) {
+	m.envWatchMu.Lock()
+	defer m.envWatchMu.Unlock()
+
+	if timer, exists := m.envReloads[path]; exists {
+		timer.Reset(envFileReloadDebounce)
+		return
+	}
+	m.envReloads[path] = time.AfterFunc(envFileReloadDebounce, func() {
+		m.envWatchMu.Lock()
+		delete(m.envReloads, path)
+		m.envWatchMu.Unlock()
+
+		m.reconnectEnvFileServers(path)
+	})
+}
+
+// reconnectEnvFileServers reconnects the connected servers whose env_file is path. Servers that
+// are not connected read the file on their next connect anyway.
+func (m *Manager) reconnectEnvFileServers(path string) {
+	m.mu.RLock()
+	var clients []*managed.Client
+	for _, client := range m.clients {
+		if client.Config.EnvFile != "" && client.Config.GetEnvFilePath() == path && client.IsConnected() {
+			clients = append(clients, client)
+		}
+	}
+	m.mu.RUnlock()
+
+	for _, client := range clients {
+		m.logger.Info("env_file changed, reconnecting server",
+			zap.String("server", client.Config.Name),
+			zap.String("env_file", path))
+
+		go func(c *managed.Client) {
+			ctx, cancel := context.WithTimeout(context.Background(), config.BatchOperationTimeout)
+			defer cancel()
+
+			_ = c.Disconnect()
+			if err := c.Connect(ctx); err != nil {
+				m.logger.Warn("Reconnect after env_file change failed",
+					zap.String("server", c.Config.Name),
+					zap.Error(err))
+			}
+		}(client)
+	}
+}
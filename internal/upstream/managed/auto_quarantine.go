@@ -0,0 +1,81 @@
+package managed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// SetAutoQuarantineCallback sets a callback invoked when the server is quarantined for failing
+// too often (auto_quarantine_after_failures)
+func (mc *Client) SetAutoQuarantineCallback(callback func(serverName string, reason string)) {
+	mc.onAutoQuarantine = callback
+}
+
+// stoppedForFailures reports whether the server was auto-disabled or auto-quarantined, in which
+// case it must not be reconnected until a user re-enables or unquarantines it
+func (mc *Client) stoppedForFailures() bool {
+	return mc.StateManager.IsAutoDisabled() || mc.StateManager.IsAutoQuarantined()
+}
+
+// isServerCallFailure reports whether a failed tool call says the server is unhealthy, so it
+// counts toward auto_quarantine_after_failures. Only connection and transport failures do:
+// errors about the call itself (invalid params, unknown tool), timeouts such as
+// max_call_duration and cancelled calls don't, so a client can't quarantine a healthy server
+// by sending bad or slow calls.
+func (mc *Client) isServerCallFailure(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return false
+	}
+	errStr := strings.ToLower(err.Error())
+	for _, callError := range []string{"timeout", "deadline exceeded", "context canceled"} {
+		if strings.Contains(errStr, callError) {
+			return false
+		}
+	}
+	return mc.isConnectionError(err)
+}
+
+// checkAndHandleAutoQuarantine quarantines the server once its consecutive connection or call
+// failures reach auto_quarantine_after_failures. It returns true if the server is auto-quarantined.
+func (mc *Client) checkAndHandleAutoQuarantine() bool {
+	if mc.StateManager.IsAutoQuarantined() {
+		return true
+	}
+	if mc.globalConfig == nil || mc.Config.IsQuarantined() {
+		return false
+	}
+
+	threshold := mc.globalConfig.AutoQuarantineAfterFailures
+	failures, quarantined := mc.StateManager.TryAutoQuarantine(threshold)
+	if !quarantined {
+		return false
+	}
+
+	reason := fmt.Sprintf("Server automatically quarantined after %d consecutive failures (threshold: %d)",
+		failures, threshold)
+
+	mc.logger.Warn("Server auto-quarantined due to consecutive failures",
+		zap.String("server", mc.Config.Name),
+		zap.Int("consecutive_failures", failures),
+		zap.Int("threshold", threshold),
+		zap.String("reason", reason))
+
+	// Disconnect in the background: this may run inside a tool call holding a call slot
+	go func() {
+		if err := mc.Disconnect(); err != nil {
+			mc.logger.Debug("Failed to disconnect auto-quarantined server",
+				zap.String("server", mc.Config.Name),
+				zap.Error(err))
+		}
+	}()
+
+	// Persist the quarantine and notify listeners
+	if mc.onAutoQuarantine != nil {
+		mc.onAutoQuarantine(mc.Config.Name, reason)
+	}
+	return true
+}
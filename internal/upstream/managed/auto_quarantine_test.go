@@ -0,0 +1,69 @@
+package managed
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
+)
+
+func TestCheckAndHandleAutoQuarantine(t *testing.T) {
+	globalConfig := config.DefaultConfig()
+	globalConfig.AutoQuarantineAfterFailures = 2
+
+	serverConfig := &config.ServerConfig{
+		Name:        "flaky-server",
+		URL:         "http://localhost:9999",
+		Protocol:    "http",
+		StartupMode: "active",
+	}
+	client, err := NewClient("flaky-server", serverConfig, zap.NewNop(), nil, globalConfig, nil)
+	require.NoError(t, err)
+
+	var quarantinedServer, quarantineReason string
+	client.SetAutoQuarantineCallback(func(serverName, reason string) {
+		quarantinedServer = serverName
+		quarantineReason = reason
+	})
+
+	client.StateManager.RecordCallFailure()
+	assert.False(t, client.checkAndHandleAutoQuarantine())
+	assert.Empty(t, quarantinedServer)
+
+	client.StateManager.RecordCallFailure()
+	assert.True(t, client.checkAndHandleAutoQuarantine())
+	assert.Equal(t, "flaky-server", quarantinedServer)
+	assert.Contains(t, quarantineReason, "after 2 consecutive failures")
+	assert.True(t, client.stoppedForFailures(), "auto-quarantined servers are not reconnected")
+
+	quarantinedServer = ""
+	assert.True(t, client.checkAndHandleAutoQuarantine())
+	assert.Empty(t, quarantinedServer, "the callback runs only once")
+}
+
+func TestIsServerCallFailure(t *testing.T) {
+	serverConfig := &config.ServerConfig{Name: "server", URL: "http://localhost:9999", Protocol: "http"}
+	client, err := NewClient("server", serverConfig, zap.NewNop(), nil, config.DefaultConfig(), nil)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	assert.True(t, client.isServerCallFailure(ctx, errors.New("dial tcp 127.0.0.1:9999: connect: connection refused")))
+	assert.True(t, client.isServerCallFailure(ctx, errors.New("SSE stream disconnected")))
+
+	// Errors a client can provoke with bad or slow calls don't count
+	assert.False(t, client.isServerCallFailure(ctx, errors.New("invalid params: missing 'repo'")))
+	assert.False(t, client.isServerCallFailure(ctx, errors.New("tool not found: delete_everything")))
+	assert.False(t, client.isServerCallFailure(ctx, context.DeadlineExceeded))
+	assert.False(t, client.isServerCallFailure(ctx, errors.New("request timeout after 2m0s")))
+
+	expired, cancel := context.WithTimeout(ctx, time.Nanosecond)
+	defer cancel()
+	<-expired.Done()
+	assert.False(t, client.isServerCallFailure(expired, errors.New("connection reset by peer")))
+}
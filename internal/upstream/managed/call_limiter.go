@@ -0,0 +1,54 @@
+package managed
+
+import (
+	"context"
+	"fmt"
+
+	"mcpproxy-go/internal/config"
+)
+
+// callLimiter is a semaphore bounding the number of in-flight tool calls to one server.
+// A nil limiter means unlimited.
+type callLimiter struct {
+	slots chan struct{}
+}
+
+// newCallLimiter returns a limiter allowing max concurrent calls, or nil when max <= 0
+func newCallLimiter(max int) *callLimiter {
+	if max <= 0 {
+		return nil
+	}
+	return &callLimiter{slots: make(chan struct{}, max)}
+}
+
+// acquire waits for a free slot until ctx is done. Calls without a deadline wait at most
+// config.CallQueueTimeout. The returned release func must be called when the call finishes.
+func (l *callLimiter) acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	// Fast path: a slot is free
+	select {
+	case l.slots <- struct{}{}:
+		return l.release, nil
+	default:
+	}
+
+	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.CallQueueTimeout)
+		defer cancel()
+	}
+
+	select {
+	case l.slots <- struct{}{}:
+		return l.release, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("timed out waiting for a free call slot: %w", ctx.Err())
+	}
+}
+
+func (l *callLimiter) release() {
+	<-l.slots
+}
@@ -0,0 +1,116 @@
+package managed
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
+)
+
+// newSlowMockServer starts an MCP server whose "slow" tool sleeps for delay and
+// records the peak number of concurrent invocations
+func newSlowMockServer(t *testing.T, delay time.Duration, peak *int32) string {
+	t.Helper()
+
+	var inFlight int32
+	mcpServer := server.NewMCPServer("slow-mock", "1.0.0", server.WithToolCapabilities(true))
+	mcpServer.AddTool(mcp.NewTool("slow"), func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			p := atomic.LoadInt32(peak)
+			if n <= p || atomic.CompareAndSwapInt32(peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(delay)
+		return mcp.NewToolResultText("done"), nil
+	})
+
+	ts := server.NewTestStreamableHTTPServer(mcpServer)
+	t.Cleanup(ts.Close)
+	return ts.URL
+}
+
+func connectMockClient(t *testing.T, url string, maxConcurrentCalls int) *Client {
+	t.Helper()
+
+	serverConfig := &config.ServerConfig{
+		Name:               "slow-mock",
+		URL:                url,
+		Protocol:           "streamable-http",
+		StartupMode:        "active",
+		MaxConcurrentCalls: maxConcurrentCalls,
+	}
+	mc, err := NewClient("slow-mock", serverConfig, zap.NewNop(), nil, config.DefaultConfig(), nil)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, mc.Connect(ctx))
+	t.Cleanup(func() { _ = mc.Disconnect() })
+	return mc
+}
+
+func TestCallToolRespectsMaxConcurrentCalls(t *testing.T) {
+	var peak int32
+	mc := connectMockClient(t, newSlowMockServer(t, 200*time.Millisecond, &peak), 2)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 6)
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_, err := mc.CallTool(ctx, "slow", nil)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&peak), "calls beyond the limit should queue")
+}
+
+func TestCallToolQueueTimeout(t *testing.T) {
+	var peak int32
+	mc := connectMockClient(t, newSlowMockServer(t, time.Second, &peak), 1)
+
+	// Occupy the only slot
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_, _ = mc.CallTool(ctx, "slow", nil)
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	_, err := mc.CallTool(ctx, "slow", nil)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "concurrent calls")
+}
+
+func TestCallLimiterUnlimited(t *testing.T) {
+	assert.Nil(t, newCallLimiter(0))
+
+	var l *callLimiter
+	release, err := l.acquire(context.Background())
+	require.NoError(t, err)
+	release()
+}
@@ -0,0 +1,75 @@
+package managed
+
+import (
+	"context"
+	"time"
+)
+
+// callRetryReconnectWait bounds how long a failed call waits for a reconnect before its retry
+const callRetryReconnectWait = 30 * time.Second
+
+// undeliveredCallErrors are errors raised before a request reached the upstream: the
+// connection could not be opened or reset, or the transport was closed before the write.
+// Timeouts and cancellations are missing on purpose, the upstream may have run those calls.
+var undeliveredCallErrors = []string{
+	"connection refused",
+	"ECONNREFUSED",
+	"no such host",
+	"network is unreachable",
+	"connection reset",
+	"failed to write request",
+	"client not started",
+	"transport not started",
+}
+
+// shouldRetryCall reports whether a failed call may be replayed: the request provably never
+// reached the upstream, the caller is still waiting and the server doesn't opt out
+func (mc *Client) shouldRetryCall(ctx context.Context, err error) bool {
+	if mc.Config.DisableCallRetry || ctx.Err() != nil {
+		return false
+	}
+	if mc.stoppedForFailures() || mc.StateManager.IsUserStopped() {
+		return false
+	}
+	return isUndeliveredCallError(err)
+}
+
+// isUndeliveredCallError reports whether err means the call was never sent to the upstream
+func isUndeliveredCallError(err error) bool {
+	if err == nil {
+		return false
+	}
+	errStr := err.Error()
+	for _, undelivered := range undeliveredCallErrors {
+		if containsString(errStr, undelivered) {
+			return true
+		}
+	}
+	return false
+}
+
+// reconnectForRetry waits until the client is ready for a call retry. A client that still
+// reports connected is retried as is, never torn down, since other calls may be running on
+// it; otherwise it is reconnected, or an ongoing reconnect is waited for.
+func (mc *Client) reconnectForRetry(ctx context.Context) bool {
+	if mc.IsConnected() {
+		return true
+	}
+	mc.tryReconnect()
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	timeout := time.NewTimer(callRetryReconnectWait)
+	defer timeout.Stop()
+
+	for !mc.IsConnected() {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-timeout.C:
+			return false
+		case <-ticker.C:
+		}
+	}
+	return true
+}
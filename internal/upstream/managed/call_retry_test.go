@@ -0,0 +1,49 @@
+package managed
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
+)
+
+func TestShouldRetryCall(t *testing.T) {
+	newClient := func(disableRetry bool) *Client {
+		serverConfig := &config.ServerConfig{
+			Name:             "test-server",
+			URL:              "http://localhost:9999",
+			Protocol:         "http",
+			StartupMode:      "active",
+			DisableCallRetry: disableRetry,
+		}
+		client, err := NewClient("test-server", serverConfig, zap.NewNop(), nil, config.DefaultConfig(), nil)
+		require.NoError(t, err)
+		return client
+	}
+	connErr := errors.New("transport error: connection reset by peer")
+
+	client := newClient(false)
+	assert.True(t, client.shouldRetryCall(context.Background(), connErr))
+	assert.False(t, client.shouldRetryCall(context.Background(), errors.New("invalid params: missing 'repo'")),
+		"tool errors are not retried")
+	assert.True(t, client.shouldRetryCall(context.Background(), errors.New("dial tcp 127.0.0.1:9999: connect: connection refused")))
+	assert.True(t, client.shouldRetryCall(context.Background(), errors.New("transport error: failed to write request: write |1: broken pipe")))
+	for _, delivered := range []string{"context deadline exceeded", "request timeout after 2m0s", "context canceled", "SSE stream disconnected"} {
+		assert.False(t, client.shouldRetryCall(context.Background(), errors.New(delivered)),
+			"%s: the upstream may have run the call", delivered)
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.False(t, client.shouldRetryCall(canceled, connErr), "calls whose caller gave up are not retried")
+
+	client.StateManager.SetUserStopped(true)
+	assert.False(t, client.shouldRetryCall(context.Background(), connErr), "stopped servers are not reconnected")
+
+	assert.False(t, newClient(true).shouldRetryCall(context.Background(), connErr), "disable_call_retry opts out")
+}
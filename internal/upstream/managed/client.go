@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"mcpproxy-go/internal/config"
@@ -40,6 +41,12 @@ type Client struct {
 	// Tool caching
 	toolCache *ToolCache
 
+	// Per-server concurrent call limit (nil = unlimited)
+	callLimiter *callLimiter
+
+	// Calls in progress, so an idle disconnect never closes a busy connection
+	inFlight atomic.Int32
+
 	// Background monitoring
 	stopMonitoring chan struct{}
 	monitoringWG   sync.WaitGroup
@@ -47,14 +54,25 @@ type Client struct {
 	// Reconnection protection
 	reconnectMu         sync.Mutex
 
-	// Auto-disable callback
-	onAutoDisable func(serverName string, reason string)
+	// Auto-disable and auto-quarantine callbacks
+	onAutoDisable       func(serverName string, reason string)
+	onAutoQuarantine    func(serverName string, reason string)
 	reconnectInProgress bool
 
+	// Restart supervision for crashed stdio subprocesses (restart_on_exit)
+	onRestart         func(serverName string, attempt, maxRestarts int)
+	restartMu         sync.Mutex
+	restartInProgress bool
+
 	// Intentional disconnect flag - prevents auto-reconnection when Disconnect() is called explicitly
 	// This distinguishes between user/manager-initiated disconnects vs unexpected process crashes
 	intentionalDisconnect bool
 	intentionalMu         sync.RWMutex
+
+	// Initialize result of the last successful handshake, kept after disconnects for debugging
+	initResult    *mcp.InitializeResult
+	initializedAt time.Time
+	initMu        sync.RWMutex
 }
 
 // NewClient creates a new managed client with state management
@@ -82,6 +100,7 @@ func NewClient(id string, serverConfig *config.ServerConfig, logger *zap.Logger,
 		globalConfig:   globalConfig,
 		storage:        storage,
 		toolCache:      NewToolCache(cacheTTL),
+		callLimiter:    newCallLimiter(serverConfig.MaxConcurrentCalls),
 		stopMonitoring: make(chan struct{}),
 	}
 
@@ -181,6 +200,9 @@ func (mc *Client) Connect(ctx context.Context) error {
 	// Transition to ready state only if not already ready
 	if mc.StateManager.GetState() != types.StateReady {
 		mc.StateManager.TransitionTo(types.StateReady)
+	} else {
+		// TransitionTo(Ready) clears last_error; do it explicitly when we were already Ready
+		mc.StateManager.ClearError()
 	}
 
 	// FIX: Explicitly reset consecutive failures on successful connection
@@ -193,6 +215,11 @@ func (mc *Client) Connect(ctx context.Context) error {
 	// Update state manager with server info
 	if serverInfo := mc.coreClient.GetServerInfo(); serverInfo != nil {
 		mc.StateManager.SetServerInfo(serverInfo.ServerInfo.Name, serverInfo.ServerInfo.Version)
+
+		mc.initMu.Lock()
+		mc.initResult = serverInfo
+		mc.initializedAt = time.Now()
+		mc.initMu.Unlock()
 	}
 
 	// Update connection history for prioritization
@@ -232,6 +259,11 @@ func (mc *Client) Connect(ctx context.Context) error {
 	mc.logger.Info("Successfully established managed connection",
 		zap.String("server", mc.Config.Name))
 
+	// A fresh connection counts as a passed health check until the next periodic check
+	if mc.Config.HealthCheck {
+		mc.StateManager.RecordHealthCheck(nil)
+	}
+
 	// Add a small delay before starting background monitoring to let connection stabilize
 	mc.logger.Debug("🔍 Adding stabilization delay before starting background monitoring",
 		zap.String("server", mc.Config.Name))
@@ -298,6 +330,20 @@ func (mc *Client) GetServerInfo() *mcp.InitializeResult {
 	return mc.coreClient.GetServerInfo()
 }
 
+// LastInitializeResult returns the initialize result of the last successful handshake and when
+// it happened. Unlike GetServerInfo it never blocks on a connect in progress and still answers
+// after the server disconnected. It returns nil if the server never completed a handshake.
+func (mc *Client) LastInitializeResult() (*mcp.InitializeResult, time.Time) {
+	mc.initMu.RLock()
+	defer mc.initMu.RUnlock()
+	return mc.initResult, mc.initializedAt
+}
+
+// RefreshOAuthToken proactively refreshes the server's OAuth token and returns the new expiry
+func (mc *Client) RefreshOAuthToken(ctx context.Context) (time.Time, error) {
+	return mc.coreClient.RefreshOAuthToken(ctx)
+}
+
 // GetLastError returns the last error from the state manager
 func (mc *Client) GetLastError() error {
 	info := mc.StateManager.GetConnectionInfo()
@@ -338,9 +384,54 @@ func (mc *Client) GetConnectionStatus() map[string]interface{} {
 		status["connected_at"] = info.ConnectedAt
 	}
 
+	if !info.LastSuccessfulCall.IsZero() {
+		status["last_successful_call"] = info.LastSuccessfulCall
+	}
+
+	if mc.StateManager.IsSleeping() {
+		status["sleeping"] = true
+	}
+
 	return status
 }
 
+// beginCall records the start of a call for idle tracking and returns the function that
+// ends it
+func (mc *Client) beginCall() func() {
+	mc.StateManager.RecordActivity()
+	mc.inFlight.Add(1)
+	return func() { mc.inFlight.Add(-1) }
+}
+
+// SleepIfIdle disconnects the server when its connection has been unused for the server's
+// idle_disconnect_after and no call is in progress. The server then sleeps: it is not
+// reconnected in the background, and the next call reconnects it. It reports whether the
+// server was put to sleep.
+func (mc *Client) SleepIfIdle(now time.Time) bool {
+	idleAfter := mc.Config.IdleDisconnectAfter.Duration()
+	if idleAfter <= 0 || mc.StateManager.IsSleeping() || mc.inFlight.Load() > 0 {
+		return false
+	}
+
+	idleSince := mc.StateManager.IdleSince()
+	if idleSince.IsZero() || now.Sub(idleSince) < idleAfter {
+		return false
+	}
+
+	mc.logger.Info("Disconnecting idle server, it reconnects on the next call",
+		zap.String("server", mc.Config.Name),
+		zap.Duration("idle_for", now.Sub(idleSince).Round(time.Second)),
+		zap.Duration("idle_disconnect_after", idleAfter))
+
+	mc.StateManager.SetSleeping(true)
+	if err := mc.Disconnect(); err != nil {
+		mc.logger.Warn("Failed to disconnect idle server",
+			zap.String("server", mc.Config.Name),
+			zap.Error(err))
+	}
+	return true
+}
+
 // GetEnvManager returns the environment manager for testing purposes
 func (mc *Client) GetEnvManager() interface{} {
 	// This is a wrapper method to access the core client's environment manager
@@ -456,25 +547,148 @@ func (mc *Client) ListTools(ctx context.Context) ([]*config.ToolMetadata, error)
 	return tools, nil
 }
 
+// ToolAnnotations returns the MCP annotations the server lists for toolName, or empty
+// annotations if the tool isn't known
+func (mc *Client) ToolAnnotations(ctx context.Context, toolName string) mcp.ToolAnnotation {
+	return mc.coreClient.ToolAnnotations(ctx, toolName)
+}
+
+// ListResources retrieves the resources advertised by the upstream server
+func (mc *Client) ListResources(ctx context.Context) ([]*config.ResourceMetadata, error) {
+	if !mc.IsConnected() {
+		return nil, fmt.Errorf("client not connected (state: %s)", mc.StateManager.GetState().String())
+	}
+
+	listCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	resources, err := mc.coreClient.ListResources(listCtx)
+	if err != nil {
+		if mc.isConnectionError(err) {
+			mc.StateManager.SetError(err)
+		}
+		return nil, fmt.Errorf("ListResources failed: %w", err)
+	}
+	return resources, nil
+}
+
+// ReadResource reads a resource from the upstream server using its unprefixed URI
+func (mc *Client) ReadResource(ctx context.Context, uri string) (*mcp.ReadResourceResult, error) {
+	if !mc.IsConnected() {
+		return nil, fmt.Errorf("client not connected (state: %s)", mc.StateManager.GetState().String())
+	}
+
+	defer mc.beginCall()()
+
+	result, err := mc.coreClient.ReadResource(ctx, uri)
+	if err != nil {
+		if mc.isConnectionError(err) {
+			mc.StateManager.SetError(err)
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListPrompts retrieves the prompts advertised by the upstream server
+func (mc *Client) ListPrompts(ctx context.Context) ([]*config.PromptMetadata, error) {
+	if !mc.IsConnected() {
+		return nil, fmt.Errorf("client not connected (state: %s)", mc.StateManager.GetState().String())
+	}
+
+	listCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	prompts, err := mc.coreClient.ListPrompts(listCtx)
+	if err != nil {
+		if mc.isConnectionError(err) {
+			mc.StateManager.SetError(err)
+		}
+		return nil, fmt.Errorf("ListPrompts failed: %w", err)
+	}
+	return prompts, nil
+}
+
+// GetPrompt renders a prompt on the upstream server using its unprefixed name
+func (mc *Client) GetPrompt(ctx context.Context, name string, args map[string]string) (*mcp.GetPromptResult, error) {
+	if !mc.IsConnected() {
+		return nil, fmt.Errorf("client not connected (state: %s)", mc.StateManager.GetState().String())
+	}
+
+	defer mc.beginCall()()
+
+	result, err := mc.coreClient.GetPrompt(ctx, name, args)
+	if err != nil {
+		if mc.isConnectionError(err) {
+			mc.StateManager.SetError(err)
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
 // CallTool executes a tool with error handling
 func (mc *Client) CallTool(ctx context.Context, toolName string, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	if !mc.IsConnected() {
 		return nil, fmt.Errorf("client not connected (state: %s)", mc.StateManager.GetState().String())
 	}
 
-	result, err := mc.coreClient.CallTool(ctx, toolName, args)
+	defer mc.beginCall()()
+
+	// Queue behind in-flight calls when max_concurrent_calls is set
+	release, err := mc.callLimiter.acquire(ctx)
+	if err != nil {
+		mc.logger.Warn("Tool call timed out waiting for a free call slot",
+			logs.CorrelationField(ctx),
+			zap.String("server", mc.Config.Name),
+			zap.String("tool", toolName),
+			zap.Int("max_concurrent_calls", mc.Config.MaxConcurrentCalls))
+		return nil, fmt.Errorf("server %s is at its limit of %d concurrent calls: %w", mc.Config.Name, mc.Config.MaxConcurrentCalls, err)
+	}
+	defer release()
+
+	callArgs := args
+	if len(mc.Config.DefaultArgs) > 0 {
+		callArgs = mc.Config.MergeDefaultArgs(args, mc.coreClient.ToolParamsJSON(ctx, toolName))
+	}
+	result, err := mc.coreClient.CallTool(ctx, toolName, callArgs)
+
+	// A call that never reached the upstream (e.g. it was restarting) is replayed once, after
+	// reconnecting if the client lost its connection; a failed replay is returned like any other error
+	if err != nil && mc.shouldRetryCall(ctx, err) {
+		mc.logger.Warn("Tool call was not delivered, retrying once",
+			logs.CorrelationField(ctx),
+			zap.String("server", mc.Config.Name),
+			zap.String("tool", toolName),
+			zap.Error(err))
+
+		if !mc.reconnectForRetry(ctx) {
+			return nil, fmt.Errorf("%w (reconnect before retry failed, state: %s)", err, mc.StateManager.GetState().String())
+		}
+
+		result, err = mc.coreClient.CallTool(ctx, toolName, callArgs)
+		if err == nil {
+			mc.logger.Info("Tool call succeeded after reconnecting",
+				logs.CorrelationField(ctx),
+				zap.String("server", mc.Config.Name),
+				zap.String("tool", toolName))
+		}
+	}
+
 	if err != nil {
 		// Check if it's a connection error and update state
 		if mc.isConnectionError(err) {
 			// Use different log levels based on error type
 			if mc.isNormalReconnectionError(err) {
 				mc.logger.Warn("Tool call failed due to connection loss, will attempt reconnection",
+					logs.CorrelationField(ctx),
 					zap.String("server", mc.Config.Name),
 					zap.String("tool", toolName),
 					zap.String("error_type", "normal_reconnection"),
 					zap.Error(err))
 			} else {
 				mc.logger.Error("Tool call failed with connection error",
+					logs.CorrelationField(ctx),
 					zap.String("server", mc.Config.Name),
 					zap.String("tool", toolName),
 					zap.Error(err))
@@ -483,13 +697,25 @@ func (mc *Client) CallTool(ctx context.Context, toolName string, args map[string
 		} else {
 			// Log non-connection errors at error level
 			mc.logger.Error("Tool call failed",
+				logs.CorrelationField(ctx),
 				zap.String("server", mc.Config.Name),
 				zap.String("tool", toolName),
 				zap.Error(err))
 		}
+
+		// Only connection and transport failures say something about the server's health
+		if mc.isServerCallFailure(ctx, err) {
+			mc.StateManager.RecordCallFailure()
+			mc.checkAndHandleAutoQuarantine()
+		}
 		return nil, err
 	}
 
+	// Error results still prove the server responds, but only clean results count as success
+	if result != nil && !result.IsError {
+		mc.StateManager.RecordSuccessfulCall()
+	}
+
 	return result, nil
 }
 
@@ -529,7 +755,7 @@ func (mc *Client) onStateChange(oldState, newState types.ConnectionState, info *
 
 		// If not auto-disabled, attempt immediate reconnection instead of waiting for health check
 		// This reduces the delay between error detection and reconnection attempt
-		if !mc.StateManager.IsAutoDisabled() && mc.ShouldRetry() {
+		if !mc.stoppedForFailures() && mc.ShouldRetry() {
 			mc.logger.Info("Triggering immediate reconnection after error",
 				zap.String("server", mc.Config.Name),
 				zap.Int("retry_count", info.RetryCount))
@@ -570,8 +796,10 @@ func (mc *Client) onStateChange(oldState, newState types.ConnectionState, info *
 			// Check and handle auto-disable
 			mc.checkAndHandleAutoDisable()
 
-			// If not auto-disabled, attempt immediate reconnection
-			if !mc.StateManager.IsAutoDisabled() && mc.ShouldRetry() {
+			// If not auto-disabled, relaunch under supervision or attempt immediate reconnection
+			if !mc.stoppedForFailures() && mc.shouldSuperviseRestart() {
+				go mc.superviseRestart()
+			} else if !mc.stoppedForFailures() && mc.ShouldRetry() {
 				mc.logger.Info("Triggering immediate reconnection after disconnect",
 					zap.String("server", mc.Config.Name))
 				go mc.tryReconnect()
@@ -625,6 +853,11 @@ func (mc *Client) backgroundHealthCheck() {
 // checkAndHandleAutoDisable checks if server should be auto-disabled and handles it immediately
 // This is called after each connection failure to prevent excessive retries
 func (mc *Client) checkAndHandleAutoDisable() {
+	// A lower auto_quarantine_after_failures threshold takes the server out of service first
+	if mc.checkAndHandleAutoQuarantine() {
+		return
+	}
+
 	info := mc.StateManager.GetConnectionInfo()
 
 	// Enhanced logging for debugging connection issues
@@ -733,12 +966,8 @@ func (mc *Client) performHealthCheck() {
 	// Check if server should be auto-disabled (using shared helper)
 	mc.checkAndHandleAutoDisable()
 
-	if mc.StateManager.IsAutoDisabled() {
-		return
-	}
-
-	// Skip health checks if server is already auto-disabled
-	if mc.StateManager.IsAutoDisabled() {
+	// Skip health checks if server is already auto-disabled or auto-quarantined
+	if mc.stoppedForFailures() {
 		return
 	}
 
@@ -818,6 +1047,9 @@ func (mc *Client) performHealthCheck() {
 	_, err := mc.coreClient.ListTools(ctx)
 	mc.listToolsInProgress = false // Reset the flag
 
+	// Record every outcome, timeouts included, so failing servers show up in health_check_failures
+	mc.StateManager.RecordHealthCheck(err)
+
 	if err != nil {
 		// Only mark as error if it's a real connection issue, not timeout during high activity
 		if mc.isConnectionError(err) {
@@ -1070,6 +1302,11 @@ func (mc *Client) IsDockerCommand() bool {
 	return mc.isDockerServer()
 }
 
+// GetContainerID returns the ID of the Docker container running this server, if known
+func (mc *Client) GetContainerID() string {
+	return mc.coreClient.GetContainerID()
+}
+
 // isDockerServer checks if the server is running via Docker
 func (mc *Client) isDockerServer() bool {
 	return containsString(mc.Config.Command, "docker")
@@ -0,0 +1,57 @@
+package managed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
+	"mcpproxy-go/internal/upstream/types"
+)
+
+func newIdleTestClient(t *testing.T, idleAfter time.Duration) *Client {
+	t.Helper()
+
+	serverConfig := &config.ServerConfig{
+		Name:                "test-server",
+		URL:                 "http://localhost:9999",
+		Protocol:            "http",
+		StartupMode:         "active",
+		IdleDisconnectAfter: config.Duration(idleAfter),
+	}
+	client, err := NewClient("test-server", serverConfig, zap.NewNop(), nil, config.DefaultConfig(), nil)
+	require.NoError(t, err)
+
+	client.StateManager.TransitionTo(types.StateConnecting)
+	client.StateManager.TransitionTo(types.StateReady)
+	return client
+}
+
+func TestSleepIfIdle(t *testing.T) {
+	client := newIdleTestClient(t, time.Minute)
+	now := time.Now()
+
+	assert.False(t, client.SleepIfIdle(now), "recently connected server is not idle")
+
+	done := client.beginCall()
+	assert.False(t, client.SleepIfIdle(now.Add(time.Hour)), "server with a call in flight is not idle")
+	done()
+
+	assert.True(t, client.SleepIfIdle(now.Add(time.Hour)))
+	assert.True(t, client.StateManager.IsSleeping())
+	assert.False(t, client.IsConnected())
+	assert.Equal(t, true, client.GetConnectionStatus()["sleeping"])
+
+	assert.False(t, client.SleepIfIdle(now.Add(2*time.Hour)), "sleeping server is not put to sleep again")
+}
+
+func TestSleepIfIdle_Disabled(t *testing.T) {
+	client := newIdleTestClient(t, 0)
+
+	assert.False(t, client.SleepIfIdle(time.Now().Add(24*time.Hour)))
+	assert.False(t, client.StateManager.IsSleeping())
+	assert.True(t, client.IsConnected())
+}
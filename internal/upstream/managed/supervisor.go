@@ -0,0 +1,88 @@
+package managed
+
+import (
+	"time"
+
+	"mcpproxy-go/internal/config"
+	"mcpproxy-go/internal/transport"
+	"mcpproxy-go/internal/upstream/types"
+
+	"go.uber.org/zap"
+)
+
+// SetRestartCallback sets a callback invoked before each supervised restart of a crashed subprocess
+func (mc *Client) SetRestartCallback(callback func(serverName string, attempt, maxRestarts int)) {
+	mc.onRestart = callback
+}
+
+// shouldSuperviseRestart reports whether an unexpected exit should be handled by
+// the restart supervisor instead of the regular reconnection logic
+func (mc *Client) shouldSuperviseRestart() bool {
+	return mc.Config.RestartOnExit && transport.DetermineTransportType(mc.Config) == transport.TransportStdio
+}
+
+// restartBackoff returns the delay before the given (1-based) restart attempt
+func restartBackoff(attempt int) time.Duration {
+	delay := config.InitialBackoffDelay
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= config.MaxBackoffDelay {
+			return config.MaxBackoffDelay
+		}
+	}
+	return delay
+}
+
+// superviseRestart relaunches a crashed stdio subprocess with exponential backoff,
+// up to MaxRestarts attempts. If all attempts fail, recovery is left to the
+// periodic health check.
+func (mc *Client) superviseRestart() {
+	mc.restartMu.Lock()
+	if mc.restartInProgress {
+		mc.restartMu.Unlock()
+		return
+	}
+	mc.restartInProgress = true
+	mc.restartMu.Unlock()
+
+	defer func() {
+		mc.restartMu.Lock()
+		mc.restartInProgress = false
+		mc.restartMu.Unlock()
+	}()
+
+	maxRestarts := mc.Config.GetMaxRestarts()
+	for attempt := 1; attempt <= maxRestarts; attempt++ {
+		time.Sleep(restartBackoff(attempt))
+
+		// Stop supervising if the server was stopped, auto-disabled, auto-quarantined or recovered in the meantime
+		mc.intentionalMu.RLock()
+		wasIntentional := mc.intentionalDisconnect
+		mc.intentionalMu.RUnlock()
+		if wasIntentional || mc.stoppedForFailures() || mc.StateManager.GetState() == types.StateReady {
+			return
+		}
+
+		mc.logger.Warn("Restarting crashed server subprocess",
+			zap.String("server", mc.Config.Name),
+			zap.Int("attempt", attempt),
+			zap.Int("max_restarts", maxRestarts))
+
+		if mc.onRestart != nil {
+			mc.onRestart(mc.Config.Name, attempt, maxRestarts)
+		}
+
+		mc.tryReconnect()
+
+		if mc.StateManager.GetState() == types.StateReady {
+			mc.logger.Info("Crashed server subprocess restarted successfully",
+				zap.String("server", mc.Config.Name),
+				zap.Int("attempt", attempt))
+			return
+		}
+	}
+
+	mc.logger.Warn("Restart attempts exhausted, falling back to periodic reconnection",
+		zap.String("server", mc.Config.Name),
+		zap.Int("max_restarts", maxRestarts))
+}
@@ -0,0 +1,43 @@
+package managed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"mcpproxy-go/internal/config"
+)
+
+func TestRestartBackoff(t *testing.T) {
+	assert.Equal(t, 1*time.Second, restartBackoff(1))
+	assert.Equal(t, 2*time.Second, restartBackoff(2))
+	assert.Equal(t, 4*time.Second, restartBackoff(3))
+	assert.Equal(t, 16*time.Second, restartBackoff(5))
+	assert.Equal(t, config.MaxBackoffDelay, restartBackoff(6))
+	assert.Equal(t, config.MaxBackoffDelay, restartBackoff(20))
+}
+
+func TestShouldSuperviseRestart(t *testing.T) {
+	tests := []struct {
+		name     string
+		server   *config.ServerConfig
+		expected bool
+	}{
+		{name: "stdio with restart_on_exit", server: &config.ServerConfig{Command: "npx", RestartOnExit: true}, expected: true},
+		{name: "stdio without restart_on_exit", server: &config.ServerConfig{Command: "npx"}, expected: false},
+		{name: "http with restart_on_exit", server: &config.ServerConfig{URL: "http://localhost:9999", Protocol: "http", RestartOnExit: true}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := &Client{Config: tt.server}
+			assert.Equal(t, tt.expected, mc.shouldSuperviseRestart())
+		})
+	}
+}
+
+func TestGetMaxRestartsDefault(t *testing.T) {
+	assert.Equal(t, config.DefaultMaxRestarts, (&config.ServerConfig{}).GetMaxRestarts())
+	assert.Equal(t, 2, (&config.ServerConfig{MaxRestarts: 2}).GetMaxRestarts())
+}
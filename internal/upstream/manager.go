@@ -8,6 +8,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/fsnotify/fsnotify"
+	"github.com/mark3labs/mcp-go/mcp"
 	"go.uber.org/zap"
 
 	"mcpproxy-go/internal/config"
@@ -40,6 +42,21 @@ type Manager struct {
 
 	// onServerAutoDisable callback to notify server when a server is auto-disabled
 	onServerAutoDisable func(serverName string, reason string)
+
+	// onServerAutoQuarantine callback to notify server when a server is auto-quarantined
+	onServerAutoQuarantine func(serverName string, reason string)
+
+	// onToolCall is notified of every tool call made through CallTool (e.g. for the audit log)
+	onToolCall ToolCallObserver
+
+	// oauthFlows tracks the latest headless OAuth login per server
+	oauthFlows   map[string]*OAuthFlow
+	oauthFlowsMu sync.Mutex
+
+	// envWatcher watches the directories of env_file settings; envReloads debounces reconnects per file
+	envWatcher *fsnotify.Watcher
+	envReloads map[string]*time.Timer
+	envWatchMu sync.Mutex
 }
 
 // NewManager creates a new upstream manager
@@ -51,6 +68,8 @@ func NewManager(logger *zap.Logger, globalConfig *config.Config, storage *storag
 		storage:         storage,
 		notificationMgr: NewNotificationManager(),
 		tokenReconnect:  make(map[string]time.Time),
+		oauthFlows:      make(map[string]*OAuthFlow),
+		envReloads:      make(map[string]*time.Timer),
 	}
 
 	// Set up OAuth completion callback to trigger connection retries (in-process)
@@ -68,6 +87,8 @@ func NewManager(logger *zap.Logger, globalConfig *config.Config, storage *storag
 	// Start database event monitor for cross-process OAuth completion notifications
 	if storage != nil {
 		go manager.startOAuthEventMonitor()
+		// Refresh OAuth tokens before they expire instead of waiting for a 401
+		go manager.startOAuthRefreshMonitor()
 	}
 
 	// Start health check monitor for servers with health_check enabled
@@ -103,6 +124,22 @@ func (m *Manager) SetServerAutoDisableCallback(callback func(serverName string,
 	m.onServerAutoDisable = callback
 }
 
+// SetServerAutoQuarantineCallback sets the callback to be invoked when a server is quarantined
+// for failing too often (auto_quarantine_after_failures)
+func (m *Manager) SetServerAutoQuarantineCallback(callback func(serverName string, reason string)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onServerAutoQuarantine = callback
+}
+
+// SetToolCallObserver sets the callback invoked after every tool call made through CallTool,
+// whether it succeeded, failed upstream or never reached the server
+func (m *Manager) SetToolCallObserver(observer ToolCallObserver) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onToolCall = observer
+}
+
 // SetStorageManager sets the storage manager for persisting state changes
 func (m *Manager) SetStorageManager(storageManager *storage.Manager) {
 	m.mu.Lock()
@@ -117,6 +154,7 @@ func (m *Manager) AddServerConfig(id string, serverConfig *config.ServerConfig)
 	defer m.mu.Unlock()
 
 	// Check if existing client exists and if config has changed
+	var replacedEnvFile string
 	if existingClient, exists := m.clients[id]; exists {
 		existingConfig := existingClient.Config
 
@@ -126,6 +164,8 @@ func (m *Manager) AddServerConfig(id string, serverConfig *config.ServerConfig)
 			existingConfig.Command != serverConfig.Command ||
 			!equalStringSlices(existingConfig.Args, serverConfig.Args) ||
 			!equalStringMaps(existingConfig.Env, serverConfig.Env) ||
+			existingConfig.EnvFile != serverConfig.EnvFile ||
+			existingConfig.Shell != serverConfig.Shell ||
 			!equalStringMaps(existingConfig.Headers, serverConfig.Headers) ||
 			existingConfig.StartupMode != serverConfig.StartupMode
 
@@ -137,6 +177,7 @@ func (m *Manager) AddServerConfig(id string, serverConfig *config.ServerConfig)
 				zap.Bool("is_connected", existingClient.IsConnected()))
 			_ = existingClient.Disconnect()
 			delete(m.clients, id)
+			replacedEnvFile = existingConfig.GetEnvFilePath()
 		} else {
 			m.logger.Debug("Server configuration unchanged, keeping existing client",
 				zap.String("id", id),
@@ -179,6 +220,9 @@ func (m *Manager) AddServerConfig(id string, serverConfig *config.ServerConfig)
 		zap.String("server", serverConfig.Name),
 		zap.Int("threshold", threshold))
 
+	// Configure per-server reconnect backoff from the global settings
+	client.StateManager.SetReconnectBackoff(m.globalConfig.GetReconnectBackoff())
+
 	// Restore auto-disable state from config (if server was previously auto-disabled)
 	if serverConfig.StartupMode == "auto_disabled" {
 		client.StateManager.SetAutoDisabled("Restored from config")
@@ -228,6 +272,49 @@ func (m *Manager) AddServerConfig(id string, serverConfig *config.ServerConfig)
 		})
 	}
 
+	// Persist auto-quarantines through the server and publish an event for each
+	client.SetAutoQuarantineCallback(func(serverName string, reason string) {
+		m.mu.RLock()
+		eventBus := m.eventBus
+		onAutoQuarantine := m.onServerAutoQuarantine
+		m.mu.RUnlock()
+
+		if onAutoQuarantine != nil {
+			onAutoQuarantine(serverName, reason)
+		}
+		if eventBus != nil {
+			eventBus.Publish(events.Event{
+				Type:       events.ServerAutoQuarantined,
+				ServerName: serverName,
+				NewState:   "quarantined",
+				Data: map[string]interface{}{
+					"reason": reason,
+				},
+				Timestamp: time.Now(),
+			})
+		}
+	})
+
+	// Publish an event for each supervised restart of a crashed stdio subprocess
+	client.SetRestartCallback(func(serverName string, attempt, maxRestarts int) {
+		m.mu.RLock()
+		eventBus := m.eventBus
+		m.mu.RUnlock()
+
+		if eventBus != nil {
+			eventBus.Publish(events.Event{
+				Type:       events.ServerRestarted,
+				ServerName: serverName,
+				NewState:   "restarting",
+				Data: map[string]interface{}{
+					"attempt":      attempt,
+					"max_restarts": maxRestarts,
+				},
+				Timestamp: time.Now(),
+			})
+		}
+	})
+
 	// Set storage manager for persisting state changes
 	if m.storageManager != nil {
 		client.SetStorageManager(m.storageManager)
@@ -235,11 +322,17 @@ func (m *Manager) AddServerConfig(id string, serverConfig *config.ServerConfig)
 			zap.String("server", serverConfig.Name))
 	}
 
+	// Reconnect the server when its env_file changes
+	m.watchEnvFile(serverConfig)
+
 	m.clients[id] = client
 	m.logger.Info("Added upstream server configuration",
 		zap.String("id", id),
 		zap.String("name", serverConfig.Name))
 
+	// Stop watching the previous env_file if no server reads it anymore
+	m.unwatchEnvFile(replacedEnvFile)
+
 	return nil
 }
 
@@ -341,6 +434,7 @@ func (m *Manager) RemoveServer(id string) {
 			zap.String("state", client.GetState().String()))
 		// Remove from map immediately to prevent further operations
 		delete(m.clients, id)
+		m.unwatchEnvFile(client.Config.GetEnvFilePath())
 		// Disconnect asynchronously to avoid blocking if connection is in progress
 		// This prevents 30s delay when removing a connecting server
 		go func(c *managed.Client) {
@@ -386,9 +480,7 @@ func (m *Manager) DiscoverTools(ctx context.Context) ([]*config.ToolMetadata, er
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	var allTools []*config.ToolMetadata
-	connectedCount := 0
-
+	listers := make(map[string]toolLister)
 	for id, client := range m.clients {
 		if client.Config.IsDisabled() {
 			continue
@@ -397,30 +489,160 @@ func (m *Manager) DiscoverTools(ctx context.Context) ([]*config.ToolMetadata, er
 			m.logger.Debug("Skipping disconnected client", zap.String("id", id), zap.String("state", client.GetState().String()))
 			continue
 		}
-		connectedCount++
+		listers[id] = client
+	}
 
-		tools, err := client.ListTools(ctx)
-		if err != nil {
-			m.logger.Error("Failed to list tools from client",
-				zap.String("id", id),
-				zap.Error(err))
+	// Bound concurrent ListTools calls so large configs don't spike CPU and network at boot
+	maxConcurrent := m.globalConfig.GetMaxConcurrentDiscovery()
+	allTools := listToolsConcurrently(ctx, listers, maxConcurrent, func(id string, err error) {
+		m.logger.Error("Failed to list tools from client",
+			zap.String("id", id),
+			zap.Error(err))
+	})
+
+	m.logger.Info("Discovered tools from upstream servers",
+		zap.Int("total_tools", len(allTools)),
+		zap.Int("connected_servers", len(listers)),
+		zap.Int("max_concurrent", maxConcurrent))
+
+	return allTools, nil
+}
+
+// DiscoverResources discovers all resources from all connected upstream servers
+func (m *Manager) DiscoverResources(ctx context.Context) ([]*config.ResourceMetadata, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	listers := make(map[string]resourceLister)
+	for id, client := range m.clients {
+		if client.Config.IsDisabled() || !client.IsConnected() {
 			continue
 		}
+		listers[id] = client
+	}
+
+	maxConcurrent := m.globalConfig.GetMaxConcurrentDiscovery()
+	allResources := listResourcesConcurrently(ctx, listers, maxConcurrent, func(id string, err error) {
+		m.logger.Warn("Failed to list resources from client",
+			zap.String("id", id),
+			zap.Error(err))
+	})
+
+	m.logger.Info("Discovered resources from upstream servers",
+		zap.Int("total_resources", len(allResources)),
+		zap.Int("connected_servers", len(listers)))
+
+	return allResources, nil
+}
+
+// ReadResource reads a resource from the owning upstream server. The URI uses the same
+// server:uri prefixing scheme as tool names.
+func (m *Manager) ReadResource(ctx context.Context, prefixedURI string) (*mcp.ReadResourceResult, error) {
+	parts := strings.SplitN(prefixedURI, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid resource URI format: %s (expected server:uri)", prefixedURI)
+	}
 
-		if tools != nil {
-			allTools = append(allTools, tools...)
+	client, err := m.connectedClient(ctx, parts[0])
+	if err != nil {
+		return nil, err
+	}
+	return client.ReadResource(ctx, parts[1])
+}
+
+// DiscoverPrompts discovers all prompts from all connected upstream servers
+func (m *Manager) DiscoverPrompts(ctx context.Context) ([]*config.PromptMetadata, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	listers := make(map[string]promptLister)
+	for id, client := range m.clients {
+		if client.Config.IsDisabled() || !client.IsConnected() {
+			continue
 		}
+		listers[id] = client
 	}
 
-	m.logger.Info("Discovered tools from upstream servers",
-		zap.Int("total_tools", len(allTools)),
-		zap.Int("connected_servers", connectedCount))
+	maxConcurrent := m.globalConfig.GetMaxConcurrentDiscovery()
+	allPrompts := listPromptsConcurrently(ctx, listers, maxConcurrent, func(id string, err error) {
+		m.logger.Warn("Failed to list prompts from client",
+			zap.String("id", id),
+			zap.Error(err))
+	})
 
-	return allTools, nil
+	m.logger.Info("Discovered prompts from upstream servers",
+		zap.Int("total_prompts", len(allPrompts)),
+		zap.Int("connected_servers", len(listers)))
+
+	return allPrompts, nil
+}
+
+// GetPrompt renders a prompt on the owning upstream server. The name uses the same
+// server:prompt prefixing scheme as tool names.
+func (m *Manager) GetPrompt(ctx context.Context, prefixedName string, args map[string]string) (*mcp.GetPromptResult, error) {
+	parts := strings.SplitN(prefixedName, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid prompt name format: %s (expected server:prompt)", prefixedName)
+	}
+
+	client, err := m.connectedClient(ctx, parts[0])
+	if err != nil {
+		return nil, err
+	}
+	return client.GetPrompt(ctx, parts[1], args)
+}
+
+// connectedClient returns the client of an enabled and connected server, waking it first if
+// it was put to sleep for being idle
+func (m *Manager) connectedClient(ctx context.Context, serverName string) (*managed.Client, error) {
+	m.mu.RLock()
+	var targetClient *managed.Client
+	for _, client := range m.clients {
+		if client.Config.Name == serverName {
+			targetClient = client
+			break
+		}
+	}
+	m.mu.RUnlock()
+
+	if targetClient == nil {
+		return nil, fmt.Errorf("no client found for server: %s", serverName)
+	}
+	if targetClient.Config.IsDisabled() {
+		return nil, fmt.Errorf("client for server %s is disabled (startup_mode: %s)", serverName, targetClient.Config.StartupMode)
+	}
+	if !targetClient.IsConnected() && targetClient.StateManager.IsSleeping() {
+		if err := targetClient.Connect(ctx); err != nil {
+			return nil, fmt.Errorf("failed to wake sleeping server '%s': %w", serverName, err)
+		}
+	}
+	if !targetClient.IsConnected() {
+		return nil, fmt.Errorf("server '%s' is not connected (state: %s)", serverName, targetClient.GetState().String())
+	}
+	return targetClient, nil
 }
 
-// CallTool calls a tool on the appropriate upstream server
+// ToolCallObserver is told about a finished tool call: toolName is "server:tool", result is
+// nil when err is set, and startTime is when CallTool was entered
+type ToolCallObserver func(ctx context.Context, toolName string, args map[string]interface{}, result interface{}, err error, startTime time.Time)
+
+// CallTool calls a tool on the appropriate upstream server. Every upstream tool call goes
+// through here, so the tool call observer sees all of them.
 func (m *Manager) CallTool(ctx context.Context, toolName string, args map[string]interface{}) (interface{}, error) {
+	startTime := time.Now()
+	result, err := m.callTool(ctx, toolName, args)
+
+	m.mu.RLock()
+	observer := m.onToolCall
+	m.mu.RUnlock()
+	if observer != nil {
+		observer(ctx, toolName, args, result, err, startTime)
+	}
+	return result, err
+}
+
+// callTool implements CallTool
+func (m *Manager) callTool(ctx context.Context, toolName string, args map[string]interface{}) (interface{}, error) {
 	// Parse tool name to extract server and tool components
 	parts := strings.SplitN(toolName, ":", 2)
 	if len(parts) != 2 {
@@ -457,11 +679,13 @@ func (m *Manager) CallTool(ctx context.Context, toolName string, args map[string
 			return nil, fmt.Errorf("server '%s' is currently connecting - please wait for connection to complete (state: %s)", serverName, state.String())
 		}
 
-		// Lazy loading: Try to connect the server if it has tools in DB
-		if m.globalConfig.EnableLazyLoading && targetClient.Config.ToolCount > 0 {
+		// Lazy loading: Try to connect the server if it has tools in DB or was put to sleep
+		// for being idle
+		if targetClient.StateManager.IsSleeping() || (m.globalConfig.EnableLazyLoading && targetClient.Config.ToolCount > 0) {
 			m.logger.Info("Lazy loading: Connecting to server on-demand",
 				zap.String("server", serverName),
 				zap.String("tool", actualToolName),
+				zap.Bool("sleeping", targetClient.StateManager.IsSleeping()),
 				zap.Int("tool_count", targetClient.Config.ToolCount))
 
 			// Release read lock temporarily to allow Connect() to acquire write lock if needed
@@ -512,6 +736,14 @@ func (m *Manager) CallTool(ctx context.Context, toolName string, args map[string
 		}
 	}
 
+	// Read-only servers only run tools the server annotates (or the config lists) as read-only
+	if targetClient.Config.ReadOnly {
+		annotations := targetClient.ToolAnnotations(ctx, actualToolName)
+		if targetClient.Config.IsToolWriteBlocked(actualToolName, annotations.ReadOnlyHint, annotations.DestructiveHint) {
+			return nil, fmt.Errorf("tool '%s' is blocked: server '%s' is read-only and the tool is not annotated or listed as read-only", actualToolName, serverName)
+		}
+	}
+
 	// Call the tool on the upstream server with enhanced error handling
 	result, err := targetClient.CallTool(ctx, actualToolName, args)
 	if err != nil {
@@ -616,6 +848,22 @@ func (m *Manager) ConnectAll(ctx context.Context) error {
 			continue
 		}
 
+		// Snoozed servers get no reconnect attempts until the snooze ends
+		if client.StateManager.IsSnoozed() {
+			m.logger.Debug("Skipping snoozed server",
+				zap.String("id", id),
+				zap.String("name", client.Config.Name))
+			continue
+		}
+
+		// Servers disconnected for being idle stay asleep until their next tool call
+		if client.StateManager.IsSleeping() {
+			m.logger.Debug("Skipping sleeping server (idle disconnect)",
+				zap.String("id", id),
+				zap.String("name", client.Config.Name))
+			continue
+		}
+
 		// Lazy loading optimization: Skip connection for servers with cached tools
 		// These servers will connect on-demand when a tool call is made
 		// ConnectionState remains Disconnected until first tool call
@@ -643,6 +891,14 @@ func (m *Manager) ConnectAll(ctx context.Context) error {
 			continue
 		}
 
+		// Servers that failed before are retried only once their reconnect backoff has elapsed
+		if client.GetConnectionInfo().RetryCount > 0 && !client.ShouldRetry() {
+			m.logger.Debug("Skipping server waiting for its reconnect backoff",
+				zap.String("id", id),
+				zap.String("name", client.Config.Name))
+			continue
+		}
+
 		jobs = append(jobs, clientJob{
 			id:     id,
 			client: client,
@@ -868,6 +1124,13 @@ func (m *Manager) handlePersistentFailure(id string, client *managed.Client) {
 		zap.String("reason", reason))
 }
 
+// ShutdownAll stops watching env_file settings and disconnects from all servers. Use it when
+// the proxy exits; DisconnectAll keeps the watches for servers that reconnect later.
+func (m *Manager) ShutdownAll() error {
+	m.stopEnvFileWatcher()
+	return m.DisconnectAll()
+}
+
 // DisconnectAll disconnects from all servers in parallel to avoid blocking
 // CRIT-005: Now aggregates all errors instead of returning only the last one
 // TIMEOUT-FIX: Execute disconnects in parallel with timeout to prevent API hangs
@@ -1045,6 +1308,55 @@ func (m *Manager) ListServers() map[string]*config.ServerConfig {
 	return servers
 }
 
+// ClearServerError clears a server's stale last_error. Returns false if it had none.
+func (m *Manager) ClearServerError(serverName string) (bool, error) {
+	m.mu.RLock()
+	client, exists := m.clients[serverName]
+	m.mu.RUnlock()
+
+	if !exists {
+		return false, fmt.Errorf("server not found: %s", serverName)
+	}
+
+	cleared := client.StateManager.ClearError()
+	if cleared {
+		m.logger.Info("Cleared server last_error",
+			zap.String("server", serverName),
+			zap.String("state", client.GetState().String()))
+	}
+	return cleared, nil
+}
+
+// SnoozeServer mutes reconnect attempts for a server for the given duration; the server stays
+// listed and retries resume on their own afterwards. A non-positive duration ends the snooze.
+// Returns when the snooze ends (zero when it was cleared).
+func (m *Manager) SnoozeServer(serverName string, duration time.Duration) (time.Time, error) {
+	m.mu.RLock()
+	client, exists := m.clients[serverName]
+	m.mu.RUnlock()
+
+	if !exists {
+		return time.Time{}, fmt.Errorf("server not found: %s", serverName)
+	}
+
+	var until time.Time
+	if duration > 0 {
+		until = time.Now().Add(duration)
+	}
+	client.StateManager.SetSnoozedUntil(until)
+
+	if until.IsZero() {
+		m.logger.Info("Resumed reconnect attempts",
+			zap.String("server", serverName))
+	} else {
+		m.logger.Info("Snoozed reconnect attempts",
+			zap.String("server", serverName),
+			zap.Duration("duration", duration),
+			zap.Time("until", until))
+	}
+	return until, nil
+}
+
 // RetryConnection triggers a connection retry for a specific server
 // This is typically called after OAuth completion to immediately use new tokens
 func (m *Manager) RetryConnection(serverName string) error {
@@ -1258,6 +1570,13 @@ func (m *Manager) scanForNewTokens() {
 // StartManualOAuth performs an in-process OAuth flow for the given server.
 // This avoids cross-process DB locking by using the daemon's storage directly.
 func (m *Manager) StartManualOAuth(serverName string, force bool) error {
+	return m.startManualOAuth(serverName, force, false, nil)
+}
+
+// startManualOAuth runs the in-process OAuth flow in the background and records its progress
+// for GetOAuthFlow. Headless flows store the authorization URL instead of opening a browser.
+// onProgress, if set, is called after every state change of the flow.
+func (m *Manager) startManualOAuth(serverName string, force, headless bool, onProgress func()) error {
 	m.mu.RLock()
 	client, exists := m.clients[serverName]
 	m.mu.RUnlock()
@@ -1278,15 +1597,34 @@ func (m *Manager) StartManualOAuth(serverName string, force bool) error {
 		return fmt.Errorf("OAuth is not supported or not required for server '%s'", cfg.Name)
 	}
 
+	notify := func() {
+		if onProgress != nil {
+			onProgress()
+		}
+	}
+	flow := m.beginOAuthFlow(cfg.Name)
+	done := func(err error) {
+		m.finishOAuthFlow(flow, cfg, err)
+		notify()
+	}
+
 	// Create a transient core client that uses the daemon's storage
 	coreClient, err := core.NewClientWithOptions(cfg.Name, cfg, m.logger, m.logConfig, m.globalConfig, m.storage, false)
 	if err != nil {
-		return fmt.Errorf("failed to create core client for OAuth: %w", err)
+		err = fmt.Errorf("failed to create core client for OAuth: %w", err)
+		done(err)
+		return err
 	}
 
 	go func() {
 		ctx, cancel := context.WithTimeout(context.Background(), config.LongRunningOperationTimeout)
 		defer cancel()
+		if headless {
+			ctx = core.WithAuthURLHandler(ctx, func(authURL string) {
+				m.setOAuthFlowURL(flow, authURL)
+				notify()
+			})
+		}
 
 		if force {
 			coreClient.ClearOAuthState()
@@ -1309,6 +1647,7 @@ func (m *Manager) StartManualOAuth(serverName string, force bool) error {
 					tcancel()
 					if testClient.GetServerInfo() != nil {
 						m.logger.Info("Preflight succeeded without OAuth; skipping OAuth flow", zap.String("server", cfg.Name))
+						done(nil)
 						return
 					}
 				}
@@ -1320,10 +1659,12 @@ func (m *Manager) StartManualOAuth(serverName string, force bool) error {
 			m.logger.Warn("In-process OAuth flow failed",
 				zap.String("server", cfg.Name),
 				zap.Error(err))
+			done(err)
 			return
 		}
 		m.logger.Info("In-process OAuth flow completed successfully",
 			zap.String("server", cfg.Name))
+		done(nil)
 		// Immediately attempt reconnect with new tokens
 		if err := m.RetryConnection(cfg.Name); err != nil {
 			m.logger.Warn("Failed to trigger reconnect after in-process OAuth",
@@ -1339,14 +1680,32 @@ func (m *Manager) StartManualOAuth(serverName string, force bool) error {
 func (m *Manager) startHealthCheckMonitor() {
 	m.logger.Info("Starting health check monitor for servers with health_check enabled")
 
+	// Each server is only retried once its own exponential backoff has elapsed
 	ticker := time.NewTicker(config.AutoRecoveryCheckInterval) // Check every 60 seconds
 	defer ticker.Stop()
 
 	for range ticker.C {
+		m.sleepIdleServers(time.Now())
 		m.performHealthChecks()
 	}
 }
 
+// sleepIdleServers disconnects servers that have been unused for their idle_disconnect_after
+func (m *Manager) sleepIdleServers(now time.Time) {
+	m.mu.RLock()
+	clients := make([]*managed.Client, 0, len(m.clients))
+	for _, client := range m.clients {
+		if client.Config.IdleDisconnectAfter > 0 {
+			clients = append(clients, client)
+		}
+	}
+	m.mu.RUnlock()
+
+	for _, client := range clients {
+		client.SleepIfIdle(now)
+	}
+}
+
 // performHealthChecks checks the health of all servers and attempts reconnection for disconnected ones
 // Servers with HealthCheck=true get active health checks, all servers get reconnection attempts
 // Uses PARALLEL reconnections with a worker pool for efficiency
@@ -1375,20 +1734,34 @@ func (m *Manager) performHealthChecks() {
 			healthCheckCount++
 		}
 
-		// Skip if disabled or auto-disabled
+		// Skip if disabled, auto-disabled or auto-quarantined
 		if client.Config.IsDisabled() {
 			continue
 		}
-		if client.StateManager.IsAutoDisabled() {
+		if client.StateManager.IsAutoDisabled() || client.StateManager.IsAutoQuarantined() {
 			continue
 		}
 		// Skip if user manually stopped
 		if client.StateManager.IsUserStopped() {
 			continue
 		}
+		// Skip if reconnects are snoozed (e.g. planned maintenance)
+		if client.StateManager.IsSnoozed() {
+			continue
+		}
+		// Skip if disconnected for being idle; the next call reconnects it
+		if client.StateManager.IsSleeping() {
+			continue
+		}
 
 		// Check connection status - reconnect ALL disconnected servers
 		if !client.IsConnected() {
+			// Servers that can't connect fail their health check; connected servers are
+			// checked with ListTools by the managed client
+			if client.Config.HealthCheck {
+				client.StateManager.RecordHealthCheck(fmt.Errorf("not connected (state: %s)", client.GetState().String()))
+			}
+
 			// Check if we should retry based on backoff
 			if !client.ShouldRetry() {
 				m.logger.Debug("Health check: Skipping reconnection (backoff not elapsed)",
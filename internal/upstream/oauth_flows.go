@@ -0,0 +1,114 @@
+package upstream
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
+	"mcpproxy-go/internal/oauth"
+)
+
+// OAuth login states reported by GetOAuthFlow
+const (
+	OAuthFlowPending = "pending" // Running; auth_url is set once the user must visit it
+	OAuthFlowSuccess = "success"
+	OAuthFlowError   = "error"
+)
+
+// OAuthFlow is the state of the latest in-process OAuth login for one server
+type OAuthFlow struct {
+	Server         string     `json:"server"`
+	Status         string     `json:"status"`
+	AuthURL        string     `json:"auth_url,omitempty"`
+	Error          string     `json:"error,omitempty"`
+	TokenExpiresAt *time.Time `json:"token_expires_at,omitempty"`
+	StartedAt      time.Time  `json:"started_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// Finished reports whether the login has succeeded or failed
+func (f OAuthFlow) Finished() bool {
+	return f.Status == OAuthFlowSuccess || f.Status == OAuthFlowError
+}
+
+// StartHeadlessOAuth starts an OAuth login for a server without opening a browser and waits
+// until the authorization URL is known, the login finishes or config.OAuthReadTimeout elapses.
+// If a login for the server is already running, its current state is returned instead.
+func (m *Manager) StartHeadlessOAuth(serverName string) (OAuthFlow, error) {
+	if flow, ok := m.GetOAuthFlow(serverName); ok && !flow.Finished() {
+		return flow, nil
+	}
+
+	ready := make(chan struct{})
+	var readyOnce sync.Once
+	if err := m.startManualOAuth(serverName, true, true, func() {
+		readyOnce.Do(func() { close(ready) })
+	}); err != nil {
+		return OAuthFlow{}, err
+	}
+
+	select {
+	case <-ready:
+	case <-time.After(config.OAuthReadTimeout):
+		m.logger.Warn("Authorization URL not available yet for headless OAuth login",
+			zap.String("server", serverName))
+	}
+
+	flow, _ := m.GetOAuthFlow(serverName)
+	return flow, nil
+}
+
+// GetOAuthFlow returns the latest in-process OAuth login for a server
+func (m *Manager) GetOAuthFlow(serverName string) (OAuthFlow, bool) {
+	m.oauthFlowsMu.Lock()
+	defer m.oauthFlowsMu.Unlock()
+
+	flow, ok := m.oauthFlows[serverName]
+	if !ok {
+		return OAuthFlow{}, false
+	}
+	return *flow, true
+}
+
+// beginOAuthFlow records a new pending login, replacing any previous one for the server
+func (m *Manager) beginOAuthFlow(serverName string) *OAuthFlow {
+	m.oauthFlowsMu.Lock()
+	defer m.oauthFlowsMu.Unlock()
+
+	now := time.Now()
+	flow := &OAuthFlow{Server: serverName, Status: OAuthFlowPending, StartedAt: now, UpdatedAt: now}
+	m.oauthFlows[serverName] = flow
+	return flow
+}
+
+func (m *Manager) setOAuthFlowURL(flow *OAuthFlow, authURL string) {
+	m.oauthFlowsMu.Lock()
+	defer m.oauthFlowsMu.Unlock()
+
+	flow.AuthURL = authURL
+	flow.UpdatedAt = time.Now()
+}
+
+// finishOAuthFlow records the outcome of a login, including the new token's expiry on success
+func (m *Manager) finishOAuthFlow(flow *OAuthFlow, cfg *config.ServerConfig, err error) {
+	var expiresAt *time.Time
+	if err == nil {
+		if record, recErr := oauth.GetStoredTokenRecord(cfg.Name, cfg.URL, m.storage); recErr == nil && !record.ExpiresAt.IsZero() {
+			expiresAt = &record.ExpiresAt
+		}
+	}
+
+	m.oauthFlowsMu.Lock()
+	defer m.oauthFlowsMu.Unlock()
+
+	if err != nil {
+		flow.Status = OAuthFlowError
+		flow.Error = err.Error()
+	} else {
+		flow.Status = OAuthFlowSuccess
+		flow.TokenExpiresAt = expiresAt
+	}
+	flow.UpdatedAt = time.Now()
+}
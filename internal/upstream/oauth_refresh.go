@@ -0,0 +1,88 @@
+package upstream
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"mcpproxy-go/internal/config"
+	"mcpproxy-go/internal/oauth"
+	"mcpproxy-go/internal/upstream/managed"
+)
+
+// oauthRefreshWindow returns the configured proactive refresh window
+func (m *Manager) oauthRefreshWindow() time.Duration {
+	if m.globalConfig != nil && m.globalConfig.OAuthRefreshWindow.Duration() > 0 {
+		return m.globalConfig.OAuthRefreshWindow.Duration()
+	}
+	return config.DefaultOAuthRefreshWindow
+}
+
+// startOAuthRefreshMonitor periodically refreshes OAuth tokens that are about to expire,
+// so connected servers don't have to hit a 401 before getting a new token
+func (m *Manager) startOAuthRefreshMonitor() {
+	m.logger.Info("Starting OAuth token refresh monitor",
+		zap.Duration("refresh_window", m.oauthRefreshWindow()))
+
+	ticker := time.NewTicker(config.OAuthRefreshCheckInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		m.refreshExpiringOAuthTokens()
+	}
+}
+
+// refreshExpiringOAuthTokens refreshes tokens of connected OAuth servers expiring within the window
+func (m *Manager) refreshExpiringOAuthTokens() {
+	m.mu.RLock()
+	clients := make([]*managed.Client, 0, len(m.clients))
+	for _, c := range m.clients {
+		clients = append(clients, c)
+	}
+	m.mu.RUnlock()
+
+	window := m.oauthRefreshWindow()
+	now := time.Now()
+
+	for _, c := range clients {
+		// Only URL-based servers that are up can use OAuth
+		if c.Config.URL == "" || c.Config.Command != "" || c.Config.IsDisabled() || !c.IsConnected() {
+			continue
+		}
+
+		record, err := oauth.GetStoredTokenRecord(c.Config.Name, c.Config.URL, m.storage)
+		if err != nil || record.RefreshToken == "" {
+			continue
+		}
+		if !shouldRefreshOAuthToken(record.ExpiresAt, window, now) {
+			continue
+		}
+
+		m.logger.Info("OAuth token nearing expiry, refreshing proactively",
+			zap.String("server", c.Config.Name),
+			zap.Time("expires_at", record.ExpiresAt))
+
+		ctx, cancel := context.WithTimeout(context.Background(), config.OAuthReadTimeout)
+		expiresAt, err := c.RefreshOAuthToken(ctx)
+		cancel()
+		if err != nil {
+			m.logger.Warn("Proactive OAuth token refresh failed",
+				zap.String("server", c.Config.Name),
+				zap.Error(err))
+			continue
+		}
+
+		m.logger.Info("Proactively refreshed OAuth token",
+			zap.String("server", c.Config.Name),
+			zap.Time("new_expires_at", expiresAt))
+	}
+}
+
+// shouldRefreshOAuthToken reports whether a token expiring at expiresAt is within the refresh window
+func shouldRefreshOAuthToken(expiresAt time.Time, window time.Duration, now time.Time) bool {
+	if expiresAt.IsZero() {
+		return false
+	}
+	return expiresAt.Sub(now) <= window
+}
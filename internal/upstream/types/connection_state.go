@@ -63,4 +63,15 @@ type ConnectionInfo struct {
 	AutoDisableReason    string          `json:"auto_disable_reason,omitempty"`// Reason for auto-disable
 	AutoDisableThreshold int             `json:"auto_disable_threshold"`       // Threshold for auto-disable (default: DefaultAutoDisableThreshold)
 	LastSuccessTime      time.Time       `json:"last_success_time,omitempty"`  // Last successful connection
+	LastSuccessfulCall   time.Time       `json:"last_successful_call,omitempty"` // Last tool call that succeeded
+}
+
+// HealthCheckResult is the outcome of the periodic health checks of a server with health_check
+// enabled. CheckedAt is zero until the first check.
+type HealthCheckResult struct {
+	CheckedAt           time.Time `json:"checked_at"`
+	Passed              bool      `json:"passed"`
+	Error               string    `json:"error,omitempty"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	LastPassedAt        time.Time `json:"last_passed_at,omitempty"`
 }
@@ -29,6 +29,9 @@ type StateManager struct {
 	firstAttemptTime time.Time // Time of first connection attempt
 	connectedAt      time.Time // Time when connection was established
 
+	// Reconnect backoff (zero value uses defaults); the delay restarts once retryCount is reset on connect
+	backoff config.ReconnectBackoff
+
 	// Auto-disable tracking
 	consecutiveFailures  int       // Consecutive failures counter
 	autoDisabled         bool      // Auto-disable flag
@@ -41,6 +44,25 @@ type StateManager struct {
 	// When app restarts, all userStopped flags are cleared and servers return to their original startup_mode
 	userStopped bool // User manually stopped via tray UI (runtime-only, never persisted)
 
+	// Reconnect attempts are skipped until this time (runtime-only, never persisted)
+	snoozedUntil time.Time
+
+	// Last tool call that completed without error; kept across reconnects (runtime-only, never persisted)
+	lastSuccessfulCall time.Time
+
+	// Idle disconnect tracking (runtime-only, never persisted): when a call last started, and
+	// whether the connection was closed for being idle and is reopened on the next call
+	lastActivity time.Time
+	sleeping     bool
+
+	// Outcome of the periodic health checks of servers with health_check enabled (runtime-only)
+	healthCheck HealthCheckResult
+
+	// Auto-quarantine tracking (runtime-only): tool calls that failed in a row since the last
+	// successful one, and whether the server was quarantined for failing too often
+	consecutiveCallFailures int
+	autoQuarantined         bool
+
 	// Persisted configuration state (stored in database)
 	serverState ServerState // Current server state (active, disabled, quarantined, etc.)
 
@@ -60,6 +82,13 @@ func NewStateManager() *StateManager {
 	}
 }
 
+// SetReconnectBackoff sets the exponential backoff used between reconnect attempts
+func (sm *StateManager) SetReconnectBackoff(backoff config.ReconnectBackoff) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.backoff = backoff
+}
+
 // SetStateChangeCallback sets a callback function that will be called on state changes
 func (sm *StateManager) SetStateChangeCallback(callback func(oldState, newState ConnectionState, info *ConnectionInfo)) {
 	sm.mu.Lock()
@@ -103,6 +132,7 @@ func (sm *StateManager) GetConnectionInfo() ConnectionInfo {
 		AutoDisableReason:    sm.autoDisableReason,
 		AutoDisableThreshold: sm.autoDisableThreshold,
 		LastSuccessTime:      sm.lastSuccessTime,
+		LastSuccessfulCall:   sm.lastSuccessfulCall,
 	}
 }
 
@@ -143,6 +173,7 @@ func (sm *StateManager) TransitionTo(newState ConnectionState) {
 	// Record successful connection time
 	if newState == StateReady {
 		sm.connectedAt = time.Now()
+		sm.sleeping = false // Any reconnect wakes a sleeping server
 		sm.lastSuccessTime = time.Now()
 		sm.lastError = nil
 		sm.retryCount = 0
@@ -189,6 +220,30 @@ func (sm *StateManager) SetError(err error) {
 	}
 }
 
+// ClearError drops the last error so stale errors stop showing once a server has recovered.
+// A server left in the Error state moves to Disconnected. Returns false if there was nothing to clear.
+func (sm *StateManager) ClearError() bool {
+	sm.mu.Lock()
+	if sm.lastError == nil && sm.currentState != StateError {
+		sm.mu.Unlock()
+		return false
+	}
+
+	oldState := sm.currentState
+	sm.lastError = nil
+	sm.isOAuthError = false
+	if sm.currentState == StateError {
+		sm.currentState = StateDisconnected
+	}
+	newState := sm.currentState
+
+	info := sm.buildConnectionInfo()
+	sm.mu.Unlock()
+
+	sm.publishConnectionStateChange(oldState, newState, &info)
+	return true
+}
+
 // SetServerInfo sets the server information
 func (sm *StateManager) SetServerInfo(name, version string) {
 	sm.mu.Lock()
@@ -263,7 +318,7 @@ func (sm *StateManager) Reset() {
 	// from the actual first attempt, not the most recent reconnection.
 	// sm.firstAttemptTime = time.Time{}
 	sm.connectedAt = time.Time{}
-	// NOTE: Do NOT reset consecutiveFailures, autoDisabled, or lastSuccessTime
+	// NOTE: Do NOT reset consecutiveFailures, autoDisabled, lastSuccessTime or lastSuccessfulCall
 	// These should persist across disconnections for proper auto-disable logic
 
 	info := sm.buildConnectionInfo()
@@ -295,6 +350,7 @@ func (sm *StateManager) buildConnectionInfo() ConnectionInfo {
 		AutoDisableReason:    sm.autoDisableReason,
 		AutoDisableThreshold: sm.autoDisableThreshold,
 		LastSuccessTime:      sm.lastSuccessTime,
+		LastSuccessfulCall:   sm.lastSuccessfulCall,
 	}
 }
 
@@ -320,6 +376,162 @@ func (sm *StateManager) SetUserStopped(stopped bool) {
 	sm.userStopped = stopped
 }
 
+// IsSnoozed returns whether reconnect attempts are currently muted
+// IMPORTANT: This is runtime-only state, never persisted to config or database
+func (sm *StateManager) IsSnoozed() bool {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return time.Now().Before(sm.snoozedUntil)
+}
+
+// SnoozedUntil returns when the current snooze ends, or the zero time when not snoozed
+func (sm *StateManager) SnoozedUntil() time.Time {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	if !time.Now().Before(sm.snoozedUntil) {
+		return time.Time{}
+	}
+	return sm.snoozedUntil
+}
+
+// SetSnoozedUntil mutes reconnect attempts until the given time; the zero time resumes them.
+// Retries resume on their own once the time has passed.
+// IMPORTANT: This is runtime-only state, never persisted to config or database
+func (sm *StateManager) SetSnoozedUntil(until time.Time) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.snoozedUntil = until
+}
+
+// RecordSuccessfulCall marks that a tool call through this connection just succeeded
+func (sm *StateManager) RecordSuccessfulCall() {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.lastSuccessfulCall = time.Now()
+	sm.consecutiveCallFailures = 0
+}
+
+// RecordCallFailure counts a tool call that failed; a successful call resets the count
+func (sm *StateManager) RecordCallFailure() {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.consecutiveCallFailures++
+}
+
+// ConsecutiveCallFailures returns the number of tool calls that failed since the last success
+func (sm *StateManager) ConsecutiveCallFailures() int {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.consecutiveCallFailures
+}
+
+// TryAutoQuarantine marks the server auto-quarantined if its consecutive connection or call
+// failures reached threshold, returning the failure count that did. Only the first caller to
+// cross the threshold gets true. A threshold of 0 or less turns auto-quarantine off.
+func (sm *StateManager) TryAutoQuarantine(threshold int) (int, bool) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	if threshold <= 0 || sm.autoQuarantined {
+		return 0, false
+	}
+	failures := max(sm.consecutiveFailures, sm.consecutiveCallFailures)
+	if failures < threshold {
+		return failures, false
+	}
+	sm.autoQuarantined = true
+	return failures, true
+}
+
+// IsAutoQuarantined returns true if the server was quarantined for failing too often
+// IMPORTANT: This is runtime-only state; the quarantine itself is persisted by the server
+func (sm *StateManager) IsAutoQuarantined() bool {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.autoQuarantined
+}
+
+// ResetAutoQuarantine clears the auto-quarantine flag and the failure counters (for manual unquarantine)
+func (sm *StateManager) ResetAutoQuarantine() {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.autoQuarantined = false
+	sm.consecutiveFailures = 0
+	sm.consecutiveCallFailures = 0
+}
+
+// LastSuccessfulCall returns when a tool call last succeeded, or the zero time if none has
+func (sm *StateManager) LastSuccessfulCall() time.Time {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.lastSuccessfulCall
+}
+
+// RecordActivity marks that a call to the server just started
+func (sm *StateManager) RecordActivity() {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.lastActivity = time.Now()
+}
+
+// IdleSince returns when the current connection was last used: the start of the last call,
+// or the time it connected if no call started since. It is the zero time when not connected.
+func (sm *StateManager) IdleSince() time.Time {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	if sm.currentState != StateReady {
+		return time.Time{}
+	}
+	if sm.lastActivity.After(sm.connectedAt) {
+		return sm.lastActivity
+	}
+	return sm.connectedAt
+}
+
+// RecordHealthCheck records the outcome of a health check; a nil err means it passed
+func (sm *StateManager) RecordHealthCheck(err error) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	now := time.Now()
+	sm.healthCheck.CheckedAt = now
+	if err == nil {
+		sm.healthCheck.Passed = true
+		sm.healthCheck.Error = ""
+		sm.healthCheck.ConsecutiveFailures = 0
+		sm.healthCheck.LastPassedAt = now
+		return
+	}
+	sm.healthCheck.Passed = false
+	sm.healthCheck.Error = err.Error()
+	sm.healthCheck.ConsecutiveFailures++
+}
+
+// LastHealthCheck returns the outcome of the last health check
+// IMPORTANT: This is runtime-only state, never persisted to config or database
+func (sm *StateManager) LastHealthCheck() HealthCheckResult {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.healthCheck
+}
+
+// IsSleeping returns whether the connection was closed for being idle. Sleeping servers are
+// not reconnected in the background; the next call reconnects them.
+// IMPORTANT: This is runtime-only state, never persisted to config or database
+func (sm *StateManager) IsSleeping() bool {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.sleeping
+}
+
+// SetSleeping sets whether the connection was closed for being idle
+// IMPORTANT: This is runtime-only state, never persisted to config or database
+func (sm *StateManager) SetSleeping(sleeping bool) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.sleeping = sleeping
+}
+
 // ============================================================================
 // ServerState Management Methods (Persisted Configuration State)
 // ============================================================================
@@ -456,24 +668,15 @@ func (sm *StateManager) ShouldRetry() bool {
 		return false
 	}
 
-	if sm.retryCount == 0 {
-		return true
+	if time.Now().Before(sm.snoozedUntil) {
+		return false
 	}
 
-	// Calculate exponential backoff
-	// Ensure retry count is valid and within safe range to avoid overflow
-	retryCount := sm.retryCount - 1
-	if retryCount < 0 {
-		retryCount = 0
-	}
-	if retryCount > 30 { // Cap at 30 to prevent overflow in 64-bit systems
-		retryCount = 30
-	}
-	backoffDuration := time.Duration(1<<uint(retryCount)) * time.Second //nolint:gosec // retryCount is bounds-checked above
-	if backoffDuration > config.MaxBackoffMinutes {
-		backoffDuration = config.MaxBackoffMinutes
+	if sm.retryCount == 0 {
+		return true
 	}
 
+	backoffDuration := sm.backoff.Delay(sm.retryCount)
 	return time.Since(sm.lastRetryTime) >= backoffDuration
 }
 
@@ -509,6 +712,10 @@ func (sm *StateManager) ShouldRetryOAuth() bool {
 		return false
 	}
 
+	if time.Now().Before(sm.snoozedUntil) {
+		return false
+	}
+
 	if sm.oauthRetryCount == 0 {
 		return true
 	}
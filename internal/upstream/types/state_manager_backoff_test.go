@@ -0,0 +1,63 @@
+package types
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"mcpproxy-go/internal/config"
+)
+
+func TestStateManager_ShouldRetryUsesReconnectBackoff(t *testing.T) {
+	sm := NewStateManager()
+	sm.SetReconnectBackoff(config.ReconnectBackoff{Initial: time.Minute, Max: time.Hour, Multiplier: 2})
+
+	// Never attempted: retry immediately
+	assert.True(t, sm.ShouldRetry())
+
+	sm.SetError(errors.New("connection refused"))
+	assert.False(t, sm.ShouldRetry(), "initial interval has not elapsed")
+
+	sm.mu.Lock()
+	sm.lastRetryTime = time.Now().Add(-61 * time.Second)
+	sm.mu.Unlock()
+	assert.True(t, sm.ShouldRetry())
+
+	// Second failure doubles the delay
+	sm.SetError(errors.New("connection refused"))
+	sm.mu.Lock()
+	sm.lastRetryTime = time.Now().Add(-61 * time.Second)
+	sm.mu.Unlock()
+	assert.False(t, sm.ShouldRetry(), "backoff should have grown to 2m")
+
+	// A successful connection resets the backoff
+	sm.TransitionTo(StateReady)
+	sm.SetError(errors.New("connection lost"))
+	sm.mu.Lock()
+	sm.lastRetryTime = time.Now().Add(-61 * time.Second)
+	sm.mu.Unlock()
+	assert.True(t, sm.ShouldRetry())
+}
+
+func TestStateManager_SnoozeMutesRetries(t *testing.T) {
+	sm := NewStateManager()
+	sm.SetError(errors.New("connection refused"))
+	sm.mu.Lock()
+	sm.lastRetryTime = time.Now().Add(-time.Hour)
+	sm.mu.Unlock()
+	assert.True(t, sm.ShouldRetry())
+
+	until := time.Now().Add(time.Hour)
+	sm.SetSnoozedUntil(until)
+	assert.True(t, sm.IsSnoozed())
+	assert.Equal(t, until, sm.SnoozedUntil())
+	assert.False(t, sm.ShouldRetry(), "snoozed servers are not retried")
+
+	// An elapsed snooze resumes retries without further action
+	sm.SetSnoozedUntil(time.Now().Add(-time.Second))
+	assert.False(t, sm.IsSnoozed())
+	assert.True(t, sm.SnoozedUntil().IsZero())
+	assert.True(t, sm.ShouldRetry())
+}
@@ -0,0 +1,104 @@
+package types
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateManager_LastSuccessfulCall(t *testing.T) {
+	sm := NewStateManager()
+	assert.True(t, sm.LastSuccessfulCall().IsZero())
+	assert.True(t, sm.GetConnectionInfo().LastSuccessfulCall.IsZero())
+
+	before := time.Now()
+	sm.RecordSuccessfulCall()
+	recorded := sm.LastSuccessfulCall()
+	assert.False(t, recorded.Before(before))
+	assert.Equal(t, recorded, sm.GetConnectionInfo().LastSuccessfulCall)
+
+	// Failures and reconnects don't clear the last success
+	sm.SetError(errors.New("connection lost"))
+	sm.Reset()
+	assert.Equal(t, recorded, sm.LastSuccessfulCall())
+}
+
+func TestStateManager_IdleSince(t *testing.T) {
+	sm := NewStateManager()
+	assert.True(t, sm.IdleSince().IsZero(), "not connected")
+
+	sm.TransitionTo(StateConnecting)
+	sm.TransitionTo(StateReady)
+	connectedAt := sm.IdleSince()
+	assert.False(t, connectedAt.IsZero(), "a fresh connection counts as activity")
+
+	time.Sleep(time.Millisecond)
+	sm.RecordActivity()
+	assert.True(t, sm.IdleSince().After(connectedAt))
+
+	sm.Reset()
+	assert.True(t, sm.IdleSince().IsZero())
+}
+
+func TestStateManager_SleepingClearedOnConnect(t *testing.T) {
+	sm := NewStateManager()
+	sm.SetSleeping(true)
+	sm.Reset()
+	assert.True(t, sm.IsSleeping(), "disconnecting keeps the server asleep")
+
+	sm.TransitionTo(StateConnecting)
+	assert.True(t, sm.IsSleeping())
+	sm.TransitionTo(StateReady)
+	assert.False(t, sm.IsSleeping())
+}
+
+func TestStateManager_RecordHealthCheck(t *testing.T) {
+	sm := NewStateManager()
+	assert.True(t, sm.LastHealthCheck().CheckedAt.IsZero())
+
+	sm.RecordHealthCheck(errors.New("connection refused"))
+	sm.RecordHealthCheck(errors.New("context deadline exceeded"))
+	result := sm.LastHealthCheck()
+	assert.False(t, result.Passed)
+	assert.Equal(t, "context deadline exceeded", result.Error)
+	assert.Equal(t, 2, result.ConsecutiveFailures)
+	assert.True(t, result.LastPassedAt.IsZero())
+
+	sm.RecordHealthCheck(nil)
+	result = sm.LastHealthCheck()
+	assert.True(t, result.Passed)
+	assert.Empty(t, result.Error)
+	assert.Equal(t, 0, result.ConsecutiveFailures)
+	assert.False(t, result.LastPassedAt.IsZero())
+	assert.Equal(t, result.CheckedAt, result.LastPassedAt)
+}
+
+func TestStateManager_TryAutoQuarantine(t *testing.T) {
+	sm := NewStateManager()
+	sm.RecordCallFailure()
+	sm.RecordCallFailure()
+	_, quarantined := sm.TryAutoQuarantine(0)
+	assert.False(t, quarantined, "a threshold of 0 turns auto-quarantine off")
+	_, quarantined = sm.TryAutoQuarantine(3)
+	assert.False(t, quarantined)
+
+	sm.RecordSuccessfulCall()
+	assert.Equal(t, 0, sm.ConsecutiveCallFailures(), "a successful call resets the count")
+
+	for i := 0; i < 3; i++ {
+		sm.RecordCallFailure()
+	}
+	failures, quarantined := sm.TryAutoQuarantine(3)
+	assert.True(t, quarantined)
+	assert.Equal(t, 3, failures)
+	assert.True(t, sm.IsAutoQuarantined())
+
+	_, quarantined = sm.TryAutoQuarantine(3)
+	assert.False(t, quarantined, "only the first caller crossing the threshold quarantines")
+
+	sm.ResetAutoQuarantine()
+	assert.False(t, sm.IsAutoQuarantined())
+	assert.Equal(t, 0, sm.ConsecutiveCallFailures())
+}
@@ -300,3 +300,25 @@ func TestStateManager_ThreadSafety(t *testing.T) {
 
 	// Verify no race conditions (test passes if no panic)
 }
+
+func TestStateManager_ClearError(t *testing.T) {
+	sm := NewStateManager()
+	sm.SetServerInfo("test-server", "1.0.0")
+
+	assert.False(t, sm.ClearError(), "nothing to clear initially")
+
+	sm.SetError(assert.AnError)
+	require.Equal(t, StateError, sm.GetState())
+	require.NotNil(t, sm.GetConnectionInfo().LastError)
+
+	assert.True(t, sm.ClearError())
+	assert.Nil(t, sm.GetConnectionInfo().LastError)
+	assert.Equal(t, StateDisconnected, sm.GetState(), "error state falls back to disconnected")
+
+	// Reconnecting clears the error too
+	sm.SetError(assert.AnError)
+	sm.TransitionTo(StateConnecting)
+	sm.TransitionTo(StateReady)
+	assert.Nil(t, sm.GetConnectionInfo().LastError)
+	assert.False(t, sm.ClearError())
+}